@@ -0,0 +1,4 @@
+package alert
+
+// +k8s:deepcopy-gen=package
+// +versionName=v1
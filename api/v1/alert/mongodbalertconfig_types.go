@@ -0,0 +1,202 @@
+package alert
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeEmail     NotificationType = "EMAIL"
+	NotificationTypeWebhook   NotificationType = "WEBHOOK"
+	NotificationTypePagerDuty NotificationType = "PAGER_DUTY"
+	NotificationTypeGroup     NotificationType = "GROUP"
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBAlertConfig{}, &MongoDBAlertConfigList{})
+}
+
+type MongoDBAlertConfigSpec struct {
+	// Reference to the MongoDB or MongoDBMultiCluster resource the Ops Manager project of which the alert is configured in.
+	MongoDBResourceRef userv1.MongoDBResourceRef `json:"mongodbResourceRef"`
+	// Type of the Ops Manager event that triggers the alert, e.g. HOST_DOWN, REPLICATION_OPLOG_WINDOW_RUNNING_OUT
+	// or OUTSIDE_METRIC_THRESHOLD.
+	// +kubebuilder:validation:MinLength=1
+	EventTypeName string `json:"eventTypeName"`
+	// Whether the alert is enabled in Ops Manager.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Rules the target of the event (host, replica set, cluster) has to match for the alert to be triggered.
+	// +optional
+	Matchers []Matcher `json:"matchers,omitempty"`
+	// Threshold of the metric that triggers the alert. Required for the OUTSIDE_METRIC_THRESHOLD event type.
+	// +optional
+	MetricThreshold *MetricThreshold `json:"metricThreshold,omitempty"`
+	// Threshold that triggers the alert for the event types which are not based on a metric, e.g. the number of
+	// hours of the oplog window.
+	// +optional
+	Threshold *Threshold `json:"threshold,omitempty"`
+	// Channels the notifications about the alert are sent through.
+	// +kubebuilder:validation:MinItems=1
+	Notifications []Notification `json:"notifications"`
+}
+
+type Matcher struct {
+	// Name of the field of the target to match, e.g. HOSTNAME, REPLICA_SET_NAME, CLUSTER_NAME or TYPE_NAME.
+	FieldName string `json:"fieldName"`
+	// +kubebuilder:validation:Enum=EQUALS;NOT_EQUALS;CONTAINS;NOT_CONTAINS;STARTS_WITH;ENDS_WITH;REGEX
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type MetricThreshold struct {
+	// Name of the metric, e.g. OPLOG_SLAVE_LAG_MASTER_TIME or DISK_PARTITION_SPACE_USED_DATA.
+	MetricName string `json:"metricName"`
+	// +kubebuilder:validation:Enum=GREATER_THAN;LESS_THAN
+	Operator string `json:"operator"`
+	// Value of the metric the alert is triggered at, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	Threshold string `json:"threshold"`
+	// Units of the threshold, e.g. SECONDS, GIGABYTES or RAW.
+	// +optional
+	Units string `json:"units,omitempty"`
+	// +kubebuilder:validation:Enum=AVERAGE
+	// +kubebuilder:default=AVERAGE
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+type Threshold struct {
+	// +kubebuilder:validation:Enum=GREATER_THAN;LESS_THAN
+	Operator  string `json:"operator"`
+	Threshold int    `json:"threshold"`
+	// +optional
+	Units string `json:"units,omitempty"`
+}
+
+type Notification struct {
+	// Type of the notification channel.
+	// +kubebuilder:validation:Enum=EMAIL;WEBHOOK;PAGER_DUTY;GROUP
+	TypeName NotificationType `json:"typeName"`
+	// Number of minutes to wait between successive notifications for unacknowledged alerts.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalMin int `json:"intervalMin,omitempty"`
+	// Number of minutes to wait after the alert condition is detected before sending the first notification.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DelayMin int `json:"delayMin,omitempty"`
+	// Email address the notifications are sent to. Required for the EMAIL type.
+	// +optional
+	EmailAddress string `json:"emailAddress,omitempty"`
+	// Secret key containing the email address the notifications are sent to, if the address is not stored in the
+	// resource. Only valid for the EMAIL type.
+	// +optional
+	EmailAddressSecretRef *userv1.SecretKeyRef `json:"emailAddressSecretRef,omitempty"`
+	// Secret key containing the URL of the webhook. Required for the WEBHOOK type.
+	// +optional
+	WebhookURLSecretRef *userv1.SecretKeyRef `json:"webhookUrlSecretRef,omitempty"`
+	// Secret key containing the secret used to sign the webhook requests. Only valid for the WEBHOOK type.
+	// +optional
+	WebhookSecretRef *userv1.SecretKeyRef `json:"webhookSecretRef,omitempty"`
+	// Secret key containing the integration key of the PagerDuty service. Required for the PAGER_DUTY type.
+	// +optional
+	ServiceKeySecretRef *userv1.SecretKeyRef `json:"serviceKeySecretRef,omitempty"`
+	// Project roles the notifications are sent to. Only valid for the GROUP type.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+	// Whether the notifications to the project members are sent by email. Only valid for the GROUP type.
+	// +optional
+	EmailEnabled *bool `json:"emailEnabled,omitempty"`
+	// Whether the notifications to the project members are sent by text message. Only valid for the GROUP type.
+	// +optional
+	SMSEnabled *bool `json:"smsEnabled,omitempty"`
+}
+
+type MongoDBAlertConfigStatus struct {
+	status.Common `json:",inline"`
+	// ID of the alert configuration in the Ops Manager project.
+	AlertConfigID string `json:"alertConfigId,omitempty"`
+	// ID of the Ops Manager project the alert is configured in.
+	ProjectID string           `json:"projectId,omitempty"`
+	Warnings  []status.Warning `json:"warnings,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB alert configuration."
+// +kubebuilder:printcolumn:name="Event Type",type="string",JSONPath=".spec.eventTypeName",description="Type of the event that triggers the alert."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDBAlertConfig resource was created."
+// +kubebuilder:resource:path=mongodbalertconfigs,scope=Namespaced,shortName=mdbac
+type MongoDBAlertConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBAlertConfigSpec `json:"spec"`
+	// +optional
+	Status MongoDBAlertConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBAlertConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDBAlertConfig `json:"items"`
+}
+
+func (a *MongoDBAlertConfig) GetCommonStatus(options ...status.Option) *status.Common {
+	return &a.Status.Common
+}
+
+func (a *MongoDBAlertConfig) GetStatus(...status.Option) interface{} {
+	return a.Status
+}
+
+func (a *MongoDBAlertConfig) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+func (a *MongoDBAlertConfig) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	a.Status.Warnings = warnings
+}
+
+func (a *MongoDBAlertConfig) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	a.Status.UpdateCommonFields(phase, a.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		a.Status.Warnings = append(a.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, AlertConfigIDOption{}); exists {
+		alertConfigID := option.(AlertConfigIDOption)
+		a.Status.AlertConfigID = alertConfigID.AlertConfigID
+		a.Status.ProjectID = alertConfigID.ProjectID
+	}
+}
+
+func (a *MongoDBAlertConfig) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: a.Name, Namespace: a.Namespace}
+}
+
+// MongoDBNamespacedName returns the namespaced name of the referenced MongoDB resource, which defaults to the namespace
+// of the MongoDBAlertConfig.
+func (a *MongoDBAlertConfig) MongoDBNamespacedName() types.NamespacedName {
+	namespace := a.Namespace
+	if a.Spec.MongoDBResourceRef.Namespace != "" {
+		namespace = a.Spec.MongoDBResourceRef.Namespace
+	}
+	return types.NamespacedName{Name: a.Spec.MongoDBResourceRef.Name, Namespace: namespace}
+}
+
+func (a *MongoDBAlertConfig) IsEnabled() bool {
+	return a.Spec.Enabled == nil || *a.Spec.Enabled
+}
@@ -0,0 +1,18 @@
+package alert
+
+import "github.com/mongodb/mongodb-kubernetes/api/v1/status"
+
+type AlertConfigIDOption struct {
+	AlertConfigID string
+	ProjectID     string
+}
+
+var _ status.Option = AlertConfigIDOption{}
+
+func NewAlertConfigIDOption(alertConfigID string, projectID string) AlertConfigIDOption {
+	return AlertConfigIDOption{AlertConfigID: alertConfigID, ProjectID: projectID}
+}
+
+func (o AlertConfigIDOption) Value() interface{} {
+	return o
+}
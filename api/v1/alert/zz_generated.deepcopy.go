@@ -0,0 +1,260 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package alert
+
+import (
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConfigIDOption) DeepCopyInto(out *AlertConfigIDOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConfigIDOption.
+func (in *AlertConfigIDOption) DeepCopy() *AlertConfigIDOption {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConfigIDOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Matcher) DeepCopyInto(out *Matcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matcher.
+func (in *Matcher) DeepCopy() *Matcher {
+	if in == nil {
+		return nil
+	}
+	out := new(Matcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricThreshold) DeepCopyInto(out *MetricThreshold) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricThreshold.
+func (in *MetricThreshold) DeepCopy() *MetricThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAlertConfig) DeepCopyInto(out *MongoDBAlertConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAlertConfig.
+func (in *MongoDBAlertConfig) DeepCopy() *MongoDBAlertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAlertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBAlertConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAlertConfigList) DeepCopyInto(out *MongoDBAlertConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MongoDBAlertConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAlertConfigList.
+func (in *MongoDBAlertConfigList) DeepCopy() *MongoDBAlertConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAlertConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBAlertConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAlertConfigSpec) DeepCopyInto(out *MongoDBAlertConfigSpec) {
+	*out = *in
+	out.MongoDBResourceRef = in.MongoDBResourceRef
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]Matcher, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetricThreshold != nil {
+		in, out := &in.MetricThreshold, &out.MetricThreshold
+		*out = new(MetricThreshold)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(Threshold)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAlertConfigSpec.
+func (in *MongoDBAlertConfigSpec) DeepCopy() *MongoDBAlertConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAlertConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBAlertConfigStatus) DeepCopyInto(out *MongoDBAlertConfigStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]status.Warning, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBAlertConfigStatus.
+func (in *MongoDBAlertConfigStatus) DeepCopy() *MongoDBAlertConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBAlertConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notification) DeepCopyInto(out *Notification) {
+	*out = *in
+	if in.EmailAddressSecretRef != nil {
+		in, out := &in.EmailAddressSecretRef, &out.EmailAddressSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.WebhookURLSecretRef != nil {
+		in, out := &in.WebhookURLSecretRef, &out.WebhookURLSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.WebhookSecretRef != nil {
+		in, out := &in.WebhookSecretRef, &out.WebhookSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.ServiceKeySecretRef != nil {
+		in, out := &in.ServiceKeySecretRef, &out.ServiceKeySecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailEnabled != nil {
+		in, out := &in.EmailEnabled, &out.EmailEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SMSEnabled != nil {
+		in, out := &in.SMSEnabled, &out.SMSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
+	if in == nil {
+		return nil
+	}
+	out := new(Notification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Threshold) DeepCopyInto(out *Threshold) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Threshold.
+func (in *Threshold) DeepCopy() *Threshold {
+	if in == nil {
+		return nil
+	}
+	out := new(Threshold)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,4 @@
+package mdb
+
+// Hub marks v1 as the version the other versions of MongoDB are converted to and from. v1 is the storage version.
+func (*MongoDB) Hub() {}
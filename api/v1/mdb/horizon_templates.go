@@ -0,0 +1,90 @@
+package mdb
+
+import (
+	"fmt"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+	"github.com/mongodb/mongodb-kubernetes/pkg/placeholders"
+)
+
+// Placeholders that can be used in spec.connectivity.replicaSetHorizonTemplates.
+const (
+	HorizonPlaceholderPodName           = "podName"
+	HorizonPlaceholderPodIndex          = "podIndex"
+	HorizonPlaceholderNamespace         = "namespace"
+	HorizonPlaceholderResourceName      = "resourceName"
+	HorizonPlaceholderStatefulSetName   = "statefulSetName"
+	HorizonPlaceholderMongodProcessFQDN = "mongodProcessFQDN"
+	HorizonPlaceholderExternalDomain    = "externalDomain"
+	HorizonPlaceholderPort              = "port"
+	HorizonPlaceholderClusterName       = "clusterName"
+	HorizonPlaceholderClusterIndex      = "clusterIndex"
+	HorizonPlaceholderExternalAddress   = "externalAddress"
+	HorizonPlaceholderExternalPort      = "externalPort"
+)
+
+// HasReplicaSetHorizonTemplates returns true if the horizons of the members are generated from templates.
+func (c *MongoDBConnectivity) HasReplicaSetHorizonTemplates() bool {
+	return c != nil && len(c.ReplicaSetHorizonTemplates) > 0
+}
+
+// ExpandReplicaSetHorizonTemplates returns the horizons of a member, expanding the templates with the placeholder
+// values of the member. An error is returned if a template uses a placeholder without a value.
+func ExpandReplicaSetHorizonTemplates(templates map[string]string, values map[string]string) (MongoDBHorizonConfig, error) {
+	horizon, _, err := placeholders.New(values).ProcessMap(templates)
+	if err != nil {
+		return nil, err
+	}
+	return horizon, nil
+}
+
+// ValidateReplicaSetHorizonTemplatePlaceholders validates that the templates only use the given placeholders.
+func ValidateReplicaSetHorizonTemplatePlaceholders(templates map[string]string, allowedPlaceholders ...string) v1.ValidationResult {
+	values := map[string]string{}
+	for _, placeholder := range allowedPlaceholders {
+		values[placeholder] = ""
+	}
+	if _, err := ExpandReplicaSetHorizonTemplates(templates, values); err != nil {
+		return v1.ValidationError("Invalid spec.connectivity.replicaSetHorizonTemplates: %s", err)
+	}
+	return v1.ValidationSuccess()
+}
+
+// GetReplicaSetHorizons returns the horizons of the first members processes of the replica set. The horizon
+// templates are expanded for every member if they are configured, otherwise the listed horizons are returned.
+// Templates which can't be expanded are rejected by the validation, so no horizons are returned for them.
+func (m *MongoDB) GetReplicaSetHorizons(members int) []MongoDBHorizonConfig {
+	if !m.Spec.Connectivity.HasReplicaSetHorizonTemplates() {
+		return m.Spec.GetHorizonConfig()
+	}
+
+	horizons := make([]MongoDBHorizonConfig, 0, members)
+	for podNum := 0; podNum < members; podNum++ {
+		horizon, err := ExpandReplicaSetHorizonTemplates(m.Spec.Connectivity.ReplicaSetHorizonTemplates, m.getHorizonTemplateValues(podNum))
+		if err != nil {
+			return nil
+		}
+		horizons = append(horizons, horizon)
+	}
+	return horizons
+}
+
+// getHorizonTemplateValues returns the values of the horizon template placeholders for the member podNum.
+func (m *MongoDB) getHorizonTemplateValues(podNum int) map[string]string {
+	podName := dns.GetPodName(m.Name, podNum)
+	externalDomain := m.Spec.GetExternalDomain()
+	values := map[string]string{
+		HorizonPlaceholderPodName:           podName,
+		HorizonPlaceholderPodIndex:          fmt.Sprintf("%d", podNum),
+		HorizonPlaceholderNamespace:         m.Namespace,
+		HorizonPlaceholderResourceName:      m.Name,
+		HorizonPlaceholderStatefulSetName:   m.Name,
+		HorizonPlaceholderMongodProcessFQDN: dns.GetPodFQDN(podName, m.ServiceName(), m.Namespace, m.Spec.GetClusterDomain(), externalDomain),
+		HorizonPlaceholderPort:              fmt.Sprintf("%d", m.Spec.GetAdditionalMongodConfig().GetPortOrDefault()),
+	}
+	if externalDomain != nil {
+		values[HorizonPlaceholderExternalDomain] = *externalDomain
+	}
+	return values
+}
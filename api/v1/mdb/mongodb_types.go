@@ -75,6 +75,7 @@ const (
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=mongodb,scope=Namespaced,shortName=mdb
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB deployment."
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Version of MongoDB server."
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="The type of MongoDB deployment. One of 'ReplicaSet', 'ShardedCluster' and 'Standalone'."
@@ -148,6 +149,11 @@ func (m *MongoDB) GetPrometheus() *mdbcv1.Prometheus {
 	return m.Spec.Prometheus
 }
 
+// GetStorageAutoscaling returns the storage autoscaling configuration of the data volume, or nil if not enabled.
+func (m *MongoDB) GetStorageAutoscaling() *StorageAutoscaling {
+	return m.Spec.StorageAutoscaling
+}
+
 func (m *MongoDB) GetBackupSpec() *Backup {
 	return m.Spec.Backup
 }
@@ -347,6 +353,18 @@ type MongoDBConnectivity struct {
 	// optionally, the port that this mongod node will be connected to from.
 	// +optional
 	ReplicaSetHorizons []MongoDBHorizonConfig `json:"replicaSetHorizons,omitempty"`
+	// ReplicaSetHorizonTemplates maps horizon names to templates of the addresses of the members, which the operator
+	// expands for every member of the replica set on each reconciliation, e.g.:
+	//  {
+	//    "external": "{podName}.{externalDomain}:{port}"
+	//  }
+	// The following placeholders can be used: {podName}, {podIndex}, {namespace}, {resourceName},
+	// {statefulSetName}, {mongodProcessFQDN}, {externalDomain} and {port}. MongoDBMultiCluster resources can also
+	// use {clusterName} and {clusterIndex}, and, when spec.externalAddressDiscovery is enabled, {externalAddress}
+	// and {externalPort}, which are replaced with the address discovered from the external Service of the member.
+	// Can't be used together with ReplicaSetHorizons.
+	// +optional
+	ReplicaSetHorizonTemplates map[string]string `json:"replicaSetHorizonTemplates,omitempty"`
 }
 
 type MongoDbStatus struct {
@@ -443,6 +461,11 @@ type MongoDbSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
 	MemberConfig []automationconfig.MemberOptions `json:"memberConfig,omitempty"`
+
+	// StorageAutoscaling enables the automatic expansion of the data volume based on the disk usage reported by the
+	// agents. It is only supported by replica sets.
+	// +optional
+	StorageAutoscaling *StorageAutoscaling `json:"storageAutoscaling,omitempty"`
 }
 
 func (m *MongoDbSpec) GetExternalDomain() *string {
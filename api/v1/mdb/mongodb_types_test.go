@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 
 	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connectionstring"
@@ -465,3 +466,28 @@ func TestAdditionalMongodConfigMarshalJSON(t *testing.T) {
 	actual := unmarshalledSpec.AdditionalMongodConfig.ToMap()
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetReplicaSetHorizons(t *testing.T) {
+	rs := NewReplicaSetBuilder().SetName("my-rs").SetNamespace("my-ns").Build()
+	rs.Spec.Connectivity.ReplicaSetHorizons = []MongoDBHorizonConfig{{"external": "a:1"}, {"external": "b:1"}, {"external": "c:1"}}
+	assert.Equal(t, rs.Spec.Connectivity.ReplicaSetHorizons, rs.GetReplicaSetHorizons(3))
+
+	rs.Spec.Connectivity.ReplicaSetHorizons = nil
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{
+		"external": "{podName}.{externalDomain}:{port}",
+		"internal": "{mongodProcessFQDN}:{port}",
+	}
+	rs.Spec.ExternalAccessConfiguration = &ExternalAccessConfiguration{ExternalDomain: ptr.To("example.com")}
+	rs.Spec.AdditionalMongodConfig = NewAdditionalMongodConfig("net.port", 30000)
+
+	assert.Equal(t, []MongoDBHorizonConfig{
+		{"external": "my-rs-0.example.com:30000", "internal": "my-rs-0.example.com:30000"},
+		{"external": "my-rs-1.example.com:30000", "internal": "my-rs-1.example.com:30000"},
+	}, rs.GetReplicaSetHorizons(2))
+
+	rs.Spec.ExternalAccessConfiguration = nil
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"internal": "{mongodProcessFQDN}:{port}"}
+	assert.Equal(t, []MongoDBHorizonConfig{
+		{"internal": "my-rs-0.my-rs-svc.my-ns.svc.cluster.local:30000"},
+	}, rs.GetReplicaSetHorizons(1))
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,12 +37,42 @@ func (m *MongoDB) ValidateDelete() (admission.Warnings, error) {
 }
 
 func replicaSetHorizonsRequireTLS(d DbCommonSpec) v1.ValidationResult {
-	if len(d.Connectivity.ReplicaSetHorizons) > 0 && !d.IsSecurityTLSConfigEnabled() {
+	if (len(d.Connectivity.ReplicaSetHorizons) > 0 || d.Connectivity.HasReplicaSetHorizonTemplates()) && !d.IsSecurityTLSConfigEnabled() {
 		return v1.ValidationError("TLS must be enabled in order to use replica set horizons")
 	}
 	return v1.ValidationSuccess()
 }
 
+func replicaSetHorizonTemplatesExcludeHorizons(d DbCommonSpec) v1.ValidationResult {
+	if len(d.Connectivity.ReplicaSetHorizons) > 0 && d.Connectivity.HasReplicaSetHorizonTemplates() {
+		return v1.ValidationError("spec.connectivity.replicaSetHorizons and spec.connectivity.replicaSetHorizonTemplates can't be set at the same time")
+	}
+	return v1.ValidationSuccess()
+}
+
+func replicaSetHorizonTemplatesAreValid(ms MongoDbSpec) v1.ValidationResult {
+	if !ms.Connectivity.HasReplicaSetHorizonTemplates() {
+		return v1.ValidationSuccess()
+	}
+	if ms.ResourceType != ReplicaSet {
+		return v1.ValidationError("spec.connectivity.replicaSetHorizonTemplates can only be used with replica sets")
+	}
+
+	placeholders := []string{
+		HorizonPlaceholderPodName,
+		HorizonPlaceholderPodIndex,
+		HorizonPlaceholderNamespace,
+		HorizonPlaceholderResourceName,
+		HorizonPlaceholderStatefulSetName,
+		HorizonPlaceholderMongodProcessFQDN,
+		HorizonPlaceholderPort,
+	}
+	if ms.GetExternalDomain() != nil {
+		placeholders = append(placeholders, HorizonPlaceholderExternalDomain)
+	}
+	return ValidateReplicaSetHorizonTemplatePlaceholders(ms.Connectivity.ReplicaSetHorizonTemplates, placeholders...)
+}
+
 func horizonsMustEqualMembers(ms MongoDbSpec) v1.ValidationResult {
 	numHorizonMembers := len(ms.Connectivity.ReplicaSetHorizons)
 	if numHorizonMembers > 0 && numHorizonMembers != ms.Members {
@@ -333,6 +364,24 @@ func replicasetMemberIsSpecified(ms MongoDbSpec) v1.ValidationResult {
 	return v1.ValidationSuccess()
 }
 
+func storageAutoscalingIsValid(ms MongoDbSpec) v1.ValidationResult {
+	if ms.StorageAutoscaling == nil {
+		return v1.ValidationSuccess()
+	}
+	if ms.ResourceType != ReplicaSet {
+		return v1.ValidationError("'spec.storageAutoscaling' can only be specified if type of MongoDB is %s", ReplicaSet)
+	}
+	autoscaling := ms.StorageAutoscaling
+	increment, err := resource.ParseQuantity(autoscaling.Increment)
+	if err != nil || increment.Sign() <= 0 {
+		return v1.ValidationError("'spec.storageAutoscaling.increment' must be a positive quantity, got %q", autoscaling.Increment)
+	}
+	if _, err := resource.ParseQuantity(autoscaling.MaxSize); err != nil {
+		return v1.ValidationError("'spec.storageAutoscaling.maxSize' must be a quantity, got %q", autoscaling.MaxSize)
+	}
+	return v1.ValidationSuccess()
+}
+
 func agentModeIsSetIfMoreThanADeploymentAuthModeIsSet(d DbCommonSpec) v1.ValidationResult {
 	if d.Security == nil || d.Security.Authentication == nil {
 		return v1.ValidationSuccess()
@@ -408,6 +457,7 @@ func specWithExactlyOneSchema(d DbCommonSpec) v1.ValidationResult {
 func CommonValidators(db DbCommonSpec) []func(d DbCommonSpec) v1.ValidationResult {
 	validators := []func(d DbCommonSpec) v1.ValidationResult{
 		replicaSetHorizonsRequireTLS,
+		replicaSetHorizonTemplatesExcludeHorizons,
 		deploymentsMustHaveTLSInX509Env,
 		deploymentsMustHaveAtLeastOneAuthModeIfAuthIsEnabled,
 		deploymentsMustHaveAgentModeInAuthModes,
@@ -449,8 +499,10 @@ func (m *MongoDB) RunValidations(old *MongoDB) []v1.ValidationResult {
 	// Topology field
 	mongoDBValidators := []func(m MongoDbSpec) v1.ValidationResult{
 		horizonsMustEqualMembers,
+		replicaSetHorizonTemplatesAreValid,
 		additionalMongodConfig,
 		replicasetMemberIsSpecified,
+		storageAutoscalingIsValid,
 	}
 
 	updateValidators := []func(newObj MongoDbSpec, oldObj MongoDbSpec) v1.ValidationResult{
@@ -233,6 +233,29 @@ func TestReplicasetMemberIsSpecified(t *testing.T) {
 	require.NoError(t, rs.ProcessValidationsOnReconcile(nil))
 }
 
+func TestStorageAutoscalingValidation(t *testing.T) {
+	rs := NewReplicaSetBuilder().Build()
+	rs.Spec.CloudManagerConfig = &PrivateCloudConfig{
+		ConfigMapRef: ConfigMapRef{Name: "cloud-manager"},
+	}
+	rs.Spec.StorageAutoscaling = &StorageAutoscaling{Increment: "10Gi", MaxSize: "100Gi"}
+	require.NoError(t, rs.ProcessValidationsOnReconcile(nil))
+
+	rs.Spec.StorageAutoscaling.Increment = "0"
+	assert.ErrorContains(t, rs.ProcessValidationsOnReconcile(nil), "'spec.storageAutoscaling.increment' must be a positive quantity")
+
+	rs.Spec.StorageAutoscaling.Increment = "10Gi"
+	rs.Spec.StorageAutoscaling.MaxSize = "lots"
+	assert.ErrorContains(t, rs.ProcessValidationsOnReconcile(nil), "'spec.storageAutoscaling.maxSize' must be a quantity")
+
+	standalone := NewStandaloneBuilder().Build()
+	standalone.Spec.CloudManagerConfig = &PrivateCloudConfig{
+		ConfigMapRef: ConfigMapRef{Name: "cloud-manager"},
+	}
+	standalone.Spec.StorageAutoscaling = &StorageAutoscaling{Increment: "10Gi", MaxSize: "100Gi"}
+	assert.ErrorContains(t, standalone.ProcessValidationsOnReconcile(nil), "can only be specified if type of MongoDB is ReplicaSet")
+}
+
 func TestReplicasetFCV(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -673,3 +696,38 @@ func TestOIDCProviderConfigUniqueIssuerURIValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDB_ProcessValidations_HorizonTemplates(t *testing.T) {
+	rs := NewReplicaSetBuilder().Build()
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.example.com:{port}"}
+	err := rs.ProcessValidationsOnReconcile(nil)
+	assert.Equal(t, "TLS must be enabled in order to use replica set horizons", err.Error())
+
+	rs = NewReplicaSetBuilder().SetSecurityTLSEnabled().SetMembers(5).AddDummyOpsManagerConfig().Build()
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.example.com:{port}"}
+	// the number of members doesn't have to match anything, as the horizons are generated for every member
+	assert.NoError(t, rs.ProcessValidationsOnReconcile(nil))
+
+	rs.Spec.Connectivity.ReplicaSetHorizons = []MongoDBHorizonConfig{{"a": "a:1"}, {"a": "b:1"}, {"a": "c:1"}, {"a": "d:1"}, {"a": "e:1"}}
+	err = rs.ProcessValidationsOnReconcile(nil)
+	assert.Equal(t, "spec.connectivity.replicaSetHorizons and spec.connectivity.replicaSetHorizonTemplates can't be set at the same time", err.Error())
+
+	rs.Spec.Connectivity.ReplicaSetHorizons = nil
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.{externalDomain}:{port}"}
+	err = rs.ProcessValidationsOnReconcile(nil)
+	assert.ErrorContains(t, err, "missing values for the following placeholders: {externalDomain}")
+
+	rs.Spec.ExternalAccessConfiguration = &ExternalAccessConfiguration{ExternalDomain: ptr.To("example.com")}
+	assert.NoError(t, rs.ProcessValidationsOnReconcile(nil))
+
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{externalAddress}:{port}"}
+	err = rs.ProcessValidationsOnReconcile(nil)
+	assert.ErrorContains(t, err, "missing values for the following placeholders: {externalAddress}")
+}
+
+func TestMongoDB_ProcessValidations_HorizonTemplatesOnlyForReplicaSets(t *testing.T) {
+	sc := NewDefaultShardedClusterBuilder().SetSecurityTLSEnabled().Build()
+	sc.Spec.Connectivity = &MongoDBConnectivity{ReplicaSetHorizonTemplates: map[string]string{"external": "{podName}.example.com:{port}"}}
+	err := sc.ProcessValidationsOnReconcile(nil)
+	assert.Equal(t, "spec.connectivity.replicaSetHorizonTemplates can only be used with replica sets", err.Error())
+}
@@ -0,0 +1,27 @@
+package mdb
+
+// StorageAutoscaling configures the expansion of the data volume once its used space goes above a threshold.
+// The storage class of the volume must allow volume expansion.
+type StorageAutoscaling struct {
+	// ThresholdPercent is the percentage of used disk space above which the data volume is expanded.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default=80
+	// +optional
+	ThresholdPercent int `json:"thresholdPercent,omitempty"`
+	// Increment is the amount of storage added to the data volume on every expansion, e.g. "10Gi".
+	Increment string `json:"increment"`
+	// MaxSize is the size the data volume is never expanded beyond, e.g. "500Gi".
+	MaxSize string `json:"maxSize"`
+}
+
+// DefaultStorageAutoscalingThresholdPercent is used when the threshold is not set.
+const DefaultStorageAutoscalingThresholdPercent = 80
+
+// GetThresholdPercent returns the threshold percent or the default one if not set.
+func (s *StorageAutoscaling) GetThresholdPercent() int {
+	if s.ThresholdPercent == 0 {
+		return DefaultStorageAutoscalingThresholdPercent
+	}
+	return s.ThresholdPercent
+}
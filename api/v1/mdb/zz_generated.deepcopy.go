@@ -708,6 +708,13 @@ func (in *MongoDBConnectivity) DeepCopyInto(out *MongoDBConnectivity) {
 			}
 		}
 	}
+	if in.ReplicaSetHorizonTemplates != nil {
+		in, out := &in.ReplicaSetHorizonTemplates, &out.ReplicaSetHorizonTemplates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBConnectivity.
@@ -862,6 +869,11 @@ func (in *MongoDbSpec) DeepCopyInto(out *MongoDbSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StorageAutoscaling != nil {
+		in, out := &in.StorageAutoscaling, &out.StorageAutoscaling
+		*out = new(StorageAutoscaling)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDbSpec.
@@ -1480,6 +1492,21 @@ func (in StartupParameters) DeepCopy() StartupParameters {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscaling) DeepCopyInto(out *StorageAutoscaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscaling.
+func (in *StorageAutoscaling) DeepCopy() *StorageAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -0,0 +1,165 @@
+package mdbmulti
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+)
+
+// DefaultExternalAddressHorizonName is the name of the replica set horizon the discovered NodePort addresses are
+// added to when no horizon name is configured.
+const DefaultExternalAddressHorizonName = "external"
+
+// ExternalDNSHostnameAnnotation is the annotation ExternalDNS reads the hostnames to publish for a Service from.
+const ExternalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
+// ExternalAddressDiscovery makes the operator expose every member through its own external Service in its member
+// cluster and configure the replica set with the addresses assigned to these Services, so that the members can
+// reach each other across clusters without a service mesh or manually managed DNS records.
+type ExternalAddressDiscovery struct {
+	// ServiceType is the type of the external Service of each member.
+	// With LoadBalancer, the address assigned to the load balancer is used as the hostname of the member.
+	// With NodePort, the Service is reachable on the address of a node of the member cluster. As the node port differs
+	// from the port mongod listens on, the address is added to the replica set horizon HorizonName instead.
+	// Defaults to LoadBalancer.
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+	// HorizonName is the name of the replica set horizon the NodePort addresses are added to. Defaults to "external".
+	// Not used when spec.connectivity.replicaSetHorizonTemplates is set, as the horizons are generated from the templates.
+	// +optional
+	HorizonName string `json:"horizonName,omitempty"`
+	// PublishDNS adds the external-dns.alpha.kubernetes.io/hostname annotation to the external Services, so that
+	// ExternalDNS publishes their addresses under the external domain of the member cluster. The members then use
+	// the external domain hostnames instead of the discovered addresses.
+	// +optional
+	PublishDNS bool `json:"publishDNS,omitempty"`
+}
+
+// ExternalAddress is the address the external Service of a member is reachable on.
+type ExternalAddress struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+// IsExternalAddressDiscoveryEnabled returns true if the operator configures the members with the addresses of their
+// external Services.
+func (m *MongoDBMultiCluster) IsExternalAddressDiscoveryEnabled() bool {
+	return m.Spec.ExternalAddressDiscovery != nil
+}
+
+// GetExternalAddressDiscoveryServiceType returns the type of the external Services whose addresses are discovered.
+func (m *MongoDBMultiCluster) GetExternalAddressDiscoveryServiceType() corev1.ServiceType {
+	if m.Spec.ExternalAddressDiscovery == nil || m.Spec.ExternalAddressDiscovery.ServiceType == "" {
+		return corev1.ServiceTypeLoadBalancer
+	}
+	return m.Spec.ExternalAddressDiscovery.ServiceType
+}
+
+// GetExternalAddressHorizonName returns the name of the replica set horizon the NodePort addresses are added to.
+func (m *MongoDBMultiCluster) GetExternalAddressHorizonName() string {
+	if m.Spec.ExternalAddressDiscovery == nil || m.Spec.ExternalAddressDiscovery.HorizonName == "" {
+		return DefaultExternalAddressHorizonName
+	}
+	return m.Spec.ExternalAddressDiscovery.HorizonName
+}
+
+// ShouldPublishExternalDNS returns true if the external Services are annotated for ExternalDNS.
+func (m *MongoDBMultiCluster) ShouldPublishExternalDNS() bool {
+	return m.Spec.ExternalAddressDiscovery != nil && m.Spec.ExternalAddressDiscovery.PublishDNS
+}
+
+// GetProcessHostnames returns the hostnames of the processes deployed in the member cluster. When the addresses of
+// the load balancers are discovered and the cluster doesn't use an external domain, the discovered addresses are
+// returned instead of the pod Service FQDNs.
+func (m *MongoDBMultiCluster) GetProcessHostnames(clusterName string, members int) []string {
+	clusterNum := m.ClusterNum(clusterName)
+	externalDomain := m.Spec.GetExternalDomainForMemberCluster(clusterName)
+	hostnames := dns.GetMultiClusterProcessHostnames(m.Name, m.Namespace, clusterNum, members, m.Spec.GetClusterDomain(), externalDomain)
+	if !m.IsExternalAddressDiscoveryEnabled() || m.GetExternalAddressDiscoveryServiceType() != corev1.ServiceTypeLoadBalancer || externalDomain != nil {
+		return hostnames
+	}
+
+	for podNum := range hostnames {
+		if address, ok := m.Status.ExternalAddresses[dns.GetMultiPodName(m.Name, clusterNum, podNum)]; ok && address.Host != "" {
+			hostnames[podNum] = address.Host
+		}
+	}
+	return hostnames
+}
+
+// validateExternalAddressDiscovery validates that every member cluster has external Services the addresses can be
+// discovered from.
+func validateExternalAddressDiscovery(ms MongoDBMultiSpec) v1.ValidationResult {
+	if ms.ExternalAddressDiscovery == nil {
+		return v1.ValidationSuccess()
+	}
+
+	for _, item := range ms.ClusterSpecList {
+		if ms.GetExternalAccessConfigurationForMemberCluster(item.ClusterName) == nil {
+			return v1.ValidationError("spec.externalAddressDiscovery requires spec.externalAccess to be configured for member cluster %s", item.ClusterName)
+		}
+		if ms.ExternalAddressDiscovery.PublishDNS && ms.GetExternalDomainForMemberCluster(item.ClusterName) == nil {
+			return v1.ValidationError("spec.externalAddressDiscovery.publishDNS requires an externalDomain for member cluster %s", item.ClusterName)
+		}
+	}
+
+	if ms.ExternalAddressDiscovery.ServiceType == corev1.ServiceTypeNodePort {
+		if !ms.IsSecurityTLSConfigEnabled() {
+			return v1.ValidationError("TLS must be enabled in order to discover NodePort addresses, as they are added to the replica set horizons")
+		}
+		if ms.Connectivity != nil && len(ms.Connectivity.ReplicaSetHorizons) > 0 {
+			return v1.ValidationError("spec.connectivity.replicaSetHorizons can't be set when the NodePort addresses are discovered")
+		}
+	}
+	return v1.ValidationSuccess()
+}
+
+// GetExternalAddressHorizons returns the horizons of the processes of the clusters in clusterSpecList, in the order
+// the processes are created in, or false if the address of any of them hasn't been discovered yet. When the
+// addresses are published with ExternalDNS, the external domain hostnames are used with the discovered node ports.
+func (m *MongoDBMultiCluster) GetExternalAddressHorizons(clusterSpecList mdbv1.ClusterSpecList) ([]mdbv1.MongoDBHorizonConfig, bool) {
+	var horizons []mdbv1.MongoDBHorizonConfig
+	for _, item := range clusterSpecList {
+		clusterHorizons, ok := m.GetExternalAddressHorizonsForMemberCluster(item.ClusterName, item.Members)
+		if !ok {
+			return nil, false
+		}
+		horizons = append(horizons, clusterHorizons...)
+	}
+	return horizons, true
+}
+
+// GetExternalAddressHorizonsForMemberCluster returns the horizons of the first members processes deployed in the
+// member cluster, or false if the address of any of them hasn't been discovered yet.
+func (m *MongoDBMultiCluster) GetExternalAddressHorizonsForMemberCluster(clusterName string, members int) ([]mdbv1.MongoDBHorizonConfig, bool) {
+	horizonName := m.GetExternalAddressHorizonName()
+	clusterNum := m.ClusterNum(clusterName)
+	externalDomain := m.Spec.GetExternalDomainForMemberCluster(clusterName)
+	var horizons []mdbv1.MongoDBHorizonConfig
+	for podNum := 0; podNum < members; podNum++ {
+		address, ok := m.Status.ExternalAddresses[dns.GetMultiPodName(m.Name, clusterNum, podNum)]
+		if !ok || address.Host == "" || address.Port == 0 {
+			return nil, false
+		}
+		host := address.Host
+		if m.ShouldPublishExternalDNS() && externalDomain != nil {
+			host = dns.GetMultiClusterPodServiceFQDN(m.Name, m.Namespace, clusterNum, externalDomain, podNum, m.Spec.GetClusterDomain())
+		}
+		horizons = append(horizons, mdbv1.MongoDBHorizonConfig{horizonName: fmt.Sprintf("%s:%d", host, address.Port)})
+	}
+	return horizons, true
+}
+
+// noExternalAddressDiscoveryToggle validates that the discovery of the external addresses is neither enabled nor
+// disabled on an existing deployment, as it changes the hostnames of all of its processes.
+func noExternalAddressDiscoveryToggle(newSpec, lastSpec MongoDBMultiSpec) v1.ValidationResult {
+	if (newSpec.ExternalAddressDiscovery == nil) != (lastSpec.ExternalAddressDiscovery == nil) {
+		return v1.ValidationError("spec.externalAddressDiscovery can't be enabled or disabled on an existing deployment, as it changes the hostnames of its processes")
+	}
+	return v1.ValidationSuccess()
+}
@@ -0,0 +1,149 @@
+package mdbmulti
+
+import (
+	"time"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+)
+
+type FailoverStrategy string
+
+// DefaultFailbackStableFor is the default time a failed cluster has to be healthy for before its members are moved
+// back.
+const DefaultFailbackStableFor = 300 * time.Second
+
+const (
+	// FailoverStrategyEven moves the members of the failed cluster one by one to the healthy cluster with the fewest
+	// members.
+	FailoverStrategyEven FailoverStrategy = "Even"
+	// FailoverStrategyRegionAware moves the members of the failed cluster to the healthy clusters in the same region,
+	// preferring the clusters in other zones, so the number of votes of each region is preserved. The region and zone
+	// of a cluster are read from the topology.kubernetes.io/region and topology.kubernetes.io/zone labels of its
+	// MongoDBMemberCluster resource. A member moved out of the region which has the majority of the votes is made
+	// non-voting so the region keeps the majority.
+	FailoverStrategyRegionAware FailoverStrategy = "RegionAware"
+	// FailoverStrategyFreeze only marks the cluster as failed, the members are not moved.
+	FailoverStrategyFreeze FailoverStrategy = "Freeze"
+)
+
+// FailoverPolicy configures how the operator moves the members of a failed member cluster when automated failover
+// is enabled.
+type FailoverPolicy struct {
+	// Strategy used to choose the clusters the members of a failed cluster are moved to. Defaults to Even.
+	// +kubebuilder:validation:Enum=Even;RegionAware;Freeze
+	// +optional
+	Strategy FailoverStrategy `json:"strategy,omitempty"`
+	// PreferredTargets are the clusters the members of a failed cluster are moved to before the Strategy is applied.
+	// The strategy is used if none of the preferred targets is healthy.
+	// +optional
+	PreferredTargets []PreferredFailoverTargets `json:"preferredTargets,omitempty"`
+	// Failback configures moving the members back to the clusters they were moved from once the failed clusters
+	// recover.
+	// +optional
+	Failback *FailbackPolicy `json:"failback,omitempty"`
+}
+
+// FailbackPolicy configures the automatic failback. Once a failed cluster is healthy for StableForSeconds, it is
+// unmarked as failed and the members are moved back to the distribution of spec.clusterSpecList one at a time,
+// waiting for the resource to reach the goal state between the moves.
+type FailbackPolicy struct {
+	// Enabled enables the automatic failback. The failed clusters have to be recovered manually otherwise.
+	Enabled bool `json:"enabled"`
+	// StableForSeconds is the time a failed cluster has to be healthy for before its members are moved back.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StableForSeconds *int `json:"stableForSeconds,omitempty"`
+}
+
+type PreferredFailoverTargets struct {
+	// ClusterName is the name of the failed cluster.
+	ClusterName string `json:"clusterName"`
+	// Targets are the clusters the members are evenly distributed to.
+	// +kubebuilder:validation:MinItems=1
+	Targets []string `json:"targets"`
+}
+
+// FailoverStatus is the plan the operator chose to fail over the members of the failed clusters.
+type FailoverStatus struct {
+	Strategy FailoverStrategy `json:"strategy"`
+	// FailedClusters are the clusters marked as failed.
+	FailedClusters []string `json:"failedClusters,omitempty"`
+	// Members is the number of members of each cluster after the failover. It's empty if the members are not moved.
+	Members []FailoverClusterMembers `json:"members,omitempty"`
+	Message string                   `json:"message,omitempty"`
+	// PlannedAt is the time the plan was chosen.
+	PlannedAt string `json:"plannedAt,omitempty"`
+}
+
+type FailoverClusterMembers struct {
+	ClusterName string `json:"clusterName"`
+	Members     int    `json:"members"`
+}
+
+// GetFailoverStrategy returns the strategy used to fail over the members of a failed cluster.
+func (m *MongoDBMultiCluster) GetFailoverStrategy() FailoverStrategy {
+	if m.Spec.Failover == nil || m.Spec.Failover.Strategy == "" {
+		return FailoverStrategyEven
+	}
+	return m.Spec.Failover.Strategy
+}
+
+// IsFailbackEnabled returns true if the members are moved back to the recovered clusters automatically.
+func (m *MongoDBMultiCluster) IsFailbackEnabled() bool {
+	return m.Spec.Failover != nil && m.Spec.Failover.Failback != nil && m.Spec.Failover.Failback.Enabled
+}
+
+// GetFailbackStableFor returns the time a failed cluster has to be healthy for before its members are moved back.
+func (m *MongoDBMultiCluster) GetFailbackStableFor() time.Duration {
+	if m.Spec.Failover == nil || m.Spec.Failover.Failback == nil || m.Spec.Failover.Failback.StableForSeconds == nil {
+		return DefaultFailbackStableFor
+	}
+	return time.Duration(*m.Spec.Failover.Failback.StableForSeconds) * time.Second
+}
+
+// GetPreferredFailoverTargets returns the clusters preferred to move the members of the failed cluster to.
+func (m *MongoDBMultiCluster) GetPreferredFailoverTargets(clusterName string) []string {
+	if m.Spec.Failover == nil {
+		return nil
+	}
+	for _, preferred := range m.Spec.Failover.PreferredTargets {
+		if preferred.ClusterName == clusterName {
+			return preferred.Targets
+		}
+	}
+	return nil
+}
+
+// validateFailoverPolicy validates that the preferred failover targets reference the clusters of the clusterSpecList.
+func validateFailoverPolicy(ms MongoDBMultiSpec) v1.ValidationResult {
+	if ms.Failover == nil {
+		return v1.ValidationSuccess()
+	}
+
+	clusterNames := map[string]struct{}{}
+	for _, item := range ms.ClusterSpecList {
+		clusterNames[item.ClusterName] = struct{}{}
+	}
+
+	failedClusters := map[string]struct{}{}
+	for _, preferred := range ms.Failover.PreferredTargets {
+		if _, ok := failedClusters[preferred.ClusterName]; ok {
+			return v1.ValidationError("The preferred failover targets of cluster %s are defined more than once", preferred.ClusterName)
+		}
+		failedClusters[preferred.ClusterName] = struct{}{}
+
+		if _, ok := clusterNames[preferred.ClusterName]; !ok {
+			return v1.ValidationWarning("The preferred failover targets are defined for cluster %s, which is not in spec.clusterSpecList", preferred.ClusterName)
+		}
+		for _, target := range preferred.Targets {
+			if target == preferred.ClusterName {
+				return v1.ValidationError("The cluster %s can't be its own preferred failover target", target)
+			}
+			if _, ok := clusterNames[target]; !ok {
+				return v1.ValidationError("The preferred failover target %s of cluster %s is not in spec.clusterSpecList", target, preferred.ClusterName)
+			}
+		}
+	}
+	return v1.ValidationSuccess()
+}
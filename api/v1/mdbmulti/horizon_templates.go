@@ -0,0 +1,98 @@
+package mdbmulti
+
+import (
+	"fmt"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+)
+
+// GetReplicaSetHorizons returns the horizons of the processes of the clusters in clusterSpecList, in the order the
+// processes are created in, expanding the horizon templates for every member. It returns false if the templates
+// can't be expanded yet because the external address of any of the members hasn't been discovered.
+func (m *MongoDBMultiCluster) GetReplicaSetHorizons(clusterSpecList mdbv1.ClusterSpecList) ([]mdbv1.MongoDBHorizonConfig, bool) {
+	var horizons []mdbv1.MongoDBHorizonConfig
+	for _, item := range clusterSpecList {
+		clusterHorizons, ok := m.GetReplicaSetHorizonsForMemberCluster(item.ClusterName, item.Members)
+		if !ok {
+			return nil, false
+		}
+		horizons = append(horizons, clusterHorizons...)
+	}
+	return horizons, true
+}
+
+// GetReplicaSetHorizonsForMemberCluster returns the horizons of the first members processes deployed in the member
+// cluster, or false if the horizon templates can't be expanded for all of them.
+func (m *MongoDBMultiCluster) GetReplicaSetHorizonsForMemberCluster(clusterName string, members int) ([]mdbv1.MongoDBHorizonConfig, bool) {
+	horizons := make([]mdbv1.MongoDBHorizonConfig, 0, members)
+	for podNum := 0; podNum < members; podNum++ {
+		horizon, err := mdbv1.ExpandReplicaSetHorizonTemplates(m.Spec.Connectivity.ReplicaSetHorizonTemplates, m.getHorizonTemplateValues(clusterName, podNum))
+		if err != nil {
+			return nil, false
+		}
+		horizons = append(horizons, horizon)
+	}
+	return horizons, true
+}
+
+// getHorizonTemplateValues returns the values of the horizon template placeholders for the member podNum of the
+// member cluster. The external address placeholders only have values once the address has been discovered.
+func (m *MongoDBMultiCluster) getHorizonTemplateValues(clusterName string, podNum int) map[string]string {
+	clusterNum := m.ClusterNum(clusterName)
+	podName := dns.GetMultiPodName(m.Name, clusterNum, podNum)
+	externalDomain := m.Spec.GetExternalDomainForMemberCluster(clusterName)
+	values := map[string]string{
+		mdbv1.HorizonPlaceholderPodName:           podName,
+		mdbv1.HorizonPlaceholderPodIndex:          fmt.Sprintf("%d", podNum),
+		mdbv1.HorizonPlaceholderNamespace:         m.Namespace,
+		mdbv1.HorizonPlaceholderResourceName:      m.Name,
+		mdbv1.HorizonPlaceholderStatefulSetName:   m.MultiStatefulsetName(clusterNum),
+		mdbv1.HorizonPlaceholderMongodProcessFQDN: dns.GetMultiClusterPodServiceFQDN(m.Name, m.Namespace, clusterNum, externalDomain, podNum, m.Spec.GetClusterDomain()),
+		mdbv1.HorizonPlaceholderPort:              fmt.Sprintf("%d", m.Spec.GetAdditionalMongodConfig().GetPortOrDefault()),
+		mdbv1.HorizonPlaceholderClusterName:       clusterName,
+		mdbv1.HorizonPlaceholderClusterIndex:      fmt.Sprintf("%d", clusterNum),
+	}
+	if externalDomain != nil {
+		values[mdbv1.HorizonPlaceholderExternalDomain] = *externalDomain
+	}
+	if address, ok := m.Status.ExternalAddresses[podName]; ok && address.Host != "" && address.Port != 0 {
+		values[mdbv1.HorizonPlaceholderExternalAddress] = address.Host
+		values[mdbv1.HorizonPlaceholderExternalPort] = fmt.Sprintf("%d", address.Port)
+	}
+	return values
+}
+
+// validateReplicaSetHorizonTemplates validates that the horizon templates only use placeholders which have values
+// for every member.
+func validateReplicaSetHorizonTemplates(ms MongoDBMultiSpec) v1.ValidationResult {
+	if !ms.Connectivity.HasReplicaSetHorizonTemplates() {
+		return v1.ValidationSuccess()
+	}
+
+	placeholders := []string{
+		mdbv1.HorizonPlaceholderPodName,
+		mdbv1.HorizonPlaceholderPodIndex,
+		mdbv1.HorizonPlaceholderNamespace,
+		mdbv1.HorizonPlaceholderResourceName,
+		mdbv1.HorizonPlaceholderStatefulSetName,
+		mdbv1.HorizonPlaceholderMongodProcessFQDN,
+		mdbv1.HorizonPlaceholderPort,
+		mdbv1.HorizonPlaceholderClusterName,
+		mdbv1.HorizonPlaceholderClusterIndex,
+	}
+	hasExternalDomains := len(ms.ClusterSpecList) > 0
+	for _, item := range ms.ClusterSpecList {
+		if ms.GetExternalDomainForMemberCluster(item.ClusterName) == nil {
+			hasExternalDomains = false
+		}
+	}
+	if hasExternalDomains {
+		placeholders = append(placeholders, mdbv1.HorizonPlaceholderExternalDomain)
+	}
+	if ms.ExternalAddressDiscovery != nil {
+		placeholders = append(placeholders, mdbv1.HorizonPlaceholderExternalAddress, mdbv1.HorizonPlaceholderExternalPort)
+	}
+	return mdbv1.ValidateReplicaSetHorizonTemplatePlaceholders(ms.Connectivity.ReplicaSetHorizonTemplates, placeholders...)
+}
@@ -221,6 +221,10 @@ type MongoDBMultiStatus struct {
 	Link                        string              `json:"link,omitempty"`
 	FeatureCompatibilityVersion string              `json:"featureCompatibilityVersion,omitempty"`
 	Warnings                    []status.Warning    `json:"warnings,omitempty"`
+	// Failover is the plan chosen to fail over the members of the failed member clusters.
+	Failover *FailoverStatus `json:"failover,omitempty"`
+	// ExternalAddresses are the addresses discovered for the external Services of the members, by process name.
+	ExternalAddresses map[string]ExternalAddress `json:"externalAddresses,omitempty"`
 }
 
 type MongoDBMultiSpec struct {
@@ -229,6 +233,16 @@ type MongoDBMultiSpec struct {
 
 	ClusterSpecList mdbv1.ClusterSpecList `json:"clusterSpecList,omitempty"`
 
+	// Failover configures how the members of a failed member cluster are moved to the healthy member clusters when
+	// automated failover is enabled.
+	// +optional
+	Failover *FailoverPolicy `json:"failover,omitempty"`
+
+	// ExternalAddressDiscovery makes the operator configure the members with the addresses assigned to their
+	// external Services, so that no service mesh is required to connect the member clusters.
+	// +optional
+	ExternalAddressDiscovery *ExternalAddressDiscovery `json:"externalAddressDiscovery,omitempty"`
+
 	// Mapping stores the deterministic index for a given cluster-name.
 	Mapping map[string]int `json:"-"`
 }
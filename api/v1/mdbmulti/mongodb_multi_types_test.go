@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 )
@@ -38,3 +41,104 @@ func TestMongoDBMultiSpecMinimumMajorVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProcessHostnames_WithExternalAddresses(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().SetName("rs").Build()
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1, ExternalAccessConfiguration: &mdb.ExternalAccessConfiguration{ExternalDomain: ptr.To("def.example.com")}},
+	}
+	mrs.Spec.ExternalAccessConfiguration = &mdb.ExternalAccessConfiguration{}
+	mrs.Status.ExternalAddresses = map[string]ExternalAddress{
+		"rs-0-0": {Host: "lb-0.example.com", Port: 27017},
+		"rs-1-0": {Host: "10.0.0.1", Port: 27017},
+	}
+
+	assert.Equal(t, []string{"rs-0-0-svc.my-namespace.svc.cluster.local", "rs-0-1-svc.my-namespace.svc.cluster.local"}, mrs.GetProcessHostnames("abc", 2))
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{}
+	// the addresses which are not discovered yet fall back to the pod service FQDNs
+	assert.Equal(t, []string{"lb-0.example.com", "rs-0-1-svc.my-namespace.svc.cluster.local"}, mrs.GetProcessHostnames("abc", 2))
+	// the clusters with an external domain keep using it
+	assert.Equal(t, []string{"rs-1-0.def.example.com"}, mrs.GetProcessHostnames("def", 1))
+
+	mrs.Spec.ExternalAddressDiscovery.ServiceType = corev1.ServiceTypeNodePort
+	assert.Equal(t, []string{"rs-0-0-svc.my-namespace.svc.cluster.local", "rs-0-1-svc.my-namespace.svc.cluster.local"}, mrs.GetProcessHostnames("abc", 2))
+}
+
+func TestGetExternalAddressHorizons(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().SetName("rs").Build()
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1},
+	}
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{ServiceType: corev1.ServiceTypeNodePort}
+	mrs.Status.ExternalAddresses = map[string]ExternalAddress{
+		"rs-0-0": {Host: "192.168.0.1", Port: 31000},
+		"rs-0-1": {Host: "192.168.0.1", Port: 31001},
+	}
+
+	_, ok := mrs.GetExternalAddressHorizons(mrs.Spec.ClusterSpecList)
+	assert.False(t, ok)
+	horizons, ok := mrs.GetExternalAddressHorizonsForMemberCluster("abc", 2)
+	assert.True(t, ok)
+	assert.Len(t, horizons, 2)
+
+	mrs.Status.ExternalAddresses["rs-1-0"] = ExternalAddress{Host: "192.168.1.1", Port: 32000}
+	horizons, ok = mrs.GetExternalAddressHorizons(mrs.Spec.ClusterSpecList)
+	assert.True(t, ok)
+	assert.Equal(t, []mdb.MongoDBHorizonConfig{
+		{"external": "192.168.0.1:31000"},
+		{"external": "192.168.0.1:31001"},
+		{"external": "192.168.1.1:32000"},
+	}, horizons)
+
+	mrs.Spec.ExternalAddressDiscovery.HorizonName = "nodeport"
+	mrs.Spec.ExternalAddressDiscovery.PublishDNS = true
+	mrs.Spec.ExternalAccessConfiguration = &mdb.ExternalAccessConfiguration{ExternalDomain: ptr.To("example.com")}
+	horizons, _ = mrs.GetExternalAddressHorizons(mrs.Spec.ClusterSpecList)
+	assert.Equal(t, mdb.MongoDBHorizonConfig{"nodeport": "rs-1-0.example.com:32000"}, horizons[2])
+}
+
+func TestGetReplicaSetHorizons(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().SetName("rs").Build()
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1},
+	}
+	mrs.Spec.ExternalAccessConfiguration = &mdb.ExternalAccessConfiguration{ExternalDomain: ptr.To("example.com")}
+	mrs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{
+		"external": "{podName}.{clusterName}.{externalDomain}:{port}",
+	}
+
+	horizons, ok := mrs.GetReplicaSetHorizons(mrs.Spec.ClusterSpecList)
+	assert.True(t, ok)
+	assert.Equal(t, []mdb.MongoDBHorizonConfig{
+		{"external": "rs-0-0.abc.example.com:27017"},
+		{"external": "rs-0-1.abc.example.com:27017"},
+		{"external": "rs-1-0.def.example.com:27017"},
+	}, horizons)
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{}
+	mrs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{
+		"loadbalancer": "{externalAddress}:{externalPort}",
+	}
+	mrs.Status.ExternalAddresses = map[string]ExternalAddress{
+		"rs-0-0": {Host: "10.0.0.1", Port: 27017},
+		"rs-0-1": {Host: "10.0.0.2", Port: 27017},
+	}
+	_, ok = mrs.GetReplicaSetHorizons(mrs.Spec.ClusterSpecList)
+	assert.False(t, ok)
+
+	horizons, ok = mrs.GetReplicaSetHorizonsForMemberCluster("abc", 2)
+	assert.True(t, ok)
+	assert.Equal(t, []mdb.MongoDBHorizonConfig{
+		{"loadbalancer": "10.0.0.1:27017"},
+		{"loadbalancer": "10.0.0.2:27017"},
+	}, horizons)
+
+	mrs.Status.ExternalAddresses["rs-1-0"] = ExternalAddress{Host: "10.0.1.1", Port: 27017}
+	horizons, ok = mrs.GetReplicaSetHorizons(mrs.Spec.ClusterSpecList)
+	assert.True(t, ok)
+	assert.Equal(t, mdb.MongoDBHorizonConfig{"loadbalancer": "10.0.1.1:27017"}, horizons[2])
+}
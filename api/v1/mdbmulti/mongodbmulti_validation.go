@@ -42,6 +42,13 @@ func (m *MongoDBMultiCluster) ProcessValidationsOnReconcile(old *MongoDBMultiClu
 func (m *MongoDBMultiCluster) RunValidations(old *MongoDBMultiCluster) []v1.ValidationResult {
 	multiClusterValidators := []func(ms MongoDBMultiSpec) v1.ValidationResult{
 		validateUniqueExternalDomains,
+		validateFailoverPolicy,
+		validateExternalAddressDiscovery,
+		validateReplicaSetHorizonTemplates,
+	}
+
+	updateValidators := []func(newSpec, lastSpec MongoDBMultiSpec) v1.ValidationResult{
+		noExternalAddressDiscoveryToggle,
 	}
 
 	// shared validators between MongoDBMulti and AppDB
@@ -74,6 +81,18 @@ func (m *MongoDBMultiCluster) RunValidations(old *MongoDBMultiCluster) []v1.Vali
 		}
 	}
 
+	// the changes are validated against the last achieved spec, as the reconciler doesn't know the previous object
+	lastSpec, err := m.ReadLastAchievedSpec()
+	if err != nil || lastSpec == nil {
+		return validationResults
+	}
+	for _, validator := range updateValidators {
+		res := validator(m.Spec, *lastSpec)
+		if res.Level > 0 {
+			validationResults = append(validationResults, res)
+		}
+	}
+
 	return validationResults
 }
 
@@ -1,15 +1,20 @@
 package mdbmulti
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/utils/ptr"
 
+	corev1 "k8s.io/api/core/v1"
+
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 )
 
 func TestUniqueClusterNames(t *testing.T) {
@@ -149,3 +154,121 @@ users:
 
 	return file
 }
+
+func TestPreferredFailoverTargets(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1},
+	}
+
+	mrs.Spec.Failover = &FailoverPolicy{PreferredTargets: []PreferredFailoverTargets{{ClusterName: "abc", Targets: []string{"ghi"}}}}
+	_, err := mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "The preferred failover target ghi of cluster abc is not in spec.clusterSpecList")
+
+	mrs.Spec.Failover = &FailoverPolicy{PreferredTargets: []PreferredFailoverTargets{{ClusterName: "abc", Targets: []string{"abc"}}}}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "The cluster abc can't be its own preferred failover target")
+
+	mrs.Spec.Failover = &FailoverPolicy{PreferredTargets: []PreferredFailoverTargets{{ClusterName: "abc", Targets: []string{"def"}}}}
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"def"}, mrs.GetPreferredFailoverTargets("abc"))
+	assert.Equal(t, FailoverStrategyEven, mrs.GetFailoverStrategy())
+}
+
+func TestExternalAddressDiscoveryValidation(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1, ExternalAccessConfiguration: &mdbv1.ExternalAccessConfiguration{}},
+	}
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{}
+
+	_, err := mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "spec.externalAddressDiscovery requires spec.externalAccess to be configured for member cluster abc")
+
+	mrs.Spec.ExternalAccessConfiguration = &mdbv1.ExternalAccessConfiguration{}
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	mrs.Spec.ExternalAddressDiscovery.PublishDNS = true
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "spec.externalAddressDiscovery.publishDNS requires an externalDomain for member cluster abc")
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{ServiceType: corev1.ServiceTypeLoadBalancer}
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{ServiceType: corev1.ServiceTypeNodePort}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "TLS must be enabled in order to discover NodePort addresses")
+
+	mrs.Spec.Security = &mdbv1.Security{TLSConfig: &mdbv1.TLSConfig{Enabled: true}}
+	mrs.Spec.Connectivity.ReplicaSetHorizons = []mdbv1.MongoDBHorizonConfig{{"external": "a:1"}, {"external": "b:1"}, {"external": "c:1"}}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "spec.connectivity.replicaSetHorizons can't be set when the NodePort addresses are discovered")
+
+	mrs.Spec.Connectivity.ReplicaSetHorizons = nil
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+}
+
+func TestExternalAddressDiscoveryToggleValidation(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{{ClusterName: "abc", Members: 2}}
+	mrs.Spec.ExternalAccessConfiguration = &mdbv1.ExternalAccessConfiguration{}
+	lastSpec, err := json.Marshal(mrs.Spec)
+	require.NoError(t, err)
+	mrs.Annotations = map[string]string{util.LastAchievedSpec: string(lastSpec)}
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "spec.externalAddressDiscovery can't be enabled or disabled on an existing deployment")
+
+	// a new deployment can discover the addresses from the start
+	mrs.Annotations = nil
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	lastSpec, err = json.Marshal(mrs.Spec)
+	require.NoError(t, err)
+	mrs.Annotations = map[string]string{util.LastAchievedSpec: string(lastSpec)}
+	mrs.Spec.ExternalAddressDiscovery.PublishDNS = false
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	mrs.Spec.ExternalAddressDiscovery = nil
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "spec.externalAddressDiscovery can't be enabled or disabled on an existing deployment")
+}
+
+func TestReplicaSetHorizonTemplatesValidation(t *testing.T) {
+	mrs := DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "abc", Members: 2},
+		{ClusterName: "def", Members: 1},
+	}
+	mrs.Spec.Security = &mdbv1.Security{TLSConfig: &mdbv1.TLSConfig{Enabled: true}}
+	mrs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.{clusterName}.example.com:{port}"}
+
+	_, err := mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	mrs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.{externalDomain}:{port}"}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "missing values for the following placeholders: {externalDomain}")
+
+	mrs.Spec.ClusterSpecList[0].ExternalAccessConfiguration = &mdbv1.ExternalAccessConfiguration{ExternalDomain: ptr.To("abc.example.com")}
+	mrs.Spec.ClusterSpecList[1].ExternalAccessConfiguration = &mdbv1.ExternalAccessConfiguration{ExternalDomain: ptr.To("def.example.com")}
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+
+	mrs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{externalAddress}:{externalPort}"}
+	_, err = mrs.ValidateCreate()
+	assert.ErrorContains(t, err, "missing values for the following placeholders: {externalAddress}, {externalPort}")
+
+	mrs.Spec.ExternalAddressDiscovery = &ExternalAddressDiscovery{}
+	_, err = mrs.ValidateCreate()
+	assert.NoError(t, err)
+}
@@ -69,6 +69,123 @@ func (in *ClusterStatusList) DeepCopy() *ClusterStatusList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailbackPolicy) DeepCopyInto(out *FailbackPolicy) {
+	*out = *in
+	if in.StableForSeconds != nil {
+		in, out := &in.StableForSeconds, &out.StableForSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailbackPolicy.
+func (in *FailbackPolicy) DeepCopy() *FailbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAddress) DeepCopyInto(out *ExternalAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAddress.
+func (in *ExternalAddress) DeepCopy() *ExternalAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAddressDiscovery) DeepCopyInto(out *ExternalAddressDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAddressDiscovery.
+func (in *ExternalAddressDiscovery) DeepCopy() *ExternalAddressDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAddressDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverClusterMembers) DeepCopyInto(out *FailoverClusterMembers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverClusterMembers.
+func (in *FailoverClusterMembers) DeepCopy() *FailoverClusterMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverClusterMembers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverPolicy) DeepCopyInto(out *FailoverPolicy) {
+	*out = *in
+	if in.PreferredTargets != nil {
+		in, out := &in.PreferredTargets, &out.PreferredTargets
+		*out = make([]PreferredFailoverTargets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Failback != nil {
+		in, out := &in.Failback, &out.Failback
+		*out = new(FailbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverPolicy.
+func (in *FailoverPolicy) DeepCopy() *FailoverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverStatus) DeepCopyInto(out *FailoverStatus) {
+	*out = *in
+	if in.FailedClusters != nil {
+		in, out := &in.FailedClusters, &out.FailedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]FailoverClusterMembers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverStatus.
+func (in *FailoverStatus) DeepCopy() *FailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBMultiCluster) DeepCopyInto(out *MongoDBMultiCluster) {
 	*out = *in
@@ -139,6 +256,16 @@ func (in *MongoDBMultiSpec) DeepCopyInto(out *MongoDBMultiSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalAddressDiscovery != nil {
+		in, out := &in.ExternalAddressDiscovery, &out.ExternalAddressDiscovery
+		*out = new(ExternalAddressDiscovery)
+		**out = **in
+	}
 	if in.Mapping != nil {
 		in, out := &in.Mapping, &out.Mapping
 		*out = make(map[string]int, len(*in))
@@ -173,6 +300,18 @@ func (in *MongoDBMultiStatus) DeepCopyInto(out *MongoDBMultiStatus) {
 		*out = make([]status.Warning, len(*in))
 		copy(*out, *in)
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(FailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalAddresses != nil {
+		in, out := &in.ExternalAddresses, &out.ExternalAddresses
+		*out = make(map[string]ExternalAddress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBMultiStatus.
@@ -204,3 +343,23 @@ func (in *MultiReplicaSetBuilder) DeepCopy() *MultiReplicaSetBuilder {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreferredFailoverTargets) DeepCopyInto(out *PreferredFailoverTargets) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferredFailoverTargets.
+func (in *PreferredFailoverTargets) DeepCopy() *PreferredFailoverTargets {
+	if in == nil {
+		return nil
+	}
+	out := new(PreferredFailoverTargets)
+	in.DeepCopyInto(out)
+	return out
+}
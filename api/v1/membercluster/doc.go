@@ -0,0 +1,4 @@
+package membercluster
+
+// +k8s:deepcopy-gen=package
+// +versionName=v1
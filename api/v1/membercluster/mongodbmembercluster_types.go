@@ -0,0 +1,162 @@
+package membercluster
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+)
+
+const (
+	// ConditionRegistered is true once the operator created a client for the member cluster and watches its resources.
+	ConditionRegistered = "Registered"
+	// ConditionHealthy reflects the last health check of the member cluster: the readiness of its API server and its
+	// health score.
+	ConditionHealthy = "Healthy"
+
+	// DefaultTokenKey is the key of the token in the token Secret, as in the Secrets of ServiceAccount tokens.
+	DefaultTokenKey = corev1.ServiceAccountTokenKey
+	// CAKey is the key of the CA certificate in the token Secret, as in the Secrets of ServiceAccount tokens.
+	CAKey = corev1.ServiceAccountRootCAKey
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBMemberCluster{}, &MongoDBMemberClusterList{})
+}
+
+type MongoDBMemberClusterSpec struct {
+	// Name of the member cluster, as referenced in the clusterSpecList of the resources. Defaults to the name of the
+	// MongoDBMemberCluster resource.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="clusterName is immutable"
+	ClusterName string `json:"clusterName,omitempty"`
+	// URL of the API server of the member cluster.
+	// +kubebuilder:validation:Pattern=`^https://`
+	APIServer string `json:"apiServer"`
+	// PEM encoded CA certificate of the API server. Defaults to the "ca.crt" key of the token Secret.
+	// +optional
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// Secret in the namespace of the operator holding the token of the ServiceAccount the operator uses in the
+	// member cluster.
+	TokenSecretRef TokenSecretRef `json:"tokenSecretRef"`
+}
+
+type TokenSecretRef struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key of the token in the Secret. Defaults to "token".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+type MongoDBMemberClusterStatus struct {
+	status.Common `json:",inline"`
+	// Conditions of the member cluster: Registered and Healthy.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// HealthScore of the member cluster from 0 to 100, combining the readiness of its nodes, the scheduling of a
+	// canary pod and, with half the weight, the readiness of the database pods and the heartbeats of the agents. It's
+	// 0 if the API server is not ready.
+	// +optional
+	HealthScore *int             `json:"healthScore,omitempty"`
+	Warnings    []status.Warning `json:"warnings,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the member cluster."
+// +kubebuilder:printcolumn:name="Healthy",type="string",JSONPath=".status.conditions[?(@.type==\"Healthy\")].status",description="Whether the member cluster is healthy."
+// +kubebuilder:printcolumn:name="Score",type="integer",JSONPath=".status.healthScore",description="Health score of the member cluster."
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".metadata.labels.topology\\.kubernetes\\.io/region",description="Region of the member cluster."
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".metadata.labels.topology\\.kubernetes\\.io/zone",description="Zone of the member cluster."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDBMemberCluster resource was created."
+// +kubebuilder:resource:path=mongodbmemberclusters,scope=Cluster,shortName=mdbmc
+
+// MongoDBMemberCluster registers a member cluster the operator deploys the resources of multi-cluster topologies to.
+// The region and zone of the member cluster are set with the labels topology.kubernetes.io/region and
+// topology.kubernetes.io/zone.
+type MongoDBMemberCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBMemberClusterSpec `json:"spec"`
+	// +optional
+	Status MongoDBMemberClusterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBMemberClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDBMemberCluster `json:"items"`
+}
+
+func (m *MongoDBMemberCluster) GetCommonStatus(options ...status.Option) *status.Common {
+	return &m.Status.Common
+}
+
+func (m *MongoDBMemberCluster) GetStatus(...status.Option) interface{} {
+	return m.Status
+}
+
+func (m *MongoDBMemberCluster) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+func (m *MongoDBMemberCluster) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	m.Status.Warnings = warnings
+}
+
+func (m *MongoDBMemberCluster) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	m.Status.UpdateCommonFields(phase, m.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		m.Status.Warnings = append(m.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, ConditionsOption{}); exists {
+		for _, condition := range option.(ConditionsOption).Conditions {
+			condition.ObservedGeneration = m.GetGeneration()
+			meta.SetStatusCondition(&m.Status.Conditions, condition)
+		}
+	}
+	if option, exists := status.GetOption(statusOptions, HealthScoreOption{}); exists {
+		m.Status.HealthScore = option.(HealthScoreOption).Score
+	}
+}
+
+// GetClusterName returns the name the member cluster is referenced with in the clusterSpecList of the resources.
+func (m *MongoDBMemberCluster) GetClusterName() string {
+	if m.Spec.ClusterName != "" {
+		return m.Spec.ClusterName
+	}
+	return m.Name
+}
+
+// GetTokenKey returns the key of the token in the token Secret.
+func (m *MongoDBMemberCluster) GetTokenKey() string {
+	if m.Spec.TokenSecretRef.Key != "" {
+		return m.Spec.TokenSecretRef.Key
+	}
+	return DefaultTokenKey
+}
+
+// Region returns the region of the member cluster, read from the topology.kubernetes.io/region label.
+func (m *MongoDBMemberCluster) Region() string {
+	return m.Labels[corev1.LabelTopologyRegion]
+}
+
+// Zone returns the zone of the member cluster, read from the topology.kubernetes.io/zone label.
+func (m *MongoDBMemberCluster) Zone() string {
+	return m.Labels[corev1.LabelTopologyZone]
+}
+
+// GetCondition returns the condition of the given type, or nil if it isn't set.
+func (m *MongoDBMemberCluster) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(m.Status.Conditions, conditionType)
+}
@@ -0,0 +1,37 @@
+package membercluster
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+)
+
+// ConditionsOption sets the conditions of the MongoDBMemberCluster, the conditions of other types are kept.
+type ConditionsOption struct {
+	Conditions []metav1.Condition
+}
+
+var _ status.Option = ConditionsOption{}
+
+func NewConditionsOption(conditions ...metav1.Condition) ConditionsOption {
+	return ConditionsOption{Conditions: conditions}
+}
+
+func (o ConditionsOption) Value() interface{} {
+	return o.Conditions
+}
+
+// HealthScoreOption sets the health score of the MongoDBMemberCluster.
+type HealthScoreOption struct {
+	Score *int
+}
+
+var _ status.Option = HealthScoreOption{}
+
+func NewHealthScoreOption(score int) HealthScoreOption {
+	return HealthScoreOption{Score: &score}
+}
+
+func (o HealthScoreOption) Value() interface{} {
+	return o.Score
+}
@@ -0,0 +1,56 @@
+package om
+
+import (
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+const (
+	// AppDBClusterSpecOverrideAnnotation holds the AppDB clusterSpecList the members of the failed clusters were moved to
+	AppDBClusterSpecOverrideAnnotation = "appDBClusterSpecOverride"
+	// OpsManagerClusterSpecOverrideAnnotation holds the Ops Manager clusterSpecList the Ops Manager and Backup Daemon
+	// replicas of the failed clusters were moved to
+	OpsManagerClusterSpecOverrideAnnotation = "opsManagerClusterSpecOverride"
+)
+
+// GetFailedClusterNames returns the member clusters marked as failed by the member cluster health checker.
+func (om *MongoDBOpsManager) GetFailedClusterNames() ([]string, error) {
+	val, ok := om.Annotations[failedcluster.FailedClusterAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var failedClusters []failedcluster.FailedCluster
+	if err := json.Unmarshal([]byte(val), &failedClusters); err != nil {
+		return nil, xerrors.Errorf("failed to read the %s annotation: %w", failedcluster.FailedClusterAnnotation, err)
+	}
+	var clusterNames []string
+	for _, c := range failedClusters {
+		clusterNames = append(clusterNames, c.ClusterName)
+	}
+	return clusterNames, nil
+}
+
+// ApplyClusterSpecOverrides replaces the clusterSpecLists of the multi-cluster AppDB and Ops Manager with the
+// overrides set when the members of a failed cluster were moved to the healthy clusters. Only the in-memory
+// resource is changed, spec.clusterSpecList is kept as defined by the user.
+func (om *MongoDBOpsManager) ApplyClusterSpecOverrides() error {
+	if val, ok := om.Annotations[AppDBClusterSpecOverrideAnnotation]; ok && om.Spec.AppDB.IsMultiCluster() {
+		var clusterSpecList mdbv1.ClusterSpecList
+		if err := json.Unmarshal([]byte(val), &clusterSpecList); err != nil {
+			return xerrors.Errorf("failed to read the %s annotation: %w", AppDBClusterSpecOverrideAnnotation, err)
+		}
+		om.Spec.AppDB.ClusterSpecList = clusterSpecList
+	}
+	if val, ok := om.Annotations[OpsManagerClusterSpecOverrideAnnotation]; ok && om.Spec.IsMultiCluster() {
+		var clusterSpecList []ClusterSpecOMItem
+		if err := json.Unmarshal([]byte(val), &clusterSpecList); err != nil {
+			return xerrors.Errorf("failed to read the %s annotation: %w", OpsManagerClusterSpecOverrideAnnotation, err)
+		}
+		om.Spec.ClusterSpecList = clusterSpecList
+	}
+	return nil
+}
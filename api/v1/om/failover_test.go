@@ -0,0 +1,54 @@
+package om
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+func TestGetFailedClusterNames(t *testing.T) {
+	opsManager := NewOpsManagerBuilderDefault().Build()
+	failedClusters, err := opsManager.GetFailedClusterNames()
+	require.NoError(t, err)
+	assert.Empty(t, failedClusters)
+
+	opsManager.Annotations = map[string]string{failedcluster.FailedClusterAnnotation: `[{"ClusterName":"cluster-a","Members":2}]`}
+	failedClusters, err = opsManager.GetFailedClusterNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster-a"}, failedClusters)
+
+	opsManager.Annotations[failedcluster.FailedClusterAnnotation] = "invalid"
+	_, err = opsManager.GetFailedClusterNames()
+	assert.Error(t, err)
+}
+
+func TestApplyClusterSpecOverrides(t *testing.T) {
+	opsManager := NewOpsManagerBuilderDefault().
+		SetAppDBTopology(ClusterTopologyMultiCluster).
+		SetAppDBClusterSpecList(mdbv1.ClusterSpecList{{ClusterName: "cluster-a", Members: 2}, {ClusterName: "cluster-b", Members: 1}}).
+		SetOpsManagerTopology(ClusterTopologyMultiCluster).
+		SetOpsManagerClusterSpecList([]ClusterSpecOMItem{{ClusterName: "cluster-a", Members: 1}, {ClusterName: "cluster-b", Members: 1}}).
+		Build()
+	opsManager.Annotations = map[string]string{
+		AppDBClusterSpecOverrideAnnotation:      `[{"clusterName":"cluster-b","members":3}]`,
+		OpsManagerClusterSpecOverrideAnnotation: `[{"clusterName":"cluster-b","members":2}]`,
+	}
+
+	require.NoError(t, opsManager.ApplyClusterSpecOverrides())
+
+	assert.Equal(t, mdbv1.ClusterSpecList{{ClusterName: "cluster-b", Members: 3}}, opsManager.Spec.AppDB.ClusterSpecList)
+	assert.Equal(t, []ClusterSpecOMItem{{ClusterName: "cluster-b", Members: 2}}, opsManager.Spec.ClusterSpecList)
+}
+
+func TestApplyClusterSpecOverrides_SingleCluster(t *testing.T) {
+	opsManager := NewOpsManagerBuilderDefault().Build()
+	opsManager.Annotations = map[string]string{AppDBClusterSpecOverrideAnnotation: `[{"clusterName":"cluster-b","members":3}]`}
+
+	require.NoError(t, opsManager.ApplyClusterSpecOverrides())
+
+	assert.Empty(t, opsManager.Spec.AppDB.ClusterSpecList)
+}
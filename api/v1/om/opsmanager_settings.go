@@ -0,0 +1,248 @@
+package om
+
+import (
+	"strconv"
+	"strings"
+
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+)
+
+type OpsManagerAuthenticationMethod string
+
+const (
+	OpsManagerAuthenticationMethodDatabase OpsManagerAuthenticationMethod = "Database"
+	OpsManagerAuthenticationMethodLDAP     OpsManagerAuthenticationMethod = "LDAP"
+	OpsManagerAuthenticationMethodSAML     OpsManagerAuthenticationMethod = "SAML"
+
+	// the keys used when the key of a secret reference is not set
+	defaultPasswordSecretKey    = "password"
+	defaultCertificateSecretKey = "tls.crt"
+)
+
+var userSvcClasses = map[OpsManagerAuthenticationMethod]string{
+	OpsManagerAuthenticationMethodDatabase: "com.xgen.svc.mms.svc.user.UserSvcDb",
+	OpsManagerAuthenticationMethodLDAP:     "com.xgen.svc.mms.svc.user.UserSvcLdap",
+	OpsManagerAuthenticationMethodSAML:     "com.xgen.svc.mms.svc.user.UserSvcSaml",
+}
+
+// OpsManagerSettings contains the typed Ops Manager settings rendered into conf-mms.properties. The values referenced
+// in Secrets are passed to Ops Manager without being stored in the resource and must exist in Kubernetes Secrets
+// in the namespace of the resource.
+type OpsManagerSettings struct {
+	// CentralURL is the URL users and agents use to access Ops Manager (mms.centralUrl).
+	// +optional
+	CentralURL string `json:"centralUrl,omitempty"`
+
+	// Proxy configures the HTTP proxy Ops Manager uses for the outgoing connections.
+	// +optional
+	Proxy *OpsManagerProxySettings `json:"proxy,omitempty"`
+
+	// SMTP configures the server Ops Manager sends emails through.
+	// +optional
+	SMTP *OpsManagerSMTPSettings `json:"smtp,omitempty"`
+
+	// Authentication configures how the Ops Manager users authenticate.
+	// +optional
+	Authentication *OpsManagerUserAuthentication `json:"authentication,omitempty"`
+}
+
+type OpsManagerProxySettings struct {
+	Host string `json:"host"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	PasswordSecretRef *userv1.SecretKeyRef `json:"passwordSecretRef,omitempty"`
+	// NonProxyHosts is the list of hosts Ops Manager connects to directly, e.g. "*.svc.cluster.local".
+	// +optional
+	NonProxyHosts []string `json:"nonProxyHosts,omitempty"`
+}
+
+type OpsManagerSMTPSettings struct {
+	// FromEmailAddress is the sender of the emails sent by Ops Manager.
+	FromEmailAddress string `json:"fromEmailAddress"`
+	// ReplyToEmailAddress is the address replies to the emails are sent to.
+	ReplyToEmailAddress string `json:"replyToEmailAddress"`
+	// AdminEmailAddress receives the emails about Ops Manager issues.
+	AdminEmailAddress string `json:"adminEmailAddress"`
+	Hostname          string `json:"hostname"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=25
+	// +optional
+	Port int `json:"port,omitempty"`
+	// TLS enables the smtps transport.
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	PasswordSecretRef *userv1.SecretKeyRef `json:"passwordSecretRef,omitempty"`
+}
+
+type OpsManagerUserAuthentication struct {
+	// Method is the authentication method of the Ops Manager users.
+	// +kubebuilder:validation:Enum=Database;LDAP;SAML
+	Method OpsManagerAuthenticationMethod `json:"method"`
+	// +optional
+	LDAP *OpsManagerLDAPSettings `json:"ldap,omitempty"`
+	// +optional
+	SAML *OpsManagerSAMLSettings `json:"saml,omitempty"`
+}
+
+type OpsManagerLDAPSettings struct {
+	// URL of the LDAP server, e.g. "ldaps://ldap.example.com:636".
+	URL    string `json:"url"`
+	BindDN string `json:"bindDn"`
+	// BindPasswordSecretRef references the password of the BindDN user, stored under the "password" key by default.
+	BindPasswordSecretRef userv1.SecretKeyRef `json:"bindPasswordSecretRef"`
+	// UserBaseDN is the base DN the users are searched in.
+	UserBaseDN string `json:"userBaseDn"`
+	// UserSearchAttribute is the attribute the username is matched against.
+	// +kubebuilder:default=uid
+	// +optional
+	UserSearchAttribute string `json:"userSearchAttribute,omitempty"`
+	// UserGroupAttribute is the attribute containing the groups of the user.
+	// +optional
+	UserGroupAttribute string `json:"userGroupAttribute,omitempty"`
+	// GlobalOwnerGroup is the LDAP group whose members get the Global Owner role.
+	GlobalOwnerGroup string `json:"globalOwnerGroup"`
+}
+
+type OpsManagerSAMLSettings struct {
+	// IdentityProviderURI is the issuer URI of the identity provider.
+	IdentityProviderURI string `json:"identityProviderUri"`
+	SSOURL              string `json:"ssoUrl"`
+	// +optional
+	SLOURL string `json:"sloUrl,omitempty"`
+	// IdentityProviderCertificateSecretRef references the PEM certificate of the identity provider, stored under
+	// the "tls.crt" key by default.
+	IdentityProviderCertificateSecretRef userv1.SecretKeyRef `json:"identityProviderCertificateSecretRef"`
+	// The names of the SAML attributes mapped to the Ops Manager user.
+	FirstNameAttribute string `json:"firstNameAttribute"`
+	LastNameAttribute  string `json:"lastNameAttribute"`
+	EmailAttribute     string `json:"emailAttribute"`
+	// GroupMemberAttribute is the attribute containing the groups of the user.
+	GroupMemberAttribute string `json:"groupMemberAttribute"`
+	// GlobalOwnerGroup is the group whose members get the Global Owner role.
+	GlobalOwnerGroup string `json:"globalOwnerGroup"`
+}
+
+// Properties returns the conf-mms.properties the settings are rendered into. The properties with values stored
+// in Secrets are returned by SecretProperties.
+func (s *OpsManagerSettings) Properties() map[string]string {
+	properties := map[string]string{}
+	if s == nil {
+		return properties
+	}
+
+	if s.CentralURL != "" {
+		properties["mms.centralUrl"] = s.CentralURL
+	}
+
+	if proxy := s.Proxy; proxy != nil {
+		properties["http.proxy.host"] = proxy.Host
+		properties["http.proxy.port"] = strconv.Itoa(proxy.Port)
+		if proxy.Username != "" {
+			properties["http.proxy.username"] = proxy.Username
+		}
+		if len(proxy.NonProxyHosts) > 0 {
+			properties["http.proxy.nonProxyHosts"] = strings.Join(proxy.NonProxyHosts, "|")
+		}
+	}
+
+	if smtp := s.SMTP; smtp != nil {
+		properties["mms.fromEmailAddr"] = smtp.FromEmailAddress
+		properties["mms.replyToEmailAddr"] = smtp.ReplyToEmailAddress
+		properties["mms.adminEmailAddr"] = smtp.AdminEmailAddress
+		properties["mms.emailDaoClass"] = "com.xgen.svc.core.dao.email.JavaEmailDao"
+		properties["mms.mail.hostname"] = smtp.Hostname
+		properties["mms.mail.port"] = strconv.Itoa(smtp.GetPort())
+		properties["mms.mail.transport"] = "smtp"
+		properties["mms.mail.tls"] = strconv.FormatBool(smtp.TLS)
+		if smtp.TLS {
+			properties["mms.mail.transport"] = "smtps"
+		}
+		if smtp.Username != "" {
+			properties["mms.mail.username"] = smtp.Username
+		}
+	}
+
+	if auth := s.Authentication; auth != nil {
+		properties["mms.userSvcClass"] = userSvcClasses[auth.Method]
+		if ldap := auth.LDAP; auth.Method == OpsManagerAuthenticationMethodLDAP && ldap != nil {
+			properties["mms.ldap.url"] = ldap.URL
+			properties["mms.ldap.bindDn"] = ldap.BindDN
+			properties["mms.ldap.user.baseDn"] = ldap.UserBaseDN
+			properties["mms.ldap.user.searchAttribute"] = ldap.GetUserSearchAttribute()
+			if ldap.UserGroupAttribute != "" {
+				properties["mms.ldap.user.group"] = ldap.UserGroupAttribute
+			}
+			properties["mms.ldap.global.role.owner"] = ldap.GlobalOwnerGroup
+		}
+		if saml := auth.SAML; auth.Method == OpsManagerAuthenticationMethodSAML && saml != nil {
+			properties["mms.saml.idp.uri"] = saml.IdentityProviderURI
+			properties["mms.saml.sso.url"] = saml.SSOURL
+			if saml.SLOURL != "" {
+				properties["mms.saml.slo.url"] = saml.SLOURL
+			}
+			properties["mms.saml.user.firstName"] = saml.FirstNameAttribute
+			properties["mms.saml.user.lastName"] = saml.LastNameAttribute
+			properties["mms.saml.user.email"] = saml.EmailAttribute
+			properties["mms.saml.group.member"] = saml.GroupMemberAttribute
+			properties["mms.saml.global.role.owner"] = saml.GlobalOwnerGroup
+		}
+	}
+
+	return properties
+}
+
+// SecretProperties returns the conf-mms.properties with the values referenced in Secrets.
+func (s *OpsManagerSettings) SecretProperties() map[string]userv1.SecretKeyRef {
+	properties := map[string]userv1.SecretKeyRef{}
+	if s == nil {
+		return properties
+	}
+
+	if s.Proxy != nil && s.Proxy.PasswordSecretRef != nil {
+		properties["http.proxy.password"] = secretKeyRefWithDefaultKey(*s.Proxy.PasswordSecretRef, defaultPasswordSecretKey)
+	}
+	if s.SMTP != nil && s.SMTP.PasswordSecretRef != nil {
+		properties["mms.mail.password"] = secretKeyRefWithDefaultKey(*s.SMTP.PasswordSecretRef, defaultPasswordSecretKey)
+	}
+	if auth := s.Authentication; auth != nil {
+		if auth.Method == OpsManagerAuthenticationMethodLDAP && auth.LDAP != nil {
+			properties["mms.ldap.bindPassword"] = secretKeyRefWithDefaultKey(auth.LDAP.BindPasswordSecretRef, defaultPasswordSecretKey)
+		}
+		if auth.Method == OpsManagerAuthenticationMethodSAML && auth.SAML != nil {
+			properties["mms.saml.x509.cert"] = secretKeyRefWithDefaultKey(auth.SAML.IdentityProviderCertificateSecretRef, defaultCertificateSecretKey)
+		}
+	}
+
+	return properties
+}
+
+// GetPort returns the SMTP port, 25 by default.
+func (s *OpsManagerSMTPSettings) GetPort() int {
+	if s.Port == 0 {
+		return 25
+	}
+	return s.Port
+}
+
+// GetUserSearchAttribute returns the attribute the LDAP users are searched by, uid by default.
+func (l *OpsManagerLDAPSettings) GetUserSearchAttribute() string {
+	if l.UserSearchAttribute == "" {
+		return "uid"
+	}
+	return l.UserSearchAttribute
+}
+
+func secretKeyRefWithDefaultKey(ref userv1.SecretKeyRef, defaultKey string) userv1.SecretKeyRef {
+	if ref.Key == "" {
+		ref.Key = defaultKey
+	}
+	return ref
+}
@@ -0,0 +1,79 @@
+package om
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+)
+
+func TestOpsManagerSettings_Properties(t *testing.T) {
+	settings := &OpsManagerSettings{
+		CentralURL: "https://om.example.com:8443",
+		Proxy: &OpsManagerProxySettings{
+			Host:              "proxy.example.com",
+			Port:              3128,
+			PasswordSecretRef: &userv1.SecretKeyRef{Name: "proxy-credentials", Key: "secret"},
+			NonProxyHosts:     []string{"*.svc.cluster.local", "localhost"},
+		},
+		Authentication: &OpsManagerUserAuthentication{
+			Method: OpsManagerAuthenticationMethodLDAP,
+			LDAP: &OpsManagerLDAPSettings{
+				URL:                   "ldaps://ldap.example.com:636",
+				BindDN:                "cn=om,dc=example,dc=com",
+				BindPasswordSecretRef: userv1.SecretKeyRef{Name: "ldap-bind"},
+				UserBaseDN:            "ou=users,dc=example,dc=com",
+				GlobalOwnerGroup:      "om-owners",
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"mms.centralUrl":                "https://om.example.com:8443",
+		"http.proxy.host":               "proxy.example.com",
+		"http.proxy.port":               "3128",
+		"http.proxy.nonProxyHosts":      "*.svc.cluster.local|localhost",
+		"mms.userSvcClass":              "com.xgen.svc.mms.svc.user.UserSvcLdap",
+		"mms.ldap.url":                  "ldaps://ldap.example.com:636",
+		"mms.ldap.bindDn":               "cn=om,dc=example,dc=com",
+		"mms.ldap.user.baseDn":          "ou=users,dc=example,dc=com",
+		"mms.ldap.user.searchAttribute": "uid",
+		"mms.ldap.global.role.owner":    "om-owners",
+	}, settings.Properties())
+
+	assert.Equal(t, map[string]userv1.SecretKeyRef{
+		"http.proxy.password":   {Name: "proxy-credentials", Key: "secret"},
+		"mms.ldap.bindPassword": {Name: "ldap-bind", Key: "password"},
+	}, settings.SecretProperties())
+}
+
+func TestOpsManagerSettings_SAMLProperties(t *testing.T) {
+	settings := &OpsManagerSettings{
+		Authentication: &OpsManagerUserAuthentication{
+			Method: OpsManagerAuthenticationMethodSAML,
+			SAML: &OpsManagerSAMLSettings{
+				IdentityProviderURI:                  "https://idp.example.com",
+				SSOURL:                               "https://idp.example.com/sso",
+				IdentityProviderCertificateSecretRef: userv1.SecretKeyRef{Name: "idp-cert"},
+				FirstNameAttribute:                   "firstName",
+				LastNameAttribute:                    "lastName",
+				EmailAttribute:                       "email",
+				GroupMemberAttribute:                 "groups",
+				GlobalOwnerGroup:                     "om-owners",
+			},
+		},
+	}
+
+	properties := settings.Properties()
+	assert.Equal(t, "com.xgen.svc.mms.svc.user.UserSvcSaml", properties["mms.userSvcClass"])
+	assert.Equal(t, "https://idp.example.com/sso", properties["mms.saml.sso.url"])
+	assert.NotContains(t, properties, "mms.saml.slo.url")
+	assert.Equal(t, map[string]userv1.SecretKeyRef{"mms.saml.x509.cert": {Name: "idp-cert", Key: "tls.crt"}}, settings.SecretProperties())
+}
+
+func TestOpsManagerSettings_Nil(t *testing.T) {
+	var settings *OpsManagerSettings
+	assert.Empty(t, settings.Properties())
+	assert.Empty(t, settings.SecretProperties())
+}
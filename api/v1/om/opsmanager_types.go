@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -41,6 +42,10 @@ const (
 	debuggingPortConfigPath    string = "mms.k8s.debuggingPort"
 	queryableBackupDefaultPort int32  = 25999
 
+	DefaultAPIKeyRotationPeriodDays = 90
+
+	DefaultUpgradeHealthCheckTimeoutMinutes = 60
+
 	LabelResourceOwner = "mongodb.com/v1.mongodbOpsManagerResourceOwner"
 )
 
@@ -115,6 +120,11 @@ type MongoDBOpsManagerSpec struct {
 	// +optional
 	Configuration map[string]string `json:"configuration,omitempty"`
 
+	// Settings configures SMTP, the authentication of the Ops Manager users and the connectivity of Ops Manager with
+	// typed fields. The properties set here can't be set in spec.configuration as well.
+	// +optional
+	Settings *OpsManagerSettings `json:"settings,omitempty"`
+
 	Version string `json:"version"`
 	// +optional
 	// +kubebuilder:validation:Minimum=1
@@ -173,6 +183,36 @@ type MongoDBOpsManagerSpec struct {
 	// When not set, the operator is using FQDN of Ops Manager's headless service `{name}-svc.{namespace}.svc.cluster.local` to connect to the instance. If that URL cannot be used, then URL in this field should be provided for the operator to connect to Ops Manager instances.
 	// +optional
 	OpsManagerURL string `json:"opsManagerURL,omitempty"`
+
+	// APIKeyRotation configures the periodic rotation of the admin API key the operator uses to manage Ops Manager.
+	// +optional
+	APIKeyRotation *APIKeyRotation `json:"apiKeyRotation,omitempty"`
+
+	// Upgrade configures how the operator upgrades Ops Manager to a new major version.
+	// +optional
+	Upgrade *OpsManagerUpgrade `json:"upgrade,omitempty"`
+}
+
+// OpsManagerUpgrade configures the major version upgrades of Ops Manager.
+type OpsManagerUpgrade struct {
+	// HealthCheckTimeoutMinutes is the number of minutes the operator waits for the upgraded Ops Manager to become
+	// ready. Ops Manager is rolled back to the previous version if it doesn't become ready in time, unless an Ops Manager
+	// container of the new version has started, as it may have migrated the Application Database.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	HealthCheckTimeoutMinutes int `json:"healthCheckTimeoutMinutes,omitempty"`
+}
+
+// APIKeyRotation describes how often the operator replaces its admin API key in Ops Manager.
+type APIKeyRotation struct {
+	// Enabled turns the rotation of the admin API key on.
+	Enabled bool `json:"enabled"`
+	// PeriodDays is the number of days after which the admin API key is replaced with a new one.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=90
+	// +optional
+	PeriodDays int `json:"periodDays,omitempty"`
 }
 
 type Logging struct {
@@ -276,6 +316,29 @@ func (ms MongoDBOpsManagerSpec) IsKmipEnabled() bool {
 	return true
 }
 
+func (ms MongoDBOpsManagerSpec) IsAPIKeyRotationEnabled() bool {
+	return ms.APIKeyRotation != nil && ms.APIKeyRotation.Enabled
+}
+
+// GetAPIKeyRotationPeriod returns the period after which the admin API key gets rotated, 90 days by default.
+func (ms MongoDBOpsManagerSpec) GetAPIKeyRotationPeriod() time.Duration {
+	days := DefaultAPIKeyRotationPeriodDays
+	if ms.APIKeyRotation != nil && ms.APIKeyRotation.PeriodDays > 0 {
+		days = ms.APIKeyRotation.PeriodDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetUpgradeHealthCheckTimeout returns how long the operator waits for Ops Manager to become ready after a major
+// version upgrade before rolling it back, 60 minutes by default.
+func (ms MongoDBOpsManagerSpec) GetUpgradeHealthCheckTimeout() time.Duration {
+	minutes := DefaultUpgradeHealthCheckTimeoutMinutes
+	if ms.Upgrade != nil && ms.Upgrade.HealthCheckTimeoutMinutes > 0 {
+		minutes = ms.Upgrade.HealthCheckTimeoutMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
 func (ms MongoDBOpsManagerSpec) GetClusterDomain() string {
 	if ms.ClusterDomain != "" {
 		return ms.ClusterDomain
@@ -463,6 +526,21 @@ type OpsManagerStatus struct {
 	Url               string                       `json:"url,omitempty"`
 	Warnings          []status.Warning             `json:"warnings,omitempty"`
 	ClusterStatusList []status.OMClusterStatusItem `json:"clusterStatusList,omitempty"`
+	// APIKeyLastRotation is the time (RFC3339) the admin API key was last rotated by the operator, or the rotation was
+	// enabled if the key wasn't rotated yet.
+	APIKeyLastRotation string `json:"apiKeyLastRotation,omitempty"`
+	// Upgrade describes the major version upgrade of Ops Manager in progress.
+	Upgrade *OpsManagerUpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// OpsManagerUpgradeStatus describes the major version upgrade of Ops Manager started by the operator.
+type OpsManagerUpgradeStatus struct {
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	// StartedAt is the time (RFC3339) the upgrade passed the pre-flight checks.
+	StartedAt string `json:"startedAt"`
+	// RolledBack is set if Ops Manager didn't become ready after the upgrade and was rolled back to FromVersion.
+	RolledBack bool `json:"rolledBack,omitempty"`
 }
 
 type AgentVersion struct {
@@ -739,11 +817,16 @@ func (om *MongoDBOpsManager) updateStatusOpsManager(phase status.Phase, statusOp
 		om.Status.OpsManagerStatus.Url = option.(status.BaseUrlOption).BaseUrl
 	}
 
+	if option, exists := status.GetOption(statusOptions, status.APIKeyRotationOption{}); exists {
+		om.Status.OpsManagerStatus.APIKeyLastRotation = option.(status.APIKeyRotationOption).LastRotation
+	}
+
 	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
 		om.Status.OpsManagerStatus.Warnings = append(om.Status.OpsManagerStatus.Warnings, option.(status.WarningsOption).Warnings...)
 	}
 
 	if phase == status.PhaseRunning {
+		om.Status.OpsManagerStatus.Upgrade = nil
 		om.Status.OpsManagerStatus.Replicas = om.Spec.GetTotalReplicas()
 		om.Status.OpsManagerStatus.ClusterStatusList = om.Spec.GetClusterStatusList()
 		om.Status.OpsManagerStatus.Version = om.Spec.Version
@@ -973,6 +1056,15 @@ func (om *MongoDBOpsManager) GetPreviousVersion() string {
 	return annotations.GetAnnotation(om, annotations.LastAppliedMongoDBVersion)
 }
 
+// GetVersionToDeploy returns the Ops Manager version the operator deploys. This is the version from the spec unless
+// the upgrade to it was rolled back, in which case the previous version keeps running.
+func (om *MongoDBOpsManager) GetVersionToDeploy() string {
+	if upgrade := om.Status.OpsManagerStatus.Upgrade; upgrade != nil && upgrade.RolledBack && upgrade.ToVersion == om.Spec.Version {
+		return upgrade.FromVersion
+	}
+	return om.Spec.Version
+}
+
 func (om *MongoDBOpsManager) CalculateFeatureCompatibilityVersion() string {
 	return fcv.CalculateFeatureCompatibilityVersion(om.Spec.AppDB.Version, om.Status.AppDbStatus.FeatureCompatibilityVersion, om.Spec.AppDB.FeatureCompatibilityVersion)
 }
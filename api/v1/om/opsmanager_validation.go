@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/blang/semver"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,6 +15,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
 
 // IMPORTANT: this package is intended to contain only "simple" validation—in
@@ -255,6 +257,54 @@ func validateBackupS3Stores(os MongoDBOpsManagerSpec) v1.ValidationResult {
 	return v1.ValidationSuccess()
 }
 
+func validateSettings(os MongoDBOpsManagerSpec) v1.ValidationResult {
+	settings := os.Settings
+	if settings == nil {
+		return v1.ValidationSuccess()
+	}
+
+	if auth := settings.Authentication; auth != nil {
+		if auth.Method == OpsManagerAuthenticationMethodLDAP && auth.LDAP == nil {
+			return v1.OpsManagerResourceValidationError("spec.settings.authentication.ldap must be specified for the LDAP authentication method", status.OpsManager)
+		}
+		if auth.Method == OpsManagerAuthenticationMethodSAML && auth.SAML == nil {
+			return v1.OpsManagerResourceValidationError("spec.settings.authentication.saml must be specified for the SAML authentication method", status.OpsManager)
+		}
+		if auth.LDAP != nil && auth.Method != OpsManagerAuthenticationMethodLDAP {
+			return v1.OpsManagerResourceValidationError("spec.settings.authentication.ldap can only be specified for the LDAP authentication method", status.OpsManager)
+		}
+		if auth.SAML != nil && auth.Method != OpsManagerAuthenticationMethodSAML {
+			return v1.OpsManagerResourceValidationError("spec.settings.authentication.saml can only be specified for the SAML authentication method", status.OpsManager)
+		}
+	}
+
+	for _, ref := range settings.SecretProperties() {
+		if ref.Name == "" {
+			return v1.OpsManagerResourceValidationError("the name of the secret references in spec.settings must not be empty", status.OpsManager)
+		}
+	}
+	// the secret properties are passed to Ops Manager as references to Kubernetes Secrets
+	if len(settings.SecretProperties()) > 0 && vault.IsVaultSecretBackend() {
+		return v1.OpsManagerResourceValidationError("the secret references in spec.settings are not supported with the Vault secret backend", status.OpsManager)
+	}
+
+	var properties []string
+	for property := range settings.Properties() {
+		properties = append(properties, property)
+	}
+	for property := range settings.SecretProperties() {
+		properties = append(properties, property)
+	}
+	slices.Sort(properties)
+	for _, property := range properties {
+		if _, ok := os.Configuration[property]; ok {
+			return v1.OpsManagerResourceValidationError("the property %s is configured in spec.settings and can't be set in spec.configuration", status.OpsManager, property)
+		}
+	}
+
+	return v1.ValidationSuccess()
+}
+
 func (om *MongoDBOpsManager) RunValidations() []v1.ValidationResult {
 	validators := []func(m MongoDBOpsManagerSpec) v1.ValidationResult{
 		validOmVersion,
@@ -269,6 +319,7 @@ func (om *MongoDBOpsManager) RunValidations() []v1.ValidationResult {
 		validateTopologyIsSpecified,
 		validateClusterSpecList,
 		validateBackupS3Stores,
+		validateSettings,
 		featureCompatibilityVersionValidation,
 		validateAppDBUniqueExternalDomains,
 	}
@@ -9,7 +9,9 @@ import (
 	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 )
 
 func TestOpsManagerValidation(t *testing.T) {
@@ -18,8 +20,59 @@ func TestOpsManagerValidation(t *testing.T) {
 		expectedPart           status.Part
 		expectedErrorMessage   string
 		expectedWarningMessage status.Warning
+		vaultBackend           bool
 	}
 	tests := map[string]args{
+		"Valid LDAP settings": {
+			testedOm: NewOpsManagerBuilderDefault().SetSettings(&OpsManagerSettings{
+				Authentication: &OpsManagerUserAuthentication{
+					Method: OpsManagerAuthenticationMethodLDAP,
+					LDAP:   &OpsManagerLDAPSettings{URL: "ldaps://ldap.example.com:636", BindPasswordSecretRef: userv1.SecretKeyRef{Name: "ldap-bind"}},
+				},
+			}).Build(),
+			expectedPart: status.None,
+		},
+		"Invalid settings without the LDAP configuration": {
+			testedOm: NewOpsManagerBuilderDefault().SetSettings(&OpsManagerSettings{
+				Authentication: &OpsManagerUserAuthentication{Method: OpsManagerAuthenticationMethodLDAP},
+			}).Build(),
+			expectedErrorMessage: "spec.settings.authentication.ldap must be specified for the LDAP authentication method",
+			expectedPart:         status.OpsManager,
+		},
+		"Invalid settings with SAML configuration for LDAP": {
+			testedOm: NewOpsManagerBuilderDefault().SetSettings(&OpsManagerSettings{
+				Authentication: &OpsManagerUserAuthentication{
+					Method: OpsManagerAuthenticationMethodLDAP,
+					LDAP:   &OpsManagerLDAPSettings{BindPasswordSecretRef: userv1.SecretKeyRef{Name: "ldap-bind"}},
+					SAML:   &OpsManagerSAMLSettings{},
+				},
+			}).Build(),
+			expectedErrorMessage: "spec.settings.authentication.saml can only be specified for the SAML authentication method",
+			expectedPart:         status.OpsManager,
+		},
+		"Invalid settings with an empty secret reference": {
+			testedOm: NewOpsManagerBuilderDefault().SetSettings(&OpsManagerSettings{
+				SMTP: &OpsManagerSMTPSettings{PasswordSecretRef: &userv1.SecretKeyRef{}},
+			}).Build(),
+			expectedErrorMessage: "the name of the secret references in spec.settings must not be empty",
+			expectedPart:         status.OpsManager,
+		},
+		"Invalid settings with secret references and the Vault secret backend": {
+			testedOm: NewOpsManagerBuilderDefault().SetSettings(&OpsManagerSettings{
+				SMTP: &OpsManagerSMTPSettings{PasswordSecretRef: &userv1.SecretKeyRef{Name: "smtp-credentials"}},
+			}).Build(),
+			vaultBackend:         true,
+			expectedErrorMessage: "the secret references in spec.settings are not supported with the Vault secret backend",
+			expectedPart:         status.OpsManager,
+		},
+		"Invalid settings conflicting with the configuration": {
+			testedOm: NewOpsManagerBuilderDefault().
+				AddConfiguration("mms.mail.hostname", "smtp.example.com").
+				SetSettings(&OpsManagerSettings{SMTP: &OpsManagerSMTPSettings{Hostname: "smtp.example.com"}}).
+				Build(),
+			expectedErrorMessage: "the property mms.mail.hostname is configured in spec.settings and can't be set in spec.configuration",
+			expectedPart:         status.OpsManager,
+		},
 		"Valid KMIP configuration": {
 			testedOm: NewOpsManagerBuilderDefault().SetBackup(MongoDBOpsManagerBackup{
 				Enabled: true,
@@ -102,6 +155,13 @@ func TestOpsManagerValidation(t *testing.T) {
 			expectedErrorMessage: "connectivity field is not configurable for application databases",
 			expectedPart:         status.AppDb,
 		},
+		"Invalid AppDB connectivity horizon templates": {
+			testedOm: NewOpsManagerBuilderDefault().
+				SetAppDbConnectivity(mdbv1.MongoDBConnectivity{ReplicaSetHorizonTemplates: map[string]string{"external": "{podName}.example.com:{port}"}}).
+				Build(),
+			expectedErrorMessage: "connectivity field is not configurable for application databases",
+			expectedPart:         status.AppDb,
+		},
 		"Invalid AppDB credentials": {
 			testedOm: NewOpsManagerBuilderDefault().
 				SetAppDbCredentials("invalid").
@@ -349,6 +409,9 @@ func TestOpsManagerValidation(t *testing.T) {
 	for testName := range tests {
 		t.Run(testName, func(t *testing.T) {
 			testConfig := tests[testName]
+			if testConfig.vaultBackend {
+				t.Setenv("SECRET_BACKEND", vault.VaultBackend)
+			}
 			part, err := testConfig.testedOm.ProcessValidationsOnReconcile()
 
 			if testConfig.expectedErrorMessage != "" {
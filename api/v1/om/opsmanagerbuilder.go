@@ -221,6 +221,11 @@ func (b *OpsManagerBuilder) SetBackup(backup MongoDBOpsManagerBackup) *OpsManage
 	return b
 }
 
+func (b *OpsManagerBuilder) SetSettings(settings *OpsManagerSettings) *OpsManagerBuilder {
+	b.om.Spec.Settings = settings
+	return b
+}
+
 func (b *OpsManagerBuilder) AddConfiguration(key, value string) *OpsManagerBuilder {
 	b.om.AddConfigIfDoesntExist(key, value)
 	return b
@@ -31,6 +31,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyRotation) DeepCopyInto(out *APIKeyRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyRotation.
+func (in *APIKeyRotation) DeepCopy() *APIKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentVersion) DeepCopyInto(out *AgentVersion) {
 	*out = *in
@@ -594,6 +609,11 @@ func (in *MongoDBOpsManagerSpec) DeepCopyInto(out *MongoDBOpsManagerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(OpsManagerSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	in.AppDB.DeepCopyInto(&out.AppDB)
 	if in.Logging != nil {
 		in, out := &in.Logging, &out.Logging
@@ -636,6 +656,16 @@ func (in *MongoDBOpsManagerSpec) DeepCopyInto(out *MongoDBOpsManagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.APIKeyRotation != nil {
+		in, out := &in.APIKeyRotation, &out.APIKeyRotation
+		*out = new(APIKeyRotation)
+		**out = **in
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(OpsManagerUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBOpsManagerSpec.
@@ -713,6 +743,113 @@ func (in *OpsManagerBuilder) DeepCopy() *OpsManagerBuilder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerLDAPSettings) DeepCopyInto(out *OpsManagerLDAPSettings) {
+	*out = *in
+	out.BindPasswordSecretRef = in.BindPasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerLDAPSettings.
+func (in *OpsManagerLDAPSettings) DeepCopy() *OpsManagerLDAPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerLDAPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerProxySettings) DeepCopyInto(out *OpsManagerProxySettings) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.NonProxyHosts != nil {
+		in, out := &in.NonProxyHosts, &out.NonProxyHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerProxySettings.
+func (in *OpsManagerProxySettings) DeepCopy() *OpsManagerProxySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerProxySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerSAMLSettings) DeepCopyInto(out *OpsManagerSAMLSettings) {
+	*out = *in
+	out.IdentityProviderCertificateSecretRef = in.IdentityProviderCertificateSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerSAMLSettings.
+func (in *OpsManagerSAMLSettings) DeepCopy() *OpsManagerSAMLSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerSAMLSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerSMTPSettings) DeepCopyInto(out *OpsManagerSMTPSettings) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerSMTPSettings.
+func (in *OpsManagerSMTPSettings) DeepCopy() *OpsManagerSMTPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerSMTPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerSettings) DeepCopyInto(out *OpsManagerSettings) {
+	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(OpsManagerProxySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(OpsManagerSMTPSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(OpsManagerUserAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerSettings.
+func (in *OpsManagerSettings) DeepCopy() *OpsManagerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsManagerStatus) DeepCopyInto(out *OpsManagerStatus) {
 	*out = *in
@@ -727,6 +864,11 @@ func (in *OpsManagerStatus) DeepCopyInto(out *OpsManagerStatus) {
 		*out = make([]status.OMClusterStatusItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(OpsManagerUpgradeStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerStatus.
@@ -739,6 +881,61 @@ func (in *OpsManagerStatus) DeepCopy() *OpsManagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerUpgrade) DeepCopyInto(out *OpsManagerUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerUpgrade.
+func (in *OpsManagerUpgrade) DeepCopy() *OpsManagerUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerUpgradeStatus) DeepCopyInto(out *OpsManagerUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerUpgradeStatus.
+func (in *OpsManagerUpgradeStatus) DeepCopy() *OpsManagerUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsManagerUserAuthentication) DeepCopyInto(out *OpsManagerUserAuthentication) {
+	*out = *in
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(OpsManagerLDAPSettings)
+		**out = **in
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(OpsManagerSAMLSettings)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsManagerUserAuthentication.
+func (in *OpsManagerUserAuthentication) DeepCopy() *OpsManagerUserAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsManagerUserAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsManagerVersionMapping) DeepCopyInto(out *OpsManagerVersionMapping) {
 	*out = *in
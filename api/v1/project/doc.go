@@ -0,0 +1,4 @@
+package project
+
+// +k8s:deepcopy-gen=package
+// +versionName=v1
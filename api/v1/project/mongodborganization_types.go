@@ -0,0 +1,119 @@
+package project
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBOrganization{}, &MongoDBOrganizationList{})
+}
+
+type MongoDBOrganizationSpec struct {
+	// Name of the organization in Ops Manager. Defaults to the name of the MongoDBOrganization resource.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// ConfigMap with the base URL and the TLS settings of Ops Manager, in the format of the project ConfigMap.
+	// The organization id and the project name of the ConfigMap are ignored.
+	OpsManagerConfig mdbv1.PrivateCloudConfig `json:"opsManager"`
+	// Name of the Secret holding the programmatic API key used to manage the organization. The key needs the
+	// Global Owner role to create the organization, or the Organization Owner role if it exists already.
+	Credentials string `json:"credentials"`
+	// Teams of the organization. Teams removed from the list are deleted from the organization.
+	// +optional
+	Teams []Team `json:"teams,omitempty"`
+}
+
+type Team struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Usernames of the Ops Manager users who are members of the team. The users have to exist in Ops Manager.
+	// +kubebuilder:validation:MinItems=1
+	Usernames []string `json:"usernames"`
+}
+
+type MongoDBOrganizationStatus struct {
+	status.Common `json:",inline"`
+	// ID of the organization in Ops Manager.
+	OrganizationID string `json:"organizationId,omitempty"`
+	// IDs of the teams managed by the resource, by team name.
+	Teams    map[string]string `json:"teams,omitempty"`
+	Warnings []status.Warning  `json:"warnings,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB organization."
+// +kubebuilder:printcolumn:name="Organization ID",type="string",JSONPath=".status.organizationId",description="ID of the organization in Ops Manager."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDBOrganization resource was created."
+// +kubebuilder:resource:path=mongodborganizations,scope=Namespaced,shortName=mdborg
+type MongoDBOrganization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBOrganizationSpec `json:"spec"`
+	// +optional
+	Status MongoDBOrganizationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBOrganizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDBOrganization `json:"items"`
+}
+
+func (o *MongoDBOrganization) GetCommonStatus(options ...status.Option) *status.Common {
+	return &o.Status.Common
+}
+
+func (o *MongoDBOrganization) GetStatus(...status.Option) interface{} {
+	return o.Status
+}
+
+func (o *MongoDBOrganization) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+func (o *MongoDBOrganization) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	o.Status.Warnings = warnings
+}
+
+func (o *MongoDBOrganization) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	o.Status.UpdateCommonFields(phase, o.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		o.Status.Warnings = append(o.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, OrganizationOption{}); exists {
+		organization := option.(OrganizationOption)
+		o.Status.OrganizationID = organization.OrganizationID
+		o.Status.Teams = organization.Teams
+	}
+}
+
+func (o *MongoDBOrganization) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: o.Name, Namespace: o.Namespace}
+}
+
+func (o *MongoDBOrganization) GetOrganizationName() string {
+	if o.Spec.Name != "" {
+		return o.Spec.Name
+	}
+	return o.Name
+}
+
+func (o *MongoDBOrganization) GetOpsManagerConfigMapKey() types.NamespacedName {
+	return types.NamespacedName{Name: o.Spec.OpsManagerConfig.ConfigMapRef.Name, Namespace: o.Namespace}
+}
+
+func (o *MongoDBOrganization) GetCredentialsSecretKey() types.NamespacedName {
+	return types.NamespacedName{Name: o.Spec.Credentials, Namespace: o.Namespace}
+}
@@ -0,0 +1,227 @@
+package project
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const LabelResourceOwner = "mongodb.com/v1.mongodbProjectResourceOwner"
+
+// ProjectRole is the name of an Ops Manager project role.
+// +kubebuilder:validation:Enum=GROUP_OWNER;GROUP_READ_ONLY;GROUP_AUTOMATION_ADMIN;GROUP_BACKUP_ADMIN;GROUP_MONITORING_ADMIN;GROUP_USER_ADMIN;GROUP_DATA_ACCESS_ADMIN;GROUP_DATA_ACCESS_READ_ONLY;GROUP_DATA_ACCESS_READ_WRITE
+type ProjectRole string
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBProject{}, &MongoDBProjectList{})
+}
+
+type MongoDBProjectSpec struct {
+	// Reference to the MongoDBOrganization resource the project is created in.
+	OrganizationRef corev1.LocalObjectReference `json:"organizationRef"`
+	// Name of the project in Ops Manager. Defaults to the name of the MongoDBProject resource.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Tags added to the project.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Teams of the organization which have access to the project, with their project roles. The teams of the
+	// organization which are not in the list are removed from the project.
+	// +optional
+	Teams []ProjectTeam `json:"teams,omitempty"`
+	// Programmatic API keys which have access to the project. The public and private key are stored into a Secret,
+	// which can be referenced as the credentials of the MongoDB resources deployed in the project.
+	// API keys removed from the list are deleted.
+	// +optional
+	APIKeys []ProjectAPIKey `json:"apiKeys,omitempty"`
+	// Settings of the project. Only the settings which are set are changed in Ops Manager.
+	// +optional
+	Settings *ProjectSettings `json:"settings,omitempty"`
+	// IP addresses or CIDR blocks the project can be accessed from. The entries of the access list of the project
+	// which are not in the list are removed. The access list is left untouched if not set.
+	// +optional
+	IPAccessList []string `json:"ipAccessList,omitempty"`
+}
+
+type ProjectSettings struct {
+	// Collect database specific statistics.
+	// +optional
+	CollectDatabaseSpecificsStatistics *bool `json:"collectDatabaseSpecificsStatistics,omitempty"`
+	// Enable the Data Explorer.
+	// +optional
+	DataExplorer *bool `json:"dataExplorer,omitempty"`
+	// Enable the Performance Advisor.
+	// +optional
+	PerformanceAdvisor *bool `json:"performanceAdvisor,omitempty"`
+	// Enable the Real Time Performance Panel.
+	// +optional
+	RealtimePerformancePanel *bool `json:"realtimePerformancePanel,omitempty"`
+	// Enable the Schema Advisor.
+	// +optional
+	SchemaAdvisor *bool `json:"schemaAdvisor,omitempty"`
+}
+
+type ProjectTeam struct {
+	// Name of the team in the referenced MongoDBOrganization.
+	Name string `json:"name"`
+	// +kubebuilder:validation:MinItems=1
+	Roles []ProjectRole `json:"roles"`
+}
+
+type ProjectAPIKey struct {
+	// Name of the API key, used as its description in Ops Manager. It has to be unique in the project.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=250
+	Name string `json:"name"`
+	// +kubebuilder:validation:MinItems=1
+	Roles []ProjectRole `json:"roles"`
+	// IP addresses or CIDR blocks the API key can be used from. The API key can be used from anywhere if it's empty,
+	// unless Ops Manager requires an API access list.
+	// +optional
+	AccessList []string `json:"accessList,omitempty"`
+	// Name of the Secret the public and private key are stored into. Defaults to "<project resource name>-<api key name>".
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+type MongoDBProjectStatus struct {
+	status.Common `json:",inline"`
+	// ID of the project in Ops Manager.
+	ProjectID string `json:"projectId,omitempty"`
+	// ID of the organization of the project in Ops Manager.
+	OrganizationID string `json:"organizationId,omitempty"`
+	// Name of the ConfigMap, created by the operator, which can be referenced by the MongoDB resources deployed in the project.
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// API keys managed by the resource.
+	APIKeys  []APIKeyStatus   `json:"apiKeys,omitempty"`
+	Warnings []status.Warning `json:"warnings,omitempty"`
+}
+
+type APIKeyStatus struct {
+	Name       string `json:"name"`
+	ID         string `json:"id"`
+	PublicKey  string `json:"publicKey"`
+	SecretName string `json:"secretName"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB project."
+// +kubebuilder:printcolumn:name="Project ID",type="string",JSONPath=".status.projectId",description="ID of the project in Ops Manager."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDBProject resource was created."
+// +kubebuilder:resource:path=mongodbprojects,scope=Namespaced,shortName=mdbp
+type MongoDBProject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBProjectSpec `json:"spec"`
+	// +optional
+	Status MongoDBProjectStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBProjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDBProject `json:"items"`
+}
+
+func (p *MongoDBProject) GetCommonStatus(options ...status.Option) *status.Common {
+	return &p.Status.Common
+}
+
+func (p *MongoDBProject) GetStatus(...status.Option) interface{} {
+	return p.Status
+}
+
+func (p *MongoDBProject) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+func (p *MongoDBProject) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	p.Status.Warnings = warnings
+}
+
+func (p *MongoDBProject) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	p.Status.UpdateCommonFields(phase, p.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		p.Status.Warnings = append(p.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, ProjectOption{}); exists {
+		project := option.(ProjectOption)
+		p.Status.ProjectID = project.ProjectID
+		p.Status.OrganizationID = project.OrganizationID
+		p.Status.ConfigMapName = project.ConfigMapName
+	}
+	if option, exists := status.GetOption(statusOptions, APIKeysOption{}); exists {
+		p.Status.APIKeys = option.(APIKeysOption).APIKeys
+	}
+}
+
+func (p *MongoDBProject) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: p.Name, Namespace: p.Namespace}
+}
+
+func (p *MongoDBProject) ObjectKey() client.ObjectKey {
+	return kube.ObjectKey(p.Namespace, p.Name)
+}
+
+func (p *MongoDBProject) GetOwnerLabels() map[string]string {
+	return map[string]string{
+		util.OperatorLabelName: util.OperatorLabelValue,
+		LabelResourceOwner:     p.Name,
+	}
+}
+
+func (p *MongoDBProject) OrganizationNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: p.Spec.OrganizationRef.Name, Namespace: p.Namespace}
+}
+
+func (p *MongoDBProject) GetProjectName() string {
+	if p.Spec.Name != "" {
+		return p.Spec.Name
+	}
+	return p.Name
+}
+
+// ProjectConfigMapName returns the name of the project ConfigMap created for the MongoDB resources deployed in the project.
+func (p *MongoDBProject) ProjectConfigMapName() string {
+	return fmt.Sprintf("%s-project-config", p.Name)
+}
+
+func (p *MongoDBProject) APIKeySecretName(apiKey ProjectAPIKey) string {
+	if apiKey.SecretName != "" {
+		return apiKey.SecretName
+	}
+	return fmt.Sprintf("%s-%s", p.Name, apiKey.Name)
+}
+
+// GetAPIKeyStatus returns the status of the API key with the name, or nil if it hasn't been created yet.
+func (p *MongoDBProject) GetAPIKeyStatus(name string) *APIKeyStatus {
+	for i := range p.Status.APIKeys {
+		if p.Status.APIKeys[i].Name == name {
+			return &p.Status.APIKeys[i]
+		}
+	}
+	return nil
+}
+
+func RoleNames(roles []ProjectRole) []string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return names
+}
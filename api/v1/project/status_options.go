@@ -0,0 +1,48 @@
+package project
+
+import "github.com/mongodb/mongodb-kubernetes/api/v1/status"
+
+type OrganizationOption struct {
+	OrganizationID string
+	Teams          map[string]string
+}
+
+var _ status.Option = OrganizationOption{}
+
+func NewOrganizationOption(organizationID string, teams map[string]string) OrganizationOption {
+	return OrganizationOption{OrganizationID: organizationID, Teams: teams}
+}
+
+func (o OrganizationOption) Value() interface{} {
+	return o
+}
+
+type ProjectOption struct {
+	ProjectID      string
+	OrganizationID string
+	ConfigMapName  string
+}
+
+var _ status.Option = ProjectOption{}
+
+func NewProjectOption(projectID string, organizationID string, configMapName string) ProjectOption {
+	return ProjectOption{ProjectID: projectID, OrganizationID: organizationID, ConfigMapName: configMapName}
+}
+
+func (o ProjectOption) Value() interface{} {
+	return o
+}
+
+type APIKeysOption struct {
+	APIKeys []APIKeyStatus
+}
+
+var _ status.Option = APIKeysOption{}
+
+func NewAPIKeysOption(apiKeys []APIKeyStatus) APIKeysOption {
+	return APIKeysOption{APIKeys: apiKeys}
+}
+
+func (o APIKeysOption) Value() interface{} {
+	return o.APIKeys
+}
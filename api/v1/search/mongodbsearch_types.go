@@ -14,6 +14,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 )
 
 const (
@@ -24,6 +25,8 @@ const (
 	MongotDefaultSyncSourceUsername       = "search-sync-source"
 
 	ForceWireprotoAnnotation = "mongodb.com/v1.force-search-wireproto"
+
+	LabelResourceOwner = "mongodbsearch"
 )
 
 func init() {
@@ -37,6 +40,28 @@ type Prometheus struct {
 	// +kubebuilder:validation:Maximum=65535
 	// +kubebuilder:default=9946
 	Port int `json:"port,omitempty"`
+	// HTTP Basic Auth username for the metrics endpoint. Basic Auth is enabled when set together with passwordSecretRef.
+	// +optional
+	Username string `json:"username,omitempty"`
+	// Secret containing the HTTP Basic Auth password for the metrics endpoint. The password is read from the "password" key by default.
+	// +optional
+	PasswordSecretRef *userv1.SecretKeyRef `json:"passwordSecretRef,omitempty"`
+	// Secret (type kubernetes.io/tls) holding the certificate and key used to serve the metrics endpoint over TLS.
+	// If the Secret contains a "ca.crt" key, it is used by the operator to verify the endpoint when reading the index lag.
+	// +optional
+	TLSSecretRef *corev1.LocalObjectReference `json:"tlsSecretKeyRef,omitempty"`
+	// Configure a dedicated Service exposing only the metrics endpoint, which can be selected by a Prometheus Operator ServiceMonitor.
+	// +optional
+	Service *PrometheusService `json:"service,omitempty"`
+}
+
+type PrometheusService struct {
+	// Labels added to the metrics Service, e.g. to be matched by the ServiceMonitor's selector.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to the metrics Service.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 func (p *Prometheus) GetPort() int32 {
@@ -44,6 +69,22 @@ func (p *Prometheus) GetPort() int32 {
 	return int32(p.Port)
 }
 
+func (p *Prometheus) IsBasicAuthEnabled() bool {
+	return p.Username != "" && p.PasswordSecretRef != nil
+}
+
+func (p *Prometheus) GetPasswordKey() string {
+	if p.PasswordSecretRef != nil && p.PasswordSecretRef.Key != "" {
+		return p.PasswordSecretRef.Key
+	}
+
+	return "password"
+}
+
+func (p *Prometheus) IsTLSEnabled() bool {
+	return p.TLSSecretRef != nil && p.TLSSecretRef.Name != ""
+}
+
 type MongoDBSearchSpec struct {
 	// Optional version of MongoDB Search component (mongot). If not set, then the operator will set the most appropriate version of MongoDB Search.
 	// +optional
@@ -71,6 +112,23 @@ type MongoDBSearchSpec struct {
 	// Configure prometheus metrics endpoint in mongot. If not set, the metrics endpoint will be disabled.
 	// +optional
 	Prometheus *Prometheus `json:"prometheus,omitempty"`
+	// Member clusters the mongot instances are deployed in when the source is a MongoDBMultiCluster resource. A group of
+	// mongot instances is deployed in every listed cluster, next to the mongod members of the source in that cluster.
+	// Required for MongoDBMultiCluster sources and not supported for the other sources.
+	// +optional
+	ClusterSpecList []ClusterSpecItem `json:"clusterSpecList,omitempty"`
+}
+
+// ClusterSpecItem configures the mongot instances deployed in a single member cluster.
+type ClusterSpecItem struct {
+	// Name of the member cluster, it must be one of the member clusters of the source.
+	ClusterName string `json:"clusterName"`
+	// StatefulSetSpec applied to the mongot StatefulSet in this member cluster after spec.statefulSet.
+	// +optional
+	StatefulSetConfiguration *common.StatefulSetConfiguration `json:"statefulSet,omitempty"`
+	// Resource requests and limits of the mongot pods in this member cluster, overriding spec.resourceRequirements.
+	// +optional
+	ResourceRequirements *corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
 }
 
 type MongoDBSource struct {
@@ -113,8 +171,18 @@ type TLS struct {
 
 type MongoDBSearchStatus struct {
 	status.Common `json:",inline"`
-	Version       string           `json:"version,omitempty"`
-	Warnings      []status.Warning `json:"warnings,omitempty"`
+	Version       string `json:"version,omitempty"`
+	// Replication lag of the search indexes, read from the mongot metrics endpoints. Only reported when prometheus is enabled.
+	// +optional
+	IndexLag *IndexLag        `json:"indexLag,omitempty"`
+	Warnings []status.Warning `json:"warnings,omitempty"`
+}
+
+type IndexLag struct {
+	// Highest replication lag reported by any of the mongot instances, in seconds. Search results may be stale by up to this amount of time.
+	MaxLagSeconds int64 `json:"maxLagSeconds"`
+	// Time the lag was last read from the mongot instances.
+	LastUpdated string `json:"lastUpdated"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -123,6 +191,7 @@ type MongoDBSearchStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB deployment."
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="MongoDB Search version reconciled by the operator."
+// +kubebuilder:printcolumn:name="Index Lag",type="integer",JSONPath=".status.indexLag.maxLagSeconds",description="Highest replication lag of the search indexes in seconds.",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDB resource was created."
 // +kubebuilder:resource:path=mongodbsearch,scope=Namespaced,shortName=mdbs
 type MongoDBSearch struct {
@@ -166,6 +235,9 @@ func (s *MongoDBSearch) UpdateStatus(phase status.Phase, statusOptions ...status
 	if option, exists := status.GetOption(statusOptions, MongoDBSearchVersionOption{}); exists {
 		s.Status.Version = option.(MongoDBSearchVersionOption).Version
 	}
+	if option, exists := status.GetOption(statusOptions, MongoDBSearchIndexLagOption{}); exists {
+		s.Status.IndexLag = option.(MongoDBSearchIndexLagOption).IndexLag
+	}
 }
 
 func (s *MongoDBSearch) NamespacedName() types.NamespacedName {
@@ -180,6 +252,72 @@ func (s *MongoDBSearch) MongotConfigConfigMapNamespacedName() types.NamespacedNa
 	return types.NamespacedName{Name: s.Name + "-search-config", Namespace: s.Namespace}
 }
 
+// ShardSearchServiceNamespacedName is the Service of the mongot instances indexing the shard with the given index
+// when the search source is a sharded cluster.
+func (s *MongoDBSearch) ShardSearchServiceNamespacedName(shardIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-%d-svc", s.Name, shardIdx), Namespace: s.Namespace}
+}
+
+// MetricsServiceNamespacedName is the Service exposing the metrics endpoint of the mongot instances.
+func (s *MongoDBSearch) MetricsServiceNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Name + "-search-metrics-svc", Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ShardMetricsServiceNamespacedName(shardIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-%d-metrics-svc", s.Name, shardIdx), Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ShardMongotConfigConfigMapNamespacedName(shardIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-%d-config", s.Name, shardIdx), Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ShardStatefulSetNamespacedName(shardIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-%d", s.Name, shardIdx), Namespace: s.Namespace}
+}
+
+// ClusterStatefulSetNamespacedName is the StatefulSet of the mongot instances deployed in the member cluster with the
+// given index when the search source is a MongoDBMultiCluster resource.
+func (s *MongoDBSearch) ClusterStatefulSetNamespacedName(clusterIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-cluster-%d", s.Name, clusterIdx), Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ClusterSearchServiceNamespacedName(clusterIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-cluster-%d-svc", s.Name, clusterIdx), Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ClusterMetricsServiceNamespacedName(clusterIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-cluster-%d-metrics-svc", s.Name, clusterIdx), Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) ClusterMongotConfigConfigMapNamespacedName(clusterIdx int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-search-cluster-%d-config", s.Name, clusterIdx), Namespace: s.Namespace}
+}
+
+// IsMultiCluster returns true if the mongot instances are deployed in the member clusters.
+func (s *MongoDBSearch) IsMultiCluster() bool {
+	return len(s.Spec.ClusterSpecList) > 0
+}
+
+// GetClusterSpecItem returns the configuration of the mongot instances in the member cluster, nil if no mongot
+// instances are deployed in it.
+func (s *MongoDBSearch) GetClusterSpecItem(clusterName string) *ClusterSpecItem {
+	for i := range s.Spec.ClusterSpecList {
+		if s.Spec.ClusterSpecList[i].ClusterName == clusterName {
+			return &s.Spec.ClusterSpecList[i]
+		}
+	}
+	return nil
+}
+
+// GetOwnerLabels returns the labels identifying the objects created for the search in the member clusters, where
+// owner references to the MongoDBSearch resource can't be used.
+func (s *MongoDBSearch) GetOwnerLabels() map[string]string {
+	return map[string]string{
+		util.OperatorLabelName: util.OperatorLabelValue,
+		LabelResourceOwner:     fmt.Sprintf("%s-%s", s.Namespace, s.Name),
+	}
+}
+
 func (s *MongoDBSearch) SourceUserPasswordSecretRef() *userv1.SecretKeyRef {
 	var syncUserPasswordSecretKey *userv1.SecretKeyRef
 	if s.Spec.Source != nil && s.Spec.Source.PasswordSecretRef != nil {
@@ -250,6 +388,20 @@ func (s *MongoDBSearch) TLSOperatorSecretNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Name: s.Name + "-search-certificate-key", Namespace: s.Namespace}
 }
 
+func (s *MongoDBSearch) PrometheusTLSSecretNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Spec.Prometheus.TLSSecretRef.Name, Namespace: s.Namespace}
+}
+
+// PrometheusTLSOperatorSecretNamespacedName will get the namespaced name of the Secret created by the operator
+// containing the combined certificate and key of the metrics endpoint.
+func (s *MongoDBSearch) PrometheusTLSOperatorSecretNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Name + "-search-prometheus-certificate-key", Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearch) PrometheusPasswordSecretNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Spec.Prometheus.PasswordSecretRef.Name, Namespace: s.Namespace}
+}
+
 func (s *MongoDBSearch) GetMongotHealthCheckPort() int32 {
 	return MongotDefautHealthCheckPort
 }
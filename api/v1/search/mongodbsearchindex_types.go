@@ -0,0 +1,180 @@
+package search
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+)
+
+type SearchIndexType string
+
+const (
+	SearchIndexTypeSearch       SearchIndexType = "search"
+	SearchIndexTypeVectorSearch SearchIndexType = "vectorSearch"
+)
+
+func init() {
+	v1.SchemeBuilder.Register(&MongoDBSearchIndex{}, &MongoDBSearchIndexList{})
+}
+
+// The index is identified by its name, database and collection, so changing them would leave the previous index behind.
+// +kubebuilder:validation:XValidation:rule="has(self.indexName) == has(oldSelf.indexName)",message="indexName is immutable"
+type MongoDBSearchIndexSpec struct {
+	// Reference to the MongoDBSearch resource deployed for the database the index is created in.
+	SearchRef corev1.LocalObjectReference `json:"searchRef"`
+	// Name of the database containing the indexed collection.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="database is immutable"
+	Database string `json:"database"`
+	// Name of the indexed collection.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="collection is immutable"
+	Collection string `json:"collection"`
+	// Name of the search index. Defaults to the name of the MongoDBSearchIndex resource.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="indexName is immutable"
+	IndexName string `json:"indexName,omitempty"`
+	// Type of the search index, either "search" for full-text search or "vectorSearch" for vector search.
+	// +kubebuilder:validation:Enum=search;vectorSearch
+	// +kubebuilder:default=search
+	// +optional
+	Type SearchIndexType `json:"type,omitempty"`
+	// Definition of the index as documented for the createSearchIndexes command, e.g. "mappings" for search indexes
+	// or "fields" for vector search indexes.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Definition mdbcv1.MapWrapper `json:"definition"`
+	// Analyzer applied to string fields when indexing. Only valid for "search" indexes. Overrides "analyzer" in the definition.
+	// +optional
+	Analyzer string `json:"analyzer,omitempty"`
+	// Analyzer applied to query text. Only valid for "search" indexes. Overrides "searchAnalyzer" in the definition.
+	// +optional
+	SearchAnalyzer string `json:"searchAnalyzer,omitempty"`
+	// Username of the database user the operator authenticates as to manage the index.
+	// The user needs the privileges to create, update, drop and list search indexes on the collection.
+	Username string `json:"username"`
+	// Secret containing the password of the database user. The password is read from the "password" key by default.
+	PasswordSecretRef userv1.SecretKeyRef `json:"passwordSecretRef"`
+}
+
+type MongoDBSearchIndexStatus struct {
+	status.Common `json:",inline"`
+	// Status of the index as reported by $listSearchIndexes, e.g. PENDING, BUILDING, READY, FAILED or STALE.
+	IndexStatus string `json:"indexStatus,omitempty"`
+	// Whether the index can be used to serve queries.
+	Queryable bool             `json:"queryable"`
+	Warnings  []status.Warning `json:"warnings,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB Search index."
+// +kubebuilder:printcolumn:name="Index Status",type="string",JSONPath=".status.indexStatus",description="Status of the index reported by MongoDB."
+// +kubebuilder:printcolumn:name="Queryable",type="boolean",JSONPath=".status.queryable",description="Whether the index can be queried."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDBSearchIndex resource was created."
+// +kubebuilder:resource:path=mongodbsearchindexes,scope=Namespaced,shortName=mdbsi
+type MongoDBSearchIndex struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MongoDBSearchIndexSpec `json:"spec"`
+	// +optional
+	Status MongoDBSearchIndexStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MongoDBSearchIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDBSearchIndex `json:"items"`
+}
+
+func (s *MongoDBSearchIndex) GetCommonStatus(options ...status.Option) *status.Common {
+	return &s.Status.Common
+}
+
+func (s *MongoDBSearchIndex) GetStatus(...status.Option) interface{} {
+	return s.Status
+}
+
+func (s *MongoDBSearchIndex) GetStatusPath(...status.Option) string {
+	return "/status"
+}
+
+func (s *MongoDBSearchIndex) SetWarnings(warnings []status.Warning, _ ...status.Option) {
+	s.Status.Warnings = warnings
+}
+
+func (s *MongoDBSearchIndex) UpdateStatus(phase status.Phase, statusOptions ...status.Option) {
+	s.Status.UpdateCommonFields(phase, s.GetGeneration(), statusOptions...)
+	if option, exists := status.GetOption(statusOptions, status.WarningsOption{}); exists {
+		s.Status.Warnings = append(s.Status.Warnings, option.(status.WarningsOption).Warnings...)
+	}
+	if option, exists := status.GetOption(statusOptions, MongoDBSearchIndexStatusOption{}); exists {
+		indexStatus := option.(MongoDBSearchIndexStatusOption)
+		s.Status.IndexStatus = indexStatus.IndexStatus
+		s.Status.Queryable = indexStatus.Queryable
+	}
+}
+
+func (s *MongoDBSearchIndex) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Name, Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearchIndex) SearchNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Spec.SearchRef.Name, Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearchIndex) GetIndexName() string {
+	if s.Spec.IndexName != "" {
+		return s.Spec.IndexName
+	}
+
+	return s.Name
+}
+
+func (s *MongoDBSearchIndex) GetIndexType() SearchIndexType {
+	if s.Spec.Type == "" {
+		return SearchIndexTypeSearch
+	}
+
+	return s.Spec.Type
+}
+
+func (s *MongoDBSearchIndex) PasswordSecretNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Name: s.Spec.PasswordSecretRef.Name, Namespace: s.Namespace}
+}
+
+func (s *MongoDBSearchIndex) PasswordSecretKey() string {
+	if s.Spec.PasswordSecretRef.Key == "" {
+		return "password"
+	}
+
+	return s.Spec.PasswordSecretRef.Key
+}
+
+// GetIndexDefinition returns the index definition sent to the database, with the analyzer settings of the spec
+// taking precedence over the ones in the definition.
+func (s *MongoDBSearchIndex) GetIndexDefinition() map[string]interface{} {
+	definition := s.Spec.Definition.DeepCopy().Object
+
+	if s.GetIndexType() == SearchIndexTypeSearch {
+		if s.Spec.Analyzer != "" {
+			definition["analyzer"] = s.Spec.Analyzer
+		}
+		if s.Spec.SearchAnalyzer != "" {
+			definition["searchAnalyzer"] = s.Spec.SearchAnalyzer
+		}
+	}
+
+	return definition
+}
@@ -15,3 +15,32 @@ func NewMongoDBSearchVersionOption(version string) MongoDBSearchVersionOption {
 func (o MongoDBSearchVersionOption) Value() interface{} {
 	return o.Version
 }
+
+type MongoDBSearchIndexLagOption struct {
+	IndexLag *IndexLag
+}
+
+var _ status.Option = MongoDBSearchIndexLagOption{}
+
+func NewMongoDBSearchIndexLagOption(maxLagSeconds int64, lastUpdated string) MongoDBSearchIndexLagOption {
+	return MongoDBSearchIndexLagOption{IndexLag: &IndexLag{MaxLagSeconds: maxLagSeconds, LastUpdated: lastUpdated}}
+}
+
+func (o MongoDBSearchIndexLagOption) Value() interface{} {
+	return o.IndexLag
+}
+
+type MongoDBSearchIndexStatusOption struct {
+	IndexStatus string
+	Queryable   bool
+}
+
+var _ status.Option = MongoDBSearchIndexStatusOption{}
+
+func NewMongoDBSearchIndexStatusOption(indexStatus string, queryable bool) MongoDBSearchIndexStatusOption {
+	return MongoDBSearchIndexStatusOption{IndexStatus: indexStatus, Queryable: queryable}
+}
+
+func (o MongoDBSearchIndexStatusOption) Value() interface{} {
+	return o
+}
@@ -28,6 +28,30 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecItem) DeepCopyInto(out *ClusterSpecItem) {
+	*out = *in
+	if in.StatefulSetConfiguration != nil {
+		in, out := &in.StatefulSetConfiguration, &out.StatefulSetConfiguration
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceRequirements != nil {
+		in, out := &in.ResourceRequirements, &out.ResourceRequirements
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecItem.
+func (in *ClusterSpecItem) DeepCopy() *ClusterSpecItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalMongoDBSource) DeepCopyInto(out *ExternalMongoDBSource) {
 	*out = *in
@@ -78,6 +102,21 @@ func (in *ExternalMongodTLS) DeepCopy() *ExternalMongodTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLag) DeepCopyInto(out *IndexLag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLag.
+func (in *IndexLag) DeepCopy() *IndexLag {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBSearch) DeepCopyInto(out *MongoDBSearch) {
 	*out = *in
@@ -105,6 +144,139 @@ func (in *MongoDBSearch) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndex) DeepCopyInto(out *MongoDBSearchIndex) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndex.
+func (in *MongoDBSearchIndex) DeepCopy() *MongoDBSearchIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBSearchIndex) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndexLagOption) DeepCopyInto(out *MongoDBSearchIndexLagOption) {
+	*out = *in
+	if in.IndexLag != nil {
+		in, out := &in.IndexLag, &out.IndexLag
+		*out = new(IndexLag)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndexLagOption.
+func (in *MongoDBSearchIndexLagOption) DeepCopy() *MongoDBSearchIndexLagOption {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndexLagOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndexList) DeepCopyInto(out *MongoDBSearchIndexList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MongoDBSearchIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndexList.
+func (in *MongoDBSearchIndexList) DeepCopy() *MongoDBSearchIndexList {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndexList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MongoDBSearchIndexList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndexSpec) DeepCopyInto(out *MongoDBSearchIndexSpec) {
+	*out = *in
+	out.SearchRef = in.SearchRef
+	in.Definition.DeepCopyInto(&out.Definition)
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndexSpec.
+func (in *MongoDBSearchIndexSpec) DeepCopy() *MongoDBSearchIndexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndexStatus) DeepCopyInto(out *MongoDBSearchIndexStatus) {
+	*out = *in
+	in.Common.DeepCopyInto(&out.Common)
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]status.Warning, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndexStatus.
+func (in *MongoDBSearchIndexStatus) DeepCopy() *MongoDBSearchIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MongoDBSearchIndexStatusOption) DeepCopyInto(out *MongoDBSearchIndexStatusOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBSearchIndexStatusOption.
+func (in *MongoDBSearchIndexStatusOption) DeepCopy() *MongoDBSearchIndexStatusOption {
+	if in == nil {
+		return nil
+	}
+	out := new(MongoDBSearchIndexStatusOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBSearchList) DeepCopyInto(out *MongoDBSearchList) {
 	*out = *in
@@ -163,7 +335,14 @@ func (in *MongoDBSearchSpec) DeepCopyInto(out *MongoDBSearchSpec) {
 	if in.Prometheus != nil {
 		in, out := &in.Prometheus, &out.Prometheus
 		*out = new(Prometheus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSpecList != nil {
+		in, out := &in.ClusterSpecList, &out.ClusterSpecList
+		*out = make([]ClusterSpecItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -181,6 +360,11 @@ func (in *MongoDBSearchSpec) DeepCopy() *MongoDBSearchSpec {
 func (in *MongoDBSearchStatus) DeepCopyInto(out *MongoDBSearchStatus) {
 	*out = *in
 	in.Common.DeepCopyInto(&out.Common)
+	if in.IndexLag != nil {
+		in, out := &in.IndexLag, &out.IndexLag
+		*out = new(IndexLag)
+		**out = **in
+	}
 	if in.Warnings != nil {
 		in, out := &in.Warnings, &out.Warnings
 		*out = make([]status.Warning, len(*in))
@@ -251,6 +435,21 @@ func (in *MongoDBSource) DeepCopy() *MongoDBSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prometheus) DeepCopyInto(out *Prometheus) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(user.SecretKeyRef)
+		**out = **in
+	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(PrometheusService)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prometheus.
@@ -263,6 +462,35 @@ func (in *Prometheus) DeepCopy() *Prometheus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusService) DeepCopyInto(out *PrometheusService) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusService.
+func (in *PrometheusService) DeepCopy() *PrometheusService {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Security) DeepCopyInto(out *Security) {
 	*out = *in
@@ -53,6 +53,19 @@ func (o BaseUrlOption) Value() interface{} {
 	return o.BaseUrl
 }
 
+// APIKeyRotationOption describes the time the Ops Manager admin API key was last rotated.
+type APIKeyRotationOption struct {
+	LastRotation string
+}
+
+func NewAPIKeyRotationOption(lastRotation string) APIKeyRotationOption {
+	return APIKeyRotationOption{LastRotation: lastRotation}
+}
+
+func (o APIKeyRotationOption) Value() interface{} {
+	return o.LastRotation
+}
+
 // OMPartOption describes the part of Ops Manager resource status to be updated
 type OMPartOption struct {
 	StatusPart Part
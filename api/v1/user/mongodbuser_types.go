@@ -191,6 +191,10 @@ func (u *MongoDBUser) SetWarnings(warnings []status.Warning, _ ...status.Option)
 	u.Status.Warnings = warnings
 }
 
+func (u *MongoDBUser) AddWarningIfNotExists(warning status.Warning) {
+	u.Status.Warnings = status.Warnings(u.Status.Warnings).AddIfNotExists(warning)
+}
+
 func (u *MongoDBUser) GetStatus(...status.Option) interface{} {
 	return u.Status
 }
@@ -0,0 +1,276 @@
+package mdb
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
+)
+
+// V1FieldsAnnotation holds the v1 fields which have no counterpart in v2, so that reading a v1 resource as v2 and
+// writing it back doesn't drop them.
+const V1FieldsAnnotation = "mongodb.com/v2.v1Fields"
+
+// v1Fields are the deprecated fields of the v1 spec. The pod specs don't contain the persistence, which is moved to
+// the v2 spec of the deployment type.
+type v1Fields struct {
+	Service string `json:"service,omitempty"`
+	// ProjectInCloudManager is true if the project ConfigMap is referenced in spec.cloudManager instead of
+	// spec.opsManager.
+	ProjectInCloudManager bool                      `json:"projectInCloudManager,omitempty"`
+	CloudManagerConfig    *mdbv1.PrivateCloudConfig `json:"cloudManager,omitempty"`
+	PodSpec               *mdbv1.MongoDbPodSpec     `json:"podSpec,omitempty"`
+	ConfigSrvPodSpec      *mdbv1.MongoDbPodSpec     `json:"configSrvPodSpec,omitempty"`
+	MongosPodSpec         *mdbv1.MongoDbPodSpec     `json:"mongosPodSpec,omitempty"`
+	ShardPodSpec          *mdbv1.MongoDbPodSpec     `json:"shardPodSpec,omitempty"`
+	ShardSpecificPodSpec  []mdbv1.MongoDbPodSpec    `json:"shardSpecificPodSpec,omitempty"`
+}
+
+var _ conversion.Convertible = &MongoDB{}
+
+// ConvertTo converts the v2 resource to the v1 hub.
+func (m *MongoDB) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*mdbv1.MongoDB)
+	if !ok {
+		return xerrors.Errorf("unsupported conversion of MongoDB to %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *m.ObjectMeta.DeepCopy()
+	fields, err := popV1Fields(&dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	dst.Status = *m.Status.DeepCopy()
+
+	spec := m.Spec.DeepCopy()
+	dst.Spec = mdbv1.MongoDbSpec{
+		DbCommonSpec: mdbv1.DbCommonSpec{
+			Version:                     spec.Version,
+			FeatureCompatibilityVersion: spec.FeatureCompatibilityVersion,
+			Agent:                       spec.Agent,
+			ClusterDomain:               spec.ClusterDomain,
+			ConnectionSpec: mdbv1.ConnectionSpec{
+				SharedConnectionSpec: mdbv1.SharedConnectionSpec{
+					OpsManagerConfig:   spec.OpsManagerConfig,
+					CloudManagerConfig: fields.CloudManagerConfig,
+				},
+				Credentials: spec.Credentials,
+			},
+			LogLevel:                    spec.LogLevel,
+			ExternalAccessConfiguration: spec.ExternalAccessConfiguration,
+			Persistent:                  spec.Persistent,
+			Security:                    spec.Security,
+			Connectivity:                spec.Connectivity,
+			Backup:                      spec.Backup,
+			Prometheus:                  spec.Prometheus,
+			StatefulSetConfiguration:    spec.StatefulSetConfiguration,
+			AdditionalMongodConfig:      spec.AdditionalMongodConfig,
+			DuplicateServiceObjects:     spec.DuplicateServiceObjects,
+			Topology:                    spec.Topology,
+		},
+		Service: fields.Service,
+	}
+	if fields.ProjectInCloudManager {
+		dst.Spec.OpsManagerConfig = nil
+		dst.Spec.CloudManagerConfig = spec.OpsManagerConfig
+	}
+
+	switch {
+	case spec.Standalone != nil:
+		dst.Spec.ResourceType = mdbv1.Standalone
+		dst.Spec.PodSpec = withPersistence(fields.PodSpec, spec.Standalone.Persistence)
+	case spec.ReplicaSet != nil:
+		dst.Spec.ResourceType = mdbv1.ReplicaSet
+		dst.Spec.Members = spec.ReplicaSet.Members
+		dst.Spec.MemberConfig = spec.ReplicaSet.MemberConfig
+		dst.Spec.StorageAutoscaling = spec.ReplicaSet.StorageAutoscaling
+		dst.Spec.PodSpec = withPersistence(fields.PodSpec, spec.ReplicaSet.Persistence)
+	case spec.ShardedCluster != nil:
+		sharded := spec.ShardedCluster
+		dst.Spec.ResourceType = mdbv1.ShardedCluster
+		dst.Spec.ShardCount = sharded.ShardCount
+		dst.Spec.MongodsPerShardCount = sharded.Shard.Members
+		dst.Spec.ConfigServerCount = sharded.ConfigServer.Members
+		dst.Spec.MongosCount = sharded.Mongos.Members
+		dst.Spec.ShardSpec = componentSpecOrNil(sharded.Shard.ShardedClusterComponentSpec)
+		dst.Spec.ConfigSrvSpec = componentSpecOrNil(sharded.ConfigServer.ShardedClusterComponentSpec)
+		dst.Spec.MongosSpec = componentSpecOrNil(sharded.Mongos.ShardedClusterComponentSpec)
+		dst.Spec.ShardOverrides = sharded.ShardOverrides
+		dst.Spec.ShardPodSpec = withPersistence(fields.ShardPodSpec, sharded.Shard.Persistence)
+		dst.Spec.ConfigSrvPodSpec = withPersistence(fields.ConfigSrvPodSpec, sharded.ConfigServer.Persistence)
+		dst.Spec.MongosPodSpec = fields.MongosPodSpec
+		dst.Spec.ShardSpecificPodSpec = fields.ShardSpecificPodSpec
+	default:
+		return xerrors.Errorf("one of spec.standalone, spec.replicaSet or spec.shardedCluster must be set")
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the v1 hub to the v2 resource. The fields of v1 which aren't relevant for the type of the
+// deployment, e.g. spec.shardCount of a replica set, are dropped.
+func (m *MongoDB) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*mdbv1.MongoDB)
+	if !ok {
+		return xerrors.Errorf("unsupported conversion of %T to MongoDB", srcRaw)
+	}
+
+	m.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	m.Status = *src.Status.DeepCopy()
+
+	spec := src.Spec.DeepCopy()
+	m.Spec = MongoDBSpec{
+		Version:                     spec.Version,
+		FeatureCompatibilityVersion: spec.FeatureCompatibilityVersion,
+		Agent:                       spec.Agent,
+		ClusterDomain:               spec.ClusterDomain,
+		Credentials:                 spec.Credentials,
+		OpsManagerConfig:            spec.OpsManagerConfig,
+		LogLevel:                    spec.LogLevel,
+		ExternalAccessConfiguration: spec.ExternalAccessConfiguration,
+		Persistent:                  spec.Persistent,
+		Security:                    spec.Security,
+		Connectivity:                spec.Connectivity,
+		Backup:                      spec.Backup,
+		Prometheus:                  spec.Prometheus,
+		StatefulSetConfiguration:    spec.StatefulSetConfiguration,
+		AdditionalMongodConfig:      spec.AdditionalMongodConfig,
+		DuplicateServiceObjects:     spec.DuplicateServiceObjects,
+		Topology:                    spec.Topology,
+	}
+
+	fields := v1Fields{Service: spec.Service}
+	if spec.OpsManagerConfig != nil {
+		fields.CloudManagerConfig = spec.CloudManagerConfig
+	} else {
+		m.Spec.OpsManagerConfig = spec.CloudManagerConfig
+		fields.ProjectInCloudManager = spec.CloudManagerConfig != nil
+	}
+
+	switch spec.ResourceType {
+	case mdbv1.Standalone:
+		m.Spec.Standalone = &StandaloneSpec{Persistence: persistence(spec.PodSpec)}
+		fields.PodSpec = withoutPersistence(spec.PodSpec)
+	case mdbv1.ReplicaSet:
+		m.Spec.ReplicaSet = &ReplicaSetSpec{
+			Members:            spec.Members,
+			MemberConfig:       spec.MemberConfig,
+			Persistence:        persistence(spec.PodSpec),
+			StorageAutoscaling: spec.StorageAutoscaling,
+		}
+		fields.PodSpec = withoutPersistence(spec.PodSpec)
+	case mdbv1.ShardedCluster:
+		m.Spec.ShardedCluster = &ShardedClusterSpec{
+			ShardCount: spec.ShardCount,
+			Shard: ShardedClusterComponentSpec{
+				Members:                     spec.MongodsPerShardCount,
+				Persistence:                 persistence(spec.ShardPodSpec),
+				ShardedClusterComponentSpec: componentSpec(spec.ShardSpec),
+			},
+			ConfigServer: ShardedClusterComponentSpec{
+				Members:                     spec.ConfigServerCount,
+				Persistence:                 persistence(spec.ConfigSrvPodSpec),
+				ShardedClusterComponentSpec: componentSpec(spec.ConfigSrvSpec),
+			},
+			Mongos: MongosSpec{
+				Members:                     spec.MongosCount,
+				ShardedClusterComponentSpec: componentSpec(spec.MongosSpec),
+			},
+			ShardOverrides: spec.ShardOverrides,
+		}
+		fields.ShardPodSpec = withoutPersistence(spec.ShardPodSpec)
+		fields.ConfigSrvPodSpec = withoutPersistence(spec.ConfigSrvPodSpec)
+		fields.MongosPodSpec = spec.MongosPodSpec
+		fields.ShardSpecificPodSpec = spec.ShardSpecificPodSpec
+	default:
+		return xerrors.Errorf("unsupported spec.type %q", spec.ResourceType)
+	}
+
+	return setV1Fields(&m.ObjectMeta, fields)
+}
+
+func popV1Fields(meta *metav1.ObjectMeta) (v1Fields, error) {
+	var fields v1Fields
+	value, ok := meta.Annotations[V1FieldsAnnotation]
+	if !ok {
+		return fields, nil
+	}
+	delete(meta.Annotations, V1FieldsAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return fields, xerrors.Errorf("failed to parse annotation %s: %w", V1FieldsAnnotation, err)
+	}
+	return fields, nil
+}
+
+func setV1Fields(meta *metav1.ObjectMeta, fields v1Fields) error {
+	delete(meta.Annotations, V1FieldsAnnotation)
+	if reflect.DeepEqual(fields, v1Fields{}) {
+		if len(meta.Annotations) == 0 {
+			meta.Annotations = nil
+		}
+		return nil
+	}
+	value, err := json.Marshal(fields)
+	if err != nil {
+		return xerrors.Errorf("failed to serialize annotation %s: %w", V1FieldsAnnotation, err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[V1FieldsAnnotation] = string(value)
+	return nil
+}
+
+func persistence(podSpec *mdbv1.MongoDbPodSpec) *common.Persistence {
+	if podSpec == nil {
+		return nil
+	}
+	return podSpec.Persistence
+}
+
+// withoutPersistence returns the pod spec without the persistence or nil if nothing else is left in it.
+func withoutPersistence(podSpec *mdbv1.MongoDbPodSpec) *mdbv1.MongoDbPodSpec {
+	if podSpec == nil {
+		return nil
+	}
+	podSpec = podSpec.DeepCopy()
+	podSpec.Persistence = nil
+	if reflect.DeepEqual(*podSpec, mdbv1.MongoDbPodSpec{}) {
+		return nil
+	}
+	return podSpec
+}
+
+func withPersistence(podSpec *mdbv1.MongoDbPodSpec, persistence *common.Persistence) *mdbv1.MongoDbPodSpec {
+	if persistence == nil {
+		return podSpec
+	}
+	if podSpec == nil {
+		podSpec = &mdbv1.MongoDbPodSpec{}
+	}
+	podSpec.Persistence = persistence
+	return podSpec
+}
+
+func componentSpec(spec *mdbv1.ShardedClusterComponentSpec) mdbv1.ShardedClusterComponentSpec {
+	if spec == nil {
+		return mdbv1.ShardedClusterComponentSpec{}
+	}
+	return *spec
+}
+
+func componentSpecOrNil(spec mdbv1.ShardedClusterComponentSpec) *mdbv1.ShardedClusterComponentSpec {
+	if reflect.DeepEqual(spec, mdbv1.ShardedClusterComponentSpec{}) {
+		return nil
+	}
+	return &spec
+}
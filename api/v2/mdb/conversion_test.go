@@ -0,0 +1,212 @@
+package mdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+)
+
+func projectConfig(name string) *mdbv1.PrivateCloudConfig {
+	return &mdbv1.PrivateCloudConfig{ConfigMapRef: mdbv1.ConfigMapRef{Name: name}}
+}
+
+func singlePersistence(storage string) *common.Persistence {
+	return &common.Persistence{SingleConfig: &common.PersistenceConfig{Storage: storage}}
+}
+
+func podTemplate(image string) common.PodTemplateSpecWrapper {
+	return common.PodTemplateSpecWrapper{PodTemplate: &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "mongodb-agent", Image: image}}},
+	}}
+}
+
+func v1MongoDB(resourceType mdbv1.ResourceType) *mdbv1.MongoDB {
+	return &mdbv1.MongoDB{
+		ObjectMeta: metav1.ObjectMeta{Name: "mdb", Namespace: "ns", Labels: map[string]string{"app": "mdb"}},
+		Spec: mdbv1.MongoDbSpec{
+			DbCommonSpec: mdbv1.DbCommonSpec{
+				Version:      "8.0.0",
+				ResourceType: resourceType,
+				ConnectionSpec: mdbv1.ConnectionSpec{
+					SharedConnectionSpec: mdbv1.SharedConnectionSpec{OpsManagerConfig: projectConfig("project")},
+					Credentials:          "credentials",
+				},
+				Security: &mdbv1.Security{Authentication: &mdbv1.Authentication{Enabled: true, Modes: []mdbv1.AuthMode{"SCRAM"}}},
+			},
+		},
+		Status: mdbv1.MongoDbStatus{Version: "8.0.0"},
+	}
+}
+
+func TestIsConvertible(t *testing.T) {
+	scheme, err := v1.SchemeBuilder.Build()
+	require.NoError(t, err)
+	require.NoError(t, AddToScheme(scheme))
+
+	// the conversion webhook is only registered by controller-runtime if MongoDB is convertible
+	convertible, err := conversion.IsConvertible(scheme, &mdbv1.MongoDB{})
+	require.NoError(t, err)
+	assert.True(t, convertible)
+}
+
+func TestConvertFrom_ReplicaSet(t *testing.T) {
+	src := v1MongoDB(mdbv1.ReplicaSet)
+	src.Spec.Members = 3
+	src.Spec.MemberConfig = []automationconfig.MemberOptions{{Votes: ptr.To(1)}, {Votes: ptr.To(1)}, {Votes: ptr.To(0)}}
+	src.Spec.PodSpec = &mdbv1.MongoDbPodSpec{Persistence: singlePersistence("20G")}
+	src.Spec.StorageAutoscaling = &mdbv1.StorageAutoscaling{Increment: "10G", MaxSize: "100G"}
+
+	dst := &MongoDB{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, "8.0.0", dst.Spec.Version)
+	assert.Equal(t, projectConfig("project"), dst.Spec.OpsManagerConfig)
+	assert.Equal(t, src.Spec.Security, dst.Spec.Security)
+	assert.Equal(t, &ReplicaSetSpec{Members: 3, MemberConfig: src.Spec.MemberConfig, Persistence: singlePersistence("20G"), StorageAutoscaling: src.Spec.StorageAutoscaling}, dst.Spec.ReplicaSet)
+	assert.Nil(t, dst.Spec.Standalone)
+	assert.Nil(t, dst.Spec.ShardedCluster)
+	// all the fields of the replica set have a counterpart in v2
+	assert.NotContains(t, dst.Annotations, V1FieldsAnnotation)
+}
+
+func TestConvertFrom_ShardedCluster(t *testing.T) {
+	src := v1MongoDB(mdbv1.ShardedCluster)
+	src.Spec.ShardCount = 2
+	src.Spec.MongodsPerShardCount = 3
+	src.Spec.ConfigServerCount = 5
+	src.Spec.MongosCount = 1
+	src.Spec.ShardSpec = &mdbv1.ShardedClusterComponentSpec{Agent: mdbv1.AgentConfig{LogLevel: "DEBUG"}}
+	src.Spec.ShardPodSpec = &mdbv1.MongoDbPodSpec{Persistence: singlePersistence("50G")}
+	src.Spec.ConfigSrvPodSpec = &mdbv1.MongoDbPodSpec{Persistence: singlePersistence("5G")}
+
+	dst := &MongoDB{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, &ShardedClusterSpec{
+		ShardCount: 2,
+		Shard: ShardedClusterComponentSpec{
+			Members:                     3,
+			Persistence:                 singlePersistence("50G"),
+			ShardedClusterComponentSpec: mdbv1.ShardedClusterComponentSpec{Agent: mdbv1.AgentConfig{LogLevel: "DEBUG"}},
+		},
+		ConfigServer: ShardedClusterComponentSpec{Members: 5, Persistence: singlePersistence("5G")},
+		Mongos:       MongosSpec{Members: 1},
+	}, dst.Spec.ShardedCluster)
+	assert.Nil(t, dst.Spec.ReplicaSet)
+	assert.NotContains(t, dst.Annotations, V1FieldsAnnotation)
+}
+
+func TestConvertFrom_UnsupportedType(t *testing.T) {
+	assert.Error(t, (&MongoDB{}).ConvertFrom(v1MongoDB("")))
+}
+
+func TestConvertTo_DeploymentTypeNotSet(t *testing.T) {
+	src := &MongoDB{Spec: MongoDBSpec{Version: "8.0.0"}}
+	assert.Error(t, src.ConvertTo(&mdbv1.MongoDB{}))
+}
+
+// TestConversion_V1RoundTrip verifies that no field of v1, deprecated or not, is lost when a v1 resource is read as
+// v2 and written back.
+func TestConversion_V1RoundTrip(t *testing.T) {
+	standalone := v1MongoDB(mdbv1.Standalone)
+	standalone.Spec.PodSpec = &mdbv1.MongoDbPodSpec{Persistence: singlePersistence("10G")}
+
+	replicaSet := v1MongoDB(mdbv1.ReplicaSet)
+	replicaSet.Spec.Members = 3
+	replicaSet.Spec.StatefulSetConfiguration = &common.StatefulSetConfiguration{}
+
+	deprecatedReplicaSet := v1MongoDB(mdbv1.ReplicaSet)
+	deprecatedReplicaSet.Spec.Members = 1
+	deprecatedReplicaSet.Spec.Service = "custom-service"
+	deprecatedReplicaSet.Spec.OpsManagerConfig = nil
+	deprecatedReplicaSet.Spec.CloudManagerConfig = projectConfig("cloud-project")
+	deprecatedReplicaSet.Spec.PodSpec = &mdbv1.MongoDbPodSpec{PodTemplateWrapper: podTemplate("agent:1"), Persistence: singlePersistence("20G")}
+	deprecatedReplicaSet.Annotations = map[string]string{"custom": "annotation"}
+
+	bothProjectConfigs := v1MongoDB(mdbv1.Standalone)
+	bothProjectConfigs.Spec.CloudManagerConfig = projectConfig("cloud-project")
+
+	shardedCluster := v1MongoDB(mdbv1.ShardedCluster)
+	shardedCluster.Spec.ShardCount = 2
+	shardedCluster.Spec.MongodsPerShardCount = 3
+	shardedCluster.Spec.ConfigServerCount = 3
+	shardedCluster.Spec.MongosCount = 2
+	shardedCluster.Spec.MongosSpec = &mdbv1.ShardedClusterComponentSpec{Agent: mdbv1.AgentConfig{LogLevel: "INFO"}}
+	shardedCluster.Spec.ShardOverrides = []mdbv1.ShardOverride{{ShardNames: []string{"mdb-0"}, Members: ptr.To(5)}}
+	shardedCluster.Spec.ShardPodSpec = &mdbv1.MongoDbPodSpec{PodTemplateWrapper: podTemplate("agent:2"), Persistence: singlePersistence("50G")}
+	shardedCluster.Spec.ConfigSrvPodSpec = &mdbv1.MongoDbPodSpec{Persistence: singlePersistence("5G")}
+	shardedCluster.Spec.MongosPodSpec = &mdbv1.MongoDbPodSpec{PodTemplateWrapper: podTemplate("agent:3")}
+	shardedCluster.Spec.ShardSpecificPodSpec = []mdbv1.MongoDbPodSpec{{Persistence: singlePersistence("100G")}}
+
+	tests := map[string]*mdbv1.MongoDB{
+		"standalone":                         standalone,
+		"replica set":                        replicaSet,
+		"replica set with deprecated fields": deprecatedReplicaSet,
+		"both project configs":               bothProjectConfigs,
+		"sharded cluster":                    shardedCluster,
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			v2 := &MongoDB{}
+			require.NoError(t, v2.ConvertFrom(src.DeepCopy()))
+
+			dst := &mdbv1.MongoDB{}
+			require.NoError(t, v2.ConvertTo(dst))
+			assert.Equal(t, src, dst)
+		})
+	}
+}
+
+func TestConversion_V2RoundTrip(t *testing.T) {
+	spec := func() MongoDBSpec {
+		return MongoDBSpec{
+			Version:          "8.0.0",
+			Credentials:      "credentials",
+			OpsManagerConfig: projectConfig("project"),
+			Topology:         mdbv1.ClusterTopologySingleCluster,
+		}
+	}
+
+	replicaSet := &MongoDB{ObjectMeta: metav1.ObjectMeta{Name: "mdb", Namespace: "ns"}, Spec: spec()}
+	replicaSet.Spec.ReplicaSet = &ReplicaSetSpec{Members: 3, Persistence: singlePersistence("20G")}
+
+	shardedCluster := &MongoDB{ObjectMeta: metav1.ObjectMeta{Name: "mdb", Namespace: "ns"}, Spec: spec()}
+	shardedCluster.Spec.ShardedCluster = &ShardedClusterSpec{
+		ShardCount: 3,
+		Shard: ShardedClusterComponentSpec{
+			Members:     3,
+			Persistence: singlePersistence("50G"),
+			ShardedClusterComponentSpec: mdbv1.ShardedClusterComponentSpec{
+				ClusterSpecList: mdbv1.ClusterSpecList{{ClusterName: "cluster-1", Members: 3}},
+			},
+		},
+		ConfigServer: ShardedClusterComponentSpec{Members: 3},
+		Mongos:       MongosSpec{Members: 2},
+	}
+
+	tests := map[string]*MongoDB{
+		"replica set":     replicaSet,
+		"sharded cluster": shardedCluster,
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			hub := &mdbv1.MongoDB{}
+			require.NoError(t, src.DeepCopy().ConvertTo(hub))
+
+			dst := &MongoDB{}
+			require.NoError(t, dst.ConvertFrom(hub))
+			assert.Equal(t, src, dst)
+		})
+	}
+}
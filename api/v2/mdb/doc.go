@@ -0,0 +1,4 @@
+package mdb
+
+// +k8s:deepcopy-gen=package
+// +versionName=v2
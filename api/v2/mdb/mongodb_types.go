@@ -0,0 +1,166 @@
+package mdb
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+)
+
+func init() {
+	SchemeBuilder.Register(&MongoDB{}, &MongoDBList{})
+}
+
+// MongoDB resources allow you to deploy Standalones, ReplicaSets or ShardedClusters to your Kubernetes cluster.
+// Contrary to v1, the configuration of each deployment type lives in its own section of the spec and the
+// deprecated fields of v1 are removed. The resources are stored as v1, the versions are converted by the
+// conversion webhook of the operator.
+
+// +kubebuilder:object:root=true
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:resource:path=mongodb,scope=Namespaced,shortName=mdb
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current state of the MongoDB deployment."
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Version of MongoDB server."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="The time since the MongoDB resource was created."
+type MongoDB struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +optional
+	Status mdbv1.MongoDbStatus `json:"status"`
+	Spec   MongoDBSpec         `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+type MongoDBList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MongoDB `json:"items"`
+}
+
+// MongoDBSpec holds the configuration shared by all deployment types and exactly one of the standalone, replicaSet
+// and shardedCluster sections, which defines the type of the deployment.
+// +kubebuilder:validation:XValidation:rule="[has(self.standalone), has(self.replicaSet), has(self.shardedCluster)].filter(x, x).size() == 1",message="Exactly one of standalone, replicaSet or shardedCluster must be set"
+type MongoDBSpec struct {
+	// +kubebuilder:validation:Pattern=^[0-9]+.[0-9]+.[0-9]+(-.+)?$|^$
+	// +kubebuilder:validation:Required
+	Version                     string            `json:"version"`
+	FeatureCompatibilityVersion *string           `json:"featureCompatibilityVersion,omitempty"`
+	Agent                       mdbv1.AgentConfig `json:"agent,omitempty"`
+	// +kubebuilder:validation:Format="hostname"
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Name of the Secret holding credentials information
+	// +kubebuilder:validation:Required
+	Credentials string `json:"credentials"`
+	// OpsManagerConfig references the ConfigMap with the configuration of the Ops Manager or Cloud Manager project.
+	// It replaces both spec.opsManager and spec.cloudManager of v1.
+	OpsManagerConfig *mdbv1.PrivateCloudConfig `json:"opsManager,omitempty"`
+
+	// +kubebuilder:validation:Enum=DEBUG;INFO;WARN;ERROR;FATAL
+	LogLevel mdbv1.LogLevel `json:"logLevel,omitempty"`
+	// ExternalAccessConfiguration provides external access configuration.
+	// +optional
+	ExternalAccessConfiguration *mdbv1.ExternalAccessConfiguration `json:"externalAccess,omitempty"`
+
+	Persistent *bool `json:"persistent,omitempty"`
+	// +optional
+	Security     *mdbv1.Security            `json:"security,omitempty"`
+	Connectivity *mdbv1.MongoDBConnectivity `json:"connectivity,omitempty"`
+	Backup       *mdbv1.Backup              `json:"backup,omitempty"`
+
+	// Prometheus configurations.
+	// +optional
+	Prometheus *mdbcv1.Prometheus `json:"prometheus,omitempty"`
+
+	// +optional
+	// StatefulSetConfiguration provides the statefulset override for each of the cluster's statefulset
+	// if "StatefulSetConfiguration" is specified at cluster level under "clusterSpecList" that takes precedence over
+	// the global one
+	StatefulSetConfiguration *common.StatefulSetConfiguration `json:"statefulSet,omitempty"`
+
+	// AdditionalMongodConfig is additional configuration that can be passed to
+	// each data-bearing mongod at runtime. Uses the same structure as the mongod
+	// configuration file:
+	// https://docs.mongodb.com/manual/reference/configuration-options/
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	AdditionalMongodConfig *mdbv1.AdditionalMongodConfig `json:"additionalMongodConfig,omitempty"`
+
+	// DuplicateServiceObjects tells the operator whether it should create the service objects per pod in all the
+	// clusters. By default, if not specified the operator would create the duplicate svc objects.
+	// +optional
+	DuplicateServiceObjects *bool `json:"duplicateServiceObjects,omitempty"`
+
+	// Topology sets the desired cluster topology of MongoDB resources
+	// It defaults (if empty or not set) to SingleCluster. If MultiCluster specified,
+	// then clusterSpecList field is mandatory and at least one member cluster has to be specified.
+	// +kubebuilder:validation:Enum=SingleCluster;MultiCluster
+	// +optional
+	Topology string `json:"topology,omitempty"`
+
+	// Standalone deploys a single mongod process.
+	// +optional
+	Standalone *StandaloneSpec `json:"standalone,omitempty"`
+	// ReplicaSet deploys a replica set.
+	// +optional
+	ReplicaSet *ReplicaSetSpec `json:"replicaSet,omitempty"`
+	// ShardedCluster deploys a sharded cluster.
+	// +optional
+	ShardedCluster *ShardedClusterSpec `json:"shardedCluster,omitempty"`
+}
+
+type StandaloneSpec struct {
+	// +optional
+	Persistence *common.Persistence `json:"persistence,omitempty"`
+}
+
+type ReplicaSetSpec struct {
+	// Amount of members for this MongoDB Replica Set
+	Members int `json:"members"`
+	// MemberConfig allows to specify votes, priorities and tags for each of the mongodb process.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	MemberConfig []automationconfig.MemberOptions `json:"memberConfig,omitempty"`
+	// +optional
+	Persistence *common.Persistence `json:"persistence,omitempty"`
+	// StorageAutoscaling enables the automatic expansion of the data volume based on the disk usage reported by the
+	// agents.
+	// +optional
+	StorageAutoscaling *mdbv1.StorageAutoscaling `json:"storageAutoscaling,omitempty"`
+}
+
+type ShardedClusterSpec struct {
+	ShardCount int `json:"shardCount"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Shard ShardedClusterComponentSpec `json:"shard"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ConfigServer ShardedClusterComponentSpec `json:"configServer"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Mongos MongosSpec `json:"mongos"`
+	// ShardOverrides allow for overriding the configuration of a specific shard.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	ShardOverrides []mdbv1.ShardOverride `json:"shardOverrides,omitempty"`
+}
+
+type ShardedClusterComponentSpec struct {
+	// Number of members of each replica set of the component. Used only in SingleCluster. For MultiCluster the
+	// number of members is specified in clusterSpecList.
+	// +optional
+	Members int `json:"members,omitempty"`
+	// +optional
+	Persistence                       *common.Persistence `json:"persistence,omitempty"`
+	mdbv1.ShardedClusterComponentSpec `json:",inline"`
+}
+
+type MongosSpec struct {
+	// Number of mongos processes. Used only in SingleCluster. For MultiCluster the number of processes is
+	// specified in clusterSpecList.
+	// +optional
+	Members                           int `json:"members,omitempty"`
+	mdbv1.ShardedClusterComponentSpec `json:",inline"`
+}
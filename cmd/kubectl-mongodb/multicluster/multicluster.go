@@ -5,6 +5,7 @@ import (
 
 	"github.com/mongodb/mongodb-kubernetes/cmd/kubectl-mongodb/multicluster/recover"
 	"github.com/mongodb/mongodb-kubernetes/cmd/kubectl-mongodb/multicluster/setup"
+	"github.com/mongodb/mongodb-kubernetes/cmd/kubectl-mongodb/multicluster/verify"
 )
 
 // MulticlusterCmd represents the multicluster command
@@ -18,4 +19,5 @@ multicluster environments that hold MongoDB resources.`,
 func init() {
 	MulticlusterCmd.AddCommand(setup.SetupCmd)
 	MulticlusterCmd.AddCommand(recover.RecoverCmd)
+	MulticlusterCmd.AddCommand(verify.VerifyCmd)
 }
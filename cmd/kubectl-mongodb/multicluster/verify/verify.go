@@ -0,0 +1,76 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/kubectl-mongodb/common"
+)
+
+func init() {
+	VerifyCmd.Flags().StringVar(&common.MemberClusters, "member-clusters", "", "Comma separated list of member clusters. [required]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.ServiceAccount, "service-account", "mongodb-kubernetes-operator-multi-cluster", "Name of the service account which is used by the Operator to communicate with the member clusters. [optional, default: mongodb-kubernetes-operator-multi-cluster]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.CentralCluster, "central-cluster", "", "The central cluster the operator is deployed in. [required]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.MemberClusterNamespace, "member-cluster-namespace", "", "The namespace the member cluster resources are deployed to. [required]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.CentralClusterNamespace, "central-cluster-namespace", "", "The namespace the Operator is deployed to. [required]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.ClusterDomain, "cluster-domain", "", "The cluster domain of the member clusters. [optional, default: cluster.local]")
+	VerifyCmd.Flags().BoolVar(&verifyFlags.SkipDNSCheck, "skip-dns-check", false, "Skip the resolution of the pod FQDNs across the member clusters, which runs a probe Pod in each member cluster. [optional default: false]")
+	VerifyCmd.Flags().StringVar(&verifyFlags.DNSProbeImage, "dns-probe-image", "busybox:1.36", "Image of the probe Pods resolving the pod FQDNs, it must provide sh and nslookup. [optional, default: busybox:1.36]")
+	VerifyCmd.Flags().DurationVar(&verifyFlags.DNSProbeTimeout, "dns-probe-timeout", 2*time.Minute, "Time to wait for the probe Pods to complete. [optional, default: 2m]")
+}
+
+// VerifyCmd represents the verify command
+var VerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the multicluster environment configured by setup",
+	Long: `'verify' checks that the multicluster environment configured by 'setup' or 'recover' works: the member clusters
+are reachable with the KubeConfig secret used by the operator, the namespaces exist, the operator has the permissions it
+requires and the pod FQDNs resolve across the member clusters. The result is printed as a pass/fail matrix.
+
+Example:
+
+kubectl-mongodb multicluster verify --central-cluster="operator-cluster" --member-clusters="cluster-1,cluster-2,cluster-3" --member-cluster-namespace=mongodb --central-cluster-namespace=mongodb
+
+`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		if err := parseVerifyFlags(); err != nil {
+			fmt.Printf("error parsing flags: %s\n", err)
+			os.Exit(1)
+		}
+
+		clientMap, err := common.CreateClientMap(verifyFlags.MemberClusters, verifyFlags.CentralCluster, common.LoadKubeConfigFilePath(), common.GetKubernetesClient)
+		if err != nil {
+			fmt.Printf("failed to create clientset map: %s", err)
+			os.Exit(1)
+		}
+
+		operatorClientMap, err := common.CreateOperatorClientMap(cmd.Context(), clientMap[verifyFlags.CentralCluster], verifyFlags)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		report := common.VerifyMultiClusterResources(cmd.Context(), verifyFlags, clientMap, operatorClientMap)
+		report.Print(os.Stdout)
+		if report.Failed() {
+			os.Exit(1)
+		}
+	},
+}
+
+var verifyFlags = common.Flags{}
+
+func parseVerifyFlags() error {
+	if slices.Contains([]string{common.MemberClusters, verifyFlags.ServiceAccount, verifyFlags.CentralCluster, verifyFlags.MemberClusterNamespace, verifyFlags.CentralClusterNamespace}, "") {
+		return xerrors.Errorf("non empty values are required for [service-account, member-clusters, central-cluster, member-cluster-namespace, central-cluster-namespace]")
+	}
+
+	verifyFlags.MemberClusters = strings.Split(common.MemberClusters, ",")
+	return nil
+}
@@ -0,0 +1,91 @@
+package alert
+
+// Notification type names supported by the Ops Manager alert configurations API.
+const (
+	NotificationTypeEmail     = "EMAIL"
+	NotificationTypeWebhook   = "WEBHOOK"
+	NotificationTypePagerDuty = "PAGER_DUTY"
+	NotificationTypeGroup     = "GROUP"
+)
+
+type ConfigReader interface {
+	// ReadAlertConfig reads an individual alert configuration of the project by its id
+	ReadAlertConfig(alertConfigID string) (*Config, error)
+}
+
+type ConfigCreator interface {
+	// CreateAlertConfig creates the alert configuration in the project and returns it with the id assigned by Ops Manager
+	CreateAlertConfig(config *Config) (*Config, error)
+}
+
+type ConfigUpdater interface {
+	// UpdateAlertConfig replaces the existing alert configuration identified by the id of the config
+	UpdateAlertConfig(config *Config) (*Config, error)
+}
+
+type ConfigDeleter interface {
+	DeleteAlertConfig(alertConfigID string) error
+}
+
+type ConfigReadCreateUpdateDeleter interface {
+	ConfigReader
+	ConfigCreator
+	ConfigUpdater
+	ConfigDeleter
+}
+
+/*
+	{
+	  "id": "5cf5a45a9ccf6400e60981b7",
+	  "groupId": "5ba0c398a957713d7f8653bd",
+	  "eventTypeName": "OUTSIDE_METRIC_THRESHOLD",
+	  "enabled": true,
+	  "matchers": [{"fieldName": "REPLICA_SET_NAME", "operator": "EQUALS", "value": "my-rs"}],
+	  "metricThreshold": {"metricName": "OPLOG_SLAVE_LAG_MASTER_TIME", "operator": "GREATER_THAN", "threshold": 60, "units": "SECONDS", "mode": "AVERAGE"},
+	  "notifications": [{"typeName": "WEBHOOK", "intervalMin": 5, "delayMin": 0, "webhookUrl": "https://example.com/alerts"}]
+	}
+*/
+type Config struct {
+	ID              string           `json:"id,omitempty"`
+	GroupID         string           `json:"groupId,omitempty"`
+	EventTypeName   string           `json:"eventTypeName"`
+	Enabled         bool             `json:"enabled"`
+	Matchers        []Matcher        `json:"matchers"`
+	MetricThreshold *MetricThreshold `json:"metricThreshold,omitempty"`
+	Threshold       *Threshold       `json:"threshold,omitempty"`
+	Notifications   []Notification   `json:"notifications"`
+}
+
+type Matcher struct {
+	FieldName string `json:"fieldName"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+}
+
+type MetricThreshold struct {
+	MetricName string  `json:"metricName"`
+	Operator   string  `json:"operator"`
+	Threshold  float64 `json:"threshold"`
+	Units      string  `json:"units,omitempty"`
+	Mode       string  `json:"mode,omitempty"`
+}
+
+type Threshold struct {
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	Units     string  `json:"units,omitempty"`
+}
+
+type Notification struct {
+	TypeName     string `json:"typeName"`
+	IntervalMin  int    `json:"intervalMin,omitempty"`
+	DelayMin     int    `json:"delayMin"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	EmailEnabled *bool  `json:"emailEnabled,omitempty"`
+	SMSEnabled   *bool  `json:"smsEnabled,omitempty"`
+	// ServiceKey is the integration key of the PagerDuty service
+	ServiceKey    string   `json:"serviceKey,omitempty"`
+	WebhookURL    string   `json:"webhookUrl,omitempty"`
+	WebhookSecret string   `json:"webhookSecret,omitempty"`
+	Roles         []string `json:"roles,omitempty"`
+}
@@ -117,6 +117,9 @@ type OpsManagerAdmin interface {
 	// CreateGlobalAPIKey creates a new Global API Key in Ops Manager
 	CreateGlobalAPIKey(description string) (Key, error)
 
+	// DeleteGlobalAPIKey removes the Global API Key with the given id from Ops Manager
+	DeleteGlobalAPIKey(id string) error
+
 	// ReadOpsManagerVersion reads the version returned in the Header
 	ReadOpsManagerVersion() (versionutil.OpsManagerVersion, error)
 }
@@ -362,6 +365,11 @@ func (a *DefaultOmAdmin) CreateGlobalAPIKey(description string) (Key, error) {
 	return *apiKey, nil
 }
 
+// DeleteGlobalAPIKey removes the Global API Key with the given id from Ops Manager.
+func (a *DefaultOmAdmin) DeleteGlobalAPIKey(id string) error {
+	return a.delete(fmt.Sprintf("admin/apiKeys/%s", id))
+}
+
 // ReadOpsManagerVersion read the version returned in the Header.
 func (a *DefaultOmAdmin) ReadOpsManagerVersion() (versionutil.OpsManagerVersion, error) {
 	_, header, err := a.get("")
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/google/uuid"
+
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
@@ -61,6 +63,7 @@ func NewMockedAdminProvider(baseUrl, publicApiKey, privateApiKey string, withSin
 	mockedAdmin.oplogConfigs = make(map[string]backup.DataStoreConfig)
 	mockedAdmin.blockStoreConfigs = make(map[string]backup.DataStoreConfig)
 	mockedAdmin.apiKeys = []Key{{
+		ID:         uuid.NewString(),
 		PrivateKey: privateApiKey,
 		PublicKey:  publicApiKey,
 	}}
@@ -238,13 +241,26 @@ func (a *MockedOmAdmin) ReadGlobalAPIKeys() ([]Key, error) {
 
 func (a *MockedOmAdmin) CreateGlobalAPIKey(description string) (Key, error) {
 	newKey := Key{
+		ID:          uuid.NewString(),
 		Description: description,
+		PublicKey:   uuid.NewString()[:8],
+		PrivateKey:  uuid.NewString(),
 		Roles:       []map[string]string{{"role_name": "GLOBAL_ONWER"}},
 	}
 	a.apiKeys = append(a.apiKeys, newKey)
 	return newKey, nil
 }
 
+func (a *MockedOmAdmin) DeleteGlobalAPIKey(id string) error {
+	for i, key := range a.apiKeys {
+		if key.ID == id {
+			a.apiKeys = append(a.apiKeys[:i], a.apiKeys[i+1:]...)
+			return nil
+		}
+	}
+	return apierror.New(fmt.Errorf("api key %s not found", id))
+}
+
 func (a *MockedOmAdmin) ReadOpsManagerVersion() (versionutil.OpsManagerVersion, error) {
 	return versionutil.OpsManagerVersion{}, nil
 }
@@ -8,10 +8,13 @@ import (
 const (
 	// Error codes that Ops Manager may return that we are concerned about
 	OrganizationNotFound       = "ORG_NAME_NOT_FOUND"
+	OrganizationIDNotFound     = "ORG_NOT_FOUND"
 	ProjectNotFound            = "GROUP_NAME_NOT_FOUND"
 	BackupDaemonConfigNotFound = "DAEMON_MACHINE_CONFIG_NOT_FOUND"
 	UserAlreadyExists          = "USER_ALREADY_EXISTS"
 	DuplicateWhitelistEntry    = "DUPLICATE_GLOBAL_WHITELIST_ENTRY"
+	AlertConfigNotFound        = "ALERT_CONFIG_NOT_FOUND"
+	APIKeyNotFound             = "API_KEY_NOT_FOUND"
 )
 
 // Error is the error extension that contains the details of OM error if OM returned the error. This allows the
@@ -94,3 +97,45 @@ func (e *Error) ErrorBackupDaemonConfigIsNotFound() bool {
 
 	return false
 }
+
+// ErrorAlertConfigIsNotFound returns whether the api-error means that the alert configuration doesn't exist in the
+// project, for example if it was removed in the Ops Manager UI.
+func (e *Error) ErrorAlertConfigIsNotFound() bool {
+	if e == nil {
+		return false
+	}
+
+	if e.Status != nil && *e.Status == 404 {
+		return true
+	}
+
+	return e.ErrorCode == AlertConfigNotFound
+}
+
+// ErrorAPIKeyIsNotFound returns whether the api-error means that the programmatic API key doesn't exist in the
+// organization.
+func (e *Error) ErrorAPIKeyIsNotFound() bool {
+	if e == nil {
+		return false
+	}
+
+	if e.Status != nil && *e.Status == 404 {
+		return true
+	}
+
+	return e.ErrorCode == APIKeyNotFound
+}
+
+// ErrorOrganizationIsNotFound returns whether the api-error means that the organization with the requested id doesn't
+// exist.
+func (e *Error) ErrorOrganizationIsNotFound() bool {
+	if e == nil {
+		return false
+	}
+
+	if e.Status != nil && *e.Status == 404 {
+		return true
+	}
+
+	return e.ErrorCode == OrganizationIDNotFound || e.ErrorCode == OrganizationNotFound
+}
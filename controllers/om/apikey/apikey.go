@@ -0,0 +1,78 @@
+package apikey
+
+// ProjectAPIKeys manages the programmatic API keys which have roles in the project.
+type ProjectAPIKeys interface {
+	// CreateProjectAPIKey creates the API key in the organization of the project and assigns it to the project with
+	// the roles. The private key is only returned on creation.
+	CreateProjectAPIKey(description string, roles []string) (*APIKey, error)
+	UpdateProjectAPIKeyRoles(apiKeyID string, roles []string) error
+}
+
+// OrganizationAPIKeys manages the programmatic API keys of the organization and their access lists.
+type OrganizationAPIKeys interface {
+	ReadOrganizationAPIKey(apiKeyID string) (*APIKey, error)
+	DeleteOrganizationAPIKey(apiKeyID string) error
+
+	ReadAPIKeyAccessList(apiKeyID string) ([]*AccessListEntry, error)
+	AddAPIKeyAccessListEntries(apiKeyID string, entries []*AccessListEntry) error
+	// RemoveAPIKeyAccessListEntry removes the IP address or CIDR block from the access list of the API key
+	RemoveAPIKeyAccessListEntry(apiKeyID string, entry string) error
+}
+
+/*
+	{
+	  "id": "5d1d12c087d9d63e6d682438",
+	  "desc": "payments-ci",
+	  "publicKey": "zmmrboas",
+	  "privateKey": "********-****-****-c4e26334754f",
+	  "roles": [{"groupId": "5d1d12c087d9d63e6d682400", "roleName": "GROUP_OWNER"}]
+	}
+*/
+type APIKey struct {
+	ID          string `json:"id,omitempty"`
+	Description string `json:"desc"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	PrivateKey  string `json:"privateKey,omitempty"`
+	Roles       []Role `json:"roles,omitempty"`
+}
+
+type Role struct {
+	GroupID  string `json:"groupId,omitempty"`
+	OrgID    string `json:"orgId,omitempty"`
+	RoleName string `json:"roleName"`
+}
+
+// ProjectRoleNames returns the names of the roles the API key has in the project.
+func (k APIKey) ProjectRoleNames(projectID string) []string {
+	var roleNames []string
+	for _, role := range k.Roles {
+		if role.GroupID == projectID {
+			roleNames = append(roleNames, role.RoleName)
+		}
+	}
+	return roleNames
+}
+
+// AccessListEntry is either an IP address or a CIDR block the API key or the project can be accessed from.
+type AccessListEntry struct {
+	IPAddress string `json:"ipAddress,omitempty"`
+	CIDRBlock string `json:"cidrBlock,omitempty"`
+}
+
+// Value returns the IP address or CIDR block of the entry.
+func (e AccessListEntry) Value() string {
+	if e.CIDRBlock != "" {
+		return e.CIDRBlock
+	}
+	return e.IPAddress
+}
+
+// Matches checks if the entry is the IP address or the CIDR block. Ops Manager returns both the IP address and its
+// /32 CIDR block for the entries added as an IP address.
+func (e AccessListEntry) Matches(ipAddressOrCIDRBlock string) bool {
+	return e.IPAddress == ipAddressOrCIDRBlock || e.CIDRBlock == ipAddressOrCIDRBlock
+}
+
+type AccessListResponse struct {
+	Entries []*AccessListEntry `json:"results"`
+}
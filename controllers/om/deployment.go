@@ -646,6 +646,37 @@ func (d Deployment) GetAgentVersion() string {
 	return maputil.ReadMapValueAsString(agentVersionMap, "name")
 }
 
+// SetProcessesLogRotate sets the system and the audit log rotation of all processes, the way Ops Manager applies the
+// log rotation configured for the project. The log rotation is not changed if its setting is nil.
+func (d Deployment) SetProcessesLogRotate(logRotate, auditLogRotate *automationconfig.CrdLogRotate) error {
+	settings := map[string]*automationconfig.CrdLogRotate{"logRotate": logRotate, "auditLogRotate": auditLogRotate}
+	for key, setting := range settings {
+		if setting == nil {
+			continue
+		}
+		for _, p := range d.getProcesses() {
+			settingMap, err := maputil.StructToMap(automationconfig.ConvertCrdLogRotateToAC(setting))
+			if err != nil {
+				return err
+			}
+			p[key] = settingMap
+		}
+	}
+	return nil
+}
+
+// HasMonitoringVersions returns true if the monitoring agents are configured in the deployment.
+func (d Deployment) HasMonitoringVersions() bool {
+	monitoringVersions, _ := d["monitoringVersions"].([]interface{})
+	return len(monitoringVersions) > 0
+}
+
+// HasBackupVersions returns true if the backup agents are configured in the deployment.
+func (d Deployment) HasBackupVersions() bool {
+	backupVersions, _ := d["backupVersions"].([]interface{})
+	return len(backupVersions) > 0
+}
+
 func (d Deployment) Debug(l *zap.SugaredLogger) {
 	dep := Deployment{}
 	for key, value := range d {
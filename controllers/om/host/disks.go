@@ -0,0 +1,57 @@
+package host
+
+import "time"
+
+// DiskPartitionSpacePercentUsed is the Ops Manager measurement of the percentage of used space of a disk partition.
+const DiskPartitionSpacePercentUsed = "DISK_PARTITION_SPACE_PERCENT_USED"
+
+type DiskPartition struct {
+	PartitionName string `json:"partitionName"`
+}
+
+type DiskPartitions struct {
+	Results []DiskPartition `json:"results"`
+}
+
+type Measurements struct {
+	Measurements []Measurement `json:"measurements"`
+}
+
+type Measurement struct {
+	Name       string      `json:"name"`
+	Units      string      `json:"units"`
+	DataPoints []DataPoint `json:"dataPoints"`
+}
+
+type DataPoint struct {
+	Timestamp string `json:"timestamp"`
+	// Value is nil if no data has been collected for the period of the data point
+	Value *float64 `json:"value"`
+}
+
+// LatestValueSince returns the value of the most recent data point which has one, ignoring the data points collected
+// before since.
+func (m Measurement) LatestValueSince(since time.Time) (float64, bool) {
+	for i := len(m.DataPoints) - 1; i >= 0; i-- {
+		if m.DataPoints[i].Value == nil {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, m.DataPoints[i].Timestamp)
+		if err != nil || timestamp.Before(since) {
+			return 0, false
+		}
+		return *m.DataPoints[i].Value, true
+	}
+	return 0, false
+}
+
+type DiskUsageReader interface {
+	// ReadDiskSpacePercentUsed returns the latest percentage of used space of every disk partition of the host,
+	// by partition name, as collected by the monitoring agent. The measurements collected before since are ignored.
+	ReadDiskSpacePercentUsed(hostID string, since time.Time) (map[string]float64, error)
+}
+
+type GetDiskUsageReader interface {
+	Getter
+	DiskUsageReader
+}
@@ -0,0 +1,31 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestMeasurement_LatestValueSince(t *testing.T) {
+	measurement := Measurement{
+		Name: DiskPartitionSpacePercentUsed,
+		DataPoints: []DataPoint{
+			{Timestamp: "2026-10-18T10:00:00Z", Value: ptr.To(70.0)},
+			{Timestamp: "2026-10-18T10:01:00Z", Value: ptr.To(85.0)},
+			{Timestamp: "2026-10-18T10:02:00Z"},
+		},
+	}
+
+	value, ok := measurement.LatestValueSince(time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, 85.0, value)
+
+	value, ok = measurement.LatestValueSince(time.Date(2026, 10, 18, 10, 1, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, 85.0, value)
+
+	_, ok = measurement.LatestValueSince(time.Date(2026, 10, 18, 10, 1, 30, 0, time.UTC))
+	assert.False(t, ok)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -19,9 +20,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/alert"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/projectsettings"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/pkg/handler"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -81,8 +86,17 @@ type MockedOmConnection struct {
 	UpdateBackupStatusFunc  func(clusterId string, status backup.Status) error
 	AgentAuthMechanism      string
 	SnapshotSchedules       map[string]*backup.SnapshotSchedule
+	AlertConfigs            map[string]*alert.Config
+	Teams                   map[string]*team.Team
+	ProjectTeams            map[string]*team.ProjectTeam
+	APIKeys                 map[string]*apikey.APIKey
+	APIKeyAccessLists       map[string][]*apikey.AccessListEntry
+	ProjectSettings         projectsettings.ProjectSettings
+	ProjectAccessList       []*apikey.AccessListEntry
 	Hostnames               []string
 	PreferredHostnames      []PreferredHostname
+	// DiskSpacePercentUsed is the used space of the disk partitions of the hosts, by hostname and partition name
+	DiskSpacePercentUsed map[string]map[string]float64
 
 	agentVersion        string
 	agentMinimumVersion string
@@ -171,6 +185,11 @@ func NewMockedOmConnection(d Deployment) *MockedOmConnection {
 	connection.BackupConfigs = make(map[string]*backup.Config)
 	connection.BackupHostClusters = make(map[string]*backup.HostCluster)
 	connection.SnapshotSchedules = make(map[string]*backup.SnapshotSchedule)
+	connection.AlertConfigs = make(map[string]*alert.Config)
+	connection.Teams = make(map[string]*team.Team)
+	connection.ProjectTeams = make(map[string]*team.ProjectTeam)
+	connection.APIKeys = make(map[string]*apikey.APIKey)
+	connection.APIKeyAccessLists = make(map[string][]*apikey.AccessListEntry)
 	// By default, we don't wait for agents to reach goal
 	connection.AgentsDelayCount = 0
 	// We use a simplified version of context as this is the only thing needed to get lock for the update
@@ -487,6 +506,16 @@ func (oc *MockedOmConnection) GetHosts() (*host.Result, error) {
 	return oc.hostResults, nil
 }
 
+func (oc *MockedOmConnection) ReadDiskSpacePercentUsed(hostID string, _ time.Time) (map[string]float64, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadDiskSpacePercentUsed))
+	for _, h := range oc.hostResults.Results {
+		if h.Id == hostID {
+			return oc.DiskSpacePercentUsed[h.Hostname], nil
+		}
+	}
+	return nil, apierror.New(xerrors.Errorf("host %s not found", hostID))
+}
+
 func (oc *MockedOmConnection) RemoveHost(hostID string) error {
 	oc.addToHistory(reflect.ValueOf(oc.RemoveHost))
 	toKeep := make([]host.Host, 0)
@@ -532,6 +561,14 @@ func (oc *MockedOmConnection) ReadOrganization(orgID string) (*Organization, err
 	return oc.findOrganization(orgID)
 }
 
+func (oc *MockedOmConnection) CreateOrganization(organization *Organization) (*Organization, error) {
+	oc.addToHistory(reflect.ValueOf(oc.CreateOrganization))
+	//nolint
+	created := &Organization{ID: strconv.Itoa(rand.Int()), Name: organization.Name}
+	oc.OrganizationsWithGroups[created] = make([]*Project, 0)
+	return created, nil
+}
+
 func (oc *MockedOmConnection) ReadProjectsInOrganizationByName(orgID string, name string) ([]*Project, error) {
 	oc.addToHistory(reflect.ValueOf(oc.ReadProjectsInOrganizationByName))
 	org, err := oc.findOrganization(orgID)
@@ -564,6 +601,12 @@ func (oc *MockedOmConnection) CreateProject(project *Project) (*Project, error)
 	}
 	project.ID = TestGroupID
 
+	// The project is created in the organization if it exists already
+	if organization, err := oc.findOrganization(project.OrgID); err == nil {
+		oc.OrganizationsWithGroups[organization] = append(oc.OrganizationsWithGroups[organization], project)
+		return project, nil
+	}
+
 	// We emulate the behavior of Ops Manager: we create the organization with random id and the name matching the project
 	//nolint
 	organization := &Organization{ID: strconv.Itoa(rand.Int()), Name: project.Name}
@@ -667,6 +710,258 @@ func (oc *MockedOmConnection) UpdateSnapshotSchedule(clusterID string, snapshotS
 	return nil
 }
 
+func (oc *MockedOmConnection) ReadAlertConfig(alertConfigID string) (*alert.Config, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadAlertConfig))
+	if config, ok := oc.AlertConfigs[alertConfigID]; ok {
+		return config, nil
+	}
+	return nil, apierror.NewErrorWithCode(apierror.AlertConfigNotFound)
+}
+
+func (oc *MockedOmConnection) CreateAlertConfig(config *alert.Config) (*alert.Config, error) {
+	oc.addToHistory(reflect.ValueOf(oc.CreateAlertConfig))
+	// We emulate the behavior of Ops Manager: the alert configuration gets a generated id
+	created := *config
+	created.ID = uuid.New().String()
+	created.GroupID = oc.GroupID()
+	oc.AlertConfigs[created.ID] = &created
+	return &created, nil
+}
+
+func (oc *MockedOmConnection) UpdateAlertConfig(config *alert.Config) (*alert.Config, error) {
+	oc.addToHistory(reflect.ValueOf(oc.UpdateAlertConfig))
+	if _, ok := oc.AlertConfigs[config.ID]; !ok {
+		return nil, apierror.NewErrorWithCode(apierror.AlertConfigNotFound)
+	}
+	updated := *config
+	updated.GroupID = oc.GroupID()
+	oc.AlertConfigs[updated.ID] = &updated
+	return &updated, nil
+}
+
+func (oc *MockedOmConnection) DeleteAlertConfig(alertConfigID string) error {
+	oc.addToHistory(reflect.ValueOf(oc.DeleteAlertConfig))
+	if _, ok := oc.AlertConfigs[alertConfigID]; !ok {
+		return apierror.NewErrorWithCode(apierror.AlertConfigNotFound)
+	}
+	delete(oc.AlertConfigs, alertConfigID)
+	return nil
+}
+
+func (oc *MockedOmConnection) ReadTeams() ([]*team.Team, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadTeams))
+	teams := make([]*team.Team, 0, len(oc.Teams))
+	for _, t := range oc.Teams {
+		teams = append(teams, t)
+	}
+	return teams, nil
+}
+
+func (oc *MockedOmConnection) CreateTeam(t *team.Team) (*team.Team, error) {
+	oc.addToHistory(reflect.ValueOf(oc.CreateTeam))
+	created := &team.Team{ID: uuid.New().String(), Name: t.Name, Usernames: append([]string{}, t.Usernames...)}
+	oc.Teams[created.ID] = created
+	return created, nil
+}
+
+func (oc *MockedOmConnection) DeleteTeam(teamID string) error {
+	oc.addToHistory(reflect.ValueOf(oc.DeleteTeam))
+	if _, ok := oc.Teams[teamID]; !ok {
+		return apierror.New(xerrors.Errorf("Team with id %s not found", teamID))
+	}
+	delete(oc.Teams, teamID)
+	return nil
+}
+
+// ReadTeamUsers returns the members of the team, the mocked users have their username as the id
+func (oc *MockedOmConnection) ReadTeamUsers(teamID string) ([]*team.User, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadTeamUsers))
+	t, ok := oc.Teams[teamID]
+	if !ok {
+		return nil, apierror.New(xerrors.Errorf("Team with id %s not found", teamID))
+	}
+	users := make([]*team.User, 0, len(t.Usernames))
+	for _, username := range t.Usernames {
+		users = append(users, &team.User{ID: username, Username: username})
+	}
+	return users, nil
+}
+
+func (oc *MockedOmConnection) AddTeamUser(teamID string, username string) error {
+	oc.addToHistory(reflect.ValueOf(oc.AddTeamUser))
+	t, ok := oc.Teams[teamID]
+	if !ok {
+		return apierror.New(xerrors.Errorf("Team with id %s not found", teamID))
+	}
+	t.Usernames = append(t.Usernames, username)
+	return nil
+}
+
+func (oc *MockedOmConnection) RemoveTeamUser(teamID string, userID string) error {
+	oc.addToHistory(reflect.ValueOf(oc.RemoveTeamUser))
+	t, ok := oc.Teams[teamID]
+	if !ok {
+		return apierror.New(xerrors.Errorf("Team with id %s not found", teamID))
+	}
+	t.Usernames = stringutil.Remove(t.Usernames, userID)
+	return nil
+}
+
+func (oc *MockedOmConnection) ReadProjectTeams() ([]*team.ProjectTeam, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadProjectTeams))
+	teams := make([]*team.ProjectTeam, 0, len(oc.ProjectTeams))
+	for _, t := range oc.ProjectTeams {
+		teams = append(teams, t)
+	}
+	return teams, nil
+}
+
+func (oc *MockedOmConnection) AssignTeamsToProject(teams []*team.ProjectTeam) error {
+	oc.addToHistory(reflect.ValueOf(oc.AssignTeamsToProject))
+	for _, t := range teams {
+		oc.ProjectTeams[t.TeamID] = &team.ProjectTeam{TeamID: t.TeamID, RoleNames: append([]string{}, t.RoleNames...)}
+	}
+	return nil
+}
+
+func (oc *MockedOmConnection) UpdateProjectTeamRoles(t *team.ProjectTeam) error {
+	oc.addToHistory(reflect.ValueOf(oc.UpdateProjectTeamRoles))
+	if _, ok := oc.ProjectTeams[t.TeamID]; !ok {
+		return apierror.New(xerrors.Errorf("Team with id %s is not assigned to the project", t.TeamID))
+	}
+	oc.ProjectTeams[t.TeamID] = &team.ProjectTeam{TeamID: t.TeamID, RoleNames: append([]string{}, t.RoleNames...)}
+	return nil
+}
+
+func (oc *MockedOmConnection) RemoveTeamFromProject(teamID string) error {
+	oc.addToHistory(reflect.ValueOf(oc.RemoveTeamFromProject))
+	delete(oc.ProjectTeams, teamID)
+	return nil
+}
+
+func (oc *MockedOmConnection) CreateProjectAPIKey(description string, roles []string) (*apikey.APIKey, error) {
+	oc.addToHistory(reflect.ValueOf(oc.CreateProjectAPIKey))
+	key := &apikey.APIKey{
+		ID:          uuid.New().String(),
+		Description: description,
+		PublicKey:   strings.ToLower(uuid.New().String()[:8]),
+		PrivateKey:  uuid.New().String(),
+	}
+	for _, role := range roles {
+		key.Roles = append(key.Roles, apikey.Role{GroupID: oc.GroupID(), RoleName: role})
+	}
+	oc.APIKeys[key.ID] = key
+	created := *key
+	return &created, nil
+}
+
+func (oc *MockedOmConnection) UpdateProjectAPIKeyRoles(apiKeyID string, roles []string) error {
+	oc.addToHistory(reflect.ValueOf(oc.UpdateProjectAPIKeyRoles))
+	key, ok := oc.APIKeys[apiKeyID]
+	if !ok {
+		return apierror.New(xerrors.Errorf("API key with id %s not found", apiKeyID))
+	}
+	key.Roles = nil
+	for _, role := range roles {
+		key.Roles = append(key.Roles, apikey.Role{GroupID: oc.GroupID(), RoleName: role})
+	}
+	return nil
+}
+
+// ReadOrganizationAPIKey returns the API key, the private key is only returned on creation as in Ops Manager
+func (oc *MockedOmConnection) ReadOrganizationAPIKey(apiKeyID string) (*apikey.APIKey, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadOrganizationAPIKey))
+	key, ok := oc.APIKeys[apiKeyID]
+	if !ok {
+		return nil, apierror.NewErrorWithCode(apierror.APIKeyNotFound)
+	}
+	redacted := *key
+	redacted.PrivateKey = ""
+	return &redacted, nil
+}
+
+func (oc *MockedOmConnection) DeleteOrganizationAPIKey(apiKeyID string) error {
+	oc.addToHistory(reflect.ValueOf(oc.DeleteOrganizationAPIKey))
+	if _, ok := oc.APIKeys[apiKeyID]; !ok {
+		return apierror.NewErrorWithCode(apierror.APIKeyNotFound)
+	}
+	delete(oc.APIKeys, apiKeyID)
+	delete(oc.APIKeyAccessLists, apiKeyID)
+	return nil
+}
+
+func (oc *MockedOmConnection) ReadAPIKeyAccessList(apiKeyID string) ([]*apikey.AccessListEntry, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadAPIKeyAccessList))
+	return oc.APIKeyAccessLists[apiKeyID], nil
+}
+
+func (oc *MockedOmConnection) AddAPIKeyAccessListEntries(apiKeyID string, entries []*apikey.AccessListEntry) error {
+	oc.addToHistory(reflect.ValueOf(oc.AddAPIKeyAccessListEntries))
+	oc.APIKeyAccessLists[apiKeyID] = append(oc.APIKeyAccessLists[apiKeyID], entries...)
+	return nil
+}
+
+func (oc *MockedOmConnection) RemoveAPIKeyAccessListEntry(apiKeyID string, entry string) error {
+	oc.addToHistory(reflect.ValueOf(oc.RemoveAPIKeyAccessListEntry))
+	entries := make([]*apikey.AccessListEntry, 0)
+	for _, e := range oc.APIKeyAccessLists[apiKeyID] {
+		if !e.Matches(entry) {
+			entries = append(entries, e)
+		}
+	}
+	oc.APIKeyAccessLists[apiKeyID] = entries
+	return nil
+}
+
+func (oc *MockedOmConnection) ReadProjectSettings() (*projectsettings.ProjectSettings, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadProjectSettings))
+	settings := oc.ProjectSettings
+	return &settings, nil
+}
+
+func (oc *MockedOmConnection) UpdateProjectSettings(settings *projectsettings.ProjectSettings) error {
+	oc.addToHistory(reflect.ValueOf(oc.UpdateProjectSettings))
+	if settings.IsCollectDatabaseSpecificsStatisticsEnabled != nil {
+		oc.ProjectSettings.IsCollectDatabaseSpecificsStatisticsEnabled = settings.IsCollectDatabaseSpecificsStatisticsEnabled
+	}
+	if settings.IsDataExplorerEnabled != nil {
+		oc.ProjectSettings.IsDataExplorerEnabled = settings.IsDataExplorerEnabled
+	}
+	if settings.IsPerformanceAdvisorEnabled != nil {
+		oc.ProjectSettings.IsPerformanceAdvisorEnabled = settings.IsPerformanceAdvisorEnabled
+	}
+	if settings.IsRealtimePerformancePanelEnabled != nil {
+		oc.ProjectSettings.IsRealtimePerformancePanelEnabled = settings.IsRealtimePerformancePanelEnabled
+	}
+	if settings.IsSchemaAdvisorEnabled != nil {
+		oc.ProjectSettings.IsSchemaAdvisorEnabled = settings.IsSchemaAdvisorEnabled
+	}
+	return nil
+}
+
+func (oc *MockedOmConnection) ReadProjectAccessList() ([]*apikey.AccessListEntry, error) {
+	oc.addToHistory(reflect.ValueOf(oc.ReadProjectAccessList))
+	return oc.ProjectAccessList, nil
+}
+
+func (oc *MockedOmConnection) AddProjectAccessListEntries(entries []*apikey.AccessListEntry) error {
+	oc.addToHistory(reflect.ValueOf(oc.AddProjectAccessListEntries))
+	oc.ProjectAccessList = append(oc.ProjectAccessList, entries...)
+	return nil
+}
+
+func (oc *MockedOmConnection) RemoveProjectAccessListEntry(entry string) error {
+	oc.addToHistory(reflect.ValueOf(oc.RemoveProjectAccessListEntry))
+	entries := make([]*apikey.AccessListEntry, 0)
+	for _, e := range oc.ProjectAccessList {
+		if !e.Matches(entry) {
+			entries = append(entries, e)
+		}
+	}
+	oc.ProjectAccessList = entries
+	return nil
+}
+
 // SetAgentVersion updates the versions returned by ReadAgentVersion method
 func (oc *MockedOmConnection) SetAgentVersion(agentVersion string, agentMinimumVersion string) {
 	oc.agentVersion = agentVersion
@@ -896,7 +1191,7 @@ func (oc *MockedOmConnection) findOrganization(orgId string) (*Organization, err
 			return k, nil
 		}
 	}
-	return nil, apierror.New(xerrors.Errorf("Organization with id %s not found", orgId))
+	return nil, &apierror.Error{ErrorCode: apierror.OrganizationIDNotFound, Detail: fmt.Sprintf("Organization with id %s not found", orgId)}
 }
 
 func (oc *MockedOmConnection) OpsManagerVersion() versionutil.OpsManagerVersion {
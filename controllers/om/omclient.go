@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/r3labs/diff/v3"
@@ -20,10 +21,14 @@ import (
 	"k8s.io/utils/ptr"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/alert"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/api"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/projectsettings"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -52,6 +57,7 @@ type Connection interface {
 	// ReadOrganizations returns all organizations at specified page
 	ReadOrganizations(page int) (Paginated, error)
 	ReadOrganization(orgID string) (*Organization, error)
+	CreateOrganization(organization *Organization) (*Organization, error)
 
 	ReadProjectsInOrganizationByName(orgID string, name string) ([]*Project, error)
 	// ReadProjectsInOrganization returns all projects in the organization at the specified page
@@ -72,6 +78,17 @@ type Connection interface {
 	backup.ConfigReader
 	backup.ConfigUpdater
 
+	alert.ConfigReadCreateUpdateDeleter
+
+	team.OrganizationTeams
+	team.ProjectTeams
+
+	apikey.ProjectAPIKeys
+	apikey.OrganizationAPIKeys
+
+	projectsettings.Settings
+	projectsettings.AccessList
+
 	OpsManagerVersion() versionutil.OpsManagerVersion
 
 	AgentKeyGenerator
@@ -84,6 +101,7 @@ type Connection interface {
 	host.Adder
 	host.GetRemover
 	host.Updater
+	host.DiskUsageReader
 
 	controlledfeature.Getter
 	controlledfeature.Updater
@@ -569,6 +587,41 @@ func (oc *HTTPOmConnection) RemoveHost(hostID string) error {
 	return oc.delete(mPath)
 }
 
+// ReadDiskSpacePercentUsed reads the latest used space measurement of every disk partition of the host
+func (oc *HTTPOmConnection) ReadDiskSpacePercentUsed(hostID string, since time.Time) (map[string]float64, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/groups/%s/hosts/%s/disks", oc.GroupID(), hostID))
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := &host.DiskPartitions{}
+	if err := json.Unmarshal(res, partitions); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	usage := map[string]float64{}
+	for _, partition := range partitions.Results {
+		mPath := fmt.Sprintf("/api/public/v1.0/groups/%s/hosts/%s/disks/%s/measurements?granularity=PT1M&period=PT10M&m=%s", oc.GroupID(), hostID, url.PathEscape(partition.PartitionName), host.DiskPartitionSpacePercentUsed)
+		res, err := oc.get(mPath)
+		if err != nil {
+			return nil, err
+		}
+
+		measurements := &host.Measurements{}
+		if err := json.Unmarshal(res, measurements); err != nil {
+			return nil, apierror.New(err)
+		}
+
+		for _, measurement := range measurements.Measurements {
+			if value, ok := measurement.LatestValueSince(since); ok && measurement.Name == host.DiskPartitionSpacePercentUsed {
+				usage[partition.PartitionName] = value
+			}
+		}
+	}
+
+	return usage, nil
+}
+
 // ReadOrganizationsByName finds the organizations by name. It uses the same endpoint as the 'ReadOrganizations' but
 // 'name' and 'page' parameters are not supposed to be used together so having a separate endpoint allows
 func (oc *HTTPOmConnection) ReadOrganizationsByName(name string) ([]*Organization, error) {
@@ -615,6 +668,20 @@ func (oc *HTTPOmConnection) ReadOrganization(orgID string) (*Organization, error
 	return organization, nil
 }
 
+func (oc *HTTPOmConnection) CreateOrganization(organization *Organization) (*Organization, error) {
+	res, err := oc.post("/api/public/v1.0/orgs", organization)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Organization{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
 func (oc *HTTPOmConnection) MarkProjectAsBackingDatabase(backingType BackingDatabaseType) error {
 	_, err := oc.post(fmt.Sprintf("/api/private/v1.0/groups/%s/markAsBackingDatabase", oc.GroupID()), string(backingType))
 	if err != nil {
@@ -759,6 +826,251 @@ func (oc *HTTPOmConnection) UpdateBackupStatus(clusterID string, status backup.S
 	return nil
 }
 
+func (oc *HTTPOmConnection) ReadAlertConfig(alertConfigID string) (*alert.Config, error) {
+	mPath := fmt.Sprintf("/api/public/v1.0/groups/%s/alertConfigs/%s", oc.GroupID(), alertConfigID)
+	res, err := oc.get(mPath)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &alert.Config{}
+	if err := json.Unmarshal(res, config); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return config, nil
+}
+
+func (oc *HTTPOmConnection) CreateAlertConfig(config *alert.Config) (*alert.Config, error) {
+	mPath := fmt.Sprintf("/api/public/v1.0/groups/%s/alertConfigs", oc.GroupID())
+	res, err := oc.post(mPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &alert.Config{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) UpdateAlertConfig(config *alert.Config) (*alert.Config, error) {
+	mPath := fmt.Sprintf("/api/public/v1.0/groups/%s/alertConfigs/%s", oc.GroupID(), config.ID)
+	res, err := oc.put(mPath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &alert.Config{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) DeleteAlertConfig(alertConfigID string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/groups/%s/alertConfigs/%s", oc.GroupID(), alertConfigID))
+}
+
+func (oc *HTTPOmConnection) ReadTeams() ([]*team.Team, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams?itemsPerPage=500", oc.OrgID()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &team.TeamsResponse{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response.Teams, nil
+}
+
+func (oc *HTTPOmConnection) CreateTeam(t *team.Team) (*team.Team, error) {
+	res, err := oc.post(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams", oc.OrgID()), t)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &team.Team{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) DeleteTeam(teamID string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams/%s", oc.OrgID(), teamID))
+}
+
+func (oc *HTTPOmConnection) ReadTeamUsers(teamID string) ([]*team.User, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams/%s/users?itemsPerPage=500", oc.OrgID(), teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &team.UsersResponse{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response.Users, nil
+}
+
+func (oc *HTTPOmConnection) AddTeamUser(teamID string, username string) error {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/users/byName/%s", url.PathEscape(username)))
+	if err != nil {
+		return err
+	}
+
+	user := &team.User{}
+	if err := json.Unmarshal(res, user); err != nil {
+		return apierror.New(err)
+	}
+
+	_, err = oc.post(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams/%s/users", oc.OrgID(), teamID), []map[string]string{{"id": user.ID}})
+	return err
+}
+
+func (oc *HTTPOmConnection) RemoveTeamUser(teamID string, userID string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/orgs/%s/teams/%s/users/%s", oc.OrgID(), teamID, userID))
+}
+
+func (oc *HTTPOmConnection) ReadProjectTeams() ([]*team.ProjectTeam, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/groups/%s/teams", oc.GroupID()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &team.ProjectTeamsResponse{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response.Teams, nil
+}
+
+func (oc *HTTPOmConnection) AssignTeamsToProject(teams []*team.ProjectTeam) error {
+	_, err := oc.post(fmt.Sprintf("/api/public/v1.0/groups/%s/teams", oc.GroupID()), teams)
+	return err
+}
+
+func (oc *HTTPOmConnection) UpdateProjectTeamRoles(t *team.ProjectTeam) error {
+	_, err := oc.patch(fmt.Sprintf("/api/public/v1.0/groups/%s/teams/%s", oc.GroupID(), t.TeamID), map[string][]string{"roleNames": t.RoleNames})
+	return err
+}
+
+func (oc *HTTPOmConnection) RemoveTeamFromProject(teamID string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/groups/%s/teams/%s", oc.GroupID(), teamID))
+}
+
+func (oc *HTTPOmConnection) CreateProjectAPIKey(description string, roles []string) (*apikey.APIKey, error) {
+	body := map[string]interface{}{"desc": description, "roles": roles}
+	res, err := oc.post(fmt.Sprintf("/api/public/v1.0/groups/%s/apiKeys", oc.GroupID()), body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apikey.APIKey{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) UpdateProjectAPIKeyRoles(apiKeyID string, roles []string) error {
+	_, err := oc.patch(fmt.Sprintf("/api/public/v1.0/groups/%s/apiKeys/%s", oc.GroupID(), apiKeyID), map[string][]string{"roles": roles})
+	return err
+}
+
+func (oc *HTTPOmConnection) ReadOrganizationAPIKey(apiKeyID string) (*apikey.APIKey, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/orgs/%s/apiKeys/%s", oc.OrgID(), apiKeyID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apikey.APIKey{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) DeleteOrganizationAPIKey(apiKeyID string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/orgs/%s/apiKeys/%s", oc.OrgID(), apiKeyID))
+}
+
+func (oc *HTTPOmConnection) ReadAPIKeyAccessList(apiKeyID string) ([]*apikey.AccessListEntry, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/orgs/%s/apiKeys/%s/accessList?itemsPerPage=500", oc.OrgID(), apiKeyID))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apikey.AccessListResponse{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response.Entries, nil
+}
+
+func (oc *HTTPOmConnection) AddAPIKeyAccessListEntries(apiKeyID string, entries []*apikey.AccessListEntry) error {
+	_, err := oc.post(fmt.Sprintf("/api/public/v1.0/orgs/%s/apiKeys/%s/accessList", oc.OrgID(), apiKeyID), entries)
+	return err
+}
+
+func (oc *HTTPOmConnection) RemoveAPIKeyAccessListEntry(apiKeyID string, entry string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/orgs/%s/apiKeys/%s/accessList/%s", oc.OrgID(), apiKeyID, url.PathEscape(entry)))
+}
+
+func (oc *HTTPOmConnection) ReadProjectSettings() (*projectsettings.ProjectSettings, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/groups/%s/settings", oc.GroupID()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &projectsettings.ProjectSettings{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response, nil
+}
+
+func (oc *HTTPOmConnection) UpdateProjectSettings(settings *projectsettings.ProjectSettings) error {
+	_, err := oc.patch(fmt.Sprintf("/api/public/v1.0/groups/%s/settings", oc.GroupID()), settings)
+	return err
+}
+
+func (oc *HTTPOmConnection) ReadProjectAccessList() ([]*apikey.AccessListEntry, error) {
+	res, err := oc.get(fmt.Sprintf("/api/public/v1.0/groups/%s/accessList?itemsPerPage=500", oc.GroupID()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apikey.AccessListResponse{}
+	if err := json.Unmarshal(res, response); err != nil {
+		return nil, apierror.New(err)
+	}
+
+	return response.Entries, nil
+}
+
+func (oc *HTTPOmConnection) AddProjectAccessListEntries(entries []*apikey.AccessListEntry) error {
+	_, err := oc.post(fmt.Sprintf("/api/public/v1.0/groups/%s/accessList", oc.GroupID()), entries)
+	return err
+}
+
+func (oc *HTTPOmConnection) RemoveProjectAccessListEntry(entry string) error {
+	return oc.delete(fmt.Sprintf("/api/public/v1.0/groups/%s/accessList/%s", oc.GroupID(), url.PathEscape(entry)))
+}
+
 func (oc *HTTPOmConnection) ReadMonitoringAgentConfig() (*MonitoringAgentConfig, error) {
 	ans, err := oc.get(fmt.Sprintf("/api/public/v1.0/groups/%s/automationConfig/monitoringAgentConfig", oc.GroupID()))
 	if err != nil {
@@ -34,9 +34,11 @@ func CreateMongodProcessesFromMongoDB(mongoDBImage string, forceEnterprise bool,
 	return processes
 }
 
-// CreateMongodProcessesWithLimitMulti creates the process array for automationConfig based on MultiCluster CR spec
-func CreateMongodProcessesWithLimitMulti(mongoDBImage string, forceEnterprise bool, mrs mdbmultiv1.MongoDBMultiCluster, certFileName string) ([]om.Process, error) {
+// CreateMongodProcessesWithLimitMulti creates the process array for automationConfig based on MultiCluster CR spec.
+// clusterMongodConfigs overrides the additional mongod configuration of the processes in the given member clusters.
+func CreateMongodProcessesWithLimitMulti(mongoDBImage string, forceEnterprise bool, mrs mdbmultiv1.MongoDBMultiCluster, certFileName string, clusterMongodConfigs map[string]*mdbv1.AdditionalMongodConfig) ([]om.Process, error) {
 	hostnames := make([]string, 0)
+	clusterNames := make([]string, 0)
 	clusterNums := make([]int, 0)
 	podNum := make([]int, 0)
 	clusterSpecList, err := mrs.GetClusterSpecItems()
@@ -45,9 +47,10 @@ func CreateMongodProcessesWithLimitMulti(mongoDBImage string, forceEnterprise bo
 	}
 
 	for _, spec := range clusterSpecList {
-		agentHostNames := dns.GetMultiClusterProcessHostnames(mrs.Name, mrs.Namespace, mrs.ClusterNum(spec.ClusterName), spec.Members, mrs.Spec.GetClusterDomain(), mrs.Spec.GetExternalDomainForMemberCluster(spec.ClusterName))
+		agentHostNames := mrs.GetProcessHostnames(spec.ClusterName, spec.Members)
 		hostnames = append(hostnames, agentHostNames...)
 		for i := 0; i < len(agentHostNames); i++ {
+			clusterNames = append(clusterNames, spec.ClusterName)
 			clusterNums = append(clusterNums, mrs.ClusterNum(spec.ClusterName))
 			podNum = append(podNum, i)
 		}
@@ -55,7 +58,11 @@ func CreateMongodProcessesWithLimitMulti(mongoDBImage string, forceEnterprise bo
 
 	processes := make([]om.Process, len(hostnames))
 	for idx := range hostnames {
-		processes[idx] = om.NewMongodProcess(fmt.Sprintf("%s-%d-%d", mrs.Name, clusterNums[idx], podNum[idx]), hostnames[idx], mongoDBImage, forceEnterprise, mrs.Spec.GetAdditionalMongodConfig(), &mrs.Spec, certFileName, mrs.Annotations, mrs.CalculateFeatureCompatibilityVersion())
+		additionalMongodConfig := mrs.Spec.GetAdditionalMongodConfig()
+		if clusterMongodConfig, ok := clusterMongodConfigs[clusterNames[idx]]; ok {
+			additionalMongodConfig = clusterMongodConfig
+		}
+		processes[idx] = om.NewMongodProcess(fmt.Sprintf("%s-%d-%d", mrs.Name, clusterNums[idx], podNum[idx]), hostnames[idx], mongoDBImage, forceEnterprise, additionalMongodConfig, &mrs.Spec, certFileName, mrs.Annotations, mrs.CalculateFeatureCompatibilityVersion())
 	}
 
 	return processes, nil
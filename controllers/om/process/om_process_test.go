@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	mdbmultiv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/maputil"
 )
 
@@ -135,6 +136,26 @@ func TestCreateMongodProcessesFromMongoDB_AdditionalConfig(t *testing.T) {
 	}
 }
 
+func TestCreateMongodProcessesWithLimitMulti_ClusterMongodConfigs(t *testing.T) {
+	mrs := mdbmultiv1.DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.AdditionalMongodConfig = mdbv1.NewAdditionalMongodConfig("storage.engine", "wiredTiger")
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "cluster-a", Members: 1},
+		{ClusterName: "cluster-b", Members: 1},
+	}
+	mrs.Spec.Mapping = map[string]int{"cluster-a": 0, "cluster-b": 1}
+
+	processes, err := CreateMongodProcessesWithLimitMulti(defaultMongoDBImage, false, *mrs, "", map[string]*mdbv1.AdditionalMongodConfig{
+		"cluster-b": mdbv1.NewAdditionalMongodConfig("storage.engine", "inMemory"),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, processes, 2)
+
+	// only the processes in clusters with their own configuration are overridden
+	assert.Equal(t, "wiredTiger", maputil.ReadMapValueAsInterface(processes[0].Args(), "storage", "engine"))
+	assert.Equal(t, "inMemory", maputil.ReadMapValueAsInterface(processes[1].Args(), "storage", "engine"))
+}
+
 func baseReplicaSet(name string, members int) *mdbv1.MongoDB {
 	return mdbv1.NewReplicaSetBuilder().
 		SetName(name).
@@ -0,0 +1,47 @@
+package projectsettings
+
+import "github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
+
+// Settings manages the settings of the project.
+type Settings interface {
+	ReadProjectSettings() (*ProjectSettings, error)
+	// UpdateProjectSettings changes the settings which are set, the other ones are left untouched
+	UpdateProjectSettings(settings *ProjectSettings) error
+}
+
+// AccessList manages the IP access list of the project.
+type AccessList interface {
+	ReadProjectAccessList() ([]*apikey.AccessListEntry, error)
+	AddProjectAccessListEntries(entries []*apikey.AccessListEntry) error
+	// RemoveProjectAccessListEntry removes the IP address or CIDR block from the access list of the project
+	RemoveProjectAccessListEntry(entry string) error
+}
+
+/*
+	{
+	  "isCollectDatabaseSpecificsStatisticsEnabled": true,
+	  "isDataExplorerEnabled": true,
+	  "isPerformanceAdvisorEnabled": true,
+	  "isRealtimePerformancePanelEnabled": true,
+	  "isSchemaAdvisorEnabled": true
+	}
+*/
+type ProjectSettings struct {
+	IsCollectDatabaseSpecificsStatisticsEnabled *bool `json:"isCollectDatabaseSpecificsStatisticsEnabled,omitempty"`
+	IsDataExplorerEnabled                       *bool `json:"isDataExplorerEnabled,omitempty"`
+	IsPerformanceAdvisorEnabled                 *bool `json:"isPerformanceAdvisorEnabled,omitempty"`
+	IsRealtimePerformancePanelEnabled           *bool `json:"isRealtimePerformancePanelEnabled,omitempty"`
+	IsSchemaAdvisorEnabled                      *bool `json:"isSchemaAdvisorEnabled,omitempty"`
+}
+
+// Differs returns true if any of the settings which are set in desired has a different value in the settings.
+func (s ProjectSettings) Differs(desired ProjectSettings) bool {
+	differs := func(current, desired *bool) bool {
+		return desired != nil && (current == nil || *current != *desired)
+	}
+	return differs(s.IsCollectDatabaseSpecificsStatisticsEnabled, desired.IsCollectDatabaseSpecificsStatisticsEnabled) ||
+		differs(s.IsDataExplorerEnabled, desired.IsDataExplorerEnabled) ||
+		differs(s.IsPerformanceAdvisorEnabled, desired.IsPerformanceAdvisorEnabled) ||
+		differs(s.IsRealtimePerformancePanelEnabled, desired.IsRealtimePerformancePanelEnabled) ||
+		differs(s.IsSchemaAdvisorEnabled, desired.IsSchemaAdvisorEnabled)
+}
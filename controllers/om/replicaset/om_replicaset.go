@@ -36,7 +36,7 @@ func BuildFromMongoDBWithReplicas(mongoDBImage string, forceEnterprise bool, mdb
 	members := process.CreateMongodProcessesFromMongoDB(mongoDBImage, forceEnterprise, mdb, replicas, fcv, tlsCertPath)
 	replicaSet := om.NewReplicaSet(mdb.Name, mdb.Spec.GetMongoDBVersion())
 	rsWithProcesses := om.NewReplicaSetWithProcesses(replicaSet, members, mdb.Spec.GetMemberOptions())
-	rsWithProcesses.SetHorizons(mdb.Spec.GetHorizonConfig())
+	rsWithProcesses.SetHorizons(mdb.GetReplicaSetHorizons(replicas))
 	return rsWithProcesses
 }
 
@@ -93,3 +93,34 @@ func TestBuildFromMongoDBWithReplicas(t *testing.T) {
 			"Member host should match process name at index %d", i)
 	}
 }
+
+func TestBuildFromMongoDBWithReplicas_HorizonTemplates(t *testing.T) {
+	mdb := &mdbv1.MongoDB{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rs",
+			Namespace: "test-namespace",
+		},
+		Spec: mdbv1.MongoDbSpec{
+			DbCommonSpec: mdbv1.DbCommonSpec{
+				Version: "7.0.5",
+				Security: &mdbv1.Security{
+					TLSConfig:      &mdbv1.TLSConfig{Enabled: true},
+					Authentication: &mdbv1.Authentication{},
+				},
+				Connectivity: &mdbv1.MongoDBConnectivity{
+					ReplicaSetHorizonTemplates: map[string]string{"external": "{podName}.{externalDomain}:{port}"},
+				},
+				ExternalAccessConfiguration: &mdbv1.ExternalAccessConfiguration{ExternalDomain: ptr.To("example.com")},
+			},
+			Members: 5,
+		},
+	}
+
+	// the horizons are generated for the members of this reconciliation only
+	rsWithProcesses := BuildFromMongoDBWithReplicas("mongodb/mongodb-enterprise-server:7.0.5", false, mdb, 2, "7.0", "")
+
+	members := rsWithProcesses.Rs["members"].([]om.ReplicaSetMember)
+	assert.Len(t, members, 2)
+	assert.Equal(t, mdbv1.MongoDBHorizonConfig{"external": "test-rs-0.example.com:27017"}, members[0]["horizons"])
+	assert.Equal(t, mdbv1.MongoDBHorizonConfig{"external": "test-rs-1.example.com:27017"}, members[1]["horizons"])
+}
@@ -0,0 +1,64 @@
+package team
+
+// OrganizationTeams manages the teams of the organization and their members.
+type OrganizationTeams interface {
+	// ReadTeams returns all the teams of the organization
+	ReadTeams() ([]*Team, error)
+	// CreateTeam creates the team in the organization, Ops Manager requires at least one user to be added on creation
+	CreateTeam(team *Team) (*Team, error)
+	DeleteTeam(teamID string) error
+
+	ReadTeamUsers(teamID string) ([]*User, error)
+	// AddTeamUser adds the existing Ops Manager user with the username to the team
+	AddTeamUser(teamID string, username string) error
+	RemoveTeamUser(teamID string, userID string) error
+}
+
+// ProjectTeams manages the teams assigned to the project and their project roles.
+type ProjectTeams interface {
+	ReadProjectTeams() ([]*ProjectTeam, error)
+	AssignTeamsToProject(teams []*ProjectTeam) error
+	UpdateProjectTeamRoles(team *ProjectTeam) error
+	RemoveTeamFromProject(teamID string) error
+}
+
+/*
+	{
+	  "id": "6b610e1087d9d66b272f0c86",
+	  "name": "payments",
+	  "usernames": ["jane.doe@example.com"]
+	}
+*/
+type Team struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	Usernames []string `json:"usernames,omitempty"`
+}
+
+type TeamsResponse struct {
+	Teams []*Team `json:"results"`
+}
+
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type UsersResponse struct {
+	Users []*User `json:"results"`
+}
+
+/*
+	{
+	  "teamId": "6b610e1087d9d66b272f0c86",
+	  "roleNames": ["GROUP_DATA_ACCESS_READ_WRITE"]
+	}
+*/
+type ProjectTeam struct {
+	TeamID    string   `json:"teamId"`
+	RoleNames []string `json:"roleNames"`
+}
+
+type ProjectTeamsResponse struct {
+	Teams []*ProjectTeam `json:"results"`
+}
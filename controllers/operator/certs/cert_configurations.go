@@ -3,6 +3,7 @@ package certs
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
@@ -170,16 +171,17 @@ func StandaloneConfig(mdb mdbv1.MongoDB) Options {
 
 // ReplicaSetConfig returns a struct which provides all of the configuration options required for the given Replica Set.
 func ReplicaSetConfig(mdb mdbv1.MongoDB) Options {
+	replicas := scale.ReplicasThisReconciliation(&mdb)
 	return Options{
 		ResourceName:                 mdb.Name,
 		CertSecretName:               mdb.GetSecurity().MemberCertificateSecretName(mdb.Name),
 		InternalClusterSecretName:    mdb.GetSecurity().InternalClusterAuthSecretName(mdb.Name),
 		Namespace:                    mdb.Namespace,
-		Replicas:                     scale.ReplicasThisReconciliation(&mdb),
+		Replicas:                     replicas,
 		ServiceName:                  mdb.ServiceName(),
 		ClusterDomain:                mdb.Spec.GetClusterDomain(),
 		additionalCertificateDomains: mdb.Spec.Security.TLSConfig.AdditionalCertificateDomains,
-		horizons:                     mdb.Spec.Connectivity.ReplicaSetHorizons,
+		horizons:                     mdb.GetReplicaSetHorizons(replicas),
 		OwnerReference:               mdb.GetOwnerReferences(),
 		ExternalDomain:               mdb.Spec.DbCommonSpec.GetExternalDomain(),
 	}
@@ -249,6 +251,15 @@ func ShardConfig(mdb mdbv1.MongoDB, shardNum int, externalDomain *string, scaler
 
 // MultiReplicaSetConfig returns a struct which provides all of the configuration required for a given MongoDB Multi Replicaset.
 func MultiReplicaSetConfig(mdbm mdbmulti.MongoDBMultiCluster, clusterNum int, clusterName string, replicas int) Options {
+	// the horizons are only verified once the templates can be expanded, or the NodePort addresses have been
+	// discovered, for all the members of the cluster
+	var horizons []mdbv1.MongoDBHorizonConfig
+	if mdbm.Spec.Connectivity.HasReplicaSetHorizonTemplates() {
+		horizons, _ = mdbm.GetReplicaSetHorizonsForMemberCluster(clusterName, replicas)
+	} else if mdbm.IsExternalAddressDiscoveryEnabled() && mdbm.GetExternalAddressDiscoveryServiceType() == corev1.ServiceTypeNodePort {
+		horizons, _ = mdbm.GetExternalAddressHorizonsForMemberCluster(clusterName, replicas)
+	}
+
 	return Options{
 		ResourceName:              mdbm.MultiStatefulsetName(clusterNum),
 		CertSecretName:            mdbm.Spec.GetSecurity().MemberCertificateSecretName(mdbm.Name),
@@ -259,6 +270,7 @@ func MultiReplicaSetConfig(mdbm mdbmulti.MongoDBMultiCluster, clusterNum int, cl
 		Topology:                  mdbv1.ClusterTopologyMultiCluster,
 		OwnerReference:            mdbm.GetOwnerReferences(),
 		ExternalDomain:            mdbm.Spec.GetExternalDomainForMemberCluster(clusterName),
+		horizons:                  horizons,
 	}
 }
 
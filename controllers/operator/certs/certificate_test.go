@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -98,6 +99,15 @@ func TestVerifyTLSSecretForStatefulSetHorizonMemberDifference(t *testing.T) {
 	assert.ErrorContains(t, err, "horizon configs")
 }
 
+func TestReplicaSetConfigWithHorizonTemplates(t *testing.T) {
+	rs := mdbv1.NewReplicaSetBuilder().SetName("my-rs").SetSecurityTLSEnabled().ExposedExternally(nil, nil, ptr.To("example.com")).Build()
+	rs.Spec.Connectivity.ReplicaSetHorizonTemplates = map[string]string{"external": "{podName}.external.com:{port}"}
+
+	opts := ReplicaSetConfig(*rs)
+	assert.Len(t, opts.horizons, 3)
+	assert.Equal(t, []string{"my-rs-2.external.com"}, GetAdditionalCertDomainsForMember(opts, 2))
+}
+
 // This test uses mock hashes and certificate because CreatePemSecretClient does not verify the certificates.
 // However, they are verified before and after calling this method.
 func TestRotateCertificate(t *testing.T) {
@@ -257,9 +257,14 @@ func validatePemData(data []byte, additionalDomains []string) error {
 	// containing the additionalDomains which we're validating for.
 	for _, cert := range certs {
 		var err error
+		// the horizons of the discovered NodePort addresses are IP addresses, which are in the IP SANs
+		addresses := append([]string{}, cert.DNSNames...)
+		for _, ip := range cert.IPAddresses {
+			addresses = append(addresses, ip.String())
+		}
 		for _, domain := range additionalDomains {
-			if !stringutil.CheckCertificateAddresses(cert.DNSNames, domain) {
-				err = xerrors.Errorf("domain %s is not contained in the list of DNSNames %v\n", domain, cert.DNSNames)
+			if !stringutil.CheckCertificateAddresses(addresses, domain) {
+				err = xerrors.Errorf("domain %s is not contained in the list of DNSNames %v\n", domain, addresses)
 				errs = multierror.Append(errs, err)
 			}
 		}
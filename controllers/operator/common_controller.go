@@ -31,8 +31,10 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/certs"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	enterprisepem "github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
@@ -45,6 +47,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
 	"github.com/mongodb/mongodb-kubernetes/pkg/passwordhash"
+	"github.com/mongodb/mongodb-kubernetes/pkg/references"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -228,6 +231,28 @@ func (r *ReconcileCommonController) getRoleRefs(ctx context.Context, roleRefs []
 	return roles, nil
 }
 
+// statusWarningsWriter is implemented by the resources reporting the warnings of their validations in the status.
+type statusWarningsWriter interface {
+	AddWarningIfNotExists(warning status.Warning)
+}
+
+// validateReferences validates the references of the resource to other Kubernetes resources, the same validations
+// are run by the admission webhook. The references to resources which don't exist are only reported as warnings in
+// the status, as they can be created after the referencing resource.
+func (r *ReconcileCommonController) validateReferences(ctx context.Context, resource client.Object, enableClusterMongoDBRoles bool, log *zap.SugaredLogger) error {
+	referenceWarnings, err := references.Process(references.NewValidator(r.client, enableClusterMongoDBRoles).Validate(ctx, resource))
+	if err != nil {
+		return err
+	}
+	for _, warning := range referenceWarnings {
+		log.Warn(warning)
+		if warningsWriter, ok := resource.(statusWarningsWriter); ok {
+			warningsWriter.AddWarningIfNotExists(warning)
+		}
+	}
+	return nil
+}
+
 // updateStatus updates the status for the CR using patch operation. Note, that the resource status is mutated and
 // it's important to pass resource by pointer to all methods which invoke current 'updateStatus'.
 func (r *ReconcileCommonController) updateStatus(ctx context.Context, reconciledResource v1.CustomResourceReadWriter, st workflow.Status, log *zap.SugaredLogger, statusOptions ...status.Option) (reconcile.Result, error) {
@@ -391,6 +416,17 @@ func ensureSupportedOpsManagerVersion(conn om.Connection) workflow.Status {
 	return workflow.OK()
 }
 
+// prepareOpsManagerConnection returns the connection to the Ops Manager project of the resource and the agent API
+// key. In plan mode the existing project is only read and no agent API key is returned, so nothing is changed in Ops
+// Manager before the plan is published.
+func prepareOpsManagerConnection(ctx context.Context, secretClient secrets.SecretClient, resource v1.ObjectOwner, projectConfig mdbv1.ProjectConfig, credsConfig mdbv1.Credentials, connectionFactory om.ConnectionFactory, log *zap.SugaredLogger) (om.Connection, string, error) {
+	if plan.IsEnabled(resource) {
+		conn, err := connection.ReadOpsManagerConnection(projectConfig, credsConfig, connectionFactory, log)
+		return conn, "", err
+	}
+	return connection.PrepareOpsManagerConnection(ctx, secretClient, projectConfig, credsConfig, connectionFactory, resource.GetNamespace(), log)
+}
+
 // scaleStatefulSet sets the number of replicas for a StatefulSet and returns a reference of the updated resource.
 func (r *ReconcileCommonController) scaleStatefulSet(ctx context.Context, namespace, name string, replicas int32, client kubernetesClient.Client) (appsv1.StatefulSet, error) {
 	if set, err := client.GetStatefulSet(ctx, kube.ObjectKey(namespace, name)); err != nil {
@@ -356,6 +356,24 @@ func TestErrorWhenRoleRefIsWrong(t *testing.T) {
 	assert.Empty(t, roles)
 }
 
+func TestValidateReferencesAllowsMissingRole(t *testing.T) {
+	ctx := context.Background()
+	roleRefs := []mdbv1.MongoDBRoleRef{
+		{
+			Name: "missing-role",
+			Kind: util.ClusterMongoDBRoleKind,
+		},
+	}
+	rs := mdbv1.NewDefaultReplicaSetBuilder().SetRoleRefs(roleRefs).Build()
+
+	kubeClient, _ := mock.NewDefaultFakeClient()
+	controller := NewReconcileCommonController(ctx, kubeClient)
+
+	// the role can be created after the resource, so the missing role is only reported as a warning
+	assert.NoError(t, controller.validateReferences(ctx, rs, true, zap.S()))
+	assert.Equal(t, []status.Warning{"ClusterMongoDBRole missing-role referenced in spec.security.roleRefs doesn't exist"}, rs.Status.Warnings)
+}
+
 func TestErrorWhenRoleDoesNotExist(t *testing.T) {
 	ctx := context.Background()
 	roleResource := role.DefaultClusterMongoDBRoleBuilder().Build()
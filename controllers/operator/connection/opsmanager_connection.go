@@ -52,6 +52,16 @@ func PrepareOpsManagerConnection(ctx context.Context, client secrets.SecretClien
 	}
 }
 
+// ReadOpsManagerConnection returns the connection to the existing Ops Manager project. Unlike
+// PrepareOpsManagerConnection, the project is neither created nor updated and the agent API key is not generated.
+func ReadOpsManagerConnection(projectConfig mdbv1.ProjectConfig, credentials mdbv1.Credentials, connectionFunc om.ConnectionFactory, log *zap.SugaredLogger) (om.Connection, error) {
+	_, conn, err := project.ReadProject(projectConfig, credentials, connectionFunc, log)
+	if err != nil {
+		return nil, xerrors.Errorf("error reading project in Ops Manager: %w", err)
+	}
+	return conn, nil
+}
+
 // EnsureTagAdded makes sure that the given project has the provided tag
 func EnsureTagAdded(conn om.Connection, project *om.Project, tag string, log *zap.SugaredLogger) error {
 	// must truncate the tag to at most 32 characters and capitalise as
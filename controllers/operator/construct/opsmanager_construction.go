@@ -3,7 +3,9 @@ package construct
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net"
+	"slices"
 
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
@@ -53,6 +55,7 @@ type OpsManagerStatefulSetOptions struct {
 	AppDBTlsCAConfigMapName      string
 	AppDBConnectionSecretName    string
 	AppDBConnectionStringHash    string
+	SettingsSecretsHash          string
 	EnvVars                      []corev1.EnvVar
 	InitOpsManagerImage          string
 	OpsManagerImage              string
@@ -92,6 +95,14 @@ func WithConnectionStringHash(hash string) func(opts *OpsManagerStatefulSetOptio
 	}
 }
 
+// WithSettingsSecretsHash sets the hash of the Secret values referenced in spec.settings, so the Pods are restarted
+// when they change.
+func WithSettingsSecretsHash(hash string) func(opts *OpsManagerStatefulSetOptions) {
+	return func(opts *OpsManagerStatefulSetOptions) {
+		opts.SettingsSecretsHash = hash
+	}
+}
+
 func WithVaultConfig(config vault.VaultConfiguration) func(opts *OpsManagerStatefulSetOptions) {
 	return func(opts *OpsManagerStatefulSetOptions) {
 		opts.VaultConfig = config
@@ -412,6 +423,14 @@ func backupAndOpsManagerSharedConfiguration(opts OpsManagerStatefulSetOptions) s
 	podtemplateAnnotation := podtemplatespec.WithAnnotations(map[string]string{
 		"connectionStringHash": opts.AppDBConnectionStringHash,
 	})
+	if opts.SettingsSecretsHash != "" {
+		podtemplateAnnotation = podtemplatespec.Apply(
+			podtemplateAnnotation,
+			podtemplatespec.WithAnnotations(map[string]string{
+				"settingsSecretsHash": opts.SettingsSecretsHash,
+			}),
+		)
+	}
 
 	if vault.IsVaultSecretBackend() {
 		podtemplateAnnotation = podtemplatespec.Apply(
@@ -657,6 +676,33 @@ func opsManagerConfigurationToEnvVars(m *omv1.MongoDBOpsManager) []corev1.EnvVar
 			Name: omv1.ConvertNameToEnvVarFormat(name), Value: value,
 		})
 	}
+	return append(envVars, opsManagerSettingsToEnvVars(m.Spec.Settings)...)
+}
+
+// opsManagerSettingsToEnvVars renders the typed Ops Manager settings into the properties passed to the container,
+// the values stored in Secrets are read by Kubernetes from the Secrets directly.
+func opsManagerSettingsToEnvVars(settings *omv1.OpsManagerSettings) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	properties := settings.Properties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: omv1.ConvertNameToEnvVarFormat(name), Value: properties[name],
+		})
+	}
+
+	secretProperties := settings.SecretProperties()
+	for _, name := range slices.Sorted(maps.Keys(secretProperties)) {
+		ref := secretProperties[name]
+		envVars = append(envVars, corev1.EnvVar{
+			Name: omv1.ConvertNameToEnvVarFormat(name),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+					Key:                  ref.Key,
+				},
+			},
+		})
+	}
 	return envVars
 }
 
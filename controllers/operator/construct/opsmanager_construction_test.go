@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/container"
@@ -409,6 +410,37 @@ func TestOpsManagerPodTemplate_Container(t *testing.T) {
 		containerObj.Lifecycle.PreStop.Exec.Command)
 }
 
+func TestOpsManagerPodTemplate_SettingsEnvVars(t *testing.T) {
+	ctx := context.Background()
+	om := omv1.NewOpsManagerBuilderDefault().Build()
+	om.Spec.Settings = &omv1.OpsManagerSettings{
+		SMTP: &omv1.OpsManagerSMTPSettings{
+			FromEmailAddress:    "om@example.com",
+			ReplyToEmailAddress: "noreply@example.com",
+			AdminEmailAddress:   "admin@example.com",
+			Hostname:            "smtp.example.com",
+			Port:                465,
+			TLS:                 true,
+			Username:            "om",
+			PasswordSecretRef:   &userv1.SecretKeyRef{Name: "smtp-credentials"},
+		},
+	}
+	sts, err := createOpsManagerStatefulset(ctx, om)
+	assert.NoError(t, err)
+
+	env := sts.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{Name: "OM_PROP_mms_mail_hostname", Value: "smtp.example.com"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "OM_PROP_mms_mail_port", Value: "465"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "OM_PROP_mms_mail_transport", Value: "smtps"})
+	assert.Contains(t, env, corev1.EnvVar{
+		Name: "OM_PROP_mms_mail_password",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "smtp-credentials"},
+			Key:                  "password",
+		}},
+	})
+}
+
 func defaultNodeAffinity() corev1.NodeAffinity {
 	return corev1.NodeAffinity{
 		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -20,9 +20,12 @@ import (
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 
 	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	alertv1 "github.com/mongodb/mongodb-kubernetes/api/v1/alert"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	memberclusterv1 "github.com/mongodb/mongodb-kubernetes/api/v1/membercluster"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	projectv1 "github.com/mongodb/mongodb-kubernetes/api/v1/project"
 	rolev1 "github.com/mongodb/mongodb-kubernetes/api/v1/role"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/user"
@@ -110,7 +113,7 @@ func NewEmptyFakeClientBuilder() *fake.ClientBuilder {
 		return nil
 	}
 
-	builder.WithStatusSubresource(&mdbv1.MongoDB{}, &mdbmulti.MongoDBMultiCluster{}, &omv1.MongoDBOpsManager{}, &user.MongoDBUser{}, &searchv1.MongoDBSearch{}, &mdbcv1.MongoDBCommunity{}, &rolev1.ClusterMongoDBRole{})
+	builder.WithStatusSubresource(&mdbv1.MongoDB{}, &mdbmulti.MongoDBMultiCluster{}, &omv1.MongoDBOpsManager{}, &user.MongoDBUser{}, &searchv1.MongoDBSearch{}, &searchv1.MongoDBSearchIndex{}, &alertv1.MongoDBAlertConfig{}, &projectv1.MongoDBOrganization{}, &projectv1.MongoDBProject{}, &mdbcv1.MongoDBCommunity{}, &rolev1.ClusterMongoDBRole{}, &memberclusterv1.MongoDBMemberCluster{})
 
 	ot := testing.NewObjectTracker(s, scheme.Codecs.UniversalDecoder())
 	return builder.WithScheme(s).WithObjectTracker(ot)
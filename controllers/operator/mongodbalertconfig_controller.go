@@ -0,0 +1,329 @@
+package operator
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	alertv1 "github.com/mongodb/mongodb-kubernetes/api/v1/alert"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/alert"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+// eventTypeOutsideMetricThreshold is the Ops Manager event type of the alerts triggered by a metric threshold
+const eventTypeOutsideMetricThreshold = "OUTSIDE_METRIC_THRESHOLD"
+
+type MongoDBAlertConfigReconciler struct {
+	*ReconcileCommonController
+	omConnectionFactory om.ConnectionFactory
+}
+
+func newMongoDBAlertConfigReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory) *MongoDBAlertConfigReconciler {
+	return &MongoDBAlertConfigReconciler{
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		omConnectionFactory:       omFunc,
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodbalertconfigs,mongodbalertconfigs/status,mongodbalertconfigs/finalizers},verbs=*,namespace=placeholder
+
+// Reconciles a mongodbalertconfigs.mongodb.com Custom resource.
+func (r *MongoDBAlertConfigReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
+	log := zap.S().With("MongoDBAlertConfig", request.NamespacedName)
+	log.Info("-> MongoDBAlertConfig.Reconcile")
+
+	alertConfig := &alertv1.MongoDBAlertConfig{}
+	if result, err := r.GetResource(ctx, request, alertConfig, log); err != nil {
+		return result, err
+	}
+
+	mdb, err := r.getMongoDB(ctx, alertConfig)
+	if err != nil {
+		log.Warnf("Couldn't fetch MongoDB Single/Multi Cluster Resource %s: %s", alertConfig.MongoDBNamespacedName(), err)
+		// without the MongoDB resource there is no project to remove the alert configuration from, so it's not blocking the deletion
+		if !alertConfig.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(alertConfig, util.AlertConfigFinalizer) {
+			return r.removeFinalizer(ctx, alertConfig, workflow.Pending("Finalizer will be removed. MongoDB resource not found"), log)
+		}
+		return r.updateStatus(ctx, alertConfig, workflow.Pending("%s", err.Error()), log)
+	}
+
+	projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, r.client, r.SecretClient, mdb, log)
+	if err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(err), log)
+	}
+
+	conn, _, err := connection.PrepareOpsManagerConnection(ctx, r.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, alertConfig.Namespace, log)
+	if err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(xerrors.Errorf("Failed to prepare Ops Manager connection: %w", err)), log)
+	}
+
+	if !alertConfig.DeletionTimestamp.IsZero() {
+		log.Info("MongoDBAlertConfig is being deleted")
+
+		if controllerutil.ContainsFinalizer(alertConfig, util.AlertConfigFinalizer) {
+			return r.preDeletionCleanup(ctx, alertConfig, conn, log)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateAlertConfigSpec(alertConfig.Spec); err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Invalid("%s", err.Error()), log)
+	}
+
+	if err := r.ensureFinalizer(ctx, alertConfig, log); err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(xerrors.Errorf("Failed to add finalizer: %w", err)), log)
+	}
+
+	desiredConfig, err := r.toOmAlertConfig(ctx, alertConfig)
+	if err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(err), log)
+	}
+
+	alertConfigID, err := ensureAlertConfig(conn, alertConfig, desiredConfig, log)
+	if err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(xerrors.Errorf("Failed to configure the alert in Ops Manager: %w", err)), log)
+	}
+
+	log.Infof("Finished reconciliation for MongoDBAlertConfig!")
+	return r.updateStatus(ctx, alertConfig, workflow.OK(), log, alertv1.NewAlertConfigIDOption(alertConfigID, conn.GroupID()))
+}
+
+// getMongoDB returns the MongoDB or MongoDBMultiCluster resource the Ops Manager project of which the alert is configured in.
+func (r *MongoDBAlertConfigReconciler) getMongoDB(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig) (project.Reader, error) {
+	name := alertConfig.MongoDBNamespacedName()
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(name.Name, name.Namespace, watch.MongoDB, alertConfig.NamespacedName())
+
+	mdb := &mdbv1.MongoDB{}
+	if err := r.client.Get(ctx, name, mdb); err == nil {
+		return mdb, nil
+	}
+
+	mdbm := &mdbmulti.MongoDBMultiCluster{}
+	err := r.client.Get(ctx, name, mdbm)
+	return mdbm, err
+}
+
+// ensureAlertConfig creates the alert configuration in the project, or updates the one created by the previous
+// reconciliations. It's created again if it was removed in Ops Manager or the resource references a different project.
+func ensureAlertConfig(conn om.Connection, alertConfig *alertv1.MongoDBAlertConfig, desiredConfig *alert.Config, log *zap.SugaredLogger) (string, error) {
+	if alertConfig.Status.AlertConfigID != "" && alertConfig.Status.ProjectID == conn.GroupID() {
+		desiredConfig.ID = alertConfig.Status.AlertConfigID
+		updatedConfig, err := conn.UpdateAlertConfig(desiredConfig)
+		if err == nil {
+			log.Debugf("Updated the alert configuration %s", updatedConfig.ID)
+			return updatedConfig.ID, nil
+		}
+		if !apierror.NewNonNil(err).ErrorAlertConfigIsNotFound() {
+			return "", err
+		}
+		log.Warnf("The alert configuration %s doesn't exist in Ops Manager anymore, creating it again", alertConfig.Status.AlertConfigID)
+		desiredConfig.ID = ""
+	} else if alertConfig.Status.AlertConfigID != "" {
+		log.Warnf("The alert configuration %s is left in the previously referenced project %s", alertConfig.Status.AlertConfigID, alertConfig.Status.ProjectID)
+	}
+
+	createdConfig, err := conn.CreateAlertConfig(desiredConfig)
+	if err != nil {
+		return "", err
+	}
+	log.Infof("Created the alert configuration %s", createdConfig.ID)
+	return createdConfig.ID, nil
+}
+
+// validateAlertConfigSpec checks the rules depending on the event and notification types, which can't be expressed
+// in the CRD schema.
+func validateAlertConfigSpec(spec alertv1.MongoDBAlertConfigSpec) error {
+	if spec.MetricThreshold != nil && spec.Threshold != nil {
+		return xerrors.Errorf("only one of metricThreshold and threshold can be specified")
+	}
+	if spec.EventTypeName == eventTypeOutsideMetricThreshold && spec.MetricThreshold == nil {
+		return xerrors.Errorf("metricThreshold is required for the %s event type", eventTypeOutsideMetricThreshold)
+	}
+
+	for i, notification := range spec.Notifications {
+		switch notification.TypeName {
+		case alertv1.NotificationTypeEmail:
+			if (notification.EmailAddress == "") == (notification.EmailAddressSecretRef == nil) {
+				return xerrors.Errorf("notification %d: exactly one of emailAddress and emailAddressSecretRef is required for the %s type", i, notification.TypeName)
+			}
+		case alertv1.NotificationTypeWebhook:
+			if notification.WebhookURLSecretRef == nil {
+				return xerrors.Errorf("notification %d: webhookUrlSecretRef is required for the %s type", i, notification.TypeName)
+			}
+		case alertv1.NotificationTypePagerDuty:
+			if notification.ServiceKeySecretRef == nil {
+				return xerrors.Errorf("notification %d: serviceKeySecretRef is required for the %s type", i, notification.TypeName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// toOmAlertConfig converts the spec into the Ops Manager alert configuration, reading the notification keys from the
+// referenced secrets.
+func (r *MongoDBAlertConfigReconciler) toOmAlertConfig(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig) (*alert.Config, error) {
+	spec := alertConfig.Spec
+	config := &alert.Config{
+		EventTypeName: spec.EventTypeName,
+		Enabled:       alertConfig.IsEnabled(),
+		Matchers:      []alert.Matcher{},
+		Notifications: []alert.Notification{},
+	}
+
+	for _, matcher := range spec.Matchers {
+		config.Matchers = append(config.Matchers, alert.Matcher{FieldName: matcher.FieldName, Operator: matcher.Operator, Value: matcher.Value})
+	}
+
+	if spec.MetricThreshold != nil {
+		threshold, err := strconv.ParseFloat(spec.MetricThreshold.Threshold, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid metric threshold %q: %w", spec.MetricThreshold.Threshold, err)
+		}
+		config.MetricThreshold = &alert.MetricThreshold{
+			MetricName: spec.MetricThreshold.MetricName,
+			Operator:   spec.MetricThreshold.Operator,
+			Threshold:  threshold,
+			Units:      spec.MetricThreshold.Units,
+			Mode:       spec.MetricThreshold.Mode,
+		}
+	}
+
+	if spec.Threshold != nil {
+		config.Threshold = &alert.Threshold{
+			Operator:  spec.Threshold.Operator,
+			Threshold: float64(spec.Threshold.Threshold),
+			Units:     spec.Threshold.Units,
+		}
+	}
+
+	for _, notification := range spec.Notifications {
+		omNotification := alert.Notification{
+			TypeName:     string(notification.TypeName),
+			IntervalMin:  notification.IntervalMin,
+			DelayMin:     notification.DelayMin,
+			EmailAddress: notification.EmailAddress,
+			EmailEnabled: notification.EmailEnabled,
+			SMSEnabled:   notification.SMSEnabled,
+			Roles:        notification.Roles,
+		}
+
+		var err error
+		if omNotification.EmailAddress == "" {
+			if omNotification.EmailAddress, err = r.readNotificationSecretKey(ctx, alertConfig, notification.EmailAddressSecretRef); err != nil {
+				return nil, err
+			}
+		}
+		if omNotification.WebhookURL, err = r.readNotificationSecretKey(ctx, alertConfig, notification.WebhookURLSecretRef); err != nil {
+			return nil, err
+		}
+		if omNotification.WebhookSecret, err = r.readNotificationSecretKey(ctx, alertConfig, notification.WebhookSecretRef); err != nil {
+			return nil, err
+		}
+		if omNotification.ServiceKey, err = r.readNotificationSecretKey(ctx, alertConfig, notification.ServiceKeySecretRef); err != nil {
+			return nil, err
+		}
+
+		config.Notifications = append(config.Notifications, omNotification)
+	}
+
+	return config, nil
+}
+
+// readNotificationSecretKey returns the value of the key in the secret and watches the secret for changes. An empty
+// string is returned if the reference is not set.
+func (r *MongoDBAlertConfigReconciler) readNotificationSecretKey(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig, secretRef *userv1.SecretKeyRef) (string, error) {
+	if secretRef == nil {
+		return "", nil
+	}
+
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(secretRef.Name, alertConfig.Namespace, watch.Secret, alertConfig.NamespacedName())
+
+	var databaseSecretPath string
+	if vault.IsVaultSecretBackend() {
+		databaseSecretPath = r.VaultClient.DatabaseSecretPath()
+	}
+	value, err := r.ReadSecretKey(ctx, kube.ObjectKey(alertConfig.Namespace, secretRef.Name), databaseSecretPath, secretRef.Key)
+	if err != nil {
+		return "", xerrors.Errorf("failed to read the key %q of the notification secret %s: %w", secretRef.Key, secretRef.Name, err)
+	}
+	return value, nil
+}
+
+func (r *MongoDBAlertConfigReconciler) preDeletionCleanup(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig, conn om.Connection, log *zap.SugaredLogger) (reconcile.Result, error) {
+	log.Info("Performing pre deletion cleanup before deleting MongoDBAlertConfig")
+
+	if alertConfig.Status.AlertConfigID != "" && alertConfig.Status.ProjectID == conn.GroupID() {
+		if err := conn.DeleteAlertConfig(alertConfig.Status.AlertConfigID); err != nil && !apierror.NewNonNil(err).ErrorAlertConfigIsNotFound() {
+			return r.updateStatus(ctx, alertConfig, workflow.Failed(xerrors.Errorf("Failed to remove the alert configuration from Ops Manager: %w", err)), log)
+		}
+	}
+
+	r.resourceWatcher.RemoveAllDependentWatchedResources(alertConfig.Namespace, alertConfig.NamespacedName())
+	return r.removeFinalizer(ctx, alertConfig, workflow.OK(), log)
+}
+
+func (r *MongoDBAlertConfigReconciler) removeFinalizer(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig, st workflow.Status, log *zap.SugaredLogger) (reconcile.Result, error) {
+	controllerutil.RemoveFinalizer(alertConfig, util.AlertConfigFinalizer)
+	if err := r.client.Update(ctx, alertConfig); err != nil {
+		return r.updateStatus(ctx, alertConfig, workflow.Failed(xerrors.Errorf("Failed to update the MongoDBAlertConfig with the removed finalizer: %w", err)), log)
+	}
+
+	// the resource is gone once the last finalizer is removed, so there is no status left to update
+	st.Log(log)
+	return st.ReconcileResult()
+}
+
+func (r *MongoDBAlertConfigReconciler) ensureFinalizer(ctx context.Context, alertConfig *alertv1.MongoDBAlertConfig, log *zap.SugaredLogger) error {
+	if finalizerAdded := controllerutil.AddFinalizer(alertConfig, util.AlertConfigFinalizer); finalizerAdded {
+		log.Info("Adding finalizer to the MongoDBAlertConfig resource")
+		if err := r.client.Update(ctx, alertConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddMongoDBAlertConfigController(ctx context.Context, mgr manager.Manager) error {
+	r := newMongoDBAlertConfigReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection)
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named(util.MongoDbAlertConfigController).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&alertv1.MongoDBAlertConfig{}).
+		Watches(&mdbv1.MongoDB{}, &watch.ResourcesHandler{ResourceType: watch.MongoDB, ResourceWatcher: r.resourceWatcher}).
+		// the MongoDBMultiCluster targets are registered with the MongoDB type, as they are looked up by the same name
+		Watches(&mdbmulti.MongoDBMultiCluster{}, &watch.ResourcesHandler{ResourceType: watch.MongoDB, ResourceWatcher: r.resourceWatcher}).
+		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.resourceWatcher}).
+		Watches(&corev1.ConfigMap{}, &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.resourceWatcher}).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Registered controller %s", util.MongoDbAlertConfigController)
+	return nil
+}
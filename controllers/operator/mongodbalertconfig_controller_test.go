@@ -0,0 +1,230 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	alertv1 "github.com/mongodb/mongodb-kubernetes/api/v1/alert"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/alert"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newTestAlertConfig() *alertv1.MongoDBAlertConfig {
+	return &alertv1.MongoDBAlertConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "replication-lag", Namespace: mock.TestNamespace},
+		Spec: alertv1.MongoDBAlertConfigSpec{
+			MongoDBResourceRef: userv1.MongoDBResourceRef{Name: "my-rs"},
+			EventTypeName:      "OUTSIDE_METRIC_THRESHOLD",
+			Matchers:           []alertv1.Matcher{{FieldName: "REPLICA_SET_NAME", Operator: "EQUALS", Value: "my-rs"}},
+			MetricThreshold: &alertv1.MetricThreshold{
+				MetricName: "OPLOG_SLAVE_LAG_MASTER_TIME",
+				Operator:   "GREATER_THAN",
+				Threshold:  "60.5",
+				Units:      "SECONDS",
+				Mode:       "AVERAGE",
+			},
+			Notifications: []alertv1.Notification{
+				{
+					TypeName:            alertv1.NotificationTypeWebhook,
+					IntervalMin:         5,
+					WebhookURLSecretRef: &userv1.SecretKeyRef{Name: "alert-channels", Key: "webhookUrl"},
+					WebhookSecretRef:    &userv1.SecretKeyRef{Name: "alert-channels", Key: "webhookSecret"},
+				},
+				{
+					TypeName:            alertv1.NotificationTypePagerDuty,
+					ServiceKeySecretRef: &userv1.SecretKeyRef{Name: "alert-channels", Key: "pagerDutyKey"},
+				},
+				{
+					TypeName:     alertv1.NotificationTypeEmail,
+					EmailAddress: "dba@example.com",
+				},
+			},
+		},
+	}
+}
+
+func alertConfigReconcilerWithResources(ctx context.Context, t *testing.T, alertConfig *alertv1.MongoDBAlertConfig) (*MongoDBAlertConfigReconciler, client.Client, *om.CachedOMConnectionFactory) {
+	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient(alertConfig)
+	require.NoError(t, kubeClient.Create(ctx, DefaultReplicaSetBuilder().SetName("my-rs").Build()))
+	require.NoError(t, kubeClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alert-channels", Namespace: mock.TestNamespace},
+		Data: map[string][]byte{
+			"webhookUrl":    []byte("https://alerts.example.com/hook"),
+			"webhookSecret": []byte("hook-secret"),
+			"pagerDutyKey":  []byte("pd-service-key"),
+		},
+	}))
+
+	return newMongoDBAlertConfigReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc), kubeClient, omConnectionFactory
+}
+
+func reconcileAlertConfig(ctx context.Context, t *testing.T, reconciler *MongoDBAlertConfigReconciler, kubeClient client.Client, alertConfig *alertv1.MongoDBAlertConfig) {
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: alertConfig.NamespacedName()})
+	require.NoError(t, err)
+	require.NoError(t, kubeClient.Get(ctx, alertConfig.NamespacedName(), alertConfig))
+}
+
+func TestAlertConfigIsCreated_OnSuccessfulReconciliation(t *testing.T) {
+	ctx := context.Background()
+	alertConfig := newTestAlertConfig()
+	reconciler, kubeClient, omConnectionFactory := alertConfigReconcilerWithResources(ctx, t, alertConfig)
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+
+	assert.Equal(t, status.PhaseRunning, alertConfig.Status.Phase)
+	assert.Contains(t, alertConfig.Finalizers, util.AlertConfigFinalizer)
+
+	conn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	require.Len(t, conn.AlertConfigs, 1)
+	omConfig := conn.AlertConfigs[alertConfig.Status.AlertConfigID]
+	require.NotNil(t, omConfig)
+	assert.Equal(t, om.TestGroupID, alertConfig.Status.ProjectID)
+
+	assert.Equal(t, "OUTSIDE_METRIC_THRESHOLD", omConfig.EventTypeName)
+	assert.True(t, omConfig.Enabled)
+	assert.Equal(t, []alert.Matcher{{FieldName: "REPLICA_SET_NAME", Operator: "EQUALS", Value: "my-rs"}}, omConfig.Matchers)
+	assert.Equal(t, 60.5, omConfig.MetricThreshold.Threshold)
+	require.Len(t, omConfig.Notifications, 3)
+	assert.Equal(t, "https://alerts.example.com/hook", omConfig.Notifications[0].WebhookURL)
+	assert.Equal(t, "hook-secret", omConfig.Notifications[0].WebhookSecret)
+	assert.Equal(t, "pd-service-key", omConfig.Notifications[1].ServiceKey)
+	assert.Equal(t, "dba@example.com", omConfig.Notifications[2].EmailAddress)
+}
+
+func TestAlertConfigIsUpdated_OnSubsequentReconciliation(t *testing.T) {
+	ctx := context.Background()
+	alertConfig := newTestAlertConfig()
+	reconciler, kubeClient, omConnectionFactory := alertConfigReconcilerWithResources(ctx, t, alertConfig)
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+	alertConfigID := alertConfig.Status.AlertConfigID
+
+	alertConfig.Spec.Enabled = ptr.To(false)
+	require.NoError(t, kubeClient.Update(ctx, alertConfig))
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+
+	conn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	require.Len(t, conn.AlertConfigs, 1)
+	assert.Equal(t, alertConfigID, alertConfig.Status.AlertConfigID)
+	assert.False(t, conn.AlertConfigs[alertConfigID].Enabled)
+}
+
+func TestAlertConfigIsRecreated_IfRemovedInOpsManager(t *testing.T) {
+	ctx := context.Background()
+	alertConfig := newTestAlertConfig()
+	reconciler, kubeClient, omConnectionFactory := alertConfigReconcilerWithResources(ctx, t, alertConfig)
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+	conn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	require.NoError(t, conn.DeleteAlertConfig(alertConfig.Status.AlertConfigID))
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+
+	assert.Equal(t, status.PhaseRunning, alertConfig.Status.Phase)
+	require.Len(t, conn.AlertConfigs, 1)
+	assert.Contains(t, conn.AlertConfigs, alertConfig.Status.AlertConfigID)
+}
+
+func TestAlertConfigIsRemoved_WhenResourceIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	alertConfig := newTestAlertConfig()
+	reconciler, kubeClient, omConnectionFactory := alertConfigReconcilerWithResources(ctx, t, alertConfig)
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+	require.NoError(t, kubeClient.Delete(ctx, alertConfig))
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: alertConfig.NamespacedName()})
+	require.NoError(t, err)
+
+	conn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	assert.Empty(t, conn.AlertConfigs)
+	err = kubeClient.Get(ctx, alertConfig.NamespacedName(), alertConfig)
+	assert.True(t, apiErrors.IsNotFound(err), "the alert config should not exist")
+}
+
+func TestAlertConfigReconciliation_FailsIfNotificationSecretIsMissing(t *testing.T) {
+	ctx := context.Background()
+	alertConfig := newTestAlertConfig()
+	alertConfig.Spec.Notifications[1].ServiceKeySecretRef.Name = "missing"
+	reconciler, kubeClient, omConnectionFactory := alertConfigReconcilerWithResources(ctx, t, alertConfig)
+
+	reconcileAlertConfig(ctx, t, reconciler, kubeClient, alertConfig)
+
+	assert.Equal(t, status.PhaseFailed, alertConfig.Status.Phase)
+	assert.Empty(t, omConnectionFactory.GetConnection().(*om.MockedOmConnection).AlertConfigs)
+}
+
+func TestValidateAlertConfigSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(spec *alertv1.MongoDBAlertConfigSpec)
+		expectedError string
+	}{
+		{
+			name:   "valid spec",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {},
+		},
+		{
+			name: "metric threshold is required for the metric event type",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {
+				spec.MetricThreshold = nil
+			},
+			expectedError: "metricThreshold is required",
+		},
+		{
+			name: "only one threshold can be set",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {
+				spec.Threshold = &alertv1.Threshold{Operator: "LESS_THAN", Threshold: 24}
+			},
+			expectedError: "only one of metricThreshold and threshold",
+		},
+		{
+			name: "webhook url is required",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {
+				spec.Notifications[0].WebhookURLSecretRef = nil
+			},
+			expectedError: "notification 0: webhookUrlSecretRef is required",
+		},
+		{
+			name: "pager duty key is required",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {
+				spec.Notifications[1].ServiceKeySecretRef = nil
+			},
+			expectedError: "notification 1: serviceKeySecretRef is required",
+		},
+		{
+			name: "email address can't be set twice",
+			modify: func(spec *alertv1.MongoDBAlertConfigSpec) {
+				spec.Notifications[2].EmailAddressSecretRef = &userv1.SecretKeyRef{Name: "alert-channels", Key: "email"}
+			},
+			expectedError: "notification 2: exactly one of emailAddress and emailAddressSecretRef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := newTestAlertConfig().Spec
+			tt.modify(&spec)
+			err := validateAlertConfigSpec(spec)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedError)
+			}
+		})
+	}
+}
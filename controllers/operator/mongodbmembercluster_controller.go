@@ -0,0 +1,316 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	memberclusterv1 "github.com/mongodb/mongodb-kubernetes/api/v1/membercluster"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	// memberClusterHealthRefreshInterval is the interval the Healthy condition is refreshed in
+	memberClusterHealthRefreshInterval = 30 * time.Second
+
+	// memberClusterCacheSyncTimeout is the time the cache of a new member cluster has to sync in
+	memberClusterCacheSyncTimeout = 30 * time.Second
+)
+
+// memberClusterStarter creates and starts the cluster object of a member cluster. The returned function stops it.
+type memberClusterStarter func(ctx context.Context, config *rest.Config) (cluster.Cluster, context.CancelFunc, error)
+
+// MongoDBMemberClusterReconciler registers the member clusters declared with MongoDBMemberCluster resources in the
+// member cluster Registry, so the multi-cluster controllers can use them without the operator being restarted.
+type MongoDBMemberClusterReconciler struct {
+	*ReconcileCommonController
+	memberClusters    *multicluster.Registry
+	operatorNamespace string
+	// clusterCtx is the context the caches of the member clusters are run in, it's cancelled when the operator stops
+	clusterCtx   context.Context
+	startCluster memberClusterStarter
+
+	mu sync.Mutex
+	// owners holds the name of the MongoDBMemberCluster resource each member cluster is registered by, the member
+	// clusters read from the KubeConfig file have no owner
+	owners map[string]string
+}
+
+func newMongoDBMemberClusterReconciler(ctx context.Context, kubeClient client.Client, memberClusters *multicluster.Registry, operatorNamespace string, startCluster memberClusterStarter) *MongoDBMemberClusterReconciler {
+	return &MongoDBMemberClusterReconciler{
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		memberClusters:            memberClusters,
+		operatorNamespace:         operatorNamespace,
+		clusterCtx:                ctx,
+		startCluster:              startCluster,
+		owners:                    map[string]string{},
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodbmemberclusters,mongodbmemberclusters/status,mongodbmemberclusters/finalizers},verbs=*
+
+// Reconciles a mongodbmemberclusters.mongodb.com Custom resource.
+func (r *MongoDBMemberClusterReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
+	log := zap.S().With("MongoDBMemberCluster", request.Name)
+	log.Info("-> MongoDBMemberCluster.Reconcile")
+
+	memberCluster := &memberclusterv1.MongoDBMemberCluster{}
+	if result, err := r.GetResource(ctx, request, memberCluster, log); err != nil {
+		return result, err
+	}
+	clusterName := memberCluster.GetClusterName()
+
+	if !memberCluster.DeletionTimestamp.IsZero() {
+		log.Info("MongoDBMemberCluster is being deleted")
+
+		if controllerutil.ContainsFinalizer(memberCluster, util.MemberClusterFinalizer) {
+			return r.unregister(ctx, memberCluster, log)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureFinalizer(ctx, memberCluster, log); err != nil {
+		return r.updateStatus(ctx, memberCluster, workflow.Failed(xerrors.Errorf("Failed to add finalizer: %w", err)), log)
+	}
+
+	if owner, registered := r.owner(clusterName); registered && owner != memberCluster.Name {
+		if owner == "" {
+			return r.updateStatus(ctx, memberCluster, workflow.Invalid("The member cluster %s is already configured in the KubeConfig of the operator", clusterName), log,
+				notRegisteredCondition("Conflict", "the member cluster is configured in the KubeConfig of the operator"))
+		}
+		return r.updateStatus(ctx, memberCluster, workflow.Invalid("The member cluster %s is already registered by the MongoDBMemberCluster %s", clusterName, owner), log,
+			notRegisteredCondition("Conflict", "the member cluster is registered by the MongoDBMemberCluster "+owner))
+	}
+
+	config, err := r.readClusterConfig(ctx, memberCluster)
+	if err != nil {
+		return r.updateStatus(ctx, memberCluster, workflow.Failed(err), log, notRegisteredCondition("InvalidCredentials", err.Error()))
+	}
+
+	if !sameClusterConfig(r.memberClusters.Config(clusterName), config) {
+		if err := r.register(memberCluster, config, log); err != nil {
+			return r.updateStatus(ctx, memberCluster, workflow.Failed(err), log, notRegisteredCondition("RegistrationFailed", err.Error()))
+		}
+	}
+
+	registered := metav1.Condition{Type: memberclusterv1.ConditionRegistered, Status: metav1.ConditionTrue, Reason: "Registered", Message: "The operator watches the resources of the member cluster"}
+	health, checked := r.memberClusters.Health(clusterName)
+	switch {
+	case !checked:
+		return r.updateStatus(ctx, memberCluster, workflow.Pending("Waiting for the first health check of the member cluster %s", clusterName).WithRetry(int(memberClusterHealthRefreshInterval.Seconds())), log,
+			memberclusterv1.NewConditionsOption(registered, metav1.Condition{Type: memberclusterv1.ConditionHealthy, Status: metav1.ConditionUnknown, Reason: "HealthCheckPending", Message: "The member cluster hasn't been health checked yet"}))
+	case !health.Healthy && len(health.Signals) == 0:
+		return r.updateStatus(ctx, memberCluster, workflow.Failed(xerrors.Errorf("The API server of the member cluster %s is not healthy", clusterName)).WithRetry(int(memberClusterHealthRefreshInterval.Seconds())), log,
+			memberclusterv1.NewHealthScoreOption(health.Score),
+			memberclusterv1.NewConditionsOption(registered, metav1.Condition{Type: memberclusterv1.ConditionHealthy, Status: metav1.ConditionFalse, Reason: "HealthCheckFailed", Message: "The API server of the member cluster is not ready"}))
+	case !health.Healthy:
+		return r.updateStatus(ctx, memberCluster, workflow.Failed(xerrors.Errorf("The health score %d of the member cluster %s is too low", health.Score, clusterName)).WithRetry(int(memberClusterHealthRefreshInterval.Seconds())), log,
+			memberclusterv1.NewHealthScoreOption(health.Score),
+			memberclusterv1.NewConditionsOption(registered, metav1.Condition{Type: memberclusterv1.ConditionHealthy, Status: metav1.ConditionFalse, Reason: "HealthScoreTooLow", Message: describeHealth(health)}))
+	}
+
+	log.Infof("Finished reconciliation for MongoDBMemberCluster!")
+	return r.updateStatus(ctx, memberCluster, workflow.OK().WithRequeueAfter(memberClusterHealthRefreshInterval), log,
+		memberclusterv1.NewHealthScoreOption(health.Score),
+		memberclusterv1.NewConditionsOption(registered, metav1.Condition{Type: memberclusterv1.ConditionHealthy, Status: metav1.ConditionTrue, Reason: "HealthCheckSucceeded", Message: describeHealth(health)}))
+}
+
+// describeHealth describes the score of each health signal of the member cluster, e.g.
+// "The API server of the member cluster is ready, health score 60: nodeReadiness 50, canaryPod 100,
+// statefulSetReadiness 0 (weight 0.5)".
+func describeHealth(health multicluster.ClusterHealth) string {
+	message := "The API server of the member cluster is ready"
+	if len(health.Signals) == 0 {
+		return message
+	}
+	var signals []string
+	for _, signal := range health.Signals {
+		description := fmt.Sprintf("%s %d", signal.Name, signal.Score)
+		if signal.Error != "" {
+			description = fmt.Sprintf("%s unavailable (%s)", signal.Name, signal.Error)
+		}
+		if signal.Weight != 1 {
+			description += fmt.Sprintf(" (weight %g)", signal.Weight)
+		}
+		signals = append(signals, description)
+	}
+	return fmt.Sprintf("%s, health score %d: %s", message, health.Score, strings.Join(signals, ", "))
+}
+
+func notRegisteredCondition(reason, message string) memberclusterv1.ConditionsOption {
+	return memberclusterv1.NewConditionsOption(metav1.Condition{Type: memberclusterv1.ConditionRegistered, Status: metav1.ConditionFalse, Reason: reason, Message: message})
+}
+
+// owner returns the name of the MongoDBMemberCluster the member cluster is registered by, registered is false if
+// the member cluster isn't in the Registry.
+func (r *MongoDBMemberClusterReconciler) owner(clusterName string) (owner string, registered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if owner, ok := r.owners[clusterName]; ok {
+		return owner, true
+	}
+	return "", r.memberClusters.Has(clusterName)
+}
+
+// readClusterConfig builds the configuration of the client of the member cluster from the token Secret, which is
+// watched for changes.
+func (r *MongoDBMemberClusterReconciler) readClusterConfig(ctx context.Context, memberCluster *memberclusterv1.MongoDBMemberCluster) (*rest.Config, error) {
+	secretKey := kube.ObjectKey(r.operatorNamespace, memberCluster.Spec.TokenSecretRef.Name)
+	// the previously referenced Secret is not watched anymore
+	r.resourceWatcher.RemoveDependentWatchedResources(types.NamespacedName{Name: memberCluster.Name})
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(secretKey.Name, secretKey.Namespace, watch.Secret, types.NamespacedName{Name: memberCluster.Name})
+
+	data, err := secret.ReadByteData(ctx, r.client, secretKey)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read the token Secret %s: %w", secretKey, err)
+	}
+	token := data[memberCluster.GetTokenKey()]
+	if len(token) == 0 {
+		return nil, xerrors.Errorf("the token Secret %s has no %s key", secretKey, memberCluster.GetTokenKey())
+	}
+	ca := []byte(memberCluster.Spec.CertificateAuthority)
+	if len(ca) == 0 {
+		ca = data[memberclusterv1.CAKey]
+	}
+	if len(ca) == 0 {
+		return nil, xerrors.Errorf("the CA of the API server is neither set in spec.certificateAuthority nor in the %s key of the token Secret %s", memberclusterv1.CAKey, secretKey)
+	}
+
+	return &rest.Config{
+		Host:            memberCluster.Spec.APIServer,
+		BearerToken:     string(token),
+		TLSClientConfig: rest.TLSClientConfig{CAData: ca},
+		Timeout:         time.Duration(env.ReadIntOrDefault(multicluster.ClusterClientTimeoutEnv, 10)) * time.Second, // nolint:forbidigo
+	}, nil
+}
+
+// sameClusterConfig returns true if the registered configuration connects to the API server with the same
+// credentials.
+func sameClusterConfig(registered, config *rest.Config) bool {
+	return registered != nil && registered.Host == config.Host && registered.BearerToken == config.BearerToken && bytes.Equal(registered.CAData, config.CAData)
+}
+
+// register starts the cluster object of the member cluster and adds it to the Registry, which makes the controllers
+// watch its resources. The cluster previously registered with other credentials is stopped.
+func (r *MongoDBMemberClusterReconciler) register(memberCluster *memberclusterv1.MongoDBMemberCluster, config *rest.Config, log *zap.SugaredLogger) error {
+	clusterName := memberCluster.GetClusterName()
+	log.Infof("Registering member cluster %s with API server %s", clusterName, config.Host)
+
+	runtimeCluster, stop, err := r.startCluster(r.clusterCtx, config)
+	if err != nil {
+		return xerrors.Errorf("failed to start the client of member cluster %s: %w", clusterName, err)
+	}
+
+	r.mu.Lock()
+	r.owners[clusterName] = memberCluster.Name
+	r.mu.Unlock()
+
+	if err := r.memberClusters.Add(clusterName, runtimeCluster, config, stop); err != nil {
+		return xerrors.Errorf("failed to watch the resources of member cluster %s: %w", clusterName, err)
+	}
+	return nil
+}
+
+// unregister removes the member cluster from the Registry, the resources deployed to it are left untouched.
+func (r *MongoDBMemberClusterReconciler) unregister(ctx context.Context, memberCluster *memberclusterv1.MongoDBMemberCluster, log *zap.SugaredLogger) (reconcile.Result, error) {
+	clusterName := memberCluster.GetClusterName()
+
+	r.mu.Lock()
+	if r.owners[clusterName] == memberCluster.Name {
+		delete(r.owners, clusterName)
+		r.memberClusters.Remove(clusterName)
+		log.Infof("Unregistered member cluster %s", clusterName)
+	}
+	r.mu.Unlock()
+
+	r.resourceWatcher.RemoveAllDependentWatchedResources(r.operatorNamespace, types.NamespacedName{Name: memberCluster.Name})
+
+	controllerutil.RemoveFinalizer(memberCluster, util.MemberClusterFinalizer)
+	if err := r.client.Update(ctx, memberCluster); err != nil {
+		return r.updateStatus(ctx, memberCluster, workflow.Failed(xerrors.Errorf("Failed to update the MongoDBMemberCluster with the removed finalizer: %w", err)), log)
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *MongoDBMemberClusterReconciler) ensureFinalizer(ctx context.Context, memberCluster *memberclusterv1.MongoDBMemberCluster, log *zap.SugaredLogger) error {
+	if finalizerAdded := controllerutil.AddFinalizer(memberCluster, util.MemberClusterFinalizer); finalizerAdded {
+		log.Info("Adding finalizer to the MongoDBMemberCluster resource")
+		if err := r.client.Update(ctx, memberCluster); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startMemberCluster creates the cluster object of the member cluster and runs its cache until the returned function
+// is called or the context is cancelled.
+func startMemberCluster(namespacesToWatch []string) memberClusterStarter {
+	return func(ctx context.Context, config *rest.Config) (cluster.Cluster, context.CancelFunc, error) {
+		runtimeCluster, err := multicluster.NewCluster(config, namespacesToWatch)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		clusterCtx, stop := context.WithCancel(ctx)
+		go func() {
+			if err := runtimeCluster.Start(clusterCtx); err != nil {
+				zap.S().Errorf("The cache of member cluster %s stopped: %s", config.Host, err)
+			}
+		}()
+
+		syncCtx, cancel := context.WithTimeout(clusterCtx, memberClusterCacheSyncTimeout)
+		defer cancel()
+		if !runtimeCluster.GetCache().WaitForCacheSync(syncCtx) {
+			stop()
+			return nil, nil, xerrors.Errorf("the cache didn't sync in %s", memberClusterCacheSyncTimeout)
+		}
+		return runtimeCluster, stop, nil
+	}
+}
+
+// AddMongoDBMemberClusterController registers the controller of the MongoDBMemberCluster resources, which adds the
+// member clusters to and removes them from the Registry.
+func AddMongoDBMemberClusterController(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry) error {
+	r := newMongoDBMemberClusterReconciler(ctx, mgr.GetClient(), memberClusters, env.ReadOrPanic(util.CurrentNamespace), startMemberCluster(GetWatchedNamespace())) // nolint:forbidigo
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named(util.MongoDbMemberClusterController).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&memberclusterv1.MongoDBMemberCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.resourceWatcher}).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Registered controller %s", util.MongoDbMemberClusterController)
+	return nil
+}
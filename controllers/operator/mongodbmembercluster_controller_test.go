@@ -0,0 +1,208 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	memberclusterv1 "github.com/mongodb/mongodb-kubernetes/api/v1/membercluster"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newTestMemberCluster() *memberclusterv1.MongoDBMemberCluster {
+	return &memberclusterv1.MongoDBMemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "us-east",
+			Labels: map[string]string{corev1.LabelTopologyRegion: "us-east-1", corev1.LabelTopologyZone: "us-east-1a"},
+		},
+		Spec: memberclusterv1.MongoDBMemberClusterSpec{
+			APIServer:      "https://us-east.example.com",
+			TokenSecretRef: memberclusterv1.TokenSecretRef{Name: "us-east-token"},
+		},
+	}
+}
+
+func newTestMemberClusterTokenSecret(token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "us-east-token", Namespace: mock.TestNamespace},
+		Data: map[string][]byte{
+			memberclusterv1.DefaultTokenKey: []byte(token),
+			memberclusterv1.CAKey:           []byte("ca"),
+		},
+	}
+}
+
+// fakeMemberClusterStarter returns member clusters backed by fake clients and counts the started and stopped ones.
+type fakeMemberClusterStarter struct {
+	started int
+	stopped int
+}
+
+func (f *fakeMemberClusterStarter) start(_ context.Context, _ *rest.Config) (cluster.Cluster, context.CancelFunc, error) {
+	f.started++
+	return multicluster.New(fake.NewFakeClient()), func() { f.stopped++ }, nil
+}
+
+func reconcileMemberCluster(ctx context.Context, t *testing.T, reconciler *MongoDBMemberClusterReconciler, kubeClient client.Client, memberCluster *memberclusterv1.MongoDBMemberCluster) reconcile.Result {
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey("", memberCluster.Name)})
+	require.NoError(t, err)
+	require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey("", memberCluster.Name), memberCluster))
+	return result
+}
+
+func TestMemberClusterIsRegistered(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewRegistry()
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	result := reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.True(t, registry.Has("us-east"))
+	assert.Equal(t, "https://us-east.example.com", registry.Config("us-east").Host)
+	assert.Equal(t, "token", registry.Config("us-east").BearerToken)
+	assert.Contains(t, memberCluster.Finalizers, util.MemberClusterFinalizer)
+
+	// the member cluster hasn't been health checked yet
+	assert.Equal(t, status.PhasePending, memberCluster.Status.Phase)
+	assert.Equal(t, memberClusterHealthRefreshInterval, result.RequeueAfter)
+	assert.Equal(t, metav1.ConditionTrue, memberCluster.GetCondition(memberclusterv1.ConditionRegistered).Status)
+	assert.Equal(t, metav1.ConditionUnknown, memberCluster.GetCondition(memberclusterv1.ConditionHealthy).Status)
+
+	registry.SetHealthy("us-east", true)
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, status.PhaseRunning, memberCluster.Status.Phase)
+	assert.Equal(t, metav1.ConditionTrue, memberCluster.GetCondition(memberclusterv1.ConditionHealthy).Status)
+	// the cluster is not restarted if the credentials didn't change
+	assert.Equal(t, 1, starter.started)
+}
+
+func TestMemberClusterHealthIsReflectedInCondition(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewRegistry()
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+	registry.SetHealthy("us-east", false)
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, status.PhaseFailed, memberCluster.Status.Phase)
+	healthy := memberCluster.GetCondition(memberclusterv1.ConditionHealthy)
+	assert.Equal(t, metav1.ConditionFalse, healthy.Status)
+	assert.Equal(t, "HealthCheckFailed", healthy.Reason)
+	// the member cluster stays registered, so it is used again once it is healthy
+	assert.True(t, registry.Has("us-east"))
+}
+
+func TestMemberClusterHealthScoreIsReflectedInStatus(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewRegistry()
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+	registry.SetHealth("us-east", multicluster.ClusterHealth{Healthy: false, Score: 25, Signals: []multicluster.SignalScore{
+		{Name: "nodeReadiness", Score: 25, Weight: 1},
+		{Name: "agentHeartbeats", Error: "there are no agents in the member cluster", Weight: 0.5},
+	}})
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, status.PhaseFailed, memberCluster.Status.Phase)
+	require.NotNil(t, memberCluster.Status.HealthScore)
+	assert.Equal(t, 25, *memberCluster.Status.HealthScore)
+	healthy := memberCluster.GetCondition(memberclusterv1.ConditionHealthy)
+	assert.Equal(t, "HealthScoreTooLow", healthy.Reason)
+	assert.Equal(t, "The API server of the member cluster is ready, health score 25: nodeReadiness 25, agentHeartbeats unavailable (there are no agents in the member cluster) (weight 0.5)", healthy.Message)
+}
+
+func TestMemberClusterIsReRegistered_WhenTokenChanges(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewRegistry()
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+	require.NoError(t, kubeClient.Update(ctx, newTestMemberClusterTokenSecret("rotated-token")))
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, 2, starter.started)
+	assert.Equal(t, 1, starter.stopped)
+	assert.Equal(t, "rotated-token", registry.Config("us-east").BearerToken)
+}
+
+func TestMemberClusterFails_WhenTokenSecretIsMissing(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster)
+	registry := multicluster.NewRegistry()
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, (&fakeMemberClusterStarter{}).start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, status.PhaseFailed, memberCluster.Status.Phase)
+	registered := memberCluster.GetCondition(memberclusterv1.ConditionRegistered)
+	assert.Equal(t, metav1.ConditionFalse, registered.Status)
+	assert.Equal(t, "InvalidCredentials", registered.Reason)
+	assert.False(t, registry.Has("us-east"))
+}
+
+func TestMemberClusterIsInvalid_WhenClusterIsInKubeConfig(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewClientRegistry(map[string]client.Client{"us-east": fake.NewFakeClient()})
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+
+	assert.Equal(t, status.PhaseFailed, memberCluster.Status.Phase)
+	assert.Equal(t, "Conflict", memberCluster.GetCondition(memberclusterv1.ConditionRegistered).Reason)
+	assert.Equal(t, 0, starter.started)
+}
+
+func TestMemberClusterIsUnregistered_OnDeletion(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := newTestMemberCluster()
+	kubeClient, _ := mock.NewDefaultFakeClient(memberCluster, newTestMemberClusterTokenSecret("token"))
+	registry := multicluster.NewRegistry()
+	starter := &fakeMemberClusterStarter{}
+	reconciler := newMongoDBMemberClusterReconciler(ctx, kubeClient, registry, mock.TestNamespace, starter.start)
+
+	reconcileMemberCluster(ctx, t, reconciler, kubeClient, memberCluster)
+	require.True(t, registry.Has("us-east"))
+
+	require.NoError(t, kubeClient.Delete(ctx, memberCluster))
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: kube.ObjectKey("", memberCluster.Name)})
+	require.NoError(t, err)
+
+	assert.False(t, registry.Has("us-east"))
+	assert.Equal(t, 1, starter.stopped)
+	err = kubeClient.Get(ctx, kube.ObjectKey("", memberCluster.Name), memberCluster)
+	assert.True(t, apiErrors.IsNotFound(err))
+}
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -34,6 +36,7 @@ import (
 	mdbmultiv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
 	rolev1 "github.com/mongodb/mongodb-kubernetes/api/v1/role"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
@@ -45,11 +48,12 @@ import (
 	mconstruct "github.com/mongodb/mongodb-kubernetes/controllers/operator/construct/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	enterprisepem "github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/recovery"
-	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
@@ -66,6 +70,7 @@ import (
 	mekoService "github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/memberwatch"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/tls"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
@@ -77,11 +82,10 @@ import (
 // ReconcileMongoDbMultiReplicaSet reconciles a MongoDB ReplicaSet across multiple Kubernetes clusters
 type ReconcileMongoDbMultiReplicaSet struct {
 	*ReconcileCommonController
-	omConnectionFactory           om.ConnectionFactory
-	memberClusterClientsMap       map[string]kubernetesClient.Client // holds the client for each of the memberclusters(where the MongoDB ReplicaSet is deployed)
-	memberClusterSecretClientsMap map[string]secrets.SecretClient
-	forceEnterprise               bool
-	enableClusterMongoDBRoles     bool
+	omConnectionFactory       om.ConnectionFactory
+	memberClusters            *multicluster.Registry // holds the member clusters where the MongoDB ReplicaSet is deployed
+	forceEnterprise           bool
+	enableClusterMongoDBRoles bool
 
 	imageUrls                         images.ImageUrls
 	initDatabaseNonStaticImageVersion string
@@ -90,24 +94,11 @@ type ReconcileMongoDbMultiReplicaSet struct {
 
 var _ reconcile.Reconciler = &ReconcileMongoDbMultiReplicaSet{}
 
-func newMultiClusterReplicaSetReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, omFunc om.ConnectionFactory, memberClustersMap map[string]client.Client) *ReconcileMongoDbMultiReplicaSet {
-	clientsMap := make(map[string]kubernetesClient.Client)
-	secretClientsMap := make(map[string]secrets.SecretClient)
-
-	// extract client from each cluster object.
-	for k, v := range memberClustersMap {
-		clientsMap[k] = kubernetesClient.NewClient(v)
-		secretClientsMap[k] = secrets.SecretClient{
-			VaultClient: nil, // Vault is not supported yet on multicluster
-			KubeClient:  clientsMap[k],
-		}
-	}
-
+func newMultiClusterReplicaSetReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, omFunc om.ConnectionFactory, memberClusters *multicluster.Registry) *ReconcileMongoDbMultiReplicaSet {
 	return &ReconcileMongoDbMultiReplicaSet{
 		ReconcileCommonController:         NewReconcileCommonController(ctx, kubeClient),
 		omConnectionFactory:               omFunc,
-		memberClusterClientsMap:           clientsMap,
-		memberClusterSecretClientsMap:     secretClientsMap,
+		memberClusters:                    memberClusters,
 		forceEnterprise:                   forceEnterprise,
 		imageUrls:                         imageUrls,
 		initDatabaseNonStaticImageVersion: initDatabaseNonStaticImageVersion,
@@ -135,7 +126,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 		return reconcileResult, err
 	}
 
-	if !architectures.IsRunningStaticArchitecture(mrs.Annotations) {
+	if !architectures.IsRunningStaticArchitecture(mrs.Annotations) && !plan.IsEnabled(&mrs) {
 		agents.UpgradeAllIfNeeded(ctx, agents.ClientSecret{Client: r.client, SecretClient: r.SecretClient}, r.omConnectionFactory, GetWatchedNamespace(), true)
 	}
 
@@ -143,12 +134,16 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 		return r.updateStatus(ctx, &mrs, workflow.Invalid("%s", err.Error()), log)
 	}
 
+	if err := r.validateReferences(ctx, &mrs, r.enableClusterMongoDBRoles, log); err != nil {
+		return r.updateStatus(ctx, &mrs, workflow.Invalid("%s", err.Error()), log)
+	}
+
 	projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, r.client, r.SecretClient, &mrs, log)
 	if err != nil {
 		return r.updateStatus(ctx, &mrs, workflow.Failed(xerrors.Errorf("Error reading project config and credentials: %w", err)), log)
 	}
 
-	conn, _, err := connection.PrepareOpsManagerConnection(ctx, r.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, mrs.Namespace, log)
+	conn, _, err := prepareOpsManagerConnection(ctx, r.SecretClient, &mrs, projectConfig, credsConfig, r.omConnectionFactory, log)
 	if err != nil {
 		return r.updateStatus(ctx, &mrs, workflow.Failed(xerrors.Errorf("error establishing connection to Ops Manager: %w", err)), log)
 	}
@@ -160,12 +155,18 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 	if err != nil {
 		return r.updateStatus(ctx, &mrs, workflow.Failed(err), log)
 	}
-	if len(failedClusterNames) > 0 && !multicluster.ShouldPerformFailover() {
+	if len(failedClusterNames) > 0 && (!multicluster.ShouldPerformFailover() || mrs.GetFailoverStrategy() == mdbmultiv1.FailoverStrategyFreeze) {
 		return r.updateStatus(ctx, &mrs, workflow.Failed(xerrors.Errorf("resource has failed clusters in the annotation: %+v", failedClusterNames)), log)
 	}
+	if len(failedClusterNames) == 0 {
+		// the failed clusters were recovered
+		mrs.Status.Failover = nil
+	}
 
 	r.SetupCommonWatchers(&mrs, nil, nil, mrs.Name)
 
+	searchMongodConfigs := r.applySearchOverrides(ctx, &mrs, log)
+
 	// If tls is enabled we need to configure the "processes" array in opsManager/Cloud Manager with the
 	// correct tlsCertPath, with the new tls design, this path has the certHash in it(so that cert can be rotated
 	// without pod restart).
@@ -190,12 +191,20 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 	agentCertSecretName := mrs.GetSecurity().AgentClientCertificateSecretName(mrs.GetName())
 	agentCertHash, agentCertPath := r.agentCertHashAndPath(ctx, log, mrs.Namespace, agentCertSecretName, "")
 
+	// The changes are only planned before any of them is applied to Ops Manager or the member clusters
+	if plan.IsEnabled(&mrs) {
+		return r.updateStatus(ctx, &mrs, r.planOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, searchMongodConfigs, log), log)
+	}
+	if err := plan.Remove(ctx, r.client, &mrs); err != nil {
+		return r.updateStatus(ctx, &mrs, workflow.Failed(err), log)
+	}
+
 	// Recovery prevents some deadlocks that can occur during reconciliation, e.g. the setting of an incorrect automation
 	// configuration and a subsequent attempt to overwrite it later, the operator would be stuck in Pending phase.
 	// See CLOUDP-189433 and CLOUDP-229222 for more details.
 	if recovery.ShouldTriggerRecovery(mrs.Status.Phase != mdbstatus.PhaseRunning, mrs.Status.LastTransition) {
 		log.Warnf("Triggering Automatic Recovery. The MongoDB resource %s/%s is in %s state since %s", mrs.Namespace, mrs.Name, mrs.Status.Phase, mrs.Status.LastTransition)
-		automationConfigError := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, true, log)
+		automationConfigError := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, searchMongodConfigs, true, log)
 		reconcileStatus := r.reconcileMemberResources(ctx, &mrs, log, conn, projectConfig, agentCertHash)
 		if !reconcileStatus.IsOK() {
 			log.Errorf("Recovery failed because of reconcile errors, %v", reconcileStatus)
@@ -212,7 +221,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) Reconcile(ctx context.Context, request
 
 	status := workflow.RunInGivenOrder(publishAutomationConfigFirst,
 		func() workflow.Status {
-			if err := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, false, log); err != nil {
+			if err := r.updateOmDeploymentRs(ctx, conn, mrs, agentCertPath, tlsCertPath, internalClusterCertPath, searchMongodConfigs, false, log); err != nil {
 				return workflow.Failed(err)
 			}
 			return workflow.OK()
@@ -336,7 +345,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) firstStatefulSet(ctx context.Context,
 	var firstMemberIdx int
 	foundOne := false
 	for idx, item := range items {
-		client, ok := r.memberClusterClientsMap[item.ClusterName]
+		client, ok := r.memberClusters.KubeClient(item.ClusterName)
 		if ok {
 			firstMemberClient = client
 			firstMemberIdx = idx
@@ -368,6 +377,10 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileMemberResources(ctx context.C
 		return workflow.Failed(err)
 	}
 
+	if status := r.reconcileExternalAddresses(ctx, mrs, log); !status.IsOK() {
+		return status
+	}
+
 	// create configmap with the hostname-override
 	err = r.reconcileHostnameOverrideConfigMap(ctx, log, *mrs)
 	if err != nil {
@@ -414,12 +427,12 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 			continue
 		}
 
-		memberClient, ok := r.memberClusterClientsMap[item.ClusterName]
+		memberClient, ok := r.memberClusters.KubeClient(item.ClusterName)
 		if !ok {
 			log.Warnf(fmt.Sprintf("failed to reconcile statefulset: cluster %s missing from client map", item.ClusterName))
 			continue
 		}
-		secretMemberClient := r.memberClusterSecretClientsMap[item.ClusterName]
+		secretMemberClient := r.memberClusters.SecretClients()[item.ClusterName]
 		replicasThisReconciliation, err := getMembersForClusterSpecItemThisReconciliation(mrs, item)
 		clusterNum := mrs.ClusterNum(item.ClusterName)
 		if err != nil {
@@ -541,7 +554,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileStatefulSets(ctx context.Cont
 			continue
 		}
 
-		workflowStatus := create.HandlePVCResize(ctx, memberClient, &sts, log)
+		workflowStatus := pvcresize.HandlePVCResize(ctx, memberClient, &sts, log)
 		if !workflowStatus.IsOK() {
 			return workflowStatus
 		}
@@ -691,8 +704,9 @@ func (r *ReconcileMongoDbMultiReplicaSet) saveLastAchievedSpec(ctx context.Conte
 }
 
 // updateOmDeploymentRs performs OM registration operation for the replicaset. So the changes will be finally propagated
-// to automation agents in containers
-func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Context, conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, agentCertPath, tlsCertPath, internalClusterCertPath string, isRecovering bool, log *zap.SugaredLogger) error {
+// to automation agents in containers. clusterMongodConfigs overrides the additional mongod configuration of the
+// processes in the given member clusters.
+func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Context, conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, agentCertPath, tlsCertPath, internalClusterCertPath string, clusterMongodConfigs map[string]*mdb.AdditionalMongodConfig, isRecovering bool, log *zap.SugaredLogger) error {
 	reachableHostnames := make([]string, 0)
 
 	clusterSpecList, err := mrs.GetClusterSpecItems()
@@ -706,7 +720,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Conte
 		log.Errorf("failed retrieving list of failed clusters: %s", err.Error())
 	}
 	for _, spec := range clusterSpecList {
-		hostnamesToAdd := dns.GetMultiClusterProcessHostnames(mrs.Name, mrs.Namespace, mrs.ClusterNum(spec.ClusterName), spec.Members, mrs.Spec.GetClusterDomain(), mrs.Spec.GetExternalDomainForMemberCluster(spec.ClusterName))
+		hostnamesToAdd := mrs.GetProcessHostnames(spec.ClusterName, spec.Members)
 		if stringutil.Contains(failedClusterNames, spec.ClusterName) {
 			log.Debugf("Skipping hostnames %+v as they are part of the failed cluster %s ", hostnamesToAdd, spec.ClusterName)
 			continue
@@ -723,33 +737,11 @@ func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Conte
 		return err
 	}
 
-	existingDeployment, err := conn.ReadDeployment()
+	rs, err := r.buildOmReplicaSet(conn, mrs, clusterSpecList, tlsCertPath, clusterMongodConfigs, isRecovering, log)
 	if err != nil {
 		return err
 	}
 
-	processIds := getReplicaSetProcessIdsFromReplicaSets(mrs.Name, existingDeployment)
-
-	// If there is no replicaset configuration saved in OM, it might be a new project, so we check the ids saved in annotation
-	// A project migration can happen if .spec.opsManager.configMapRef is changed, or the original configMap has been modified.
-	if len(processIds) == 0 {
-		processIds, err = getReplicaSetProcessIdsFromAnnotation(mrs)
-		if err != nil {
-			return xerrors.Errorf("failed to get member ids from annotation: %w", err)
-		}
-	}
-	log.Debugf("Existing process Ids: %+v", processIds)
-
-	processes, err := process.CreateMongodProcessesWithLimitMulti(r.imageUrls[mcoConstruct.MongodbImageEnv], r.forceEnterprise, mrs, tlsCertPath)
-	if err != nil && !isRecovering {
-		return err
-	}
-
-	if len(processes) != len(mrs.Spec.GetMemberOptions()) {
-		log.Warnf("the number of member options is different than the number of mongod processes to be created: %d processes - %d replica set member options", len(processes), len(mrs.Spec.GetMemberOptions()))
-	}
-	rs := om.NewMultiClusterReplicaSetWithProcesses(om.NewReplicaSet(mrs.Name, mrs.Spec.Version), processes, mrs.Spec.GetMemberOptions(), processIds, mrs.Spec.Connectivity)
-
 	caFilePath := fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
 
 	status, additionalReconciliationRequired := r.updateOmAuthentication(ctx, conn, rs.GetProcessNames(), &mrs, agentCertPath, caFilePath, internalClusterCertPath, isRecovering, log)
@@ -795,6 +787,85 @@ func (r *ReconcileMongoDbMultiReplicaSet) updateOmDeploymentRs(ctx context.Conte
 	return nil
 }
 
+// planOmDeploymentRs publishes the changes updateOmDeploymentRs and the roles reconciliation would make to the
+// automation config, the agent configs and the agents log rotation without applying them. The plan shows the deployment
+// with the members of the current reconciliation, so while the clusters are scaled one member at a time only the next
+// step is included. If the authentication can only be enabled in several steps, the plan includes the first one.
+func (r *ReconcileMongoDbMultiReplicaSet) planOmDeploymentRs(ctx context.Context, conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, agentCertPath, tlsCertPath, internalClusterCertPath string, clusterMongodConfigs map[string]*mdb.AdditionalMongodConfig, log *zap.SugaredLogger) workflow.Status {
+	clusterSpecList, err := mrs.GetClusterSpecItems()
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	caFilePath := fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
+
+	return plan.Run(ctx, r.client, conn, &mrs, func(conn om.Connection) workflow.Status {
+		if status := r.ensureRoles(ctx, mrs.Spec.DbCommonSpec, r.enableClusterMongoDBRoles, conn, kube.ObjectKeyFromApiObject(&mrs), log); !status.IsOK() {
+			return status
+		}
+		rs, err := r.buildOmReplicaSet(conn, mrs, clusterSpecList, tlsCertPath, clusterMongodConfigs, false, log)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		if status, _ := r.updateOmAuthentication(ctx, conn, rs.GetProcessNames(), &mrs, agentCertPath, caFilePath, internalClusterCertPath, false, log); !status.IsOK() {
+			return status
+		}
+		err = conn.ReadUpdateDeployment(func(d om.Deployment) error {
+			return ReconcileReplicaSetAC(ctx, d, mrs.Spec.DbCommonSpec, mrs.GetLastAdditionalMongodConfig(), mrs.Name, rs, caFilePath, internalClusterCertPath, nil, log)
+		}, log)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		status, _ := ReconcileLogRotateSetting(conn, mrs.Spec.Agent, log)
+		return status
+	})
+}
+
+// buildOmReplicaSet returns the replica set and its processes the way they are merged into the Ops Manager deployment.
+// The ids of the existing members are kept.
+func (r *ReconcileMongoDbMultiReplicaSet) buildOmReplicaSet(conn om.Connection, mrs mdbmultiv1.MongoDBMultiCluster, clusterSpecList mdb.ClusterSpecList, tlsCertPath string, clusterMongodConfigs map[string]*mdb.AdditionalMongodConfig, isRecovering bool, log *zap.SugaredLogger) (om.ReplicaSetWithProcesses, error) {
+	existingDeployment, err := conn.ReadDeployment()
+	if err != nil {
+		return om.ReplicaSetWithProcesses{}, err
+	}
+
+	processIds := getReplicaSetProcessIdsFromReplicaSets(mrs.Name, existingDeployment)
+
+	// If there is no replicaset configuration saved in OM, it might be a new project, so we check the ids saved in annotation
+	// A project migration can happen if .spec.opsManager.configMapRef is changed, or the original configMap has been modified.
+	if len(processIds) == 0 {
+		processIds, err = getReplicaSetProcessIdsFromAnnotation(mrs)
+		if err != nil {
+			return om.ReplicaSetWithProcesses{}, xerrors.Errorf("failed to get member ids from annotation: %w", err)
+		}
+	}
+	log.Debugf("Existing process Ids: %+v", processIds)
+
+	processes, err := process.CreateMongodProcessesWithLimitMulti(r.imageUrls[mcoConstruct.MongodbImageEnv], r.forceEnterprise, mrs, tlsCertPath, clusterMongodConfigs)
+	if err != nil && !isRecovering {
+		return om.ReplicaSetWithProcesses{}, err
+	}
+
+	if len(processes) != len(mrs.Spec.GetMemberOptions()) {
+		log.Warnf("the number of member options is different than the number of mongod processes to be created: %d processes - %d replica set member options", len(processes), len(mrs.Spec.GetMemberOptions()))
+	}
+	connectivity := mrs.Spec.Connectivity
+	if mrs.Spec.Connectivity.HasReplicaSetHorizonTemplates() {
+		horizons, ok := mrs.GetReplicaSetHorizons(clusterSpecList)
+		if !ok && !isRecovering {
+			return om.ReplicaSetWithProcesses{}, xerrors.Errorf("the addresses of the external services of all the members have to be discovered before the replica set horizon templates are expanded")
+		}
+		connectivity = &mdb.MongoDBConnectivity{ReplicaSetHorizons: horizons}
+	} else if mrs.IsExternalAddressDiscoveryEnabled() && mrs.GetExternalAddressDiscoveryServiceType() == corev1.ServiceTypeNodePort {
+		horizons, ok := mrs.GetExternalAddressHorizons(clusterSpecList)
+		if !ok && !isRecovering {
+			return om.ReplicaSetWithProcesses{}, xerrors.Errorf("the addresses of the external services of all the members have to be discovered before the replica set horizons are configured")
+		}
+		connectivity = &mdb.MongoDBConnectivity{ReplicaSetHorizons: horizons}
+	}
+	rs := om.NewMultiClusterReplicaSetWithProcesses(om.NewReplicaSet(mrs.Name, mrs.Spec.Version), processes, mrs.Spec.GetMemberOptions(), processIds, connectivity)
+	return rs, nil
+}
+
 func getReplicaSetProcessIdsFromReplicaSets(replicaSetName string, deployment om.Deployment) map[string]int {
 	processIds := map[string]int{}
 
@@ -862,6 +933,14 @@ func getExternalService(mrs *mdbmultiv1.MongoDBMultiCluster, clusterName string,
 		svc.Annotations = merge.StringToStringMap(svc.Annotations, additionalAnnotations)
 	}
 
+	if mrs.IsExternalAddressDiscoveryEnabled() {
+		svc.Spec.Type = mrs.GetExternalAddressDiscoveryServiceType()
+		if externalDomain := mrs.Spec.GetExternalDomainForMemberCluster(clusterName); mrs.ShouldPublishExternalDNS() && externalDomain != nil {
+			externalDNSHostname := dns.GetMultiClusterPodServiceFQDN(mrs.Name, mrs.Namespace, clusterNum, externalDomain, podNum, mrs.Spec.GetClusterDomain())
+			svc.Annotations = merge.StringToStringMap(map[string]string{mdbmultiv1.ExternalDNSHostnameAnnotation: externalDNSHostname}, svc.Annotations)
+		}
+	}
+
 	return svc
 }
 
@@ -912,7 +991,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileServices(ctx context.Context,
 			continue
 		}
 
-		client, ok := r.memberClusterClientsMap[e.ClusterName]
+		client, ok := r.memberClusters.KubeClient(e.ClusterName)
 		if !ok {
 			log.Warnf(fmt.Sprintf("cluster %s missing from client map", e.ClusterName))
 			continue
@@ -941,7 +1020,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileServices(ctx context.Context,
 
 	// by default, we would create the duplicate services
 	shouldCreateDuplicates := mrs.Spec.DuplicateServiceObjects == nil || *mrs.Spec.DuplicateServiceObjects
-	for memberClusterName, memberClusterClient := range r.memberClusterClientsMap {
+	for memberClusterName, memberClusterClient := range r.memberClusters.KubeClients() {
 		if stringutil.Contains(failedClusterNames, memberClusterName) {
 			log.Warnf(fmt.Sprintf("cluster %s is marked as failed, skipping creation of services", memberClusterName))
 			continue
@@ -962,6 +1041,156 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileServices(ctx context.Context,
 	return nil
 }
 
+// reconcileExternalAddresses discovers the addresses assigned to the external Services of the members and stores them
+// in the status, where they are read from when the process hostnames or the replica set horizons are configured.
+// The addresses of the members in failed or unreachable clusters are kept as they were last discovered, and once
+// discovered, the load balancer address of a member isn't changed as it's the hostname of its process. The NodePort
+// addresses are only added to the replica set horizons, so they follow the nodes of the member cluster.
+func (r *ReconcileMongoDbMultiReplicaSet) reconcileExternalAddresses(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, log *zap.SugaredLogger) workflow.Status {
+	if !mrs.IsExternalAddressDiscoveryEnabled() {
+		mrs.Status.ExternalAddresses = nil
+		return workflow.OK()
+	}
+
+	clusterSpecList, err := mrs.GetClusterSpecItems()
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	failedClusterNames, err := mrs.GetFailedClusterNames()
+	if err != nil {
+		log.Errorf("failed retrieving list of failed clusters: %s", err.Error())
+	}
+
+	serviceType := mrs.GetExternalAddressDiscoveryServiceType()
+	addresses := map[string]mdbmultiv1.ExternalAddress{}
+	var pendingServices []string
+	for _, item := range clusterSpecList {
+		clusterNum := mrs.ClusterNum(item.ClusterName)
+		memberClient, ok := r.memberClusters.KubeClient(item.ClusterName)
+		if !ok || stringutil.Contains(failedClusterNames, item.ClusterName) {
+			for podNum := 0; podNum < item.Members; podNum++ {
+				podName := dns.GetMultiPodName(mrs.Name, clusterNum, podNum)
+				if address, ok := mrs.Status.ExternalAddresses[podName]; ok {
+					addresses[podName] = address
+				}
+			}
+			continue
+		}
+
+		nodeAddress := ""
+		if serviceType == corev1.ServiceTypeNodePort && item.Members > 0 {
+			nodeAddress, err = getNodeAddress(ctx, memberClient)
+			if err != nil {
+				return workflow.Failed(xerrors.Errorf("failed to get the address of the nodes of cluster %s: %w", item.ClusterName, err))
+			}
+		}
+
+		for podNum := 0; podNum < item.Members; podNum++ {
+			podName := dns.GetMultiPodName(mrs.Name, clusterNum, podNum)
+			svcName := dns.GetMultiExternalServiceName(mrs.Name, clusterNum, podNum)
+			svc, err := memberClient.GetService(ctx, kube.ObjectKey(mrs.Namespace, svcName))
+			if err != nil && !apiErrors.IsNotFound(err) {
+				return workflow.Failed(xerrors.Errorf("failed to get external service %s in cluster %s: %w", svcName, item.ClusterName, err))
+			}
+			address, ok := getExternalServiceAddress(svc, serviceType, nodeAddress)
+
+			// the load balancer address is the hostname of the process, which can't be changed once the process is created
+			if existing, known := mrs.Status.ExternalAddresses[podName]; serviceType == corev1.ServiceTypeLoadBalancer && known && existing.Host != "" {
+				if ok && address != existing {
+					log.Warnf("The address of external service %s changed from %s to %s, the member %s keeps the hostname %s", svcName, existing.Host, address.Host, podName, existing.Host)
+				}
+				addresses[podName] = existing
+				continue
+			}
+
+			if !ok {
+				pendingServices = append(pendingServices, svcName)
+				continue
+			}
+			addresses[podName] = address
+		}
+	}
+
+	mrs.Status.ExternalAddresses = addresses
+	if len(pendingServices) > 0 {
+		return workflow.Pending("Waiting for the addresses of the external services %v to be assigned", pendingServices)
+	}
+	log.Debugf("Discovered the addresses of the external services: %+v", addresses)
+	return workflow.OK()
+}
+
+// getExternalServiceAddress returns the address a member is reachable on through its external Service: the address of
+// the load balancer, or the node address with the node port of the Service.
+func getExternalServiceAddress(svc corev1.Service, serviceType corev1.ServiceType, nodeAddress string) (mdbmultiv1.ExternalAddress, bool) {
+	var mongodbPort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Name == "mongodb" {
+			mongodbPort = &svc.Spec.Ports[i]
+		}
+	}
+	if mongodbPort == nil {
+		return mdbmultiv1.ExternalAddress{}, false
+	}
+
+	if serviceType == corev1.ServiceTypeNodePort {
+		if nodeAddress == "" || mongodbPort.NodePort == 0 {
+			return mdbmultiv1.ExternalAddress{}, false
+		}
+		return mdbmultiv1.ExternalAddress{Host: nodeAddress, Port: mongodbPort.NodePort}, true
+	}
+
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return mdbmultiv1.ExternalAddress{Host: ingress.Hostname, Port: mongodbPort.Port}, true
+		}
+		if ingress.IP != "" {
+			return mdbmultiv1.ExternalAddress{Host: ingress.IP, Port: mongodbPort.Port}, true
+		}
+	}
+	return mdbmultiv1.ExternalAddress{}, false
+}
+
+// getNodeAddress returns the address the NodePort Services of a member cluster are reached on. NodePort Services are
+// exposed on every node, so the external IP of the first ready node is used, or its internal IP if no ready node has
+// an external IP.
+func getNodeAddress(ctx context.Context, c client.Client) (string, error) {
+	nodes := corev1.NodeList{}
+	if err := c.List(ctx, &nodes); err != nil {
+		return "", err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+
+	internalIP := ""
+	for _, node := range nodes.Items {
+		if !isNodeReady(node) {
+			continue
+		}
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeExternalIP && address.Address != "" {
+				return address.Address, nil
+			}
+			if address.Type == corev1.NodeInternalIP && internalIP == "" {
+				internalIP = address.Address
+			}
+		}
+	}
+	if internalIP == "" {
+		return "", xerrors.Errorf("no ready node with an address was found")
+	}
+	return internalIP, nil
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func ensureSRVService(ctx context.Context, client service.GetUpdateCreator, svc corev1.Service, clusterName string) error {
 	err := mekoService.CreateOrUpdateService(ctx, client, svc)
 	if err != nil && !apiErrors.IsAlreadyExists(err) {
@@ -971,7 +1200,8 @@ func ensureSRVService(ctx context.Context, client service.GetUpdateCreator, svc
 }
 
 // ensureServices creates pod services and/or external services.
-// If externalAccess is defined (at spec or clusterSpecItem level) then we always create an external service.
+// If externalAccess is defined (at spec or clusterSpecItem level) then we always create an external service, except for
+// the duplicates of the services of other clusters when the external addresses are discovered.
 // If externalDomain is defined then we DO NOT create pod services (service created for each pod selecting only 1 pod).
 // When there are external domains used, we don't use internal pod-service FQDNs as hostnames at all,
 // so there is no point in creating pod services.
@@ -980,7 +1210,10 @@ func ensureSRVService(ctx context.Context, client service.GetUpdateCreator, svc
 func ensureServices(ctx context.Context, client service.GetUpdateCreator, clientClusterName string, m *mdbmultiv1.MongoDBMultiCluster, clusterSpecItem mdb.ClusterSpecItem, log *zap.SugaredLogger) error {
 	for podNum := 0; podNum < clusterSpecItem.Members; podNum++ {
 		var svc corev1.Service
-		if m.Spec.GetExternalAccessConfigurationForMemberCluster(clusterSpecItem.ClusterName) != nil {
+		// the discovered addresses are only read from the external services of the cluster the member is deployed
+		// in, so no load balancers are allocated for the duplicates
+		isDiscoveredDuplicate := m.IsExternalAddressDiscoveryEnabled() && clientClusterName != clusterSpecItem.ClusterName
+		if m.Spec.GetExternalAccessConfigurationForMemberCluster(clusterSpecItem.ClusterName) != nil && !isDiscoveredDuplicate {
 			svc = getExternalService(m, clusterSpecItem.ClusterName, podNum)
 			externalDomain := m.Spec.GetExternalDomainForMemberCluster(clusterSpecItem.ClusterName)
 			placeholderReplacer := create.GetMultiClusterMongoDBPlaceholderReplacer(m.Name, m.Name, m.Namespace, clusterSpecItem.ClusterName, m.ClusterNum(clusterSpecItem.ClusterName), externalDomain, m.Spec.ClusterDomain, podNum)
@@ -1020,10 +1253,8 @@ func ensureHeadlessService(ctx context.Context, client service.GetUpdateCreator,
 func getHostnameOverrideConfigMap(mrs mdbmultiv1.MongoDBMultiCluster, clusterNum int, clusterName string, members int) corev1.ConfigMap {
 	data := make(map[string]string)
 
-	externalDomain := mrs.Spec.GetExternalDomainForMemberCluster(clusterName)
-	for podNum := 0; podNum < members; podNum++ {
-		key := dns.GetMultiPodName(mrs.Name, clusterNum, podNum)
-		data[key] = dns.GetMultiClusterPodServiceFQDN(mrs.Name, mrs.Namespace, clusterNum, externalDomain, podNum, mrs.Spec.GetClusterDomain())
+	for podNum, hostname := range mrs.GetProcessHostnames(clusterName, members) {
+		data[dns.GetMultiPodName(mrs.Name, clusterNum, podNum)] = hostname
 	}
 
 	cm := corev1.ConfigMap{
@@ -1053,7 +1284,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileHostnameOverrideConfigMap(ctx
 			continue
 		}
 
-		client, ok := r.memberClusterClientsMap[e.ClusterName]
+		client, ok := r.memberClusters.KubeClient(e.ClusterName)
 		if !ok {
 			log.Warnf(fmt.Sprintf("failed to create configmap: cluster %s is missing from client map", e.ClusterName))
 			continue
@@ -1089,7 +1320,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileOMCAConfigMap(ctx context.Con
 			log.Warnf("failed to create configmap %s: cluster %s is marked as failed", configMapName, clusterSpecItem.ClusterName)
 			continue
 		}
-		client := r.memberClusterClientsMap[clusterSpecItem.ClusterName]
+		client, _ := r.memberClusters.KubeClient(clusterSpecItem.ClusterName)
 		memberCm := configmap.Builder().SetName(configMapName).SetNamespace(mrs.Namespace).SetData(cm.Data).Build()
 		err := configmap.CreateOrUpdate(ctx, client, memberCm)
 		if err != nil && !apiErrors.IsAlreadyExists(err) {
@@ -1102,9 +1333,9 @@ func (r *ReconcileMongoDbMultiReplicaSet) reconcileOMCAConfigMap(ctx context.Con
 
 // AddMultiReplicaSetController creates a new MongoDbMultiReplicaset Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
-func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClustersMap map[string]cluster.Cluster) error {
+func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusters *multicluster.Registry) error {
 	// Create a new controller
-	reconciler := newMultiClusterReplicaSetReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, om.NewOpsManagerConnection, multicluster.ClustersMapToClientMap(memberClustersMap))
+	reconciler := newMultiClusterReplicaSetReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, om.NewOpsManagerConnection, memberClusters)
 	c, err := controller.New(util.MongoDbMultiClusterController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -1151,24 +1382,46 @@ func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imag
 		}
 	}
 
-	// register watcher across member clusters
-	for k, v := range memberClustersMap {
-		err := c.Watch(source.Kind[client.Object](v.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet()))
-		if err != nil {
-			return xerrors.Errorf("failed to set Watch on member cluster: %s, err: %w", k, err)
+	// register watcher across member clusters, including the ones registered while the operator is running
+	err = memberClusters.OnAdd(func(clusterName string, memberCluster cluster.Cluster) error {
+		if err := c.Watch(source.Kind[client.Object](memberCluster.GetCache(), &appsv1.StatefulSet{}, &khandler.EnqueueRequestForOwnerMultiCluster{}, watch.PredicatesForMultiStatefulSet())); err != nil {
+			return xerrors.Errorf("failed to set Watch on member cluster: %s, err: %w", clusterName, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// the operator watches the member clusters' API servers to determine whether the clusters are healthy or not
 	eventChannel := make(chan event.GenericEvent)
-	memberClusterHealthChecker := memberwatch.MemberClusterHealthChecker{Cache: make(map[string]*memberwatch.MemberHeathCheck)}
-	go memberClusterHealthChecker.WatchMemberClusterHealth(ctx, zap.S(), eventChannel, reconciler.client, memberClustersMap)
+	memberClusterHealthChecker := memberwatch.MemberClusterHealthChecker{
+		Cache:    make(map[string]*memberwatch.MemberHeathCheck),
+		Recorder: mgr.GetEventRecorderFor(util.MongoDbMultiClusterController),
+		Signals: []memberwatch.HealthSignal{
+			memberwatch.NodeReadinessSignal{},
+			memberwatch.NewCanaryPodSignal(canaryNamespace()),
+		},
+		DatabaseSignals: []memberwatch.HealthSignal{
+			memberwatch.StatefulSetReadinessSignal{},
+			memberwatch.NewAgentHeartbeatSignal(reconciler.agentHeartbeats),
+		},
+		DatabaseSignalWeight: memberwatch.DefaultDatabaseSignalWeight,
+		HealthScoreThreshold: env.ReadIntOrDefault(multicluster.HealthScoreThresholdEnv, multicluster.DefaultHealthScoreThreshold), // nolint:forbidigo
+		OpsManagerEvents:     OpsManagerFailoverChannel,
+	}
+	go memberClusterHealthChecker.WatchMemberClusterHealth(ctx, zap.S(), eventChannel, reconciler.client, memberClusters)
 
 	err = c.Watch(source.Channel[client.Object](eventChannel, &handler.EnqueueRequestForObject{}))
 	if err != nil {
 		zap.S().Errorf("failed to watch for member cluster healthcheck: %s", err)
 	}
 
+	err = c.Watch(source.Kind(mgr.GetCache(), &searchv1.MongoDBSearch{}, multiClusterSearchSourceEventHandler(mgr.GetClient())))
+	if err != nil {
+		return err
+	}
+
 	err = c.Watch(source.Kind[client.Object](mgr.GetCache(), &corev1.ConfigMap{},
 		watch.ConfigMapEventHandler{
 			ConfigMapName:      util.MemberListConfigMapName,
@@ -1184,6 +1437,113 @@ func AddMultiReplicaSetController(ctx context.Context, mgr manager.Manager, imag
 	return err
 }
 
+// applySearchOverrides points the mongod processes in every member cluster to the mongot instances deployed in the
+// same cluster, if there is a MongoDBSearch using this resource as its source. The mongot instances are only reachable
+// from their own member cluster, so the overrides are skipped if a member cluster with mongod members has no mongot
+// instances, which the MongoDBSearch reconciler reports as invalid. It returns the additional mongod configuration of
+// each member cluster, or nil if the overrides are not applied.
+func (r *ReconcileMongoDbMultiReplicaSet) applySearchOverrides(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, log *zap.SugaredLogger) map[string]*mdb.AdditionalMongodConfig {
+	search := r.lookupCorrespondingSearchResource(ctx, mrs, log)
+	if search == nil {
+		log.Debugf("No MongoDBSearch resource found, skipping search overrides")
+		return nil
+	}
+
+	// the mongot instances in clusters which aren't member clusters of this resource are never used
+	var searchClusterNames []string
+	for _, item := range search.Spec.ClusterSpecList {
+		if mrs.GetClusterSpecByName(item.ClusterName) != nil {
+			searchClusterNames = append(searchClusterNames, item.ClusterName)
+		}
+	}
+	for _, item := range mrs.Spec.ClusterSpecList {
+		if item.Members > 0 && !slices.Contains(searchClusterNames, item.ClusterName) {
+			log.Warnf("MongoDBSearch %s doesn't deploy mongot instances in member cluster %s, skipping search overrides", search.NamespacedName(), item.ClusterName)
+			return nil
+		}
+	}
+	if len(searchClusterNames) == 0 {
+		log.Warnf("MongoDBSearch %s doesn't deploy mongot instances in any member cluster, skipping search overrides", search.NamespacedName())
+		return nil
+	}
+
+	log.Infof("Applying search overrides from MongoDBSearch %s", search.NamespacedName())
+
+	clusterDomain := mrs.Spec.GetClusterDomain()
+	defaultSearchMongodConfig := searchcontroller.GetClusterMongodConfigParameters(search, mrs.ClusterNum(searchClusterNames[0]), clusterDomain)
+
+	// The parameters are also added to the spec, so they are part of the last achieved spec and removed from the
+	// processes once the MongoDBSearch is deleted. The per-cluster values take precedence.
+	if mrs.Spec.AdditionalMongodConfig == nil {
+		mrs.Spec.AdditionalMongodConfig = mdb.NewEmptyAdditionalMongodConfig()
+	}
+	mrs.Spec.AdditionalMongodConfig.AddOption("setParameter", defaultSearchMongodConfig["setParameter"])
+
+	clusterMongodConfigs := map[string]*mdb.AdditionalMongodConfig{}
+	for _, clusterName := range searchClusterNames {
+		clusterMongodConfig := mrs.Spec.AdditionalMongodConfig.DeepCopy()
+		searchMongodConfig := searchcontroller.GetClusterMongodConfigParameters(search, mrs.ClusterNum(clusterName), clusterDomain)
+		clusterMongodConfig.AddOption("setParameter", searchMongodConfig["setParameter"])
+		clusterMongodConfigs[clusterName] = clusterMongodConfig
+	}
+
+	return clusterMongodConfigs
+}
+
+func (r *ReconcileMongoDbMultiReplicaSet) lookupCorrespondingSearchResource(ctx context.Context, mrs *mdbmultiv1.MongoDBMultiCluster, log *zap.SugaredLogger) *searchv1.MongoDBSearch {
+	var search *searchv1.MongoDBSearch
+	searchList := &searchv1.MongoDBSearchList{}
+	if err := r.client.List(ctx, searchList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(searchcontroller.MongoDBSearchIndexFieldName, mrs.GetNamespace()+"/"+mrs.GetName()),
+	}); err != nil {
+		log.Debugf("Failed to list MongoDBSearch resources: %v", err)
+	}
+	// this validates that there is exactly one MongoDBSearch pointing to this resource, that it deploys mongot
+	// instances in member clusters and that this resource passes search validations. If either fails, proceed
+	// without a search target for the mongod automation config.
+	if len(searchList.Items) == 1 && searchList.Items[0].IsMultiCluster() {
+		searchSource := searchcontroller.NewMultiClusterResourceSearchSource(mrs)
+		if searchSource.Validate() == nil {
+			search = &searchList.Items[0]
+		}
+	}
+	return search
+}
+
+// agentHeartbeats returns the number of automation agents of the resource in each member cluster and how many of them
+// pinged Ops Manager recently. It's used as a health signal of the member clusters, so it only reads Ops Manager.
+func (r *ReconcileMongoDbMultiReplicaSet) agentHeartbeats(ctx context.Context, mrs mdbmultiv1.MongoDBMultiCluster) (map[string]memberwatch.AgentHeartbeatCount, error) {
+	log := zap.S().With("MultiReplicaSet", mrs.ObjectKey())
+	projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, r.client, r.SecretClient, &mrs, log)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connection.ReadOpsManagerConnection(projectConfig, credsConfig, r.omConnectionFactory, log)
+	if err != nil {
+		return nil, err
+	}
+	clusterState, err := agents.GetMongoDBClusterState(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeats := map[string]memberwatch.AgentHeartbeatCount{}
+	for _, item := range mrs.GetDesiredSpecList() {
+		if item.Members == 0 {
+			continue
+		}
+		count := memberwatch.AgentHeartbeatCount{}
+		for _, hostname := range mrs.GetProcessHostnames(item.ClusterName, item.Members) {
+			count.Expected++
+			if !clusterState.GetProcessState(hostname).IsStale() {
+				count.Alive++
+			}
+		}
+		heartbeats[item.ClusterName] = count
+	}
+	return heartbeats, nil
+}
+
 // OnDelete cleans up Ops Manager state and all Kubernetes resources associated with this instance.
 func (r *ReconcileMongoDbMultiReplicaSet) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
 	mrs := obj.(*mdbmultiv1.MongoDBMultiCluster)
@@ -1198,7 +1558,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) cleanOpsManagerState(ctx context.Conte
 	}
 
 	log.Infow("Removing replica set from Ops Manager", "config", mrs.Spec)
-	conn, _, err := connection.PrepareOpsManagerConnection(ctx, r.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, mrs.Namespace, log)
+	conn, _, err := prepareOpsManagerConnection(ctx, r.SecretClient, &mrs, projectConfig, credsConfig, r.omConnectionFactory, log)
 	if err != nil {
 		return err
 	}
@@ -1257,7 +1617,7 @@ func (r *ReconcileMongoDbMultiReplicaSet) deleteManagedResources(ctx context.Con
 
 	for _, item := range clusterSpecList {
 		clusterName := item.ClusterName
-		clusterClient := r.memberClusterClientsMap[clusterName]
+		clusterClient, _ := r.memberClusters.KubeClient(clusterName)
 		if err := r.deleteClusterResources(ctx, clusterClient, clusterName, &mrs, log); err != nil {
 			errs = multierror.Append(errs, xerrors.Errorf("failed deleting dependant resources in cluster %s: %w", clusterName, err))
 		}
@@ -3,10 +3,13 @@ package operator
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -31,10 +34,12 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/agentVersionManagement"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
@@ -517,6 +522,262 @@ func TestServiceCreation_WithDuplicates(t *testing.T) {
 	}
 }
 
+func TestExternalAddressDiscovery_LoadBalancer(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().
+		SetClusterSpecList(clusters).
+		SetExternalAccess(mdb.ExternalAccessConfiguration{}, nil).
+		Build()
+	mrs.Spec.ExternalAddressDiscovery = &mdbmulti.ExternalAddressDiscovery{}
+
+	var expectedHostnames []string
+	for _, item := range mrs.Spec.ClusterSpecList {
+		for podNum := 0; podNum < item.Members; podNum++ {
+			expectedHostnames = append(expectedHostnames, fmt.Sprintf("10.0.%d.%d", mrs.ClusterNum(item.ClusterName), podNum))
+		}
+	}
+
+	reconciler, client, memberClusterMap, omConnectionFactory := multiReplicaSetReconciler(ctx, nil, "", "", mrs)
+	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
+		connection.(*om.MockedOmConnection).Hostnames = expectedHostnames
+	})
+
+	// the load balancers haven't been assigned addresses yet
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, true)
+	assert.Equal(t, status.PhasePending, mrs.Status.Phase)
+	assert.Contains(t, mrs.Status.Message, "Waiting for the addresses of the external services")
+
+	for _, item := range mrs.Spec.ClusterSpecList {
+		clusterNum := mrs.ClusterNum(item.ClusterName)
+		for podNum := 0; podNum < item.Members; podNum++ {
+			svc := corev1.Service{}
+			err := memberClusterMap[item.ClusterName].Get(ctx, kube.ObjectKey(mrs.Namespace, fmt.Sprintf("%s-%d-%d-svc-external", mrs.Name, clusterNum, podNum)), &svc)
+			require.NoError(t, err)
+			assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: fmt.Sprintf("10.0.%d.%d", clusterNum, podNum)}}
+			require.NoError(t, memberClusterMap[item.ClusterName].Status().Update(ctx, &svc))
+
+			// no load balancers are allocated for the duplicates of the external services in the other clusters
+			for _, otherCluster := range clusters {
+				if otherCluster != item.ClusterName {
+					err = memberClusterMap[otherCluster].Get(ctx, kube.ObjectKey(mrs.Namespace, svc.Name), &corev1.Service{})
+					assert.Error(t, err)
+				}
+			}
+		}
+	}
+
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	var hostnames []string
+	for _, p := range omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetProcesses() {
+		hostnames = append(hostnames, p.HostName())
+	}
+	assert.ElementsMatch(t, expectedHostnames, hostnames)
+
+	for _, item := range mrs.Spec.ClusterSpecList {
+		clusterNum := mrs.ClusterNum(item.ClusterName)
+		cm := corev1.ConfigMap{}
+		err := memberClusterMap[item.ClusterName].Get(ctx, kube.ObjectKey(mrs.Namespace, fmt.Sprintf("%s-hostname-override", mrs.Name)), &cm)
+		require.NoError(t, err)
+		for podNum := 0; podNum < item.Members; podNum++ {
+			podName := fmt.Sprintf("%s-%d-%d", mrs.Name, clusterNum, podNum)
+			assert.Equal(t, fmt.Sprintf("10.0.%d.%d", clusterNum, podNum), cm.Data[podName])
+			assert.Equal(t, mdbmulti.ExternalAddress{Host: fmt.Sprintf("10.0.%d.%d", clusterNum, podNum), Port: 27017}, mrs.Status.ExternalAddresses[podName])
+		}
+	}
+
+	// a new address of the load balancer doesn't rename the existing process
+	svc := corev1.Service{}
+	svcName := fmt.Sprintf("%s-%d-0-svc-external", mrs.Name, mrs.ClusterNum(clusters[0]))
+	require.NoError(t, memberClusterMap[clusters[0]].Get(ctx, kube.ObjectKey(mrs.Namespace, svcName), &svc))
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.1.0.0"}}
+	require.NoError(t, memberClusterMap[clusters[0]].Status().Update(ctx, &svc))
+
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	hostnames = nil
+	for _, p := range omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetProcesses() {
+		hostnames = append(hostnames, p.HostName())
+	}
+	assert.ElementsMatch(t, expectedHostnames, hostnames)
+	podName := fmt.Sprintf("%s-%d-0", mrs.Name, mrs.ClusterNum(clusters[0]))
+	assert.Equal(t, mdbmulti.ExternalAddress{Host: fmt.Sprintf("10.0.%d.0", mrs.ClusterNum(clusters[0])), Port: 27017}, mrs.Status.ExternalAddresses[podName])
+}
+
+func TestExternalAddressDiscovery_NodePort(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().
+		SetClusterSpecList(clusters).
+		SetExternalAccess(mdb.ExternalAccessConfiguration{}, nil).
+		SetSecurity(&mdb.Security{
+			TLSConfig:                 &mdb.TLSConfig{Enabled: true, CA: "some-ca"},
+			CertificatesSecretsPrefix: "some-prefix",
+		}).
+		Build()
+	mrs.Spec.ExternalAddressDiscovery = &mdbmulti.ExternalAddressDiscovery{ServiceType: corev1.ServiceTypeNodePort}
+
+	reconciler, client, memberClusterMap, omConnectionFactory := multiReplicaSetReconciler(ctx, nil, "", "", mrs)
+	createMultiClusterReplicaSetTLSData(t, ctx, client, mrs, "some-ca")
+
+	// the certificate has to be valid for the node addresses added to the horizons
+	secret := corev1.Secret{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(mrs.Namespace, fmt.Sprintf("some-prefix-%s-cert", mrs.Name)), &secret))
+	secret.Data["tls.crt"], secret.Data["tls.key"] = createMockCertAndKeyBytes(func(cert *x509.Certificate) {
+		for clusterNum := range clusters {
+			cert.IPAddresses = append(cert.IPAddresses, net.ParseIP(fmt.Sprintf("192.168.%d.2", clusterNum)))
+		}
+	})
+	require.NoError(t, client.Update(ctx, &secret))
+
+	for _, clusterName := range clusters {
+		clusterNum := mrs.ClusterNum(clusterName)
+		// the first node isn't ready, so the address of the second one is used
+		notReady := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: fmt.Sprintf("192.168.%d.1", clusterNum)}},
+			},
+		}
+		ready := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: fmt.Sprintf("192.168.%d.2", clusterNum)}},
+			},
+		}
+		require.NoError(t, memberClusterMap[clusterName].Create(ctx, notReady))
+		require.NoError(t, memberClusterMap[clusterName].Create(ctx, ready))
+	}
+
+	// the node ports haven't been allocated yet
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, true)
+	assert.Equal(t, status.PhasePending, mrs.Status.Phase)
+	assert.Contains(t, mrs.Status.Message, "Waiting for the addresses of the external services")
+
+	for _, item := range mrs.Spec.ClusterSpecList {
+		clusterNum := mrs.ClusterNum(item.ClusterName)
+		for podNum := 0; podNum < item.Members; podNum++ {
+			svc := corev1.Service{}
+			err := memberClusterMap[item.ClusterName].Get(ctx, kube.ObjectKey(mrs.Namespace, fmt.Sprintf("%s-%d-%d-svc-external", mrs.Name, clusterNum, podNum)), &svc)
+			require.NoError(t, err)
+			assert.Equal(t, corev1.ServiceTypeNodePort, svc.Spec.Type)
+
+			for i := range svc.Spec.Ports {
+				svc.Spec.Ports[i].NodePort = int32(30000 + 100*clusterNum + 10*podNum + i)
+			}
+			require.NoError(t, memberClusterMap[item.ClusterName].Update(ctx, &svc))
+		}
+	}
+
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	dep, err := omConnectionFactory.GetConnection().ReadDeployment()
+	require.NoError(t, err)
+	horizons := map[string]any{}
+	for _, member := range dep.GetReplicaSetByName(mrs.Name).Members() {
+		horizons[member.Name()] = member["horizons"]
+	}
+	for _, item := range mrs.Spec.ClusterSpecList {
+		clusterNum := mrs.ClusterNum(item.ClusterName)
+		for podNum := 0; podNum < item.Members; podNum++ {
+			podName := fmt.Sprintf("%s-%d-%d", mrs.Name, clusterNum, podNum)
+			nodePort := 30000 + 100*clusterNum + 10*podNum
+			assert.Equal(t, mdbmulti.ExternalAddress{Host: fmt.Sprintf("192.168.%d.2", clusterNum), Port: int32(nodePort)}, mrs.Status.ExternalAddresses[podName])
+			assert.Equal(t, mdb.MongoDBHorizonConfig{"external": fmt.Sprintf("192.168.%d.2:%d", clusterNum, nodePort)}, horizons[podName])
+		}
+	}
+
+	// the processes keep the hostnames of their pod Services
+	for _, p := range omConnectionFactory.GetConnection().(*om.MockedOmConnection).GetProcesses() {
+		assert.Contains(t, p.HostName(), ".svc.cluster.local")
+	}
+}
+
+func TestExternalAddressDiscovery_PublishDNS(t *testing.T) {
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().
+		SetClusterSpecList(clusters).
+		SetExternalAccess(mdb.ExternalAccessConfiguration{ExternalDomain: ptr.To("cluster-%d.testing")}, ptr.To("cluster-%d.testing")).
+		Build()
+	mrs.Spec.ExternalAddressDiscovery = &mdbmulti.ExternalAddressDiscovery{PublishDNS: true}
+	for _, clusterName := range clusters {
+		mrs.ClusterNum(clusterName)
+	}
+
+	svc := getExternalService(mrs, clusters[1], 2)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, fmt.Sprintf("%s-1-2.cluster-1.testing", mrs.Name), svc.Annotations[mdbmulti.ExternalDNSHostnameAnnotation])
+
+	mrs.Spec.ExternalAddressDiscovery.PublishDNS = false
+	svc = getExternalService(mrs, clusters[1], 2)
+	assert.NotContains(t, svc.Annotations, mdbmulti.ExternalDNSHostnameAnnotation)
+}
+
+func TestGetExternalServiceAddress(t *testing.T) {
+	svc := corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+		{Name: "mongodb", Port: 27017, NodePort: 31017},
+		{Name: "backup", Port: 27018, NodePort: 31018},
+	}}}
+
+	_, ok := getExternalServiceAddress(svc, corev1.ServiceTypeLoadBalancer, "")
+	assert.False(t, ok)
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com", IP: "10.0.0.1"}}
+	address, ok := getExternalServiceAddress(svc, corev1.ServiceTypeLoadBalancer, "")
+	assert.True(t, ok)
+	assert.Equal(t, mdbmulti.ExternalAddress{Host: "lb.example.com", Port: 27017}, address)
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	address, ok = getExternalServiceAddress(svc, corev1.ServiceTypeLoadBalancer, "")
+	assert.True(t, ok)
+	assert.Equal(t, mdbmulti.ExternalAddress{Host: "10.0.0.1", Port: 27017}, address)
+
+	_, ok = getExternalServiceAddress(svc, corev1.ServiceTypeNodePort, "")
+	assert.False(t, ok)
+
+	address, ok = getExternalServiceAddress(svc, corev1.ServiceTypeNodePort, "192.168.0.1")
+	assert.True(t, ok)
+	assert.Equal(t, mdbmulti.ExternalAddress{Host: "192.168.0.1", Port: 31017}, address)
+}
+
+func TestGetNodeAddress(t *testing.T) {
+	ctx := context.Background()
+	node := func(name string, ready bool, addresses ...corev1.NodeAddress) *corev1.Node {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: readyStatus}},
+				Addresses:  addresses,
+			},
+		}
+	}
+
+	c := mock.NewEmptyFakeClientBuilder().WithObjects(
+		node("node-a", false, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "1.1.1.1"}),
+		node("node-b", true, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}),
+		node("node-c", true, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.3"}, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "3.3.3.3"}),
+	).Build()
+	address, err := getNodeAddress(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, "3.3.3.3", address)
+
+	c = mock.NewEmptyFakeClientBuilder().WithObjects(
+		node("node-b", true, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}),
+	).Build()
+	address, err = getNodeAddress(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", address)
+
+	_, err = getNodeAddress(ctx, mock.NewEmptyFakeClientBuilder().Build())
+	assert.Error(t, err)
+}
+
 func TestHeadlessServiceCreation(t *testing.T) {
 	ctx := context.Background()
 	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().
@@ -757,7 +1018,7 @@ func TestMultiReplicaSetRace(t *testing.T) {
 
 	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory().WithResourceToProjectMapping(resourceToProjectMapping)
 	memberClusterMap := getFakeMultiClusterMapWithConfiguredInterceptor(clusters, omConnectionFactory, true, true)
-	reconciler := newMultiClusterReplicaSetReconciler(ctx, fakeClient, nil, "fake-initDatabaseNonStaticImageVersion", "fake-databaseNonStaticImageVersion", false, false, omConnectionFactory.GetConnectionFunc, memberClusterMap)
+	reconciler := newMultiClusterReplicaSetReconciler(ctx, fakeClient, nil, "fake-initDatabaseNonStaticImageVersion", "fake-databaseNonStaticImageVersion", false, false, omConnectionFactory.GetConnectionFunc, multicluster.NewClientRegistry(memberClusterMap))
 
 	testConcurrentReconciles(ctx, t, fakeClient, reconciler, rs1, rs2, rs3)
 }
@@ -773,6 +1034,49 @@ func buildMultiReplicaSetWithCustomProject(mcReplicaSetName string) (*mdbmulti.M
 		Build(), mock.GetProjectConfigMap(configMapName, projectName, ""), projectName
 }
 
+// TestMultiReplicaSetPlanMode verifies that the next scaling step is planned without changing Ops Manager or the
+// StatefulSets of the member clusters, and that it's applied once the plan mode is disabled.
+func TestMultiReplicaSetPlanMode(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().SetClusterSpecList(clusters).Build()
+	mrs.Spec.ClusterSpecList[0].Members = 1
+	mrs.Spec.ClusterSpecList[1].Members = 1
+	mrs.Spec.ClusterSpecList[2].Members = 1
+	reconciler, client, memberClusters, omConnectionFactory := defaultMultiReplicaSetReconciler(ctx, nil, "", "", mrs)
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+	mockedOmConn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	mockedOmConn.CleanHistory()
+
+	mrs.Annotations = map[string]string{util.PlanAnnotation: "true"}
+	mrs.Spec.ClusterSpecList[0].Members = 2
+	require.NoError(t, client.Update(ctx, mrs))
+	result, err := reconciler.Reconcile(ctx, requestFromObject(mrs))
+	require.NoError(t, err)
+	assert.Equal(t, 60*time.Second, result.RequeueAfter)
+	require.NoError(t, client.Get(ctx, mrs.ObjectKey(), mrs))
+	assert.Equal(t, status.PhasePending, mrs.Status.Phase)
+	assert.Contains(t, mrs.Status.Message, "Plan mode is enabled")
+
+	planText, err := configmap.ReadKey(ctx, client, plan.ConfigMapKey, kube.ObjectKey(mrs.Namespace, plan.ConfigMapName(mrs.Name)))
+	require.NoError(t, err)
+	processName := fmt.Sprintf("%s-0-1", mrs.Name)
+	assert.Contains(t, planText, fmt.Sprintf("+ processes[%s]", processName))
+	assert.Contains(t, planText, fmt.Sprintf("+ replicaSets[%s].members[%s]", mrs.Name, processName))
+
+	mockedOmConn.CheckNumberOfUpdateRequests(t, 0)
+	assert.Len(t, mockedOmConn.GetProcesses(), 3)
+	assertStatefulSetReplicas(ctx, t, mrs, memberClusters, 1, 1, 1)
+
+	delete(mrs.Annotations, util.PlanAnnotation)
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	assert.Len(t, mockedOmConn.GetProcesses(), 4)
+	assertStatefulSetReplicas(ctx, t, mrs, memberClusters, 2, 1, 1)
+	exists, err := configmap.Exists(ctx, client, kube.ObjectKey(mrs.Namespace, plan.ConfigMapName(mrs.Name)))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestScaling(t *testing.T) {
 	ctx := context.Background()
 
@@ -1364,6 +1668,29 @@ func TestMultiClusterFailover(t *testing.T) {
 	assert.Equal(t, expectedNodeCount, currentNodeCount)
 }
 
+func TestMultiClusterFailover_Freeze(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().SetClusterSpecList(clusters).Build()
+	mrs.Spec.Failover = &mdbmulti.FailoverPolicy{Strategy: mdbmulti.FailoverStrategyFreeze}
+
+	reconciler, client, _, _ := defaultMultiReplicaSetReconciler(ctx, nil, "", "", mrs)
+	checkMultiReconcileSuccessful(ctx, t, reconciler, mrs, client, false)
+
+	t.Setenv("PERFORM_FAILOVER", "true")
+	err := memberwatch.AddFailoverAnnotation(ctx, *mrs, mrs.Spec.ClusterSpecList[0].ClusterName, client)
+	assert.NoError(t, err)
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(mrs), mrs))
+
+	// the members are not moved, so the resource can't be reconciled until the cluster is recovered
+	_, ok := mdbmulti.HasClustersToFailOver(mrs.Annotations)
+	assert.False(t, ok)
+
+	_, err = reconciler.Reconcile(ctx, requestFromObject(mrs))
+	assert.NoError(t, err)
+	require.NoError(t, client.Get(ctx, kube.ObjectKeyFromApiObject(mrs), mrs))
+	assert.Equal(t, status.PhaseFailed, mrs.Status.Phase)
+}
+
 func TestMultiReplicaSet_AgentVersionMapping(t *testing.T) {
 	ctx := context.Background()
 	defaultResource := mdbmulti.DefaultMultiReplicaSetBuilder().SetClusterSpecList(clusters).Build()
@@ -1513,7 +1840,7 @@ func calculateHostNamesForExternalDomains(m *mdbmulti.MongoDBMultiCluster) []str
 func multiReplicaSetReconciler(ctx context.Context, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, m *mdbmulti.MongoDBMultiCluster) (*ReconcileMongoDbMultiReplicaSet, kubernetesClient.Client, map[string]client.Client, *om.CachedOMConnectionFactory) {
 	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient(m)
 	memberClusterMap := getFakeMultiClusterMap(omConnectionFactory)
-	return newMultiClusterReplicaSetReconciler(ctx, kubeClient, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, false, false, omConnectionFactory.GetConnectionFunc, memberClusterMap), kubeClient, memberClusterMap, omConnectionFactory
+	return newMultiClusterReplicaSetReconciler(ctx, kubeClient, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, false, false, omConnectionFactory.GetConnectionFunc, multicluster.NewClientRegistry(memberClusterMap)), kubeClient, memberClusterMap, omConnectionFactory
 }
 
 func getFakeMultiClusterMap(omConnectionFactory *om.CachedOMConnectionFactory) map[string]client.Client {
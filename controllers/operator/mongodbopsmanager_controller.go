@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"syscall"
+	"time"
 
 	"github.com/blang/semver"
 	"go.uber.org/zap"
@@ -18,7 +20,6 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -57,6 +58,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -70,6 +72,10 @@ import (
 
 var OmUpdateChannel chan event.GenericEvent
 
+// OpsManagerFailoverChannel enqueues the multi-cluster MongoDBOpsManager resources the member cluster health checker
+// failed over, it's only set if the operator watches both the MongoDBOpsManager and the MongoDBMultiCluster resources.
+var OpsManagerFailoverChannel chan event.GenericEvent
+
 const (
 	oldestSupportedOpsManagerVersion = "5.0.0"
 	programmaticKeyVersion           = "5.0.0"
@@ -92,7 +98,7 @@ type OpsManagerReconciler struct {
 	oldestSupportedVersion semver.Version
 	programmaticKeyVersion semver.Version
 
-	memberClustersMap map[string]client.Client
+	memberClusters *multicluster.Registry
 
 	imageUrls                  images.ImageUrls
 	initAppdbVersion           string
@@ -101,7 +107,7 @@ type OpsManagerReconciler struct {
 
 var _ reconcile.Reconciler = &OpsManagerReconciler{}
 
-func NewOpsManagerReconciler(ctx context.Context, kubeClient client.Client, memberClustersMap map[string]client.Client, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string, omFunc om.ConnectionFactory, initializer api.Initializer, adminProvider api.AdminProvider) *OpsManagerReconciler {
+func NewOpsManagerReconciler(ctx context.Context, kubeClient client.Client, memberClusters *multicluster.Registry, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string, omFunc om.ConnectionFactory, initializer api.Initializer, adminProvider api.AdminProvider) *OpsManagerReconciler {
 	return &OpsManagerReconciler{
 		ReconcileCommonController:  NewReconcileCommonController(ctx, kubeClient),
 		omConnectionFactory:        omFunc,
@@ -109,7 +115,7 @@ func NewOpsManagerReconciler(ctx context.Context, kubeClient client.Client, memb
 		omAdminProvider:            adminProvider,
 		oldestSupportedVersion:     semver.MustParse(oldestSupportedOpsManagerVersion),
 		programmaticKeyVersion:     semver.MustParse(programmaticKeyVersion),
-		memberClustersMap:          memberClustersMap,
+		memberClusters:             memberClusters,
 		imageUrls:                  imageUrls,
 		initAppdbVersion:           initAppdbVersion,
 		initOpsManagerImageVersion: initOpsManagerImageVersion,
@@ -395,6 +401,11 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 		return reconcileResult, err
 	}
 
+	// the members of the failed clusters are moved to the healthy clusters by overriding the clusterSpecLists
+	if err := opsManager.ApplyClusterSpecOverrides(); err != nil {
+		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
+	}
+
 	log.Info("-> OpsManager.Reconcile")
 	log.Infow("OpsManager.Spec", "spec", opsManager.Spec)
 	log.Infow("OpsManager.Status", "status", opsManager.Status)
@@ -444,6 +455,7 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 	}
 	// register backup
 	r.watchMongoDBResourcesReferencedByBackup(ctx, opsManager, log)
+	r.watchSecretsReferencedBySettings(opsManager)
 
 	result, err := appDbReconciler.ReconcileAppDB(ctx, opsManager)
 	if err != nil || (result != emptyResult && result != retryResult) {
@@ -455,7 +467,7 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 		return r.updateStatus(ctx, opsManager, workflow.Failed(xerrors.Errorf("Error getting AppDB password: %w", err)), log, opsManagerExtraStatusParams)
 	}
 
-	opsManagerReconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClustersMap, log)
+	opsManagerReconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClusterClients(opsManager, log), log)
 	if err != nil {
 		return r.updateStatus(ctx, opsManager, workflow.Failed(err), log, opsManagerExtraStatusParams)
 	}
@@ -467,8 +479,13 @@ func (r *OpsManagerReconciler) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// Validate the major version upgrade of Ops Manager before the StatefulSets are changed
+	if status := r.prepareOpsManagerUpgrade(ctx, opsManagerReconcilerHelper, opsManager, log); !status.IsOK() {
+		return r.updateStatus(ctx, opsManager, status, log, opsManagerExtraStatusParams)
+	}
+
 	initOpsManagerImage := images.ContainerImage(r.imageUrls, util.InitOpsManagerImageUrl, r.initOpsManagerImageVersion)
-	opsManagerImage := images.ContainerImage(r.imageUrls, util.OpsManagerImageUrl, opsManager.Spec.Version)
+	opsManagerImage := images.ContainerImage(r.imageUrls, util.OpsManagerImageUrl, opsManager.GetVersionToDeploy())
 
 	// 2. Reconcile Ops Manager
 	status, omAdmin := r.reconcileOpsManager(ctx, opsManagerReconcilerHelper, opsManager, appDBConnectionString, initOpsManagerImage, opsManagerImage, log)
@@ -686,6 +703,9 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 		statefulSetStatus = statefulSetStatus.Merge(status)
 	}
 	if !statefulSetStatus.IsOK() {
+		if status := r.checkOpsManagerUpgradeHealth(ctx, reconcilerHelper, opsManager, opsManagerImage, time.Now(), log); !status.IsOK() {
+			return status, nil
+		}
 		return statefulSetStatus, nil
 	}
 
@@ -708,8 +728,14 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 		return workflow.Failed(err), nil
 	}
 
+	// Ops Manager keeps running the previous version after a failed upgrade, this is reported until the spec is changed
+	reportedStatus := status
+	if rolledBackStatus := opsManagerUpgradeRolledBackStatus(opsManager); !rolledBackStatus.IsOK() {
+		reportedStatus = rolledBackStatus
+	}
+
 	statusOptions := []mdbstatus.Option{mdbstatus.NewOMPartOption(mdbstatus.OpsManager), mdbstatus.NewBaseUrlOption(opsManagerURL)}
-	if _, err := r.updateStatus(ctx, opsManager, workflow.OK(), log, statusOptions...); err != nil {
+	if _, err := r.updateStatus(ctx, opsManager, reportedStatus, log, statusOptions...); err != nil {
 		return workflow.Failed(err), nil
 	}
 
@@ -719,12 +745,12 @@ func (r *OpsManagerReconciler) reconcileOpsManager(ctx context.Context, reconcil
 // triggerOmChangedEventIfNeeded triggers upgrade process for all the MongoDB agents in the system if the major/minor version upgrade
 // happened for Ops Manager
 func triggerOmChangedEventIfNeeded(ctx context.Context, opsManager *omv1.MongoDBOpsManager, c kubernetesClient.Client, log *zap.SugaredLogger) error {
-	if opsManager.Spec.Version == opsManager.Status.OpsManagerStatus.Version || opsManager.Status.OpsManagerStatus.Version == "" {
+	if opsManager.GetVersionToDeploy() == opsManager.Status.OpsManagerStatus.Version || opsManager.Status.OpsManagerStatus.Version == "" {
 		return nil
 	}
-	newVersion, err := versionutil.StringToSemverVersion(opsManager.Spec.Version)
+	newVersion, err := versionutil.StringToSemverVersion(opsManager.GetVersionToDeploy())
 	if err != nil {
-		return xerrors.Errorf("failed to parse Ops Manager version %s: %w", opsManager.Spec.Version, err)
+		return xerrors.Errorf("failed to parse Ops Manager version %s: %w", opsManager.GetVersionToDeploy(), err)
 	}
 	oldVersion, err := versionutil.StringToSemverVersion(opsManager.Status.OpsManagerStatus.Version)
 	if err != nil {
@@ -766,7 +792,7 @@ func triggerOmChangedEventIfNeeded(ctx context.Context, opsManager *omv1.MongoDB
 // Later, the normal reconcile process will update the STS and start the backup daemon.
 func (r *OpsManagerReconciler) stopBackupDaemonIfNeeded(ctx context.Context, reconcileHelper *OpsManagerReconcilerHelper) error {
 	opsManager := reconcileHelper.opsManager
-	if opsManager.Spec.Version == opsManager.Status.OpsManagerStatus.Version || opsManager.Status.OpsManagerStatus.Version == "" {
+	if opsManager.GetVersionToDeploy() == opsManager.Status.OpsManagerStatus.Version || opsManager.Status.OpsManagerStatus.Version == "" {
 		return nil
 	}
 
@@ -839,7 +865,7 @@ func (r *OpsManagerReconciler) reconcileBackupDaemon(ctx context.Context, reconc
 		}
 	}
 
-	if _, err := r.updateStatus(ctx, opsManager, workflow.OK(), log, backupStatusPartOption); err != nil {
+	if _, err := r.updateStatus(ctx, opsManager, workflow.OK(), log, backupStatusPartOption, mdbstatus.NewPVCsStatusOptionEmptyStatus()); err != nil {
 		return workflow.Failed(err)
 	}
 
@@ -898,6 +924,35 @@ func hashConnectionString(connectionString string) string {
 	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(hashBytes[:])
 }
 
+// settingsSecretsHash returns the hash of the values of the Secrets referenced in spec.settings. The values are passed
+// to the containers as environment variables, so the Secrets must exist in the member cluster and the Pods are
+// restarted when the hash changes.
+func settingsSecretsHash(ctx context.Context, secretGetter secret.Getter, opsManager *omv1.MongoDBOpsManager) (string, error) {
+	secretProperties := opsManager.Spec.Settings.SecretProperties()
+	if len(secretProperties) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, property := range slices.Sorted(maps.Keys(secretProperties)) {
+		ref := secretProperties[property]
+		settingsSecret, err := secretGetter.GetSecret(ctx, kube.ObjectKey(opsManager.Namespace, ref.Name))
+		if err != nil {
+			if apiErrors.IsNotFound(err) {
+				return "", xerrors.Errorf("the Secret %s referenced by the property %s in spec.settings doesn't exist", ref.Name, property)
+			}
+			return "", xerrors.Errorf("failed to read the Secret %s referenced by the property %s in spec.settings: %w", ref.Name, property, err)
+		}
+		value, ok := settingsSecret.Data[ref.Key]
+		if !ok {
+			return "", xerrors.Errorf("the Secret %s referenced by the property %s in spec.settings doesn't contain the key %s", ref.Name, property, ref.Key)
+		}
+		hash.Write([]byte(property))
+		hash.Write(value)
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(hash.Sum(nil)), nil
+}
+
 func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, appDBConnectionString string, memberCluster multicluster.MemberCluster, initOpsManagerImage, opsManagerImage string, log *zap.SugaredLogger) workflow.Status {
 	opsManager := reconcilerHelper.opsManager
 
@@ -913,11 +968,17 @@ func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx co
 		log.Debugf("Error while retrieving debug port for Ops Manager: %s", err)
 	}
 
+	secretsHash, err := settingsSecretsHash(ctx, memberCluster.Client, opsManager)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
 	clusterSpecItem := reconcilerHelper.getClusterSpecOMItem(memberCluster.Name)
 	sts, err := construct.OpsManagerStatefulSet(ctx, r.SecretClient, opsManager, memberCluster, log,
 		construct.WithInitOpsManagerImage(initOpsManagerImage),
 		construct.WithOpsManagerImage(opsManagerImage),
 		construct.WithConnectionStringHash(hashConnectionString(appDBConnectionString)),
+		construct.WithSettingsSecretsHash(secretsHash),
 		construct.WithVaultConfig(vaultConfig),
 		construct.WithKmipConfig(ctx, opsManager, memberCluster.Client, log),
 		construct.WithStsOverride(clusterSpecItem.GetStatefulSetSpecOverride()),
@@ -935,8 +996,8 @@ func (r *OpsManagerReconciler) createOpsManagerStatefulsetInMemberCluster(ctx co
 	return workflow.OK()
 }
 
-func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClustersMap map[string]cluster.Cluster, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string) error {
-	reconciler := NewOpsManagerReconciler(ctx, mgr.GetClient(), multicluster.ClustersMapToClientMap(memberClustersMap), imageUrls, initAppdbVersion, initOpsManagerImageVersion, om.NewOpsManagerConnection, &api.DefaultInitializer{}, api.NewOmAdmin)
+func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string) error {
+	reconciler := NewOpsManagerReconciler(ctx, mgr.GetClient(), memberClusters, imageUrls, initAppdbVersion, initOpsManagerImageVersion, om.NewOpsManagerConnection, &api.DefaultInitializer{}, api.NewOmAdmin)
 	c, err := controller.New(util.MongoDbOpsManagerController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
@@ -968,6 +1029,13 @@ func AddOpsManagerController(ctx context.Context, mgr manager.Manager, memberClu
 		return err
 	}
 
+	// the member cluster health checker enqueues the resources after failing over their failed clusters
+	if OpsManagerFailoverChannel != nil {
+		if err = c.Watch(source.Channel[client.Object](OpsManagerFailoverChannel, &handler.EnqueueRequestForObject{})); err != nil {
+			return err
+		}
+	}
+
 	// if vault secret backend is enabled watch for Vault secret change and trigger reconcile
 	if vault.IsVaultSecretBackend() {
 		eventChannel := make(chan event.GenericEvent)
@@ -1024,11 +1092,17 @@ func (r *OpsManagerReconciler) createBackupDaemonStatefulset(ctx context.Context
 	if r.VaultClient != nil {
 		vaultConfig = r.VaultClient.VaultConfig
 	}
+	secretsHash, err := settingsSecretsHash(ctx, memberCluster.Client, reconcilerHelper.opsManager)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
 	clusterSpecItem := reconcilerHelper.getClusterSpecOMItem(memberCluster.Name)
 	sts, err := construct.BackupDaemonStatefulSet(ctx, r.SecretClient, reconcilerHelper.opsManager, memberCluster, log,
 		construct.WithInitOpsManagerImage(initOpsManagerImage),
 		construct.WithOpsManagerImage(opsManagerImage),
 		construct.WithConnectionStringHash(hashConnectionString(appDBConnectionString)),
+		construct.WithSettingsSecretsHash(secretsHash),
 		construct.WithVaultConfig(vaultConfig),
 		// TODO KMIP support will not work across clusters
 		construct.WithKmipConfig(ctx, reconcilerHelper.opsManager, memberCluster.Client, log),
@@ -1039,6 +1113,16 @@ func (r *OpsManagerReconciler) createBackupDaemonStatefulset(ctx context.Context
 		return workflow.Failed(xerrors.Errorf("error building stateful set: %w", err))
 	}
 
+	workflowStatus := pvcresize.HandlePVCResize(ctx, memberCluster.Client, &sts, log)
+	if !workflowStatus.IsOK() {
+		return workflowStatus
+	}
+	if workflow.ContainsPVCOption(workflowStatus.StatusOptions()) {
+		if _, err := r.updateStatus(ctx, reconcilerHelper.opsManager, workflow.Pending(""), log, append(workflowStatus.StatusOptions(), mdbstatus.NewOMPartOption(mdbstatus.Backup))...); err != nil {
+			return workflow.Failed(xerrors.Errorf("error updating status: %w", err))
+		}
+	}
+
 	needToRequeue, err := create.BackupDaemonInKubernetes(ctx, memberCluster.Client, reconcilerHelper.opsManager, sts, log)
 	if err != nil {
 		return workflow.Failed(err)
@@ -1095,6 +1179,17 @@ func (r *OpsManagerReconciler) watchCaReferencedByKmip(opsManager *omv1.MongoDBO
 		kube.ObjectKeyFromApiObject(opsManager))
 }
 
+func (r *OpsManagerReconciler) watchSecretsReferencedBySettings(opsManager *omv1.MongoDBOpsManager) {
+	for _, ref := range opsManager.Spec.Settings.SecretProperties() {
+		r.resourceWatcher.AddWatchedResourceIfNotAdded(
+			ref.Name,
+			opsManager.Namespace,
+			watch.Secret,
+			kube.ObjectKeyFromApiObject(opsManager),
+		)
+	}
+}
+
 func (r *OpsManagerReconciler) watchMongoDBResourcesReferencedByBackup(ctx context.Context, opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) {
 	if !opsManager.Spec.Backup.Enabled {
 		return
@@ -1156,6 +1251,10 @@ func buildMongoConnectionUrl(opsManager *omv1.MongoDBOpsManager, password string
 }
 
 func setConfigProperty(opsManager *omv1.MongoDBOpsManager, key, value string, log *zap.SugaredLogger) {
+	if _, ok := opsManager.Spec.Settings.Properties()[key]; ok {
+		// the property is configured in spec.settings
+		return
+	}
 	if opsManager.AddConfigIfDoesntExist(key, value) {
 		if key == util.MmsMongoUri {
 			log.Debugw("Configured property", key, util.RedactMongoURI(value))
@@ -1488,9 +1587,132 @@ func (r *OpsManagerReconciler) prepareOpsManager(ctx context.Context, opsManager
 	}
 
 	admin := r.omAdminProvider(centralURL, cred.PublicAPIKey, cred.PrivateAPIKey, ca)
+
+	// 4. Rotate the admin API key if the rotation policy requires this
+	if !opsManager.Spec.IsAPIKeyRotationEnabled() {
+		// The rotation period starts over when the rotation is enabled again
+		if opsManager.Status.OpsManagerStatus.APIKeyLastRotation != "" {
+			if err := r.saveAPIKeyLastRotation(ctx, opsManager, "", log); err != nil {
+				return workflow.Failed(err), nil
+			}
+		}
+		return workflow.OK(), admin
+	}
+
+	if _, ok := apiKeyLastRotation(opsManager); !ok {
+		// The rotation period starts when the rotation is enabled, the current key is not rotated right away
+		if err := r.saveAPIKeyLastRotation(ctx, opsManager, time.Now().UTC().Format(time.RFC3339), log); err != nil {
+			return workflow.Failed(err), nil
+		}
+		return workflow.OK(), admin
+	}
+
+	if isAPIKeyRotationDue(opsManager, time.Now()) {
+		return r.rotateAdminAPIKey(ctx, opsManager, admin, centralURL, ca, adminKeySecretName, operatorVaultPath, cred, log)
+	}
+
 	return workflow.OK(), admin
 }
 
+// apiKeyLastRotation returns the time of the last admin API key rotation (or of enabling the rotation) recorded in
+// the status. False is returned if there is no valid time recorded.
+func apiKeyLastRotation(opsManager *omv1.MongoDBOpsManager) (time.Time, bool) {
+	lastRotation, err := time.Parse(time.RFC3339, opsManager.Status.OpsManagerStatus.APIKeyLastRotation)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lastRotation, true
+}
+
+// isAPIKeyRotationDue returns true if the rotation period has passed since the last rotation. The rotation is never
+// due before the start of the rotation period is recorded in the status.
+func isAPIKeyRotationDue(opsManager *omv1.MongoDBOpsManager, now time.Time) bool {
+	lastRotation, ok := apiKeyLastRotation(opsManager)
+	if !ok {
+		return false
+	}
+	return now.Sub(lastRotation) >= opsManager.Spec.GetAPIKeyRotationPeriod()
+}
+
+// saveAPIKeyLastRotation saves the time of the last admin API key rotation to the Ops Manager status right away, so
+// that the key is not rotated again if the reconciliation fails before the status is updated at the end.
+func (r *OpsManagerReconciler) saveAPIKeyLastRotation(ctx context.Context, opsManager *omv1.MongoDBOpsManager, lastRotation string, log *zap.SugaredLogger) error {
+	if _, err := r.updateStatus(ctx, opsManager, workflow.Pending(""), log, mdbstatus.NewOMPartOption(mdbstatus.OpsManager), mdbstatus.NewAPIKeyRotationOption(lastRotation)); err != nil {
+		return xerrors.Errorf("failed to save the time of the admin API key rotation: %w", err)
+	}
+	return nil
+}
+
+// rotateAdminAPIKey replaces the admin API key used by the Operator with a new one. The new key is created and verified
+// against Ops Manager before it's saved to the admin key secret (or Vault), only then the old key gets removed.
+// If anything fails before the secret is updated, the new key is removed and the old one stays in use.
+func (r *OpsManagerReconciler) rotateAdminAPIKey(ctx context.Context, opsManager *omv1.MongoDBOpsManager, admin api.OpsManagerAdmin, centralURL string, ca *string, adminKeySecretName client.ObjectKey, operatorVaultPath string, cred mdbv1.Credentials, log *zap.SugaredLogger) (workflow.Status, api.OpsManagerAdmin) {
+	keys, err := admin.ReadGlobalAPIKeys()
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to read the global API keys from Ops Manager: %w", err)), nil
+	}
+	oldKeyID := ""
+	for _, key := range keys {
+		if key.PublicKey == cred.PublicAPIKey {
+			oldKeyID = key.ID
+		}
+	}
+
+	now := time.Now()
+	newKey, err := admin.CreateGlobalAPIKey(fmt.Sprintf("%s operator key %s", opsManager.Name, now.UTC().Format(time.DateOnly)))
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to create a new admin API key in Ops Manager: %w", err)), nil
+	}
+
+	discardNewKey := func(cause error) workflow.Status {
+		if err := admin.DeleteGlobalAPIKey(newKey.ID); err != nil {
+			log.Warnf("Failed to remove the unused admin API key %s from Ops Manager: %s", newKey.PublicKey, err)
+		}
+		return workflow.Failed(cause)
+	}
+
+	newAdmin := r.omAdminProvider(centralURL, newKey.PublicKey, newKey.PrivateKey, ca)
+	if _, err := newAdmin.ReadOpsManagerVersion(); err != nil {
+		return discardNewKey(xerrors.Errorf("the new admin API key was rejected by Ops Manager: %w", err)), nil
+	}
+
+	secretData, err := r.ReadSecret(ctx, adminKeySecretName, operatorVaultPath)
+	if err != nil {
+		return discardNewKey(xerrors.Errorf("failed to read the admin API key secret %s: %w", adminKeySecretName, err)), nil
+	}
+	secretData[util.OmPublicApiKey] = newKey.PublicKey
+	secretData[util.OmPrivateKey] = newKey.PrivateKey
+
+	adminSecret := secret.Builder().
+		SetNamespace(adminKeySecretName.Namespace).
+		SetName(adminKeySecretName.Name).
+		SetStringMapToData(secretData).
+		SetLabels(map[string]string{}).Build()
+
+	if err := r.PutSecret(ctx, adminSecret, operatorVaultPath); err != nil {
+		return discardNewKey(xerrors.Errorf("failed to save the new admin API key. %s. The error : %w",
+			detailedAPIErrorMsg(adminKeySecretName), err)), nil
+	}
+	log.Infof("Rotated the admin API key, the new public key is %s", newKey.PublicKey)
+
+	saveErr := r.saveAPIKeyLastRotation(ctx, opsManager, now.UTC().Format(time.RFC3339), log)
+
+	// The secret already holds the new key, so failing to remove the old one doesn't fail the rotation
+	status := workflow.OK()
+	if oldKeyID == "" {
+		log.Warnf("The previous admin API key %s was not found in Ops Manager and was not removed", cred.PublicAPIKey)
+	} else if err := newAdmin.DeleteGlobalAPIKey(oldKeyID); err != nil {
+		log.Warnf("Failed to remove the previous admin API key %s from Ops Manager: %s", cred.PublicAPIKey, err)
+		status = status.WithWarnings([]mdbstatus.Warning{mdbstatus.Warning(fmt.Sprintf("The previous admin API key %s couldn't be removed from Ops Manager, remove it manually", cred.PublicAPIKey))})
+	}
+
+	if saveErr != nil {
+		return workflow.Failed(saveErr), nil
+	}
+
+	return status, newAdmin
+}
+
 // prepareBackupInOpsManager makes the changes to backup admin configuration based on the Ops Manager spec
 func (r *OpsManagerReconciler) prepareBackupInOpsManager(ctx context.Context, reconcileHelper *OpsManagerReconcilerHelper, opsManager *omv1.MongoDBOpsManager, omAdmin api.OpsManagerAdmin, appDBConnectionString string, log *zap.SugaredLogger) workflow.Status {
 	if !opsManager.Spec.Backup.Enabled {
@@ -2116,7 +2338,7 @@ func newUserFromSecret(data map[string]string) (api.User, error) {
 // it's used in MongoDBOpsManagerEventHandler
 func (r *OpsManagerReconciler) OnDelete(ctx context.Context, obj interface{}, log *zap.SugaredLogger) {
 	opsManager := obj.(*omv1.MongoDBOpsManager)
-	helper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClustersMap, log)
+	helper, err := NewOpsManagerReconcilerHelper(ctx, r, opsManager, r.memberClusterClients(opsManager, log), log)
 	if err != nil {
 		log.Errorf("Error initializing OM reconciler helper: %s", err)
 		return
@@ -2148,7 +2370,25 @@ func (r *OpsManagerReconciler) OnDelete(ctx context.Context, obj interface{}, lo
 }
 
 func (r *OpsManagerReconciler) createNewAppDBReconciler(ctx context.Context, opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) (*ReconcileAppDbReplicaSet, error) {
-	return NewAppDBReplicaSetReconciler(ctx, r.imageUrls, r.initAppdbVersion, opsManager.Spec.AppDB, r.ReconcileCommonController, r.omConnectionFactory, opsManager.Annotations, r.memberClustersMap, log)
+	return NewAppDBReplicaSetReconciler(ctx, r.imageUrls, r.initAppdbVersion, opsManager.Spec.AppDB, r.ReconcileCommonController, r.omConnectionFactory, opsManager.Annotations, r.memberClusterClients(opsManager, log), log)
+}
+
+// memberClusterClients returns the clients of the member clusters, except the clusters marked as failed which are
+// treated as unreachable until the member cluster health checker fails them back.
+func (r *OpsManagerReconciler) memberClusterClients(opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) map[string]client.Client {
+	clientMap := r.memberClusters.ClientMap()
+	failedClusterNames, err := opsManager.GetFailedClusterNames()
+	if err != nil {
+		// When failing to retrieve the list of failed clusters we proceed assuming there are no failed clusters
+		log.Errorf("failed retrieving list of failed clusters: %s", err.Error())
+	}
+	for _, clusterName := range failedClusterNames {
+		if _, ok := clientMap[clusterName]; ok {
+			log.Warnf("Ignoring member cluster %s, it is marked as failed", clusterName)
+			delete(clientMap, clusterName)
+		}
+	}
+	return clientMap
 }
 
 // getAnnotationsForOpsManagerResource returns all the annotations that should be applied to the resource
@@ -7,10 +7,12 @@ import (
 	"net"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,9 +46,11 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/constants"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
 )
 
 func TestOpsManagerReconciler_watchedResources(t *testing.T) {
@@ -367,6 +371,159 @@ func TestOpsManagerReconciler_prepareOpsManagerDuplicatedUser(t *testing.T) {
 	assert.NotContains(t, mock.GetMapForObject(client, &corev1.Secret{}), kube.ObjectKey(OperatorNamespace, APIKeySecretName))
 }
 
+// failingVersionOmAdmin is an Ops Manager admin which rejects any request, used to emulate an API key that doesn't work
+type failingVersionOmAdmin struct {
+	*api.MockedOmAdmin
+}
+
+func (a failingVersionOmAdmin) ReadOpsManagerVersion() (versionutil.OpsManagerVersion, error) {
+	return versionutil.OpsManagerVersion{}, xerrors.New("401 Unauthorized")
+}
+
+func TestOpsManagerReconciler_prepareOpsManagerRotatesAPIKey(t *testing.T) {
+	ctx := context.Background()
+	api.CurrMockedAdmin = nil
+	testOm := DefaultOpsManagerBuilder().Build()
+	testOm.Spec.APIKeyRotation = &omv1.APIKeyRotation{Enabled: true, PeriodDays: 90}
+	testOm.Status.OpsManagerStatus.APIKeyLastRotation = time.Now().Add(-91 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+
+	reconcileStatus, admin := reconciler.prepareOpsManager(ctx, testOm, testOm.CentralURL(), zap.S())
+	require.True(t, reconcileStatus.IsOK())
+	assert.NotNil(t, admin)
+
+	// the time of the rotation is saved to the status right after the secret is updated
+	savedOm := &omv1.MongoDBOpsManager{}
+	require.NoError(t, client.Get(ctx, testOm.ObjectKey(), savedOm))
+	lastRotation, err := time.Parse(time.RFC3339, savedOm.Status.OpsManagerStatus.APIKeyLastRotation)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), lastRotation, time.Minute)
+
+	APIKeySecretName, err := testOm.APIKeySecretName(ctx, secrets.SecretClient{KubeClient: client}, "")
+	require.NoError(t, err)
+	data, err := secret.ReadStringData(ctx, client, kube.ObjectKey(OperatorNamespace, APIKeySecretName))
+	require.NoError(t, err)
+	assert.NotEqual(t, "jane.doe@g.com", data["publicKey"])
+
+	// the old key was removed from Ops Manager, only the new one is left
+	keys, _ := api.CurrMockedAdmin.ReadGlobalAPIKeys()
+	require.Len(t, keys, 1)
+	assert.Equal(t, data["publicKey"], keys[0].PublicKey)
+	assert.Equal(t, data["privateKey"], keys[0].PrivateKey)
+}
+
+func TestOpsManagerReconciler_prepareOpsManagerDoesNotRotateAPIKeyBeforePeriod(t *testing.T) {
+	ctx := context.Background()
+	api.CurrMockedAdmin = nil
+	testOm := DefaultOpsManagerBuilder().Build()
+	testOm.Spec.APIKeyRotation = &omv1.APIKeyRotation{Enabled: true, PeriodDays: 90}
+	testOm.Status.OpsManagerStatus.APIKeyLastRotation = time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+
+	reconcileStatus, _ := reconciler.prepareOpsManager(ctx, testOm, testOm.CentralURL(), zap.S())
+	assert.Equal(t, workflow.OK(), reconcileStatus)
+
+	APIKeySecretName, err := testOm.APIKeySecretName(ctx, secrets.SecretClient{KubeClient: client}, "")
+	require.NoError(t, err)
+	data, _ := secret.ReadStringData(ctx, client, kube.ObjectKey(OperatorNamespace, APIKeySecretName))
+	assert.Equal(t, "jane.doe@g.com", data["publicKey"])
+
+	keys, _ := api.CurrMockedAdmin.ReadGlobalAPIKeys()
+	assert.Len(t, keys, 1)
+}
+
+func TestOpsManagerReconciler_prepareOpsManagerStartsAPIKeyRotationPeriod(t *testing.T) {
+	ctx := context.Background()
+	api.CurrMockedAdmin = nil
+	testOm := DefaultOpsManagerBuilder().Build()
+	testOm.Spec.APIKeyRotation = &omv1.APIKeyRotation{Enabled: true, PeriodDays: 90}
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+
+	reconcileStatus, _ := reconciler.prepareOpsManager(ctx, testOm, testOm.CentralURL(), zap.S())
+	assert.Equal(t, workflow.OK(), reconcileStatus)
+
+	// the key is not rotated when the rotation gets enabled, the rotation period starts instead
+	APIKeySecretName, err := testOm.APIKeySecretName(ctx, secrets.SecretClient{KubeClient: client}, "")
+	require.NoError(t, err)
+	data, _ := secret.ReadStringData(ctx, client, kube.ObjectKey(OperatorNamespace, APIKeySecretName))
+	assert.Equal(t, "jane.doe@g.com", data["publicKey"])
+
+	savedOm := &omv1.MongoDBOpsManager{}
+	require.NoError(t, client.Get(ctx, testOm.ObjectKey(), savedOm))
+	periodStart, err := time.Parse(time.RFC3339, savedOm.Status.OpsManagerStatus.APIKeyLastRotation)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), periodStart, time.Minute)
+
+	// the period starts over when the rotation is enabled again
+	savedOm.Spec.APIKeyRotation.Enabled = false
+	reconcileStatus, _ = reconciler.prepareOpsManager(ctx, savedOm, savedOm.CentralURL(), zap.S())
+	assert.Equal(t, workflow.OK(), reconcileStatus)
+	require.NoError(t, client.Get(ctx, testOm.ObjectKey(), savedOm))
+	assert.Empty(t, savedOm.Status.OpsManagerStatus.APIKeyLastRotation)
+}
+
+func TestOpsManagerReconciler_prepareOpsManagerKeepsAPIKeyIfNewKeyIsRejected(t *testing.T) {
+	ctx := context.Background()
+	api.CurrMockedAdmin = nil
+	testOm := DefaultOpsManagerBuilder().Build()
+	testOm.Spec.APIKeyRotation = &omv1.APIKeyRotation{Enabled: true}
+	testOm.Status.OpsManagerStatus.APIKeyLastRotation = time.Now().Add(-91 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+	reconciler.omAdminProvider = func(baseUrl, user, publicApiKey string, ca *string) api.OpsManagerAdmin {
+		if api.CurrMockedAdmin == nil {
+			api.CurrMockedAdmin = api.NewMockedAdminProvider(baseUrl, user, publicApiKey, true).(*api.MockedOmAdmin)
+		}
+		if user != "jane.doe@g.com" {
+			return failingVersionOmAdmin{MockedOmAdmin: api.CurrMockedAdmin}
+		}
+		return api.CurrMockedAdmin
+	}
+
+	reconcileStatus, admin := reconciler.prepareOpsManager(ctx, testOm, testOm.CentralURL(), zap.S())
+	assert.Equal(t, status.PhaseFailed, reconcileStatus.Phase())
+	assert.Nil(t, admin)
+
+	APIKeySecretName, err := testOm.APIKeySecretName(ctx, secrets.SecretClient{KubeClient: client}, "")
+	require.NoError(t, err)
+	data, _ := secret.ReadStringData(ctx, client, kube.ObjectKey(OperatorNamespace, APIKeySecretName))
+	assert.Equal(t, "jane.doe@g.com", data["publicKey"])
+
+	// the rejected key was cleaned up, the old one is still in Ops Manager
+	keys, _ := api.CurrMockedAdmin.ReadGlobalAPIKeys()
+	require.Len(t, keys, 1)
+	assert.Equal(t, "jane.doe@g.com", keys[0].PublicKey)
+}
+
+func TestIsAPIKeyRotationDue(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		lastRotation string
+		periodDays   int
+		expected     bool
+	}{
+		{name: "period not started", lastRotation: "", periodDays: 90, expected: false},
+		{name: "invalid last rotation", lastRotation: "yesterday", periodDays: 90, expected: false},
+		{name: "period not passed", lastRotation: now.Add(-89 * 24 * time.Hour).Format(time.RFC3339), periodDays: 90, expected: false},
+		{name: "period passed", lastRotation: now.Add(-90 * 24 * time.Hour).Format(time.RFC3339), periodDays: 90, expected: true},
+		{name: "default period", lastRotation: now.Add(-30 * 24 * time.Hour).Format(time.RFC3339), periodDays: 0, expected: false},
+		{name: "custom period", lastRotation: now.Add(-30 * 24 * time.Hour).Format(time.RFC3339), periodDays: 7, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testOm := DefaultOpsManagerBuilder().Build()
+			testOm.Spec.APIKeyRotation = &omv1.APIKeyRotation{Enabled: true, PeriodDays: tt.periodDays}
+			testOm.Status.OpsManagerStatus.APIKeyLastRotation = tt.lastRotation
+			assert.Equal(t, tt.expected, isAPIKeyRotationDue(testOm, now))
+		})
+	}
+}
+
 func TestOpsManagerGeneratesAppDBPassword_IfNotProvided(t *testing.T) {
 	ctx := context.Background()
 
@@ -478,6 +635,45 @@ func TestOpsManagerPodTemplateSpec_IsAnnotatedWithHash(t *testing.T) {
 		"Changing version should not change connection string and so the hash should stay the same")
 }
 
+func TestOpsManagerPodTemplateSpec_IsAnnotatedWithSettingsSecretsHash(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().SetBackup(omv1.MongoDBOpsManagerBackup{Enabled: false}).Build()
+	testOm.Spec.Settings = &omv1.OpsManagerSettings{
+		SMTP: &omv1.OpsManagerSMTPSettings{Hostname: "smtp.example.com", PasswordSecretRef: &userv1.SecretKeyRef{Name: "smtp-credentials"}},
+	}
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, client, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+
+	// the Secret doesn't exist yet
+	_, err := settingsSecretsHash(ctx, reconciler.client, testOm)
+	assert.ErrorContains(t, err, "the Secret smtp-credentials referenced by the property mms.mail.password in spec.settings doesn't exist")
+
+	smtpSecret := secret.Builder().
+		SetName("smtp-credentials").
+		SetNamespace(testOm.Namespace).
+		SetByteData(map[string][]byte{"password": []byte("smtp-password")}).
+		Build()
+	require.NoError(t, reconciler.client.CreateSecret(ctx, smtpSecret))
+
+	checkOMReconciliationSuccessful(ctx, t, reconciler, testOm, reconciler.client)
+
+	assert.Contains(t, reconciler.resourceWatcher.GetWatchedResources(), watch.Object{
+		ResourceType: watch.Secret,
+		Resource:     types.NamespacedName{Name: "smtp-credentials", Namespace: testOm.Namespace},
+	})
+
+	sts := appsv1.StatefulSet{}
+	require.NoError(t, client.Get(ctx, kube.ObjectKey(testOm.Namespace, testOm.Name), &sts))
+	previousHash := sts.Spec.Template.Annotations["settingsSecretsHash"]
+	assert.NotEmpty(t, previousHash)
+
+	smtpSecret.Data["password"] = []byte("new-smtp-password")
+	require.NoError(t, reconciler.client.UpdateSecret(ctx, smtpSecret))
+	newHash, err := settingsSecretsHash(ctx, reconciler.client, testOm)
+	require.NoError(t, err)
+	assert.NotEqual(t, previousHash, newHash, "changing the password should restart the Ops Manager Pods")
+}
+
 func TestOpsManagerReconcileContainerImages(t *testing.T) {
 	initOpsManagerRelatedImageEnv := fmt.Sprintf("RELATED_IMAGE_%s_1_2_3", util.InitOpsManagerImageUrl)
 	opsManagerRelatedImageEnv := fmt.Sprintf("RELATED_IMAGE_%s_8_0_0", util.OpsManagerImageUrl)
@@ -1258,7 +1454,7 @@ func defaultTestOmReconciler(ctx context.Context, t *testing.T, imageUrls images
 
 	initializer := &MockedInitializer{expectedOmURL: opsManager.CentralURL(), t: t}
 
-	reconciler := NewOpsManagerReconciler(ctx, kubeClient, globalMemberClustersMap, imageUrls, initAppdbVersion, initOpsManagerImageVersion, omConnectionFactory.GetConnectionFunc, initializer, func(baseUrl string, user string, publicApiKey string, ca *string) api.OpsManagerAdmin {
+	reconciler := NewOpsManagerReconciler(ctx, kubeClient, multicluster.NewClientRegistry(globalMemberClustersMap), imageUrls, initAppdbVersion, initOpsManagerImageVersion, omConnectionFactory.GetConnectionFunc, initializer, func(baseUrl string, user string, publicApiKey string, ca *string) api.OpsManagerAdmin {
 		if api.CurrMockedAdmin == nil {
 			api.CurrMockedAdmin = api.NewMockedAdminProvider(baseUrl, user, publicApiKey, true).(*api.MockedOmAdmin)
 		}
@@ -1400,3 +1596,17 @@ func addOMTLSResources(ctx context.Context, client client.Client, secretName str
 	certSecret.Data = certs
 	_ = client.Create(ctx, certSecret)
 }
+
+func TestEnsureConfiguration_KeepsPropertiesFromSettings(t *testing.T) {
+	ctx := context.Background()
+	testOm := DefaultOpsManagerBuilder().Build()
+	testOm.Spec.Settings = &omv1.OpsManagerSettings{CentralURL: "https://om.example.com:8443"}
+	reconciler, _, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, om.NewDefaultCachedOMConnectionFactory())
+	reconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, reconciler, testOm, nil, zap.S())
+	require.NoError(t, err)
+
+	reconciler.ensureConfiguration(reconcilerHelper, zap.S())
+
+	assert.NotContains(t, testOm.Spec.Configuration, util.MmsCentralUrlPropKey)
+	assert.Equal(t, "true", testOm.Spec.Configuration[util.MmsFeatureControls])
+}
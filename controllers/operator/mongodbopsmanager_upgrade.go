@@ -0,0 +1,208 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/pkg/agentVersionManagement"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/omupgrade"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// prepareOpsManagerUpgrade runs the pre-flight checks for an Ops Manager major version upgrade. The upgrade path and the
+// versions of the Application Database and the agents are validated against the version manifest and the upgrade
+// is blocked if any of the prerequisites is not met.
+// Once the checks pass, the Backup Daemons are stopped (they are upgraded only after Ops Manager is running the new
+// version) and the upgrade is recorded in the status, so the Ops Manager StatefulSets can be rolled.
+func (r *OpsManagerReconciler) prepareOpsManagerUpgrade(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManager *omv1.MongoDBOpsManager, log *zap.SugaredLogger) workflow.Status {
+	currentVersion := opsManager.Status.OpsManagerStatus.Version
+	targetVersion := opsManager.Spec.Version
+
+	if upgrade := opsManager.Status.OpsManagerStatus.Upgrade; upgrade != nil {
+		if upgrade.ToVersion == targetVersion {
+			// The pre-flight checks for this upgrade have passed already
+			return workflow.OK()
+		}
+		// The spec version was changed after the upgrade had started (e.g. reverted after a rollback)
+		log.Infof("Ops Manager version changed to %s, discarding the upgrade from %s to %s", targetVersion, upgrade.FromVersion, upgrade.ToVersion)
+		opsManager.Status.OpsManagerStatus.Upgrade = nil
+	}
+
+	if currentVersion == "" || !omupgrade.IsMajorUpgrade(currentVersion, targetVersion) {
+		return workflow.OK()
+	}
+
+	manifest, err := omupgrade.ReadManifest(agentVersionManagement.MappingFilePath())
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to read the version manifest to validate the Ops Manager upgrade: %w", err))
+	}
+
+	if !manifest.HasUpgradePaths() {
+		log.Warnf("The version manifest %s doesn't describe the Ops Manager upgrade paths, the upgrade path from %s to %s is not validated", agentVersionManagement.MappingFilePath(), currentVersion, targetVersion)
+	}
+
+	appDBVersion := opsManager.Status.AppDbStatus.Version
+	if appDBVersion == "" {
+		appDBVersion = opsManager.Spec.AppDB.GetMongoDBVersion()
+	}
+	upgrade := omupgrade.Upgrade{
+		FromVersion:  currentVersion,
+		ToVersion:    targetVersion,
+		AppDBVersion: appDBVersion,
+		AppDBFCV:     opsManager.CalculateFeatureCompatibilityVersion(),
+	}
+	if err := manifest.Validate(upgrade); err != nil {
+		return workflow.Invalid("The upgrade of Ops Manager from %s to %s is blocked: %s", currentVersion, targetVersion, err)
+	}
+
+	if err := r.stopBackupDaemonIfNeeded(ctx, reconcilerHelper); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to stop the Backup Daemons before the Ops Manager upgrade: %w", err))
+	}
+
+	log.Infof("Pre-flight checks passed, upgrading Ops Manager from %s to %s", currentVersion, targetVersion)
+	opsManager.Status.OpsManagerStatus.Upgrade = &omv1.OpsManagerUpgradeStatus{
+		FromVersion: currentVersion,
+		ToVersion:   targetVersion,
+		StartedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	return workflow.OK()
+}
+
+// checkOpsManagerUpgradeHealth is called while the Ops Manager StatefulSets are not ready. If Ops Manager doesn't become
+// ready within the health check timeout after a major version upgrade, the upgrade is marked as rolled back and a
+// failed status is returned, so the next reconciliations deploy the previous version again.
+// Ops Manager migrates the schema of the Application Database when the new version starts, and the previous version
+// can't run against a migrated schema. So the upgrade is only rolled back if no Ops Manager container of the new
+// version has ever started (e.g. the image can't be pulled), otherwise the upgrade has to be fixed manually.
+func (r *OpsManagerReconciler) checkOpsManagerUpgradeHealth(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManager *omv1.MongoDBOpsManager, opsManagerImage string, now time.Time, log *zap.SugaredLogger) workflow.Status {
+	upgrade := opsManager.Status.OpsManagerStatus.Upgrade
+	if upgrade == nil {
+		return workflow.OK()
+	}
+
+	if upgrade.RolledBack {
+		if err := r.deleteOpsManagerPodsNotRunningImage(ctx, reconcilerHelper, opsManagerImage, log); err != nil {
+			return workflow.Failed(xerrors.Errorf("failed to roll back Ops Manager to %s: %w", upgrade.FromVersion, err))
+		}
+		return workflow.OK()
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, upgrade.StartedAt)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to parse the Ops Manager upgrade start time %s: %w", upgrade.StartedAt, err))
+	}
+	timeout := opsManager.Spec.GetUpgradeHealthCheckTimeout()
+	if now.Sub(startedAt) < timeout {
+		return workflow.OK()
+	}
+
+	started, err := r.opsManagerContainerHasStarted(ctx, reconcilerHelper, opsManagerImage)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to check the Ops Manager Pods of the upgrade to %s: %w", upgrade.ToVersion, err))
+	}
+	if started {
+		return workflow.Failed(xerrors.Errorf("Ops Manager %s didn't become ready within %s. It isn't rolled back to %s, as it may have migrated the Application Database already. Check the Ops Manager logs and fix the upgrade manually",
+			upgrade.ToVersion, timeout, upgrade.FromVersion))
+	}
+
+	log.Warnf("Ops Manager %s didn't become ready within %s, rolling back to %s", upgrade.ToVersion, timeout, upgrade.FromVersion)
+	upgrade.RolledBack = true
+	return workflow.Failed(xerrors.Errorf("Ops Manager %s didn't become ready within %s, rolling back to %s", upgrade.ToVersion, timeout, upgrade.FromVersion))
+}
+
+// opsManagerUpgradeRolledBackStatus returns the status reported for Ops Manager once it's running the previous version
+// after a failed upgrade, or OK if there was no rollback.
+func opsManagerUpgradeRolledBackStatus(opsManager *omv1.MongoDBOpsManager) workflow.Status {
+	upgrade := opsManager.Status.OpsManagerStatus.Upgrade
+	if upgrade == nil || !upgrade.RolledBack {
+		return workflow.OK()
+	}
+	return workflow.Failed(xerrors.Errorf("The upgrade of Ops Manager to %s was rolled back as Ops Manager didn't become ready, version %s is running. Set spec.version to %s or to a different version to continue",
+		upgrade.ToVersion, upgrade.FromVersion, upgrade.FromVersion))
+}
+
+// deleteOpsManagerPodsNotRunningImage removes the Ops Manager Pods left with the image of the failed upgrade. The
+// StatefulSet controller doesn't replace Pods which never became ready, so the rollback would get stuck otherwise
+// (https://github.com/kubernetes/kubernetes/issues/67250).
+func (r *OpsManagerReconciler) deleteOpsManagerPodsNotRunningImage(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManagerImage string, log *zap.SugaredLogger) error {
+	opsManager := reconcilerHelper.opsManager
+	for _, memberCluster := range reconcilerHelper.getHealthyMemberClusters() {
+		sts, err := memberCluster.Client.GetStatefulSet(ctx, kube.ObjectKey(opsManager.Namespace, reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster)))
+		if err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return err
+		}
+
+		pods := &corev1.PodList{}
+		if err := memberCluster.Client.List(ctx, pods, client.InNamespace(opsManager.Namespace), client.MatchingLabels(sts.Spec.Selector.MatchLabels)); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if podRunsImage(pod, util.OpsManagerContainerName, opsManagerImage) {
+				continue
+			}
+			log.Infof("Deleting Ops Manager Pod %s in cluster %s to roll it back to %s", pod.Name, memberCluster.Name, opsManagerImage)
+			if err := memberCluster.Client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// opsManagerContainerHasStarted returns true if the Ops Manager container of any Pod running the given image has
+// started at least once.
+func (r *OpsManagerReconciler) opsManagerContainerHasStarted(ctx context.Context, reconcilerHelper *OpsManagerReconcilerHelper, opsManagerImage string) (bool, error) {
+	opsManager := reconcilerHelper.opsManager
+	for _, memberCluster := range reconcilerHelper.getHealthyMemberClusters() {
+		sts, err := memberCluster.Client.GetStatefulSet(ctx, kube.ObjectKey(opsManager.Namespace, reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster)))
+		if err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return false, err
+		}
+
+		pods := &corev1.PodList{}
+		if err := memberCluster.Client.List(ctx, pods, client.InNamespace(opsManager.Namespace), client.MatchingLabels(sts.Spec.Selector.MatchLabels)); err != nil {
+			return false, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if podRunsImage(pod, util.OpsManagerContainerName, opsManagerImage) && containerHasStarted(pod, util.OpsManagerContainerName) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func containerHasStarted(pod *corev1.Pod, containerName string) bool {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == containerName {
+			return containerStatus.State.Running != nil || containerStatus.State.Terminated != nil || containerStatus.LastTerminationState.Terminated != nil
+		}
+	}
+	return false
+}
+
+func podRunsImage(pod *corev1.Pod, containerName, image string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return container.Image == image
+		}
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+package operator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/utils/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/agentVersionManagement"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func upgradeTestOpsManager(fromVersion, toVersion, appDBVersion string) *omv1.MongoDBOpsManager {
+	testOm := DefaultOpsManagerBuilder().SetVersion(toVersion).SetOMStatusVersion(fromVersion).SetAppDbVersion(appDBVersion).Build()
+	testOm.Status.AppDbStatus.Version = appDBVersion
+	return testOm
+}
+
+func upgradeTestReconcilerHelper(ctx context.Context, t *testing.T, testOm *omv1.MongoDBOpsManager) (*OpsManagerReconciler, *OpsManagerReconcilerHelper) {
+	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
+	reconciler, _, _ := defaultTestOmReconciler(ctx, t, nil, "", "", testOm, nil, omConnectionFactory)
+	reconcilerHelper, err := NewOpsManagerReconcilerHelper(ctx, reconciler, testOm, nil, zap.S())
+	require.NoError(t, err)
+	return reconciler, reconcilerHelper
+}
+
+func TestPrepareOpsManagerUpgrade_SkipsChecksForMinorUpgrade(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "7.0.13", "4.2.24-ent")
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	upgradeStatus := reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+
+	assert.True(t, upgradeStatus.IsOK())
+	assert.Nil(t, testOm.Status.OpsManagerStatus.Upgrade)
+}
+
+func TestPrepareOpsManagerUpgrade_BlocksUnsupportedUpgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		fromVersion     string
+		toVersion       string
+		appDBVersion    string
+		expectedMessage string
+	}{
+		{
+			name:            "upgrade path is not supported",
+			fromVersion:     "6.0.26",
+			toVersion:       "8.0.0",
+			appDBVersion:    "6.0.5-ent",
+			expectedMessage: "The upgrade of Ops Manager from 6.0.26 to 8.0.0 is blocked: Ops Manager 6.0.26 can't be upgraded to 8.0.0 directly",
+		},
+		{
+			name:            "application database is too old",
+			fromVersion:     "7.0.12",
+			toVersion:       "8.0.0",
+			appDBVersion:    "5.0.15-ent",
+			expectedMessage: "requires the Application Database version 6.0.0 or later",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			testOm := upgradeTestOpsManager(tt.fromVersion, tt.toVersion, tt.appDBVersion)
+			reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+			upgradeStatus := reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+
+			assert.Equal(t, status.PhaseFailed, upgradeStatus.Phase())
+			option, exists := status.GetOption(upgradeStatus.StatusOptions(), status.MessageOption{})
+			require.True(t, exists)
+			assert.Contains(t, option.(status.MessageOption).Message, tt.expectedMessage)
+			assert.Nil(t, testOm.Status.OpsManagerStatus.Upgrade)
+		})
+	}
+}
+
+func TestPrepareOpsManagerUpgrade_AllowsUpgradeIfManifestHasNoUpgradePaths(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "release.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"supportedImages": {"ops-manager": {"versions": ["6.0.26", "8.0.0"]}, "mongodb-agent": {"opsManagerMapping": {"ops_manager": {"8.0.0": {"agent_version": "108.0.0.8694-1"}}}}}}`), 0o600))
+	t.Setenv(agentVersionManagement.MappingFilePathEnv, manifestPath)
+
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("6.0.26", "8.0.0", "5.0.15-ent")
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	upgradeStatus := reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+
+	assert.True(t, upgradeStatus.IsOK())
+	require.NotNil(t, testOm.Status.OpsManagerStatus.Upgrade)
+	assert.Equal(t, "8.0.0", testOm.Status.OpsManagerStatus.Upgrade.ToVersion)
+}
+
+func TestPrepareOpsManagerUpgrade_StopsBackupDaemonsAndRecordsUpgrade(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "8.0.0", "6.0.5-ent")
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	memberCluster := reconcilerHelper.getHealthyMemberClusters()[0]
+	backupStsKey := kube.ObjectKey(testOm.Namespace, reconcilerHelper.BackupDaemonStatefulSetNameForMemberCluster(memberCluster))
+	require.NoError(t, memberCluster.Client.Create(ctx, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: backupStsKey.Name, Namespace: backupStsKey.Namespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+	}))
+
+	upgradeStatus := reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+	require.True(t, upgradeStatus.IsOK())
+
+	upgrade := testOm.Status.OpsManagerStatus.Upgrade
+	require.NotNil(t, upgrade)
+	assert.Equal(t, "7.0.12", upgrade.FromVersion)
+	assert.Equal(t, "8.0.0", upgrade.ToVersion)
+	assert.False(t, upgrade.RolledBack)
+
+	backupSts, err := memberCluster.Client.GetStatefulSet(ctx, backupStsKey)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *backupSts.Spec.Replicas)
+
+	// the checks are not repeated for the upgrade in progress
+	upgradeStatus = reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+	assert.True(t, upgradeStatus.IsOK())
+	assert.Equal(t, upgrade, testOm.Status.OpsManagerStatus.Upgrade)
+}
+
+func TestPrepareOpsManagerUpgrade_DiscardsUpgradeIfVersionChanged(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "7.0.12", "6.0.5-ent")
+	testOm.Status.OpsManagerStatus.Upgrade = &omv1.OpsManagerUpgradeStatus{FromVersion: "7.0.12", ToVersion: "8.0.0", StartedAt: time.Now().Format(time.RFC3339), RolledBack: true}
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	upgradeStatus := reconciler.prepareOpsManagerUpgrade(ctx, reconcilerHelper, testOm, zap.S())
+
+	assert.True(t, upgradeStatus.IsOK())
+	assert.Nil(t, testOm.Status.OpsManagerStatus.Upgrade)
+	assert.Equal(t, "7.0.12", testOm.GetVersionToDeploy())
+}
+
+func TestCheckOpsManagerUpgradeHealth_RollsBackAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "8.0.0", "6.0.5-ent")
+	testOm.Spec.Upgrade = &omv1.OpsManagerUpgrade{HealthCheckTimeoutMinutes: 30}
+	startedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	testOm.Status.OpsManagerStatus.Upgrade = &omv1.OpsManagerUpgradeStatus{FromVersion: "7.0.12", ToVersion: "8.0.0", StartedAt: startedAt.Format(time.RFC3339)}
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	healthStatus := reconciler.checkOpsManagerUpgradeHealth(ctx, reconcilerHelper, testOm, "quay.io/mongodb/mongodb-enterprise-ops-manager:8.0.0", startedAt.Add(20*time.Minute), zap.S())
+	assert.True(t, healthStatus.IsOK())
+	assert.False(t, testOm.Status.OpsManagerStatus.Upgrade.RolledBack)
+	assert.Equal(t, "8.0.0", testOm.GetVersionToDeploy())
+
+	healthStatus = reconciler.checkOpsManagerUpgradeHealth(ctx, reconcilerHelper, testOm, "quay.io/mongodb/mongodb-enterprise-ops-manager:8.0.0", startedAt.Add(31*time.Minute), zap.S())
+	assert.Equal(t, status.PhaseFailed, healthStatus.Phase())
+	assert.True(t, testOm.Status.OpsManagerStatus.Upgrade.RolledBack)
+	assert.Equal(t, "7.0.12", testOm.GetVersionToDeploy())
+
+	rolledBackStatus := opsManagerUpgradeRolledBackStatus(testOm)
+	assert.Equal(t, status.PhaseFailed, rolledBackStatus.Phase())
+	option, exists := status.GetOption(rolledBackStatus.StatusOptions(), status.MessageOption{})
+	require.True(t, exists)
+	assert.Contains(t, option.(status.MessageOption).Message, "The upgrade of Ops Manager to 8.0.0 was rolled back")
+}
+
+func TestCheckOpsManagerUpgradeHealth_NoRollbackIfNewVersionStarted(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "8.0.0", "6.0.5-ent")
+	startedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	testOm.Status.OpsManagerStatus.Upgrade = &omv1.OpsManagerUpgradeStatus{FromVersion: "7.0.12", ToVersion: "8.0.0", StartedAt: startedAt.Format(time.RFC3339)}
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	memberCluster := reconcilerHelper.getHealthyMemberClusters()[0]
+	stsName := reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster)
+	labels := map[string]string{"app": testOm.SvcName()}
+	require.NoError(t, memberCluster.Client.Create(ctx, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: stsName, Namespace: testOm.Namespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1)), Selector: &metav1.LabelSelector{MatchLabels: labels}},
+	}))
+	newImage := "quay.io/mongodb/mongodb-enterprise-ops-manager:8.0.0"
+	require.NoError(t, memberCluster.Client.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: stsName + "-0", Namespace: testOm.Namespace, Labels: labels},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: util.OpsManagerContainerName, Image: newImage}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:                 util.OpsManagerContainerName,
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+		}}},
+	}))
+
+	healthStatus := reconciler.checkOpsManagerUpgradeHealth(ctx, reconcilerHelper, testOm, newImage, startedAt.Add(61*time.Minute), zap.S())
+
+	assert.Equal(t, status.PhaseFailed, healthStatus.Phase())
+	option, exists := status.GetOption(healthStatus.StatusOptions(), status.MessageOption{})
+	require.True(t, exists)
+	assert.Contains(t, option.(status.MessageOption).Message, "It isn't rolled back to 7.0.12, as it may have migrated the Application Database already")
+	assert.False(t, testOm.Status.OpsManagerStatus.Upgrade.RolledBack)
+	assert.Equal(t, "8.0.0", testOm.GetVersionToDeploy())
+}
+
+func TestCheckOpsManagerUpgradeHealth_DeletesPodsOfFailedUpgrade(t *testing.T) {
+	ctx := context.Background()
+	testOm := upgradeTestOpsManager("7.0.12", "8.0.0", "6.0.5-ent")
+	testOm.Status.OpsManagerStatus.Upgrade = &omv1.OpsManagerUpgradeStatus{FromVersion: "7.0.12", ToVersion: "8.0.0", StartedAt: time.Now().Format(time.RFC3339), RolledBack: true}
+	reconciler, reconcilerHelper := upgradeTestReconcilerHelper(ctx, t, testOm)
+
+	memberCluster := reconcilerHelper.getHealthyMemberClusters()[0]
+	stsName := reconcilerHelper.OpsManagerStatefulSetNameForMemberCluster(memberCluster)
+	labels := map[string]string{"app": testOm.SvcName()}
+	require.NoError(t, memberCluster.Client.Create(ctx, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: stsName, Namespace: testOm.Namespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2)), Selector: &metav1.LabelSelector{MatchLabels: labels}},
+	}))
+	oldImage := "quay.io/mongodb/mongodb-enterprise-ops-manager:7.0.12"
+	for name, image := range map[string]string{stsName + "-0": oldImage, stsName + "-1": "quay.io/mongodb/mongodb-enterprise-ops-manager:8.0.0"} {
+		require.NoError(t, memberCluster.Client.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testOm.Namespace, Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: util.OpsManagerContainerName, Image: image}}},
+		}))
+	}
+
+	healthStatus := reconciler.checkOpsManagerUpgradeHealth(ctx, reconcilerHelper, testOm, oldImage, time.Now(), zap.S())
+	require.True(t, healthStatus.IsOK())
+
+	pods := &corev1.PodList{}
+	require.NoError(t, memberCluster.Client.List(ctx, pods))
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, stsName+"-0", pods.Items[0].Name)
+}
@@ -0,0 +1,287 @@
+package operator
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	projectv1 "github.com/mongodb/mongodb-kubernetes/api/v1/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+type MongoDBOrganizationReconciler struct {
+	*ReconcileCommonController
+	omConnectionFactory om.ConnectionFactory
+}
+
+func newMongoDBOrganizationReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory) *MongoDBOrganizationReconciler {
+	return &MongoDBOrganizationReconciler{
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		omConnectionFactory:       omFunc,
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodborganizations,mongodborganizations/status,mongodborganizations/finalizers},verbs=*,namespace=placeholder
+
+// Reconciles a mongodborganizations.mongodb.com Custom resource.
+func (r *MongoDBOrganizationReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
+	log := zap.S().With("MongoDBOrganization", request.NamespacedName)
+	log.Info("-> MongoDBOrganization.Reconcile")
+
+	organization := &projectv1.MongoDBOrganization{}
+	if result, err := r.GetResource(ctx, request, organization, log); err != nil {
+		return result, err
+	}
+
+	opsManagerConfig, credentials, err := readOrganizationConnectionDetails(ctx, r.client, r.SecretClient, r.resourceWatcher, organization, organization.NamespacedName(), log)
+	if err != nil {
+		// without the connection details the teams can't be removed, so it's not blocking the deletion
+		if !organization.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(organization, util.OrganizationFinalizer) {
+			log.Warnf("The teams of the organization can't be removed: %s", err)
+			return r.removeFinalizer(ctx, organization, workflow.OK(), log)
+		}
+		return r.updateStatus(ctx, organization, workflow.Failed(err), log)
+	}
+
+	conn := project.NewOrganizationConnection(opsManagerConfig, credentials, organization.Status.OrganizationID, r.omConnectionFactory)
+
+	if !organization.DeletionTimestamp.IsZero() {
+		log.Info("MongoDBOrganization is being deleted")
+
+		if controllerutil.ContainsFinalizer(organization, util.OrganizationFinalizer) {
+			return r.preDeletionCleanup(ctx, organization, conn, log)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := validateOrganizationSpec(organization.Spec); err != nil {
+		return r.updateStatus(ctx, organization, workflow.Invalid("%s", err.Error()), log)
+	}
+
+	if err := r.ensureFinalizer(ctx, organization, log); err != nil {
+		return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to add finalizer: %w", err)), log)
+	}
+
+	omOrganization, err := project.ReadOrCreateOrganization(conn, organization.Status.OrganizationID, organization.GetOrganizationName(), log)
+	if err != nil {
+		return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to read or create the organization in Ops Manager: %w", err)), log)
+	}
+
+	teamIDs, err := ensureOrganizationTeams(conn, organization, log)
+	if err != nil {
+		// the teams created so far are kept in the status, so they are not created twice
+		return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to configure the teams of the organization: %w", err)), log, projectv1.NewOrganizationOption(omOrganization.ID, teamIDs))
+	}
+
+	log.Infof("Finished reconciliation for MongoDBOrganization!")
+	return r.updateStatus(ctx, organization, workflow.OK(), log, projectv1.NewOrganizationOption(omOrganization.ID, teamIDs))
+}
+
+// readOrganizationConnectionDetails reads the connection details of Ops Manager and the API key used to manage the
+// organization. Both of them are watched for changes on behalf of the dependent resource.
+func readOrganizationConnectionDetails(ctx context.Context, cmGetter configmap.Getter, secretClient secrets.SecretClient, resourceWatcher *watch.ResourceWatcher, organization *projectv1.MongoDBOrganization, dependent types.NamespacedName, log *zap.SugaredLogger) (mdbv1.ProjectConfig, mdbv1.Credentials, error) {
+	configMapKey := organization.GetOpsManagerConfigMapKey()
+	credentialsKey := organization.GetCredentialsSecretKey()
+	resourceWatcher.AddWatchedResourceIfNotAdded(configMapKey.Name, configMapKey.Namespace, watch.ConfigMap, dependent)
+	resourceWatcher.AddWatchedResourceIfNotAdded(credentialsKey.Name, credentialsKey.Namespace, watch.Secret, dependent)
+
+	opsManagerConfig, err := project.ReadOpsManagerConfig(ctx, cmGetter, configMapKey)
+	if err != nil {
+		return mdbv1.ProjectConfig{}, mdbv1.Credentials{}, xerrors.Errorf("error reading Ops Manager config: %w", err)
+	}
+	credentials, err := project.ReadCredentials(ctx, secretClient, credentialsKey, log)
+	if err != nil {
+		return mdbv1.ProjectConfig{}, mdbv1.Credentials{}, xerrors.Errorf("error reading Credentials secret: %w", err)
+	}
+	return opsManagerConfig, credentials, nil
+}
+
+// validateOrganizationSpec checks the team names are unique, which can't be expressed in the CRD schema.
+func validateOrganizationSpec(spec projectv1.MongoDBOrganizationSpec) error {
+	names := map[string]struct{}{}
+	for _, t := range spec.Teams {
+		if _, ok := names[t.Name]; ok {
+			return xerrors.Errorf("the team %s is specified more than once", t.Name)
+		}
+		names[t.Name] = struct{}{}
+	}
+	return nil
+}
+
+// ensureOrganizationTeams makes the teams of the organization and their members match the spec. The teams which were
+// created by the previous reconciliations and are not in the spec anymore are removed. It returns the ids of the
+// managed teams by name.
+func ensureOrganizationTeams(conn om.Connection, organization *projectv1.MongoDBOrganization, log *zap.SugaredLogger) (map[string]string, error) {
+	teamIDs := map[string]string{}
+	for name, id := range organization.Status.Teams {
+		teamIDs[name] = id
+	}
+
+	omTeams, err := conn.ReadTeams()
+	if err != nil {
+		return teamIDs, err
+	}
+	omTeamsByID := map[string]*team.Team{}
+	omTeamsByName := map[string]*team.Team{}
+	for _, t := range omTeams {
+		omTeamsByID[t.ID] = t
+		omTeamsByName[t.Name] = t
+	}
+
+	desiredTeams := map[string]struct{}{}
+	for _, desiredTeam := range organization.Spec.Teams {
+		desiredTeams[desiredTeam.Name] = struct{}{}
+
+		omTeam, ok := omTeamsByID[teamIDs[desiredTeam.Name]]
+		if !ok {
+			omTeam, ok = omTeamsByName[desiredTeam.Name]
+		}
+		if !ok {
+			created, err := conn.CreateTeam(&team.Team{Name: desiredTeam.Name, Usernames: desiredTeam.Usernames})
+			if err != nil {
+				return teamIDs, xerrors.Errorf("failed to create the team %s: %w", desiredTeam.Name, err)
+			}
+			log.Infof("Created the team %s", desiredTeam.Name)
+			teamIDs[desiredTeam.Name] = created.ID
+			continue
+		}
+
+		teamIDs[desiredTeam.Name] = omTeam.ID
+		if err := ensureTeamUsers(conn, omTeam.ID, desiredTeam.Usernames, log); err != nil {
+			return teamIDs, xerrors.Errorf("failed to configure the members of the team %s: %w", desiredTeam.Name, err)
+		}
+	}
+
+	for name, id := range teamIDs {
+		if _, ok := desiredTeams[name]; ok {
+			continue
+		}
+		if _, ok := omTeamsByID[id]; ok {
+			if err := conn.DeleteTeam(id); err != nil {
+				return teamIDs, xerrors.Errorf("failed to remove the team %s: %w", name, err)
+			}
+			log.Infof("Removed the team %s", name)
+		}
+		delete(teamIDs, name)
+	}
+
+	return teamIDs, nil
+}
+
+func ensureTeamUsers(conn om.Connection, teamID string, usernames []string, log *zap.SugaredLogger) error {
+	users, err := conn.ReadTeamUsers(teamID)
+	if err != nil {
+		return err
+	}
+
+	currentUsers := map[string]string{}
+	for _, user := range users {
+		currentUsers[user.Username] = user.ID
+	}
+
+	desiredUsers := map[string]struct{}{}
+	for _, username := range usernames {
+		desiredUsers[username] = struct{}{}
+		if _, ok := currentUsers[username]; !ok {
+			if err := conn.AddTeamUser(teamID, username); err != nil {
+				return xerrors.Errorf("failed to add the user %s: %w", username, err)
+			}
+			log.Debugf("Added the user %s to the team %s", username, teamID)
+		}
+	}
+
+	for username, userID := range currentUsers {
+		if _, ok := desiredUsers[username]; !ok {
+			if err := conn.RemoveTeamUser(teamID, userID); err != nil {
+				return xerrors.Errorf("failed to remove the user %s: %w", username, err)
+			}
+			log.Debugf("Removed the user %s from the team %s", username, teamID)
+		}
+	}
+
+	return nil
+}
+
+// preDeletionCleanup removes the teams managed by the resource. The organization itself is left in Ops Manager, as it
+// may contain projects which are not managed by the operator.
+func (r *MongoDBOrganizationReconciler) preDeletionCleanup(ctx context.Context, organization *projectv1.MongoDBOrganization, conn om.Connection, log *zap.SugaredLogger) (reconcile.Result, error) {
+	log.Info("Performing pre deletion cleanup before deleting MongoDBOrganization")
+
+	if organization.Status.OrganizationID != "" {
+		conn.ConfigureProject(&om.Project{OrgID: organization.Status.OrganizationID})
+
+		omTeams, err := conn.ReadTeams()
+		if err != nil {
+			return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to read the teams of the organization: %w", err)), log)
+		}
+		for _, omTeam := range omTeams {
+			if organization.Status.Teams[omTeam.Name] != omTeam.ID {
+				continue
+			}
+			if err := conn.DeleteTeam(omTeam.ID); err != nil {
+				return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to remove the team %s: %w", omTeam.Name, err)), log)
+			}
+		}
+	}
+
+	r.resourceWatcher.RemoveAllDependentWatchedResources(organization.Namespace, organization.NamespacedName())
+	return r.removeFinalizer(ctx, organization, workflow.OK(), log)
+}
+
+func (r *MongoDBOrganizationReconciler) removeFinalizer(ctx context.Context, organization *projectv1.MongoDBOrganization, st workflow.Status, log *zap.SugaredLogger) (reconcile.Result, error) {
+	controllerutil.RemoveFinalizer(organization, util.OrganizationFinalizer)
+	if err := r.client.Update(ctx, organization); err != nil {
+		return r.updateStatus(ctx, organization, workflow.Failed(xerrors.Errorf("Failed to update the MongoDBOrganization with the removed finalizer: %w", err)), log)
+	}
+
+	st.Log(log)
+	return st.ReconcileResult()
+}
+
+func (r *MongoDBOrganizationReconciler) ensureFinalizer(ctx context.Context, organization *projectv1.MongoDBOrganization, log *zap.SugaredLogger) error {
+	if finalizerAdded := controllerutil.AddFinalizer(organization, util.OrganizationFinalizer); finalizerAdded {
+		log.Info("Adding finalizer to the MongoDBOrganization resource")
+		if err := r.client.Update(ctx, organization); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddMongoDBOrganizationController(ctx context.Context, mgr manager.Manager) error {
+	r := newMongoDBOrganizationReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection)
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named(util.MongoDbOrganizationController).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&projectv1.MongoDBOrganization{}).
+		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.resourceWatcher}).
+		Watches(&corev1.ConfigMap{}, &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.resourceWatcher}).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Registered controller %s", util.MongoDbOrganizationController)
+	return nil
+}
@@ -0,0 +1,178 @@
+package operator
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	projectv1 "github.com/mongodb/mongodb-kubernetes/api/v1/project"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newTestOrganization() *projectv1.MongoDBOrganization {
+	return &projectv1.MongoDBOrganization{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: mock.TestNamespace},
+		Spec: projectv1.MongoDBOrganizationSpec{
+			OpsManagerConfig: mdbv1.PrivateCloudConfig{ConfigMapRef: mdbv1.ConfigMapRef{Name: mock.TestProjectConfigMapName}},
+			Credentials:      mock.TestCredentialsSecretName,
+			Teams: []projectv1.Team{
+				{Name: "dbas", Usernames: []string{"alice", "bob"}},
+				{Name: "developers", Usernames: []string{"carol"}},
+			},
+		},
+	}
+}
+
+// newSharedMockedOmConnection returns the mocked connection returned by the factory for any context, so the
+// organizations, teams and API keys are shared between the controllers like in a single Ops Manager instance.
+func newSharedMockedOmConnection() (*om.MockedOmConnection, om.ConnectionFactory) {
+	conn := om.NewEmptyMockedOmConnection(&om.OMContext{}).(*om.MockedOmConnection)
+	return conn, func(*om.OMContext) om.Connection {
+		return conn
+	}
+}
+
+func reconcileOrganization(ctx context.Context, t *testing.T, reconciler *MongoDBOrganizationReconciler, kubeClient client.Client, organization *projectv1.MongoDBOrganization) {
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: organization.NamespacedName()})
+	require.NoError(t, err)
+	require.NoError(t, kubeClient.Get(ctx, organization.NamespacedName(), organization))
+}
+
+func findOmOrganization(conn *om.MockedOmConnection, name string) *om.Organization {
+	for organization := range conn.OrganizationsWithGroups {
+		if organization.Name == name {
+			return organization
+		}
+	}
+	return nil
+}
+
+func teamUsernames(t *testing.T, conn *om.MockedOmConnection, teamID string) []string {
+	users, err := conn.ReadTeamUsers(teamID)
+	require.NoError(t, err)
+	var usernames []string
+	for _, user := range users {
+		usernames = append(usernames, user.Username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+func TestOrganizationAndTeamsAreCreated_OnSuccessfulReconciliation(t *testing.T) {
+	ctx := context.Background()
+	organization := newTestOrganization()
+	kubeClient, _ := mock.NewDefaultFakeClient(organization)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	reconciler := newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+
+	assert.Equal(t, status.PhaseRunning, organization.Status.Phase)
+	assert.Contains(t, organization.Finalizers, util.OrganizationFinalizer)
+
+	omOrganization := findOmOrganization(conn, "payments")
+	require.NotNil(t, omOrganization)
+	assert.Equal(t, omOrganization.ID, organization.Status.OrganizationID)
+
+	require.Len(t, organization.Status.Teams, 2)
+	require.Len(t, conn.Teams, 2)
+	assert.Equal(t, "dbas", conn.Teams[organization.Status.Teams["dbas"]].Name)
+	assert.Equal(t, []string{"alice", "bob"}, teamUsernames(t, conn, organization.Status.Teams["dbas"]))
+	assert.Equal(t, []string{"carol"}, teamUsernames(t, conn, organization.Status.Teams["developers"]))
+}
+
+func TestExistingOrganizationAndTeamAreReused(t *testing.T) {
+	ctx := context.Background()
+	organization := newTestOrganization()
+	kubeClient, _ := mock.NewDefaultFakeClient(organization)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	existingOrganization := &om.Organization{ID: "existing-org", Name: "payments"}
+	conn.OrganizationsWithGroups[existingOrganization] = []*om.Project{}
+	conn.Teams["existing-team"] = &team.Team{ID: "existing-team", Name: "dbas", Usernames: []string{"alice", "dave"}}
+	reconciler := newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+
+	assert.Equal(t, status.PhaseRunning, organization.Status.Phase)
+	assert.Equal(t, "existing-org", organization.Status.OrganizationID)
+	assert.Equal(t, "existing-team", organization.Status.Teams["dbas"])
+	assert.Equal(t, []string{"alice", "bob"}, teamUsernames(t, conn, "existing-team"))
+	assert.Len(t, conn.Teams, 2)
+}
+
+func TestOrganizationTeamsAreUpdated_OnSubsequentReconciliation(t *testing.T) {
+	ctx := context.Background()
+	organization := newTestOrganization()
+	kubeClient, _ := mock.NewDefaultFakeClient(organization)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	reconciler := newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+	dbasID := organization.Status.Teams["dbas"]
+	developersID := organization.Status.Teams["developers"]
+
+	organization.Spec.Teams = []projectv1.Team{{Name: "dbas", Usernames: []string{"bob", "erin"}}}
+	require.NoError(t, kubeClient.Update(ctx, organization))
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+
+	assert.Equal(t, status.PhaseRunning, organization.Status.Phase)
+	assert.Equal(t, map[string]string{"dbas": dbasID}, organization.Status.Teams)
+	assert.Equal(t, []string{"bob", "erin"}, teamUsernames(t, conn, dbasID))
+	assert.NotContains(t, conn.Teams, developersID)
+}
+
+func TestOrganizationTeamsAreRemoved_WhenResourceIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	organization := newTestOrganization()
+	kubeClient, _ := mock.NewDefaultFakeClient(organization)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	conn.Teams["unmanaged-team"] = &team.Team{ID: "unmanaged-team", Name: "auditors", Usernames: []string{"frank"}}
+	reconciler := newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+	require.NoError(t, kubeClient.Delete(ctx, organization))
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: organization.NamespacedName()})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"unmanaged-team"}, teamIDs(conn))
+	assert.NotNil(t, findOmOrganization(conn, "payments"), "the organization is left in Ops Manager")
+	err = kubeClient.Get(ctx, organization.NamespacedName(), organization)
+	assert.True(t, apiErrors.IsNotFound(err), "the organization should not exist")
+}
+
+func TestOrganizationReconciliation_IsInvalidForDuplicatedTeams(t *testing.T) {
+	ctx := context.Background()
+	organization := newTestOrganization()
+	organization.Spec.Teams[1].Name = "dbas"
+	kubeClient, _ := mock.NewDefaultFakeClient(organization)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	reconciler := newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileOrganization(ctx, t, reconciler, kubeClient, organization)
+
+	assert.Equal(t, status.PhaseFailed, organization.Status.Phase)
+	assert.Contains(t, organization.Status.Message, "The team dbas is specified more than once")
+	assert.Empty(t, conn.Teams)
+}
+
+func teamIDs(conn *om.MockedOmConnection) []string {
+	var ids []string
+	for id := range conn.Teams {
+		ids = append(ids, id)
+	}
+	return ids
+}
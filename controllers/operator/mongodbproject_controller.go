@@ -0,0 +1,539 @@
+package operator
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	projectv1 "github.com/mongodb/mongodb-kubernetes/api/v1/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/projectsettings"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
+)
+
+type MongoDBProjectReconciler struct {
+	*ReconcileCommonController
+	omConnectionFactory om.ConnectionFactory
+}
+
+func newMongoDBProjectReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory) *MongoDBProjectReconciler {
+	return &MongoDBProjectReconciler{
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		omConnectionFactory:       omFunc,
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodbprojects,mongodbprojects/status,mongodbprojects/finalizers},verbs=*,namespace=placeholder
+
+// Reconciles a mongodbprojects.mongodb.com Custom resource.
+func (r *MongoDBProjectReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
+	log := zap.S().With("MongoDBProject", request.NamespacedName)
+	log.Info("-> MongoDBProject.Reconcile")
+
+	mdbProject := &projectv1.MongoDBProject{}
+	if result, err := r.GetResource(ctx, request, mdbProject, log); err != nil {
+		return result, err
+	}
+
+	organization, err := r.getOrganization(ctx, mdbProject)
+	if err != nil {
+		log.Warnf("Couldn't fetch MongoDBOrganization %s: %s", mdbProject.OrganizationNamespacedName(), err)
+		// without the organization there are no credentials to remove the API keys with, so it's not blocking the deletion
+		if !mdbProject.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(mdbProject, util.ProjectFinalizer) {
+			return r.removeFinalizer(ctx, mdbProject, workflow.Pending("Finalizer will be removed. MongoDBOrganization resource not found"), log)
+		}
+		return r.updateStatus(ctx, mdbProject, workflow.Pending("%s", err.Error()), log)
+	}
+
+	opsManagerConfig, credentials, err := readOrganizationConnectionDetails(ctx, r.client, r.SecretClient, r.resourceWatcher, organization, mdbProject.NamespacedName(), log)
+	if err != nil {
+		if !mdbProject.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(mdbProject, util.ProjectFinalizer) {
+			log.Warnf("The API keys of the project can't be removed: %s", err)
+			return r.removeFinalizer(ctx, mdbProject, workflow.OK(), log)
+		}
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(err), log)
+	}
+
+	if !mdbProject.DeletionTimestamp.IsZero() {
+		log.Info("MongoDBProject is being deleted")
+
+		if controllerutil.ContainsFinalizer(mdbProject, util.ProjectFinalizer) {
+			conn := project.NewOrganizationConnection(opsManagerConfig, credentials, mdbProject.Status.OrganizationID, r.omConnectionFactory)
+			return r.preDeletionCleanup(ctx, mdbProject, conn, log)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if organization.Status.OrganizationID == "" {
+		return r.updateStatus(ctx, mdbProject, workflow.Pending("The organization of the MongoDBOrganization %s is not created in Ops Manager yet", organization.Name), log)
+	}
+
+	if err := validateProjectSpec(mdbProject.Spec); err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Invalid("%s", err.Error()), log)
+	}
+
+	if err := r.ensureFinalizer(ctx, mdbProject, log); err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to add finalizer: %w", err)), log)
+	}
+
+	opsManagerConfig.ProjectName = mdbProject.GetProjectName()
+	opsManagerConfig.OrgID = organization.Status.OrganizationID
+	omProject, conn, err := project.ReadOrCreateProject(opsManagerConfig, credentials, r.omConnectionFactory, log)
+	if err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("error reading or creating project in Ops Manager: %w", err)), log)
+	}
+	projectOption := projectv1.NewProjectOption(omProject.ID, omProject.OrgID, mdbProject.ProjectConfigMapName())
+
+	for _, tag := range mdbProject.Spec.Tags {
+		if err := connection.EnsureTagAdded(conn, omProject, tag, log); err != nil {
+			return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to add the tag %s to the project: %w", tag, err)), log, projectOption)
+		}
+	}
+
+	if st := ensureProjectTeams(conn, mdbProject, organization, log); !st.IsOK() {
+		return r.updateStatus(ctx, mdbProject, st, log, projectOption)
+	}
+
+	if err := ensureProjectSettings(conn, mdbProject.Spec.Settings, log); err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to configure the settings of the project: %w", err)), log, projectOption)
+	}
+
+	if mdbProject.Spec.IPAccessList != nil {
+		if err := ensureProjectAccessList(conn, mdbProject.Spec.IPAccessList, log); err != nil {
+			return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to configure the IP access list of the project: %w", err)), log, projectOption)
+		}
+	}
+
+	apiKeys, err := r.ensureAPIKeys(ctx, conn, mdbProject, log)
+	if err != nil {
+		// the API keys created so far are kept in the status, so they are not created twice
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to configure the API keys of the project: %w", err)), log, projectOption, projectv1.NewAPIKeysOption(apiKeys))
+	}
+
+	if err := r.ensureProjectConfigMap(ctx, mdbProject, organization, omProject); err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to create the project ConfigMap: %w", err)), log, projectOption, projectv1.NewAPIKeysOption(apiKeys))
+	}
+
+	log.Infof("Finished reconciliation for MongoDBProject!")
+	return r.updateStatus(ctx, mdbProject, workflow.OK(), log, projectOption, projectv1.NewAPIKeysOption(apiKeys))
+}
+
+func (r *MongoDBProjectReconciler) getOrganization(ctx context.Context, mdbProject *projectv1.MongoDBProject) (*projectv1.MongoDBOrganization, error) {
+	name := mdbProject.OrganizationNamespacedName()
+	r.resourceWatcher.AddWatchedResourceIfNotAdded(name.Name, name.Namespace, watch.MongoDBOrganization, mdbProject.NamespacedName())
+
+	organization := &projectv1.MongoDBOrganization{}
+	if err := r.client.Get(ctx, name, organization); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// validateProjectSpec checks the team and API key names are unique, which can't be expressed in the CRD schema.
+func validateProjectSpec(spec projectv1.MongoDBProjectSpec) error {
+	teamNames := map[string]struct{}{}
+	for _, t := range spec.Teams {
+		if _, ok := teamNames[t.Name]; ok {
+			return xerrors.Errorf("the team %s is specified more than once", t.Name)
+		}
+		teamNames[t.Name] = struct{}{}
+	}
+
+	apiKeyNames := map[string]struct{}{}
+	for _, apiKey := range spec.APIKeys {
+		if _, ok := apiKeyNames[apiKey.Name]; ok {
+			return xerrors.Errorf("the API key %s is specified more than once", apiKey.Name)
+		}
+		apiKeyNames[apiKey.Name] = struct{}{}
+	}
+	return nil
+}
+
+// ensureProjectTeams assigns the teams to the project with the roles from the spec. The teams of the organization
+// managed by the MongoDBOrganization resource which are not in the spec are removed from the project, the other
+// teams assigned in Ops Manager are left untouched.
+func ensureProjectTeams(conn om.Connection, mdbProject *projectv1.MongoDBProject, organization *projectv1.MongoDBOrganization, log *zap.SugaredLogger) workflow.Status {
+	projectTeams, err := conn.ReadProjectTeams()
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("Failed to read the teams of the project: %w", err))
+	}
+	currentTeams := map[string]*team.ProjectTeam{}
+	for _, t := range projectTeams {
+		currentTeams[t.TeamID] = t
+	}
+
+	desiredTeamIDs := map[string]struct{}{}
+	var teamsToAssign []*team.ProjectTeam
+	for _, desiredTeam := range mdbProject.Spec.Teams {
+		teamID, ok := organization.Status.Teams[desiredTeam.Name]
+		if !ok {
+			return workflow.Pending("The team %s is not created in the MongoDBOrganization %s yet", desiredTeam.Name, organization.Name)
+		}
+		desiredTeamIDs[teamID] = struct{}{}
+
+		desiredRoles := projectv1.RoleNames(desiredTeam.Roles)
+		currentTeam, ok := currentTeams[teamID]
+		if !ok {
+			teamsToAssign = append(teamsToAssign, &team.ProjectTeam{TeamID: teamID, RoleNames: desiredRoles})
+			continue
+		}
+		if !sameRoles(currentTeam.RoleNames, desiredRoles) {
+			if err := conn.UpdateProjectTeamRoles(&team.ProjectTeam{TeamID: teamID, RoleNames: desiredRoles}); err != nil {
+				return workflow.Failed(xerrors.Errorf("Failed to update the roles of the team %s: %w", desiredTeam.Name, err))
+			}
+			log.Infof("Updated the roles of the team %s in the project", desiredTeam.Name)
+		}
+	}
+
+	if len(teamsToAssign) > 0 {
+		if err := conn.AssignTeamsToProject(teamsToAssign); err != nil {
+			return workflow.Failed(xerrors.Errorf("Failed to assign the teams to the project: %w", err))
+		}
+		log.Infof("Assigned %d teams to the project", len(teamsToAssign))
+	}
+
+	for name, teamID := range organization.Status.Teams {
+		if _, desired := desiredTeamIDs[teamID]; desired {
+			continue
+		}
+		if _, assigned := currentTeams[teamID]; assigned {
+			if err := conn.RemoveTeamFromProject(teamID); err != nil {
+				return workflow.Failed(xerrors.Errorf("Failed to remove the team %s from the project: %w", name, err))
+			}
+			log.Infof("Removed the team %s from the project", name)
+		}
+	}
+
+	return workflow.OK()
+}
+
+// ensureAPIKeys makes the API keys of the project match the spec and stores their public and private keys into
+// Secrets. As the private key is only returned by Ops Manager on creation, the API key is created again if its
+// Secret is lost. It returns the status of the managed API keys, including the ones created before an error.
+func (r *MongoDBProjectReconciler) ensureAPIKeys(ctx context.Context, conn om.Connection, mdbProject *projectv1.MongoDBProject, log *zap.SugaredLogger) ([]projectv1.APIKeyStatus, error) {
+	var apiKeys []projectv1.APIKeyStatus
+	withUnprocessed := func() []projectv1.APIKeyStatus {
+		for _, apiKeyStatus := range mdbProject.Status.APIKeys {
+			if !slices.ContainsFunc(apiKeys, func(s projectv1.APIKeyStatus) bool { return s.Name == apiKeyStatus.Name }) {
+				apiKeys = append(apiKeys, apiKeyStatus)
+			}
+		}
+		return apiKeys
+	}
+
+	desiredNames := map[string]struct{}{}
+	for _, desiredAPIKey := range mdbProject.Spec.APIKeys {
+		desiredNames[desiredAPIKey.Name] = struct{}{}
+		secretName := mdbProject.APIKeySecretName(desiredAPIKey)
+		r.resourceWatcher.AddWatchedResourceIfNotAdded(secretName, mdbProject.Namespace, watch.Secret, mdbProject.NamespacedName())
+
+		apiKeyStatus, err := r.ensureAPIKey(ctx, conn, mdbProject, desiredAPIKey, log)
+		if err != nil {
+			return withUnprocessed(), xerrors.Errorf("failed to configure the API key %s: %w", desiredAPIKey.Name, err)
+		}
+		apiKeys = append(apiKeys, apiKeyStatus)
+
+		if err := ensureAPIKeyAccessList(conn, apiKeyStatus.ID, desiredAPIKey.AccessList, log); err != nil {
+			return withUnprocessed(), xerrors.Errorf("failed to configure the access list of the API key %s: %w", desiredAPIKey.Name, err)
+		}
+	}
+
+	for _, apiKeyStatus := range mdbProject.Status.APIKeys {
+		if _, ok := desiredNames[apiKeyStatus.Name]; ok {
+			continue
+		}
+		if err := r.deleteAPIKey(ctx, conn, mdbProject, apiKeyStatus); err != nil {
+			return withUnprocessed(), err
+		}
+		log.Infof("Removed the API key %s", apiKeyStatus.Name)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *MongoDBProjectReconciler) ensureAPIKey(ctx context.Context, conn om.Connection, mdbProject *projectv1.MongoDBProject, desiredAPIKey projectv1.ProjectAPIKey, log *zap.SugaredLogger) (projectv1.APIKeyStatus, error) {
+	secretName := mdbProject.APIKeySecretName(desiredAPIKey)
+	desiredRoles := projectv1.RoleNames(desiredAPIKey.Roles)
+
+	if currentStatus := mdbProject.GetAPIKeyStatus(desiredAPIKey.Name); currentStatus != nil {
+		omAPIKey, err := conn.ReadOrganizationAPIKey(currentStatus.ID)
+		if err != nil && !apierror.NewNonNil(err).ErrorAPIKeyIsNotFound() {
+			return projectv1.APIKeyStatus{}, err
+		}
+
+		omAPIKeyExists := err == nil
+		secretIsValid := false
+		if omAPIKeyExists && currentStatus.SecretName == secretName {
+			// only a missing Secret leads to a new API key, any other error is returned before Ops Manager is changed
+			if secretIsValid, err = r.apiKeySecretIsValid(ctx, mdbProject, secretName, omAPIKey.PublicKey); err != nil {
+				return projectv1.APIKeyStatus{}, xerrors.Errorf("failed to read the Secret %s of the API key: %w", secretName, err)
+			}
+		}
+
+		switch {
+		case !omAPIKeyExists:
+			log.Warnf("The API key %s doesn't exist in Ops Manager anymore, creating it again", desiredAPIKey.Name)
+		case !secretIsValid:
+			// the private key can't be read from Ops Manager, so the only way to restore the secret is a new API key
+			log.Warnf("The Secret of the API key %s is missing or outdated, creating the API key again", desiredAPIKey.Name)
+			if err := r.deleteAPIKey(ctx, conn, mdbProject, *currentStatus); err != nil {
+				return projectv1.APIKeyStatus{}, err
+			}
+		default:
+			if !sameRoles(omAPIKey.ProjectRoleNames(conn.GroupID()), desiredRoles) {
+				if err := conn.UpdateProjectAPIKeyRoles(currentStatus.ID, desiredRoles); err != nil {
+					return projectv1.APIKeyStatus{}, err
+				}
+				log.Infof("Updated the roles of the API key %s", desiredAPIKey.Name)
+			}
+			return *currentStatus, nil
+		}
+	}
+
+	created, err := conn.CreateProjectAPIKey(desiredAPIKey.Name, desiredRoles)
+	if err != nil {
+		return projectv1.APIKeyStatus{}, err
+	}
+	log.Infof("Created the API key %s", desiredAPIKey.Name)
+	apiKeyStatus := projectv1.APIKeyStatus{Name: desiredAPIKey.Name, ID: created.ID, PublicKey: created.PublicKey, SecretName: secretName}
+
+	apiKeySecret := secret.Builder().
+		SetName(secretName).
+		SetNamespace(mdbProject.Namespace).
+		SetLabels(mdbProject.GetOwnerLabels()).
+		SetOwnerReferences(kube.BaseOwnerReference(mdbProject)).
+		SetField(util.OmPublicApiKey, created.PublicKey).
+		SetField(util.OmPrivateKey, created.PrivateKey).
+		Build()
+	if err := r.PutSecret(ctx, apiKeySecret, r.operatorSecretPath()); err != nil {
+		// the API key is useless without its private key, so it's removed to be created again on the next reconciliation
+		if deleteErr := conn.DeleteOrganizationAPIKey(created.ID); deleteErr != nil {
+			log.Warnf("Failed to remove the API key %s: %s", desiredAPIKey.Name, deleteErr)
+		}
+		return projectv1.APIKeyStatus{}, xerrors.Errorf("failed to store the API key into the Secret %s: %w", secretName, err)
+	}
+
+	return apiKeyStatus, nil
+}
+
+// apiKeySecretIsValid checks the Secret of the API key exists and contains the key pair of the API key. A missing
+// Secret is not an error, as it is recreated with a new API key.
+func (r *MongoDBProjectReconciler) apiKeySecretIsValid(ctx context.Context, mdbProject *projectv1.MongoDBProject, secretName string, publicKey string) (bool, error) {
+	data, err := r.ReadSecret(ctx, kube.ObjectKey(mdbProject.Namespace, secretName), r.operatorSecretPath())
+	if secrets.SecretNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return data[util.OmPublicApiKey] == publicKey && data[util.OmPrivateKey] != "", nil
+}
+
+func (r *MongoDBProjectReconciler) deleteAPIKey(ctx context.Context, conn om.Connection, mdbProject *projectv1.MongoDBProject, apiKeyStatus projectv1.APIKeyStatus) error {
+	if err := conn.DeleteOrganizationAPIKey(apiKeyStatus.ID); err != nil && !apierror.NewNonNil(err).ErrorAPIKeyIsNotFound() {
+		return xerrors.Errorf("failed to remove the API key %s: %w", apiKeyStatus.Name, err)
+	}
+	if err := r.DeleteSecret(ctx, kube.ObjectKey(mdbProject.Namespace, apiKeyStatus.SecretName)); err != nil && !secrets.SecretNotExist(err) {
+		return xerrors.Errorf("failed to remove the Secret of the API key %s: %w", apiKeyStatus.Name, err)
+	}
+	return nil
+}
+
+func (r *MongoDBProjectReconciler) operatorSecretPath() string {
+	if vault.IsVaultSecretBackend() {
+		return r.VaultClient.OperatorSecretPath()
+	}
+	return ""
+}
+
+// ensureProjectSettings changes the settings of the project which are set in the spec and differ in Ops Manager.
+func ensureProjectSettings(conn om.Connection, settings *projectv1.ProjectSettings, log *zap.SugaredLogger) error {
+	if settings == nil {
+		return nil
+	}
+
+	desiredSettings := projectsettings.ProjectSettings{
+		IsCollectDatabaseSpecificsStatisticsEnabled: settings.CollectDatabaseSpecificsStatistics,
+		IsDataExplorerEnabled:                       settings.DataExplorer,
+		IsPerformanceAdvisorEnabled:                 settings.PerformanceAdvisor,
+		IsRealtimePerformancePanelEnabled:           settings.RealtimePerformancePanel,
+		IsSchemaAdvisorEnabled:                      settings.SchemaAdvisor,
+	}
+	currentSettings, err := conn.ReadProjectSettings()
+	if err != nil {
+		return err
+	}
+	if !currentSettings.Differs(desiredSettings) {
+		return nil
+	}
+
+	if err := conn.UpdateProjectSettings(&desiredSettings); err != nil {
+		return err
+	}
+	log.Info("Updated the settings of the project")
+	return nil
+}
+
+// ensureProjectAccessList adds the missing IP addresses and CIDR blocks to the IP access list of the project, and
+// removes the ones which are not in the spec.
+func ensureProjectAccessList(conn om.Connection, accessList []string, log *zap.SugaredLogger) error {
+	currentEntries, err := conn.ReadProjectAccessList()
+	if err != nil {
+		return err
+	}
+	return ensureAccessList(currentEntries, accessList, conn.AddProjectAccessListEntries, conn.RemoveProjectAccessListEntry, "the project", log)
+}
+
+// ensureAPIKeyAccessList adds the missing IP addresses and CIDR blocks to the access list of the API key, and removes
+// the ones which are not in the spec.
+func ensureAPIKeyAccessList(conn om.Connection, apiKeyID string, accessList []string, log *zap.SugaredLogger) error {
+	currentEntries, err := conn.ReadAPIKeyAccessList(apiKeyID)
+	if err != nil {
+		return err
+	}
+	addEntries := func(entries []*apikey.AccessListEntry) error {
+		return conn.AddAPIKeyAccessListEntries(apiKeyID, entries)
+	}
+	removeEntry := func(entry string) error {
+		return conn.RemoveAPIKeyAccessListEntry(apiKeyID, entry)
+	}
+	return ensureAccessList(currentEntries, accessList, addEntries, removeEntry, "the API key "+apiKeyID, log)
+}
+
+// ensureAccessList makes the current entries of an access list match the desired IP addresses and CIDR blocks.
+func ensureAccessList(currentEntries []*apikey.AccessListEntry, accessList []string, addEntries func([]*apikey.AccessListEntry) error, removeEntry func(string) error, owner string, log *zap.SugaredLogger) error {
+	var entriesToAdd []*apikey.AccessListEntry
+	for _, desiredEntry := range accessList {
+		if slices.ContainsFunc(currentEntries, func(e *apikey.AccessListEntry) bool { return e.Matches(desiredEntry) }) {
+			continue
+		}
+		if strings.Contains(desiredEntry, "/") {
+			entriesToAdd = append(entriesToAdd, &apikey.AccessListEntry{CIDRBlock: desiredEntry})
+		} else {
+			entriesToAdd = append(entriesToAdd, &apikey.AccessListEntry{IPAddress: desiredEntry})
+		}
+	}
+	if len(entriesToAdd) > 0 {
+		if err := addEntries(entriesToAdd); err != nil {
+			return err
+		}
+		log.Debugf("Added %d entries to the access list of %s", len(entriesToAdd), owner)
+	}
+
+	for _, currentEntry := range currentEntries {
+		if slices.ContainsFunc(accessList, currentEntry.Matches) {
+			continue
+		}
+		if err := removeEntry(currentEntry.Value()); err != nil {
+			return err
+		}
+		log.Debugf("Removed %s from the access list of %s", currentEntry.Value(), owner)
+	}
+
+	return nil
+}
+
+// ensureProjectConfigMap creates the ConfigMap which can be referenced by the MongoDB resources deployed in the
+// project. It copies the TLS settings of the organization ConfigMap.
+func (r *MongoDBProjectReconciler) ensureProjectConfigMap(ctx context.Context, mdbProject *projectv1.MongoDBProject, organization *projectv1.MongoDBOrganization, omProject *om.Project) error {
+	data, err := configmap.ReadData(ctx, r.client, organization.GetOpsManagerConfigMapKey())
+	if err != nil {
+		return err
+	}
+	data[util.OmOrgId] = omProject.OrgID
+	data[util.OmProjectName] = omProject.Name
+
+	cm := configmap.Builder().
+		SetName(mdbProject.ProjectConfigMapName()).
+		SetNamespace(mdbProject.Namespace).
+		SetLabels(mdbProject.GetOwnerLabels()).
+		SetOwnerReferences(kube.BaseOwnerReference(mdbProject)).
+		SetData(data).
+		Build()
+	return configmap.CreateOrUpdate(ctx, r.client, cm)
+}
+
+// preDeletionCleanup removes the API keys managed by the resource. The project itself is left in Ops Manager, as it
+// may still contain deployments.
+func (r *MongoDBProjectReconciler) preDeletionCleanup(ctx context.Context, mdbProject *projectv1.MongoDBProject, conn om.Connection, log *zap.SugaredLogger) (reconcile.Result, error) {
+	log.Info("Performing pre deletion cleanup before deleting MongoDBProject")
+
+	for _, apiKeyStatus := range mdbProject.Status.APIKeys {
+		if err := conn.DeleteOrganizationAPIKey(apiKeyStatus.ID); err != nil && !apierror.NewNonNil(err).ErrorAPIKeyIsNotFound() {
+			return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to remove the API key %s: %w", apiKeyStatus.Name, err)), log)
+		}
+	}
+
+	r.resourceWatcher.RemoveAllDependentWatchedResources(mdbProject.Namespace, mdbProject.NamespacedName())
+	return r.removeFinalizer(ctx, mdbProject, workflow.OK(), log)
+}
+
+func (r *MongoDBProjectReconciler) removeFinalizer(ctx context.Context, mdbProject *projectv1.MongoDBProject, st workflow.Status, log *zap.SugaredLogger) (reconcile.Result, error) {
+	controllerutil.RemoveFinalizer(mdbProject, util.ProjectFinalizer)
+	if err := r.client.Update(ctx, mdbProject); err != nil {
+		return r.updateStatus(ctx, mdbProject, workflow.Failed(xerrors.Errorf("Failed to update the MongoDBProject with the removed finalizer: %w", err)), log)
+	}
+
+	st.Log(log)
+	return st.ReconcileResult()
+}
+
+func (r *MongoDBProjectReconciler) ensureFinalizer(ctx context.Context, mdbProject *projectv1.MongoDBProject, log *zap.SugaredLogger) error {
+	if finalizerAdded := controllerutil.AddFinalizer(mdbProject, util.ProjectFinalizer); finalizerAdded {
+		log.Info("Adding finalizer to the MongoDBProject resource")
+		if err := r.client.Update(ctx, mdbProject); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sameRoles returns whether both lists contain the same roles, regardless of their order.
+func sameRoles(roles []string, otherRoles []string) bool {
+	sortedRoles := slices.Sorted(slices.Values(roles))
+	sortedOtherRoles := slices.Sorted(slices.Values(otherRoles))
+	return slices.Equal(sortedRoles, sortedOtherRoles)
+}
+
+func AddMongoDBProjectController(ctx context.Context, mgr manager.Manager) error {
+	r := newMongoDBProjectReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection)
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named(util.MongoDbProjectController).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&projectv1.MongoDBProject{}).
+		Watches(&projectv1.MongoDBOrganization{}, &watch.ResourcesHandler{ResourceType: watch.MongoDBOrganization, ResourceWatcher: r.resourceWatcher}).
+		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.resourceWatcher}).
+		Watches(&corev1.ConfigMap{}, &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.resourceWatcher}).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Registered controller %s", util.MongoDbProjectController)
+	return nil
+}
@@ -0,0 +1,300 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	projectv1 "github.com/mongodb/mongodb-kubernetes/api/v1/project"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newTestProject() *projectv1.MongoDBProject {
+	return &projectv1.MongoDBProject{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments-api", Namespace: mock.TestNamespace},
+		Spec: projectv1.MongoDBProjectSpec{
+			OrganizationRef: corev1.LocalObjectReference{Name: "payments"},
+			Tags:            []string{"payments"},
+			Teams: []projectv1.ProjectTeam{
+				{Name: "dbas", Roles: []projectv1.ProjectRole{"GROUP_OWNER"}},
+			},
+			APIKeys: []projectv1.ProjectAPIKey{
+				{
+					Name:       "ci",
+					Roles:      []projectv1.ProjectRole{"GROUP_AUTOMATION_ADMIN"},
+					AccessList: []string{"10.0.0.1", "192.168.0.0/24"},
+				},
+			},
+			Settings:     &projectv1.ProjectSettings{DataExplorer: ptr.To(false), PerformanceAdvisor: ptr.To(true)},
+			IPAccessList: []string{"10.1.0.0/16"},
+		},
+	}
+}
+
+// projectReconcilerWithOrganization returns the reconciler of the project after the referenced organization was
+// created in Ops Manager.
+func projectReconcilerWithOrganization(ctx context.Context, t *testing.T, mdbProject *projectv1.MongoDBProject) (*MongoDBProjectReconciler, client.Client, *om.MockedOmConnection, *projectv1.MongoDBOrganization) {
+	organization := newTestOrganization()
+	kubeClient, _ := mock.NewDefaultFakeClient(organization, mdbProject)
+	conn, connectionFactory := newSharedMockedOmConnection()
+
+	reconcileOrganization(ctx, t, newMongoDBOrganizationReconciler(ctx, kubeClient, connectionFactory), kubeClient, organization)
+	require.Equal(t, status.PhaseRunning, organization.Status.Phase)
+
+	return newMongoDBProjectReconciler(ctx, kubeClient, connectionFactory), kubeClient, conn, organization
+}
+
+func reconcileProject(ctx context.Context, t *testing.T, reconciler *MongoDBProjectReconciler, kubeClient client.Client, mdbProject *projectv1.MongoDBProject) {
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: mdbProject.NamespacedName()})
+	require.NoError(t, err)
+	require.NoError(t, kubeClient.Get(ctx, mdbProject.NamespacedName(), mdbProject))
+}
+
+func accessListValues(conn *om.MockedOmConnection, apiKeyID string) []string {
+	var values []string
+	for _, entry := range conn.APIKeyAccessLists[apiKeyID] {
+		values = append(values, entry.Value())
+	}
+	return values
+}
+
+func TestProjectIsCreated_OnSuccessfulReconciliation(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	reconciler, kubeClient, conn, organization := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhaseRunning, mdbProject.Status.Phase)
+	assert.Contains(t, mdbProject.Finalizers, util.ProjectFinalizer)
+	assert.Equal(t, om.TestGroupID, mdbProject.Status.ProjectID)
+	assert.Equal(t, organization.Status.OrganizationID, mdbProject.Status.OrganizationID)
+	assert.Equal(t, "payments-api-project-config", mdbProject.Status.ConfigMapName)
+
+	omProject, err := conn.ReadProjectsInOrganizationByName(organization.Status.OrganizationID, "payments-api")
+	require.NoError(t, err)
+	require.Len(t, omProject, 1)
+	assert.Contains(t, omProject[0].Tags, "PAYMENTS")
+
+	dbasID := organization.Status.Teams["dbas"]
+	require.Contains(t, conn.ProjectTeams, dbasID)
+	assert.Equal(t, []string{"GROUP_OWNER"}, conn.ProjectTeams[dbasID].RoleNames)
+	assert.NotContains(t, conn.ProjectTeams, organization.Status.Teams["developers"])
+
+	require.Len(t, mdbProject.Status.APIKeys, 1)
+	apiKeyStatus := mdbProject.Status.APIKeys[0]
+	assert.Equal(t, "payments-api-ci", apiKeyStatus.SecretName)
+	omAPIKey := conn.APIKeys[apiKeyStatus.ID]
+	require.NotNil(t, omAPIKey)
+	assert.Equal(t, "ci", omAPIKey.Description)
+	assert.Equal(t, []string{"GROUP_AUTOMATION_ADMIN"}, omAPIKey.ProjectRoleNames(om.TestGroupID))
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/24"}, accessListValues(conn, apiKeyStatus.ID))
+
+	assert.Equal(t, ptr.To(false), conn.ProjectSettings.IsDataExplorerEnabled)
+	assert.Equal(t, ptr.To(true), conn.ProjectSettings.IsPerformanceAdvisorEnabled)
+	assert.Nil(t, conn.ProjectSettings.IsSchemaAdvisorEnabled)
+	require.Len(t, conn.ProjectAccessList, 1)
+	assert.Equal(t, "10.1.0.0/16", conn.ProjectAccessList[0].Value())
+
+	apiKeySecret := &corev1.Secret{}
+	require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(mock.TestNamespace, "payments-api-ci"), apiKeySecret))
+	assert.Equal(t, omAPIKey.PublicKey, string(apiKeySecret.Data[util.OmPublicApiKey]))
+	assert.Equal(t, omAPIKey.PrivateKey, string(apiKeySecret.Data[util.OmPrivateKey]))
+
+	projectConfigMap := &corev1.ConfigMap{}
+	require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(mock.TestNamespace, "payments-api-project-config"), projectConfigMap))
+	assert.Equal(t, "http://mycompany.example.com:8080", projectConfigMap.Data[util.OmBaseUrl])
+	assert.Equal(t, organization.Status.OrganizationID, projectConfigMap.Data[util.OmOrgId])
+	assert.Equal(t, "payments-api", projectConfigMap.Data[util.OmProjectName])
+}
+
+func TestProjectReconciliation_IsPendingUntilOrganizationIsCreated(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	kubeClient, _ := mock.NewDefaultFakeClient(newTestOrganization(), mdbProject)
+	conn, connectionFactory := newSharedMockedOmConnection()
+	reconciler := newMongoDBProjectReconciler(ctx, kubeClient, connectionFactory)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhasePending, mdbProject.Status.Phase)
+	assert.Empty(t, conn.APIKeys)
+}
+
+func TestProjectReconciliation_IsPendingIfTeamIsMissing(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	mdbProject.Spec.Teams = append(mdbProject.Spec.Teams, projectv1.ProjectTeam{Name: "auditors", Roles: []projectv1.ProjectRole{"GROUP_READ_ONLY"}})
+	reconciler, kubeClient, conn, _ := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhasePending, mdbProject.Status.Phase)
+	assert.Contains(t, mdbProject.Status.Message, "The team auditors is not created in the MongoDBOrganization payments yet")
+	assert.Empty(t, conn.ProjectTeams)
+}
+
+func TestProjectIsUpdated_OnSubsequentReconciliation(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	mdbProject.Spec.APIKeys = append(mdbProject.Spec.APIKeys, projectv1.ProjectAPIKey{Name: "monitoring", Roles: []projectv1.ProjectRole{"GROUP_READ_ONLY"}})
+	reconciler, kubeClient, conn, organization := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+	ciStatus := *mdbProject.GetAPIKeyStatus("ci")
+	monitoringStatus := *mdbProject.GetAPIKeyStatus("monitoring")
+
+	mdbProject.Spec.Teams = []projectv1.ProjectTeam{{Name: "developers", Roles: []projectv1.ProjectRole{"GROUP_READ_ONLY"}}}
+	mdbProject.Spec.APIKeys = []projectv1.ProjectAPIKey{
+		{Name: "ci", Roles: []projectv1.ProjectRole{"GROUP_OWNER"}, AccessList: []string{"192.168.0.0/24", "10.0.0.2"}},
+	}
+	require.NoError(t, kubeClient.Update(ctx, mdbProject))
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhaseRunning, mdbProject.Status.Phase)
+	assert.NotContains(t, conn.ProjectTeams, organization.Status.Teams["dbas"])
+	assert.Equal(t, []string{"GROUP_READ_ONLY"}, conn.ProjectTeams[organization.Status.Teams["developers"]].RoleNames)
+
+	assert.Equal(t, []projectv1.APIKeyStatus{ciStatus}, mdbProject.Status.APIKeys)
+	assert.Equal(t, []string{"GROUP_OWNER"}, conn.APIKeys[ciStatus.ID].ProjectRoleNames(om.TestGroupID))
+	assert.ElementsMatch(t, []string{"192.168.0.0/24", "10.0.0.2"}, accessListValues(conn, ciStatus.ID))
+
+	assert.NotContains(t, conn.APIKeys, monitoringStatus.ID)
+	err := kubeClient.Get(ctx, kube.ObjectKey(mock.TestNamespace, monitoringStatus.SecretName), &corev1.Secret{})
+	assert.True(t, apiErrors.IsNotFound(err), "the secret of the removed API key should not exist")
+}
+
+func TestAPIKeyIsRecreated_IfSecretIsLost(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	reconciler, kubeClient, conn, _ := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+	previousID := mdbProject.Status.APIKeys[0].ID
+	require.NoError(t, kubeClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "payments-api-ci", Namespace: mock.TestNamespace}}))
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhaseRunning, mdbProject.Status.Phase)
+	require.Len(t, mdbProject.Status.APIKeys, 1)
+	newID := mdbProject.Status.APIKeys[0].ID
+	assert.NotEqual(t, previousID, newID)
+	assert.Equal(t, []string{newID}, apiKeyIDs(conn))
+
+	apiKeySecret := &corev1.Secret{}
+	require.NoError(t, kubeClient.Get(ctx, kube.ObjectKey(mock.TestNamespace, "payments-api-ci"), apiKeySecret))
+	assert.Equal(t, conn.APIKeys[newID].PrivateKey, string(apiKeySecret.Data[util.OmPrivateKey]))
+}
+
+// secretReadFailingClient fails to read the Secret with the given name with an error other than NotFound.
+type secretReadFailingClient struct {
+	client.Client
+	secretName string
+}
+
+func (c secretReadFailingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Secret); ok && key.Name == c.secretName {
+		return apiErrors.NewForbidden(corev1.Resource("secrets"), key.Name, xerrors.New("access denied"))
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestAPIKeyIsKept_IfSecretCannotBeRead(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	reconciler, kubeClient, conn, _ := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+	apiKeyStatus := mdbProject.Status.APIKeys[0]
+
+	reconciler = newMongoDBProjectReconciler(ctx, secretReadFailingClient{Client: kubeClient, secretName: "payments-api-ci"}, func(*om.OMContext) om.Connection { return conn })
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+
+	assert.Equal(t, status.PhaseFailed, mdbProject.Status.Phase)
+	assert.Contains(t, mdbProject.Status.Message, "failed to read the Secret payments-api-ci of the API key")
+	assert.Equal(t, []projectv1.APIKeyStatus{apiKeyStatus}, mdbProject.Status.APIKeys)
+	assert.Equal(t, []string{apiKeyStatus.ID}, apiKeyIDs(conn))
+}
+
+func TestAPIKeysAreRemoved_WhenProjectIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	mdbProject := newTestProject()
+	reconciler, kubeClient, conn, _ := projectReconcilerWithOrganization(ctx, t, mdbProject)
+
+	reconcileProject(ctx, t, reconciler, kubeClient, mdbProject)
+	require.NotEmpty(t, conn.APIKeys)
+	require.NoError(t, kubeClient.Delete(ctx, mdbProject))
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: mdbProject.NamespacedName()})
+	require.NoError(t, err)
+
+	assert.Empty(t, conn.APIKeys)
+	err = kubeClient.Get(ctx, mdbProject.NamespacedName(), mdbProject)
+	assert.True(t, apiErrors.IsNotFound(err), "the project should not exist")
+}
+
+func TestEnsureAPIKeyAccessList(t *testing.T) {
+	conn := om.NewEmptyMockedOmConnection(&om.OMContext{}).(*om.MockedOmConnection)
+	// Ops Manager returns both the IP address and the CIDR block for the entries added as an IP address
+	conn.APIKeyAccessLists["key"] = []*apikey.AccessListEntry{
+		{IPAddress: "10.0.0.1", CIDRBlock: "10.0.0.1/32"},
+		{IPAddress: "10.0.0.2", CIDRBlock: "10.0.0.2/32"},
+	}
+
+	require.NoError(t, ensureAPIKeyAccessList(conn, "key", []string{"10.0.0.1", "172.16.0.0/12"}, zap.S()))
+
+	assert.Equal(t, []string{"10.0.0.1/32", "172.16.0.0/12"}, accessListValues(conn, "key"))
+}
+
+func TestEnsureProjectAccessList(t *testing.T) {
+	conn := om.NewEmptyMockedOmConnection(&om.OMContext{}).(*om.MockedOmConnection)
+	conn.ProjectAccessList = []*apikey.AccessListEntry{
+		{IPAddress: "10.0.0.1", CIDRBlock: "10.0.0.1/32"},
+		{CIDRBlock: "192.168.0.0/24"},
+	}
+
+	require.NoError(t, ensureProjectAccessList(conn, []string{"10.0.0.1", "172.16.0.0/12"}, zap.S()))
+
+	var values []string
+	for _, entry := range conn.ProjectAccessList {
+		values = append(values, entry.Value())
+	}
+	assert.Equal(t, []string{"10.0.0.1/32", "172.16.0.0/12"}, values)
+}
+
+func TestEnsureProjectSettings_OnlyUpdatesDifferentSettings(t *testing.T) {
+	conn := om.NewEmptyMockedOmConnection(&om.OMContext{}).(*om.MockedOmConnection)
+	conn.ProjectSettings.IsDataExplorerEnabled = ptr.To(true)
+
+	require.NoError(t, ensureProjectSettings(conn, &projectv1.ProjectSettings{DataExplorer: ptr.To(true)}, zap.S()))
+	conn.CheckOperationsDidntHappen(t, reflect.ValueOf(conn.UpdateProjectSettings))
+
+	require.NoError(t, ensureProjectSettings(conn, &projectv1.ProjectSettings{DataExplorer: ptr.To(false), SchemaAdvisor: ptr.To(true)}, zap.S()))
+	assert.Equal(t, ptr.To(false), conn.ProjectSettings.IsDataExplorerEnabled)
+	assert.Equal(t, ptr.To(true), conn.ProjectSettings.IsSchemaAdvisorEnabled)
+}
+
+func apiKeyIDs(conn *om.MockedOmConnection) []string {
+	var ids []string
+	for id := range conn.APIKeys {
+		ids = append(ids, id)
+	}
+	return ids
+}
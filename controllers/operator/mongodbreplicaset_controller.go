@@ -9,7 +9,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -39,8 +38,10 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	enterprisepem "github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/recovery"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/storageautoscaling"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
@@ -54,6 +55,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -177,7 +179,7 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	reconciler := r.reconciler
 
 	// === 1. Initial Checks and setup
-	if !architectures.IsRunningStaticArchitecture(rs.Annotations) {
+	if !architectures.IsRunningStaticArchitecture(rs.Annotations) && !plan.IsEnabled(rs) {
 		agents.UpgradeAllIfNeeded(ctx, agents.ClientSecret{Client: reconciler.client, SecretClient: reconciler.SecretClient}, reconciler.omConnectionFactory, GetWatchedNamespace(), false)
 	}
 
@@ -189,12 +191,16 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 		return r.updateStatus(ctx, workflow.Invalid("%s", err.Error()))
 	}
 
+	if err := reconciler.validateReferences(ctx, rs, reconciler.enableClusterMongoDBRoles, log); err != nil {
+		return r.updateStatus(ctx, workflow.Invalid("%s", err.Error()))
+	}
+
 	projectConfig, credsConfig, err := project.ReadConfigAndCredentials(ctx, reconciler.client, reconciler.SecretClient, rs, log)
 	if err != nil {
 		return r.updateStatus(ctx, workflow.Failed(err))
 	}
 
-	conn, _, err := connection.PrepareOpsManagerConnection(ctx, reconciler.SecretClient, projectConfig, credsConfig, reconciler.omConnectionFactory, rs.Namespace, log)
+	conn, _, err := prepareOpsManagerConnection(ctx, reconciler.SecretClient, rs, projectConfig, credsConfig, reconciler.omConnectionFactory, log)
 	if err != nil {
 		return r.updateStatus(ctx, workflow.Failed(xerrors.Errorf("failed to prepare Ops Manager connection: %w", err)))
 	}
@@ -214,10 +220,6 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 		return r.updateStatus(ctx, status)
 	}
 
-	if status := controlledfeature.EnsureFeatureControls(*rs, conn, conn.OpsManagerVersion(), log); !status.IsOK() {
-		return r.updateStatus(ctx, status)
-	}
-
 	// === 2. Auth and Certificates
 	// Get certificate paths for later use
 	rsCertsConfig := certs.ReplicaSetConfig(*rs)
@@ -237,6 +239,18 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 		tlsCertPath = fmt.Sprintf("%s/%s", util.TLSCertMountPath, tlsCertHash)
 	}
 
+	// The changes are only planned before any of them is applied to Ops Manager
+	if plan.IsEnabled(rs) {
+		return r.updateStatus(ctx, r.planOmDeploymentRs(ctx, conn, tlsCertPath, internalClusterCertPath))
+	}
+	if err := plan.Remove(ctx, reconciler.client, rs); err != nil {
+		return r.updateStatus(ctx, workflow.Failed(err))
+	}
+
+	if status := controlledfeature.EnsureFeatureControls(*rs, conn, conn.OpsManagerVersion(), log); !status.IsOK() {
+		return r.updateStatus(ctx, status)
+	}
+
 	agentCertSecretName := rs.GetSecurity().AgentClientCertificateSecretName(rs.Name)
 	agentCertHash, agentCertPath := reconciler.agentCertHashAndPath(ctx, log, rs.Namespace, agentCertSecretName, databaseSecretPath)
 
@@ -321,7 +335,12 @@ func (r *ReplicaSetReconcilerHelper) Reconcile(ctx context.Context) (reconcile.R
 	}
 
 	log.Infof("Finished reconciliation for MongoDbReplicaSet! %s", completionMessage(conn.BaseURL(), conn.GroupID()))
-	return r.updateStatus(ctx, workflow.OK(), mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.MembersOption(rs), mdbstatus.NewPVCsStatusOptionEmptyStatus())
+	okStatus := workflow.OK()
+	if rs.GetStorageAutoscaling() != nil {
+		// the disk usage needs to be checked periodically while storage autoscaling is enabled
+		okStatus = okStatus.WithRequeueAfter(storageautoscaling.CheckInterval)
+	}
+	return r.updateStatus(ctx, okStatus, mdbstatus.NewBaseUrlOption(deployment.Link(conn.BaseURL(), conn.GroupID())), mdbstatus.MembersOption(rs), mdbstatus.NewPVCsStatusOptionEmptyStatus())
 }
 
 func newReplicaSetReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, omFunc om.ConnectionFactory) *ReconcileMongoDbReplicaSet {
@@ -514,6 +533,11 @@ func (r *ReplicaSetReconcilerHelper) reconcileStatefulSet(ctx context.Context, c
 
 	sts := construct.DatabaseStatefulSet(*rs, rsConfig, log)
 
+	// this is also needed when autoscaling is disabled, to keep the size the data volume has been expanded to
+	if err := storageautoscaling.EnsureDataVolumeSize(ctx, reconciler.client, conn, rs.GetStorageAutoscaling(), &sts, getAllHostsForReplicas(rs, scale.ReplicasThisReconciliation(rs)), log); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to autoscale the storage: %w", err))
+	}
+
 	// Handle PVC resize if needed
 	if workflowStatus := r.handlePVCResize(ctx, &sts); !workflowStatus.IsOK() {
 		return workflowStatus
@@ -534,7 +558,7 @@ func (r *ReplicaSetReconcilerHelper) reconcileStatefulSet(ctx context.Context, c
 }
 
 func (r *ReplicaSetReconcilerHelper) handlePVCResize(ctx context.Context, sts *appsv1.StatefulSet) workflow.Status {
-	workflowStatus := create.HandlePVCResize(ctx, r.reconciler.client, sts, r.log)
+	workflowStatus := pvcresize.HandlePVCResize(ctx, r.reconciler.client, sts, r.log)
 	if !workflowStatus.IsOK() {
 		return workflowStatus
 	}
@@ -660,14 +684,7 @@ func AddReplicaSetController(ctx context.Context, mgr manager.Manager, imageUrls
 		}
 	}
 
-	err = c.Watch(source.Kind(mgr.GetCache(), &searchv1.MongoDBSearch{},
-		handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, search *searchv1.MongoDBSearch) []reconcile.Request {
-			source := search.GetMongoDBResourceRef()
-			if source == nil {
-				return []reconcile.Request{}
-			}
-			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: source.Namespace, Name: source.Name}}}
-		})))
+	err = c.Watch(source.Kind(mgr.GetCache(), &searchv1.MongoDBSearch{}, searchSourceEventHandler(mgr.GetClient(), mdbv1.ReplicaSet)))
 	if err != nil {
 		return err
 	}
@@ -760,6 +777,47 @@ func (r *ReplicaSetReconcilerHelper) updateOmDeploymentRs(ctx context.Context, c
 	return workflow.OK()
 }
 
+// planOmDeploymentRs publishes the changes updateOmDeploymentRs and the roles reconciliation would make to the
+// automation config, the agent configs and the agents log rotation without applying them. The plan shows the
+// deployment after the scaling is finished, and doesn't include the changes of the Prometheus settings. If the
+// authentication can only be enabled in several steps, the plan includes the first one.
+func (r *ReplicaSetReconcilerHelper) planOmDeploymentRs(ctx context.Context, conn om.Connection, tlsCertPath, internalClusterCertPath string) workflow.Status {
+	rs := r.resource
+	log := r.log
+	reconciler := r.reconciler
+	caFilePath := fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
+
+	var databaseSecretPath string
+	if reconciler.VaultClient != nil {
+		databaseSecretPath = reconciler.VaultClient.DatabaseSecretPath()
+	}
+	_, agentCertPath := reconciler.agentCertHashAndPath(ctx, log, rs.Namespace, rs.GetSecurity().AgentClientCertificateSecretName(rs.Name), databaseSecretPath)
+
+	r.applySearchOverrides(ctx)
+	replicaSet := replicaset.BuildFromMongoDBWithReplicas(reconciler.imageUrls[mcoConstruct.MongodbImageEnv], reconciler.forceEnterprise, rs, rs.DesiredReplicas(), rs.CalculateFeatureCompatibilityVersion(), tlsCertPath)
+	lastRsConfig, err := mdbv1.GetLastAdditionalMongodConfigByType(r.deploymentState.LastAchievedSpec, mdbv1.ReplicaSetConfig)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
+	return plan.Run(ctx, reconciler.client, conn, rs, func(conn om.Connection) workflow.Status {
+		if status := reconciler.ensureRoles(ctx, rs.Spec.DbCommonSpec, reconciler.enableClusterMongoDBRoles, conn, kube.ObjectKeyFromApiObject(rs), log); !status.IsOK() {
+			return status
+		}
+		if status, _ := reconciler.updateOmAuthentication(ctx, conn, replicaSet.GetProcessNames(), rs, agentCertPath, caFilePath, internalClusterCertPath, false, log); !status.IsOK() {
+			return status
+		}
+		err := conn.ReadUpdateDeployment(func(d om.Deployment) error {
+			return ReconcileReplicaSetAC(ctx, d, rs.Spec.DbCommonSpec, lastRsConfig.ToMap(), rs.Name, replicaSet, caFilePath, internalClusterCertPath, nil, log)
+		}, log)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		status, _ := ReconcileLogRotateSetting(conn, rs.Spec.Agent, log)
+		return status
+	})
+}
+
 func (r *ReplicaSetReconcilerHelper) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
 	rs := obj.(*mdbv1.MongoDB)
 
@@ -33,16 +33,18 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
-	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 )
@@ -955,6 +957,74 @@ func TestReplicaSetAnnotations_PreservedOnSubsequentFailure(t *testing.T) {
 		"Should still reflect previous successful state (3 members, not 5)")
 }
 
+// TestReplicaSetPlanMode verifies that the changes to the deployment are only published while the plan annotation is
+// set, and applied once it is removed.
+func TestReplicaSetPlanMode(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().Build()
+
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+	mockedOmConn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	mockedOmConn.CleanHistory()
+
+	rs.Annotations[util.PlanAnnotation] = "true"
+	rs.Spec.Members = 4
+	require.NoError(t, client.Update(ctx, rs))
+	checkReconcilePending(ctx, t, reconciler, rs, "Plan mode is enabled: 4 change(s)", client, 60)
+
+	planText, err := configmap.ReadKey(ctx, client, plan.ConfigMapKey, kube.ObjectKey(rs.Namespace, plan.ConfigMapName(rs.Name)))
+	require.NoError(t, err)
+	assert.Contains(t, planText, fmt.Sprintf("+ processes[%s-3]", rs.Name))
+	assert.Contains(t, planText, fmt.Sprintf("+ replicaSets[%s].members[%s-3]", rs.Name, rs.Name))
+
+	mockedOmConn.CheckNumberOfUpdateRequests(t, 0)
+	assert.Len(t, mockedOmConn.GetProcesses(), 3)
+	sts, err := client.GetStatefulSet(ctx, rs.ObjectKey())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *sts.Spec.Replicas)
+
+	delete(rs.Annotations, util.PlanAnnotation)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+
+	assert.Len(t, mockedOmConn.GetProcesses(), 4)
+	exists, err := configmap.Exists(ctx, client, kube.ObjectKey(rs.Namespace, plan.ConfigMapName(rs.Name)))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+// TestReplicaSetPlanMode_IncludesAuthentication verifies that the authentication changes are planned, and that
+// nothing is written to Ops Manager in plan mode.
+func TestReplicaSetPlanMode_IncludesAuthentication(t *testing.T) {
+	ctx := context.Background()
+	rs := DefaultReplicaSetBuilder().Build()
+
+	reconciler, client, omConnectionFactory := defaultReplicaSetReconciler(ctx, nil, "", "", rs)
+	checkReconcileSuccessful(ctx, t, reconciler, rs, client)
+	mockedOmConn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	mockedOmConn.CleanHistory()
+
+	rs.Annotations[util.PlanAnnotation] = "true"
+	rs.Spec.Security.Authentication = &mdbv1.Authentication{Enabled: true, Modes: []mdbv1.AuthMode{"SCRAM"}}
+	require.NoError(t, client.Update(ctx, rs))
+	checkReconcilePending(ctx, t, reconciler, rs, "Plan mode is enabled", client, 60)
+
+	planText, err := configmap.ReadKey(ctx, client, plan.ConfigMapKey, kube.ObjectKey(rs.Namespace, plan.ConfigMapName(rs.Name)))
+	require.NoError(t, err)
+	assert.Contains(t, planText, `~ auth.disabled: true -> false`)
+
+	mockedOmConn.CheckNumberOfUpdateRequests(t, 0)
+	mockedOmConn.CheckOperationsDidntHappen(t,
+		reflect.ValueOf(mockedOmConn.UpdateControlledFeature),
+		reflect.ValueOf(mockedOmConn.UpdateMonitoringAgentConfig),
+		reflect.ValueOf(mockedOmConn.UpdateBackupAgentConfig),
+		reflect.ValueOf(mockedOmConn.UpdateProject),
+	)
+	ac, err := mockedOmConn.ReadAutomationConfig()
+	require.NoError(t, err)
+	assert.True(t, ac.Auth.Disabled)
+}
+
 // TestVaultAnnotations_NotWrittenWhenDisabled verifies that vault annotations are NOT
 // written when vault backend is disabled.
 func TestVaultAnnotations_NotWrittenWhenDisabled(t *testing.T) {
@@ -987,7 +1057,7 @@ func testPVCFinishedResizing(t *testing.T, ctx context.Context, memberClient kub
 	// Simulate that the PVC has finished resizing
 	setPVCWithUpdatedResource(ctx, t, memberClient, p)
 
-	st := create.HandlePVCResize(ctx, memberClient, statefulSet, logger)
+	st := pvcresize.HandlePVCResize(ctx, memberClient, statefulSet, logger)
 
 	assert.Equal(t, status.PhaseRunning, st.Phase())
 	assert.Equal(t, &status.PVC{Phase: pvc.PhaseSTSOrphaned, StatefulsetName: "example-sts"}, getPVCOption(st))
@@ -996,7 +1066,7 @@ func testPVCFinishedResizing(t *testing.T, ctx context.Context, memberClient kub
 
 	// *** "No Storage Change, No Action Required" ***
 	statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
-	st = create.HandlePVCResize(ctx, memberClient, statefulSet, logger)
+	st = pvcresize.HandlePVCResize(ctx, memberClient, statefulSet, logger)
 
 	assert.Equal(t, status.PhaseRunning, st.Phase())
 
@@ -1012,7 +1082,7 @@ func testPVCStillResizing(t *testing.T, ctx context.Context, memberClient kubern
 	// Simulate that the PVC is still resizing by not updating the Capacity in the PVC status
 
 	// Call the HandlePVCResize function
-	st := create.HandlePVCResize(ctx, memberClient, statefulSet, logger)
+	st := pvcresize.HandlePVCResize(ctx, memberClient, statefulSet, logger)
 
 	// Verify the function returns Pending
 	assert.Equal(t, status.PhasePending, st.Phase())
@@ -1028,7 +1098,7 @@ func testPVCResizeStarted(t *testing.T, ctx context.Context, memberClient kubern
 	statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
 
 	// Call the HandlePVCResize function
-	st := create.HandlePVCResize(ctx, memberClient, statefulSet, logger)
+	st := pvcresize.HandlePVCResize(ctx, memberClient, statefulSet, logger)
 
 	// Verify the function returns Pending
 	assert.Equal(t, status.PhasePending, st.Phase())
@@ -1039,7 +1109,7 @@ func testPVCResizeStarted(t *testing.T, ctx context.Context, memberClient kubern
 }
 
 func testPhaseNoActionRequired(t *testing.T, ctx context.Context, memberClient kubernetesClient.Client, statefulSet *appsv1.StatefulSet, logger *zap.SugaredLogger) {
-	st := create.HandlePVCResize(ctx, memberClient, statefulSet, logger)
+	st := pvcresize.HandlePVCResize(ctx, memberClient, statefulSet, logger)
 	// Verify the function returns Pending
 	assert.Equal(t, status.PhaseRunning, st.Phase())
 	require.Nil(t, getPVCOption(st))
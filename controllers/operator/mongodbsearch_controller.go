@@ -4,12 +4,18 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,12 +24,16 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	mdbmultiv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
 	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/references"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
 )
@@ -32,13 +42,22 @@ type MongoDBSearchReconciler struct {
 	kubeClient           kubernetesClient.Client
 	watch                *watch.ResourceWatcher
 	operatorSearchConfig searchcontroller.OperatorSearchConfig
+	references           *references.Validator
+	memberClusters       *multicluster.Registry
 }
 
-func newMongoDBSearchReconciler(client client.Client, operatorSearchConfig searchcontroller.OperatorSearchConfig) *MongoDBSearchReconciler {
+func newMongoDBSearchReconciler(client client.Client, operatorSearchConfig searchcontroller.OperatorSearchConfig, memberClusters *multicluster.Registry) *MongoDBSearchReconciler {
+	if memberClusters == nil {
+		memberClusters = multicluster.NewRegistry()
+	}
+
 	return &MongoDBSearchReconciler{
 		kubeClient:           kubernetesClient.NewClient(client),
 		watch:                watch.NewResourceWatcher(),
 		operatorSearchConfig: operatorSearchConfig,
+		// the search doesn't reference any ClusterMongoDBRoles
+		references:     references.NewValidator(client, false),
+		memberClusters: memberClusters,
 	}
 }
 
@@ -52,7 +71,15 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 		return result, err
 	}
 
-	searchSource, err := r.getSourceMongoDBForSearch(ctx, r.kubeClient, mdbSearch, log)
+	referenceWarnings, err := references.Process(r.references.Validate(ctx, mdbSearch))
+	if err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, mdbSearch, workflow.Invalid("%s", err.Error()), log)
+	}
+	for _, warning := range referenceWarnings {
+		log.Warn(warning)
+	}
+
+	searchSource, err := getSourceMongoDBForSearch(ctx, r.kubeClient, r.watch, mdbSearch.NamespacedName(), mdbSearch, log)
 	if err != nil {
 		return reconcile.Result{RequeueAfter: time.Second * util.RetryTimeSec}, err
 	}
@@ -78,17 +105,57 @@ func (r *MongoDBSearchReconciler) Reconcile(ctx context.Context, request reconci
 		r.watch.AddWatchedResourceIfNotAdded(mdbSearch.Spec.Security.TLS.CertificateKeySecret.Name, mdbSearch.Namespace, watch.Secret, mdbSearch.NamespacedName())
 	}
 
-	reconcileHelper := searchcontroller.NewMongoDBSearchReconcileHelper(kubernetesClient.NewClient(r.kubeClient), mdbSearch, searchSource, r.operatorSearchConfig)
+	// Watch the metrics endpoint TLS certificate and Basic Auth password secrets for changes
+	if prometheus := mdbSearch.GetPrometheus(); prometheus != nil {
+		if prometheus.IsTLSEnabled() {
+			r.watch.AddWatchedResourceIfNotAdded(prometheus.TLSSecretRef.Name, mdbSearch.Namespace, watch.Secret, mdbSearch.NamespacedName())
+		}
+		if prometheus.IsBasicAuthEnabled() {
+			r.watch.AddWatchedResourceIfNotAdded(prometheus.PasswordSecretRef.Name, mdbSearch.Namespace, watch.Secret, mdbSearch.NamespacedName())
+		}
+	}
+
+	reconcileHelper := searchcontroller.NewMongoDBSearchReconcileHelper(kubernetesClient.NewClient(r.kubeClient), mdbSearch, searchSource, r.operatorSearchConfig, r.memberClusters.KubeClients())
 
 	return reconcileHelper.Reconcile(ctx, log).ReconcileResult()
 }
 
-func (r *MongoDBSearchReconciler) getSourceMongoDBForSearch(ctx context.Context, kubeClient client.Client, search *searchv1.MongoDBSearch, log *zap.SugaredLogger) (searchcontroller.SearchSourceDBResource, error) {
+// OnDelete removes the mongot instances deployed in member clusters, which aren't garbage collected as they can't
+// have owner references to the MongoDBSearch resource. All the registered member clusters are cleaned up, as the
+// mongot instances of the clusters removed from spec.clusterSpecList may not have been deleted yet.
+func (r *MongoDBSearchReconciler) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
+	mdbSearch := obj.(*searchv1.MongoDBSearch)
+
+	var errs error
+	for _, item := range mdbSearch.Spec.ClusterSpecList {
+		if _, ok := r.memberClusters.KubeClient(item.ClusterName); !ok {
+			errs = multierror.Append(errs, xerrors.Errorf("member cluster %s is not registered in the operator", item.ClusterName))
+		}
+	}
+	if mdbSearch.IsMultiCluster() {
+		for clusterName, memberClient := range r.memberClusters.KubeClients() {
+			if err := searchcontroller.DeleteMemberClusterObjects(ctx, memberClient, mdbSearch); err != nil {
+				errs = multierror.Append(errs, xerrors.Errorf("failed deleting mongot resources in cluster %s: %w", clusterName, err))
+				continue
+			}
+			log.Infof("Removed mongot resources in cluster %s", clusterName)
+		}
+	}
+
+	r.watch.RemoveDependentWatchedResources(mdbSearch.NamespacedName())
+
+	return errs
+}
+
+// getSourceMongoDBForSearch resolves the source database of the search instance and registers a watch on it for the
+// watchedBy resource.
+func getSourceMongoDBForSearch(ctx context.Context, kubeClient client.Client, resourceWatcher *watch.ResourceWatcher, watchedBy types.NamespacedName, search *searchv1.MongoDBSearch, log *zap.SugaredLogger) (searchcontroller.SearchSourceDBResource, error) {
 	// Resolve the source database for this Search instance.
 	// If .spec.source.external is defined immediately return the external search source.
 	// Otherwise, read .spec.source.mongodbResourceRef or use the implicit database resource name (same as the Search resource's name).
 	// Try to get a MongoDB CR with the computed name and return the enterprise search source if successful.
 	// Otherwise, try to get a MongoDBCommunity CR with the same name and return the community search source.
+	// Finally, try to get a MongoDBMultiCluster CR with the same name and return the multi-cluster search source.
 	// If everything fails just error out and the controller will retry reconciliation.
 
 	if search.IsExternalMongoDBSource() {
@@ -109,7 +176,7 @@ func (r *MongoDBSearchReconciler) getSourceMongoDBForSearch(ctx context.Context,
 			return nil, xerrors.Errorf("error getting MongoDB %s: %w", sourceName, err)
 		}
 	} else {
-		r.watch.AddWatchedResourceIfNotAdded(sourceMongoDBResourceRef.Name, sourceMongoDBResourceRef.Namespace, watch.MongoDB, search.NamespacedName())
+		resourceWatcher.AddWatchedResourceIfNotAdded(sourceMongoDBResourceRef.Name, sourceMongoDBResourceRef.Namespace, watch.MongoDB, watchedBy)
 		return searchcontroller.NewEnterpriseResourceSearchSource(mdb), nil
 	}
 
@@ -119,13 +186,60 @@ func (r *MongoDBSearchReconciler) getSourceMongoDBForSearch(ctx context.Context,
 			return nil, xerrors.Errorf("error getting MongoDBCommunity %s: %w", sourceName, err)
 		}
 	} else {
-		r.watch.AddWatchedResourceIfNotAdded(sourceMongoDBResourceRef.Name, sourceMongoDBResourceRef.Namespace, "MongoDBCommunity", search.NamespacedName())
+		resourceWatcher.AddWatchedResourceIfNotAdded(sourceMongoDBResourceRef.Name, sourceMongoDBResourceRef.Namespace, "MongoDBCommunity", watchedBy)
 		return searchcontroller.NewCommunityResourceSearchSource(mdbc), nil
 	}
 
+	mrs := &mdbmultiv1.MongoDBMultiCluster{}
+	if err := kubeClient.Get(ctx, sourceName, mrs); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, xerrors.Errorf("error getting MongoDBMultiCluster %s: %w", sourceName, err)
+		}
+	} else {
+		resourceWatcher.AddWatchedResourceIfNotAdded(sourceMongoDBResourceRef.Name, sourceMongoDBResourceRef.Namespace, "MongoDBMultiCluster", watchedBy)
+		return searchcontroller.NewMultiClusterResourceSearchSource(mrs), nil
+	}
+
 	return nil, xerrors.Errorf("No database resource named %s found", sourceName)
 }
 
+// searchSourceEventHandler enqueues the MongoDB resource used as the source of a changed MongoDBSearch, but only if
+// it is of the given resource type, so that only the controller managing that resource type reconciles it.
+func searchSourceEventHandler(kubeClient client.Client, resourceType mdbv1.ResourceType) handler.TypedEventHandler[*searchv1.MongoDBSearch, reconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, search *searchv1.MongoDBSearch) []reconcile.Request {
+		source := search.GetMongoDBResourceRef()
+		if source == nil {
+			return []reconcile.Request{}
+		}
+
+		sourceName := types.NamespacedName{Namespace: source.Namespace, Name: source.Name}
+		mdb := &mdbv1.MongoDB{}
+		if err := kubeClient.Get(ctx, sourceName, mdb); err != nil || mdb.GetResourceType() != resourceType {
+			return []reconcile.Request{}
+		}
+
+		return []reconcile.Request{{NamespacedName: sourceName}}
+	})
+}
+
+// multiClusterSearchSourceEventHandler enqueues the MongoDBMultiCluster resource used as the source of a changed
+// MongoDBSearch.
+func multiClusterSearchSourceEventHandler(kubeClient client.Client) handler.TypedEventHandler[*searchv1.MongoDBSearch, reconcile.Request] {
+	return handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, search *searchv1.MongoDBSearch) []reconcile.Request {
+		source := search.GetMongoDBResourceRef()
+		if source == nil {
+			return []reconcile.Request{}
+		}
+
+		sourceName := types.NamespacedName{Namespace: source.Namespace, Name: source.Name}
+		if err := kubeClient.Get(ctx, sourceName, &mdbmultiv1.MongoDBMultiCluster{}); err != nil {
+			return []reconcile.Request{}
+		}
+
+		return []reconcile.Request{{NamespacedName: sourceName}}
+	})
+}
+
 func mdbcSearchIndexBuilder(rawObj client.Object) []string {
 	mdbSearch := rawObj.(*searchv1.MongoDBSearch)
 	resourceRef := mdbSearch.GetMongoDBResourceRef()
@@ -136,18 +250,25 @@ func mdbcSearchIndexBuilder(rawObj client.Object) []string {
 	return []string{resourceRef.Namespace + "/" + resourceRef.Name}
 }
 
-func AddMongoDBSearchController(ctx context.Context, mgr manager.Manager, operatorSearchConfig searchcontroller.OperatorSearchConfig) error {
+func AddMongoDBSearchController(ctx context.Context, mgr manager.Manager, operatorSearchConfig searchcontroller.OperatorSearchConfig, memberClusters *multicluster.Registry) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &searchv1.MongoDBSearch{}, searchcontroller.MongoDBSearchIndexFieldName, mdbcSearchIndexBuilder); err != nil {
 		return err
 	}
 
-	r := newMongoDBSearchReconciler(kubernetesClient.NewClient(mgr.GetClient()), operatorSearchConfig)
+	r := newMongoDBSearchReconciler(kubernetesClient.NewClient(mgr.GetClient()), operatorSearchConfig, memberClusters)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
 		For(&searchv1.MongoDBSearch{}).
+		// the deletion events are handled to clean up the mongot instances deployed in member clusters
+		Watches(&searchv1.MongoDBSearch{}, &ResourceEventHandler{deleter: r}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc:  func(event.CreateEvent) bool { return false },
+			UpdateFunc:  func(event.UpdateEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		})).
 		Watches(&mdbv1.MongoDB{}, &watch.ResourcesHandler{ResourceType: watch.MongoDB, ResourceWatcher: r.watch}).
 		Watches(&mdbcv1.MongoDBCommunity{}, &watch.ResourcesHandler{ResourceType: "MongoDBCommunity", ResourceWatcher: r.watch}).
+		Watches(&mdbmultiv1.MongoDBMultiCluster{}, &watch.ResourcesHandler{ResourceType: "MongoDBMultiCluster", ResourceWatcher: r.watch}).
 		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.watch}).
 		Watches(&corev1.ConfigMap{}, &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.watch}).
 		Owns(&appsv1.StatefulSet{}).
@@ -82,7 +82,7 @@ func newSearchReconcilerWithOperatorConfig(
 
 	fakeClient := builder.Build()
 
-	return newMongoDBSearchReconciler(fakeClient, operatorConfig), fakeClient
+	return newMongoDBSearchReconciler(fakeClient, operatorConfig, nil), fakeClient
 }
 
 func newSearchReconciler(
@@ -298,6 +298,18 @@ func TestMongoDBSearchReconcile_InvalidVersion(t *testing.T) {
 	checkSearchReconcileFailed(ctx, t, reconciler, c, search, "MongoDB version")
 }
 
+func TestMongoDBSearchReconcile_ExternalSourceWithoutKeyfile(t *testing.T) {
+	ctx := context.Background()
+	search := newMongoDBSearch("search", mock.TestNamespace, "")
+	search.Annotations = map[string]string{searchv1.ForceWireprotoAnnotation: "true"}
+	search.Spec.Source = &searchv1.MongoDBSource{
+		ExternalMongoDBSource: &searchv1.ExternalMongoDBSource{HostAndPorts: []string{"mdb-0.example.com:27017"}},
+	}
+	reconciler, c := newSearchReconciler(nil, search)
+
+	checkSearchReconcileFailed(ctx, t, reconciler, c, search, "The keyfile of the external source must be set")
+}
+
 func TestMongoDBSearchReconcile_MultipleSearchResources(t *testing.T) {
 	ctx := context.Background()
 	search1 := newMongoDBSearch("search1", mock.TestNamespace, "mdb")
@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+type MongoDBSearchIndexReconciler struct {
+	kubeClient         kubernetesClient.Client
+	watch              *watch.ResourceWatcher
+	indexClientFactory searchcontroller.SearchIndexClientFactory
+}
+
+func newMongoDBSearchIndexReconciler(client client.Client, indexClientFactory searchcontroller.SearchIndexClientFactory) *MongoDBSearchIndexReconciler {
+	return &MongoDBSearchIndexReconciler{
+		kubeClient:         kubernetesClient.NewClient(client),
+		watch:              watch.NewResourceWatcher(),
+		indexClientFactory: indexClientFactory,
+	}
+}
+
+// +kubebuilder:rbac:groups=mongodb.com,resources={mongodbsearchindexes,mongodbsearchindexes/status,mongodbsearchindexes/finalizers},verbs=*,namespace=placeholder
+func (r *MongoDBSearchIndexReconciler) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, e error) {
+	log := zap.S().With("MongoDBSearchIndex", request.NamespacedName)
+	log.Info("-> MongoDBSearchIndex.Reconcile")
+
+	index := &searchv1.MongoDBSearchIndex{}
+	if result, err := commoncontroller.GetResource(ctx, r.kubeClient, request, index, log); err != nil {
+		return result, err
+	}
+
+	searchSource, err := r.getSearchSource(ctx, index, log)
+	if err != nil {
+		log.Warnf("Couldn't resolve the search source of MongoDBSearchIndex: %s", err)
+		// without the search source there is no database to drop the index from, so it's not blocking the deletion
+		if !index.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(index, util.SearchIndexFinalizer) {
+			return r.removeFinalizer(ctx, index, workflow.Pending("Finalizer will be removed. Search source not found"), log)
+		}
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, index, workflow.Pending("%s", err.Error()), log)
+	}
+
+	r.watch.AddWatchedResourceIfNotAdded(index.Spec.PasswordSecretRef.Name, index.Namespace, watch.Secret, index.NamespacedName())
+	if tlsSourceConfig := searchSource.TLSConfig(); tlsSourceConfig != nil {
+		for wType, resources := range tlsSourceConfig.ResourcesToWatch {
+			for _, resource := range resources {
+				r.watch.AddWatchedResourceIfNotAdded(resource.Name, resource.Namespace, wType, index.NamespacedName())
+			}
+		}
+	}
+
+	reconcileHelper := searchcontroller.NewMongoDBSearchIndexReconcileHelper(r.kubeClient, index, searchSource, r.indexClientFactory)
+
+	if !index.DeletionTimestamp.IsZero() {
+		log.Info("MongoDBSearchIndex is being deleted")
+
+		if controllerutil.ContainsFinalizer(index, util.SearchIndexFinalizer) {
+			return r.preDeletionCleanup(ctx, index, reconcileHelper, log)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.ensureFinalizer(ctx, index, log); err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, index, workflow.Failed(xerrors.Errorf("Failed to add finalizer: %w", err)), log)
+	}
+
+	return reconcileHelper.Reconcile(ctx, log).ReconcileResult()
+}
+
+// getSearchSource resolves the database the index is created in through the referenced MongoDBSearch resource.
+func (r *MongoDBSearchIndexReconciler) getSearchSource(ctx context.Context, index *searchv1.MongoDBSearchIndex, log *zap.SugaredLogger) (searchcontroller.SearchSourceDBResource, error) {
+	searchName := index.SearchNamespacedName()
+	r.watch.AddWatchedResourceIfNotAdded(searchName.Name, searchName.Namespace, watch.MongoDBSearch, index.NamespacedName())
+
+	mdbSearch := &searchv1.MongoDBSearch{}
+	if err := r.kubeClient.Get(ctx, searchName, mdbSearch); err != nil {
+		return nil, xerrors.Errorf("error getting MongoDBSearch %s: %w", searchName, err)
+	}
+
+	return getSourceMongoDBForSearch(ctx, r.kubeClient, r.watch, index.NamespacedName(), mdbSearch, log)
+}
+
+func (r *MongoDBSearchIndexReconciler) preDeletionCleanup(ctx context.Context, index *searchv1.MongoDBSearchIndex, reconcileHelper *searchcontroller.MongoDBSearchIndexReconcileHelper, log *zap.SugaredLogger) (reconcile.Result, error) {
+	log.Info("Performing pre deletion cleanup before deleting MongoDBSearchIndex")
+
+	if err := reconcileHelper.DropIndex(ctx, log); err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, index, workflow.Failed(xerrors.Errorf("Failed to drop the search index: %w", err)), log)
+	}
+
+	return r.removeFinalizer(ctx, index, workflow.OK(), log)
+}
+
+func (r *MongoDBSearchIndexReconciler) removeFinalizer(ctx context.Context, index *searchv1.MongoDBSearchIndex, st workflow.Status, log *zap.SugaredLogger) (reconcile.Result, error) {
+	controllerutil.RemoveFinalizer(index, util.SearchIndexFinalizer)
+	if err := r.kubeClient.Update(ctx, index); err != nil {
+		return commoncontroller.UpdateStatus(ctx, r.kubeClient, index, workflow.Failed(xerrors.Errorf("Failed to update the MongoDBSearchIndex with the removed finalizer: %w", err)), log)
+	}
+
+	// the resource is gone once the last finalizer is removed, so there is no status left to update
+	st.Log(log)
+	return st.ReconcileResult()
+}
+
+func (r *MongoDBSearchIndexReconciler) ensureFinalizer(ctx context.Context, index *searchv1.MongoDBSearchIndex, log *zap.SugaredLogger) error {
+	if finalizerAdded := controllerutil.AddFinalizer(index, util.SearchIndexFinalizer); finalizerAdded {
+		log.Info("Adding finalizer to the MongoDBSearchIndex resource")
+		if err := r.kubeClient.Update(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddMongoDBSearchIndexController(mgr manager.Manager) error {
+	r := newMongoDBSearchIndexReconciler(mgr.GetClient(), searchcontroller.NewSearchIndexClient)
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named(util.MongoDbSearchIndexController).
+		WithOptions(controller.Options{MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}). // nolint:forbidigo
+		For(&searchv1.MongoDBSearchIndex{}).
+		Watches(&searchv1.MongoDBSearch{}, &watch.ResourcesHandler{ResourceType: watch.MongoDBSearch, ResourceWatcher: r.watch}).
+		Watches(&mdbv1.MongoDB{}, &watch.ResourcesHandler{ResourceType: watch.MongoDB, ResourceWatcher: r.watch}).
+		Watches(&mdbcv1.MongoDBCommunity{}, &watch.ResourcesHandler{ResourceType: "MongoDBCommunity", ResourceWatcher: r.watch}).
+		Watches(&corev1.Secret{}, &watch.ResourcesHandler{ResourceType: watch.Secret, ResourceWatcher: r.watch}).
+		Watches(&corev1.ConfigMap{}, &watch.ResourcesHandler{ResourceType: watch.ConfigMap, ResourceWatcher: r.watch}).
+		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Infof("Registered controller %s", util.MongoDbSearchIndexController)
+	return nil
+}
@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newMongoDBSearchIndex(name, namespace, searchName string) *searchv1.MongoDBSearchIndex {
+	definition := mdbcv1.NewMapWrapper()
+	definition.Object["mappings"] = map[string]interface{}{"dynamic": true}
+
+	return &searchv1.MongoDBSearchIndex{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: searchv1.MongoDBSearchIndexSpec{
+			SearchRef:         corev1.LocalObjectReference{Name: searchName},
+			Database:          "sample_mflix",
+			Collection:        "movies",
+			Definition:        definition,
+			Username:          "index-admin",
+			PasswordSecretRef: userv1.SecretKeyRef{Name: "index-admin-password"},
+		},
+	}
+}
+
+func newSearchIndexReconciler(indexClient *searchcontroller.MockedSearchIndexClient, objects ...client.Object) (*MongoDBSearchIndexReconciler, client.Client) {
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "index-admin-password", Namespace: mock.TestNamespace},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	fakeClient := mock.NewEmptyFakeClientBuilder().WithObjects(passwordSecret).WithObjects(objects...).Build()
+	return newMongoDBSearchIndexReconciler(fakeClient, indexClient.Factory()), fakeClient
+}
+
+func TestMongoDBSearchIndexReconcile_CreatesIndexAndAddsFinalizer(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newMongoDBCommunity("mdb", mock.TestNamespace)
+	search := newMongoDBSearch("search", mock.TestNamespace, "mdb")
+	index := newMongoDBSearchIndex("movies", mock.TestNamespace, "search")
+	indexClient := searchcontroller.NewMockedSearchIndexClient()
+	reconciler, c := newSearchIndexReconciler(indexClient, mdbc, search, index)
+
+	res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: index.NamespacedName()})
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+
+	require.NoError(t, c.Get(ctx, index.NamespacedName(), index))
+	assert.True(t, controllerutil.ContainsFinalizer(index, util.SearchIndexFinalizer))
+	assert.Equal(t, status.PhasePending, index.Status.Phase)
+	assert.Contains(t, indexClient.Indexes, "sample_mflix.movies.movies")
+}
+
+func TestMongoDBSearchIndexReconcile_DropsIndexOnDeletion(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newMongoDBCommunity("mdb", mock.TestNamespace)
+	search := newMongoDBSearch("search", mock.TestNamespace, "mdb")
+	index := newMongoDBSearchIndex("movies", mock.TestNamespace, "search")
+	indexClient := searchcontroller.NewMockedSearchIndexClient()
+	reconciler, c := newSearchIndexReconciler(indexClient, mdbc, search, index)
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: index.NamespacedName()})
+	require.NoError(t, err)
+	require.Contains(t, indexClient.Indexes, "sample_mflix.movies.movies")
+
+	require.NoError(t, c.Get(ctx, index.NamespacedName(), index))
+	require.NoError(t, c.Delete(ctx, index))
+
+	_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: index.NamespacedName()})
+	require.NoError(t, err)
+
+	assert.Empty(t, indexClient.Indexes)
+	err = c.Get(ctx, index.NamespacedName(), index)
+	assert.True(t, apiErrors.IsNotFound(err))
+}
+
+func TestMongoDBSearchIndexReconcile_MissingSearch(t *testing.T) {
+	ctx := t.Context()
+	index := newMongoDBSearchIndex("movies", mock.TestNamespace, "search")
+	indexClient := searchcontroller.NewMockedSearchIndexClient()
+	reconciler, c := newSearchIndexReconciler(indexClient, index)
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: index.NamespacedName()})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, index.NamespacedName(), index))
+	assert.Equal(t, status.PhasePending, index.Status.Phase)
+	assert.Empty(t, indexClient.Indexes)
+}
@@ -12,12 +12,13 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -31,6 +32,7 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
 	rolev1 "github.com/mongodb/mongodb-kubernetes/api/v1/role"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
 	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
@@ -46,10 +48,12 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	enterprisepem "github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/recovery"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
@@ -64,10 +68,12 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	mekoService "github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/maputil"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
 	"github.com/mongodb/mongodb-kubernetes/pkg/vault"
 	"github.com/mongodb/mongodb-kubernetes/pkg/vault/vaultwatcher"
@@ -77,7 +83,7 @@ import (
 type ReconcileMongoDbShardedCluster struct {
 	*ReconcileCommonController
 	omConnectionFactory       om.ConnectionFactory
-	memberClustersMap         map[string]client.Client
+	memberClusters            *multicluster.Registry
 	imageUrls                 images.ImageUrls
 	forceEnterprise           bool
 	enableClusterMongoDBRoles bool
@@ -86,11 +92,11 @@ type ReconcileMongoDbShardedCluster struct {
 	databaseNonStaticImageVersion     string
 }
 
-func newShardedClusterReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusterMap map[string]client.Client, omFunc om.ConnectionFactory) *ReconcileMongoDbShardedCluster {
+func newShardedClusterReconciler(ctx context.Context, kubeClient client.Client, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusters *multicluster.Registry, omFunc om.ConnectionFactory) *ReconcileMongoDbShardedCluster {
 	return &ReconcileMongoDbShardedCluster{
 		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
 		omConnectionFactory:       omFunc,
-		memberClustersMap:         memberClusterMap,
+		memberClusters:            memberClusters,
 		forceEnterprise:           forceEnterprise,
 		imageUrls:                 imageUrls,
 		enableClusterMongoDBRoles: enableClusterMongoDBRoles,
@@ -612,6 +618,10 @@ type ShardedClusterReconcileHelper struct {
 
 	// This parameter helps us decide whether write operations should be conducted in the constructor.
 	readOnly bool
+
+	// shouldMirrorKeyfileForMongot is set when a MongoDBSearch using this sharded cluster as its source requires
+	// the keyfile to authenticate mongot's wireproto server.
+	shouldMirrorKeyfileForMongot bool
 }
 
 func NewReadOnlyClusterReconcilerHelper(
@@ -799,7 +809,7 @@ func (r *ReconcileMongoDbShardedCluster) Reconcile(ctx context.Context, request
 		return reconcileResult, err
 	}
 
-	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, sc, r.memberClustersMap, r.omConnectionFactory, log)
+	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, sc, r.memberClusters.ClientMap(), r.omConnectionFactory, log)
 	if err != nil {
 		return r.updateStatus(ctx, sc, workflow.Failed(xerrors.Errorf("Failed to initialize sharded cluster reconciler: %w", err)), log)
 	}
@@ -808,7 +818,7 @@ func (r *ReconcileMongoDbShardedCluster) Reconcile(ctx context.Context, request
 
 // OnDelete tries to complete a Deletion reconciliation event
 func (r *ReconcileMongoDbShardedCluster) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
-	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, obj.(*mdbv1.MongoDB), r.memberClustersMap, r.omConnectionFactory, log)
+	reconcilerHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, r.imageUrls, r.initDatabaseNonStaticImageVersion, r.databaseNonStaticImageVersion, r.forceEnterprise, r.enableClusterMongoDBRoles, obj.(*mdbv1.MongoDB), r.memberClusters.ClientMap(), r.omConnectionFactory, log)
 	if err != nil {
 		return err
 	}
@@ -821,6 +831,10 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 		return r.commonController.updateStatus(ctx, sc, workflow.Invalid("%s", err.Error()), log)
 	}
 
+	if err := r.commonController.validateReferences(ctx, sc, r.enableClusterMongoDBRoles, log); err != nil {
+		return r.commonController.updateStatus(ctx, sc, workflow.Invalid("%s", err.Error()), log)
+	}
+
 	log.Info("-> ShardedCluster.Reconcile")
 	log.Infow("ShardedCluster.Spec", "spec", sc.Spec)
 	log.Infow("ShardedCluster.Status", "status", r.deploymentState.Status)
@@ -845,7 +859,7 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 		return r.updateStatus(ctx, sc, workflow.Failed(err), log)
 	}
 
-	if !architectures.IsRunningStaticArchitecture(sc.Annotations) {
+	if !architectures.IsRunningStaticArchitecture(sc.Annotations) && !plan.IsEnabled(sc) {
 		agents.UpgradeAllIfNeeded(ctx, agents.ClientSecret{Client: r.commonController.client, SecretClient: r.commonController.SecretClient}, r.omConnectionFactory, GetWatchedNamespace(), false)
 	}
 
@@ -854,13 +868,16 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 		return r.updateStatus(ctx, sc, workflow.Failed(err), log)
 	}
 
-	conn, agentAPIKey, err := connection.PrepareOpsManagerConnection(ctx, r.commonController.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, sc.Namespace, log)
+	conn, agentAPIKey, err := prepareOpsManagerConnection(ctx, r.commonController.SecretClient, sc, projectConfig, credsConfig, r.omConnectionFactory, log)
 	if err != nil {
 		return r.updateStatus(ctx, sc, workflow.Failed(err), log)
 	}
 
-	if err := r.replicateAgentKeySecret(ctx, conn, agentAPIKey, log); err != nil {
-		return r.updateStatus(ctx, sc, workflow.Failed(err), log)
+	// in plan mode no agent API key is generated, the Kubernetes resources are only created once the changes are applied
+	if !plan.IsEnabled(sc) {
+		if err := r.replicateAgentKeySecret(ctx, conn, agentAPIKey, log); err != nil {
+			return r.updateStatus(ctx, sc, workflow.Failed(err), log)
+		}
 	}
 	if err := r.reconcileHostnameOverrideConfigMap(ctx, log); err != nil {
 		return r.updateStatus(ctx, sc, workflow.Failed(err), log)
@@ -883,6 +900,7 @@ func (r *ShardedClusterReconcileHelper) Reconcile(ctx context.Context, log *zap.
 	}
 
 	r.automationAgentVersion = automationAgentVersion
+	r.shouldMirrorKeyfileForMongot = r.applySearchOverrides(ctx, log)
 
 	workflowStatus := r.doShardedClusterProcessing(ctx, sc, conn, projectConfig, log)
 	if !workflowStatus.IsOK() || workflowStatus.Phase() == mdbstatus.PhaseUnsupported {
@@ -1011,10 +1029,6 @@ func (r *ShardedClusterReconcileHelper) doShardedClusterProcessing(ctx context.C
 		certTLSType:          certSecretTypesForSTS,
 	}
 
-	if err = r.prepareScaleDownShardedCluster(conn, log); err != nil {
-		return workflow.Failed(xerrors.Errorf("failed to perform scale down preliminary actions: %w", err))
-	}
-
 	if workflowStatus := validateMongoDBResource(sc, conn); !workflowStatus.IsOK() {
 		return workflowStatus
 	}
@@ -1032,6 +1046,20 @@ func (r *ShardedClusterReconcileHelper) doShardedClusterProcessing(ctx context.C
 		caFilePath = fmt.Sprintf("%s/ca-pem", util.TLSCaMountPath)
 	}
 
+	// The changes are only planned before any of them is applied to Ops Manager
+	if plan.IsEnabled(sc) {
+		opts.caFilePath = caFilePath
+		_, opts.agentCertPath = r.commonController.agentCertHashAndPath(ctx, log, sc.Namespace, sc.GetSecurity().AgentClientCertificateSecretName(sc.Name), databaseSecretPath)
+		return r.planOmDeploymentShardedCluster(ctx, conn, sc, opts, log)
+	}
+	if err := plan.Remove(ctx, r.commonController.client, sc); err != nil {
+		return workflow.Failed(err)
+	}
+
+	if err = r.prepareScaleDownShardedCluster(conn, log); err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to perform scale down preliminary actions: %w", err))
+	}
+
 	if workflowStatus := controlledfeature.EnsureFeatureControls(*sc, conn, conn.OpsManagerVersion(), log); !workflowStatus.IsOK() {
 		return workflowStatus
 	}
@@ -1436,7 +1464,7 @@ func (r *ShardedClusterReconcileHelper) getMergedStatefulsetStatus(ctx context.C
 }
 
 func (r *ShardedClusterReconcileHelper) handlePVCResize(ctx context.Context, memberCluster multicluster.MemberCluster, sts *appsv1.StatefulSet, log *zap.SugaredLogger) workflow.Status {
-	workflowStatus := create.HandlePVCResize(ctx, memberCluster.Client, sts, log)
+	workflowStatus := pvcresize.HandlePVCResize(ctx, memberCluster.Client, sts, log)
 	if !workflowStatus.IsOK() {
 		return workflowStatus
 	}
@@ -1608,9 +1636,9 @@ func logDiffOfProcessNames(acProcesses []string, healthyProcesses []string, log
 	}
 }
 
-func AddShardedClusterController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClustersMap map[string]cluster.Cluster) error {
+func AddShardedClusterController(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusters *multicluster.Registry) error {
 	// Create a new controller
-	reconciler := newShardedClusterReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, multicluster.ClustersMapToClientMap(memberClustersMap), om.NewOpsManagerConnection)
+	reconciler := newShardedClusterReconciler(ctx, mgr.GetClient(), imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusters, om.NewOpsManagerConnection)
 	options := controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)} // nolint:forbidigo
 	c, err := controller.New(util.MongoDbShardedClusterController, mgr, options)
 	if err != nil {
@@ -1659,11 +1687,110 @@ func AddShardedClusterController(ctx context.Context, mgr manager.Manager, image
 		}
 	}
 
+	err = c.Watch(source.Kind(mgr.GetCache(), &searchv1.MongoDBSearch{}, searchSourceEventHandler(mgr.GetClient(), mdbv1.ShardedCluster)))
+	if err != nil {
+		return err
+	}
+
 	zap.S().Infof("Registered controller %s", util.MongoDbShardedClusterController)
 
 	return nil
 }
 
+// applySearchOverrides points every shard's mongod processes to the mongot instances indexing that shard, and the
+// mongos processes to the mongot instances of the first shard, if there is a MongoDBSearch using this sharded cluster
+// as its source. It returns true if such a MongoDBSearch exists.
+func (r *ShardedClusterReconcileHelper) applySearchOverrides(ctx context.Context, log *zap.SugaredLogger) bool {
+	sc := r.sc
+
+	search := r.lookupCorrespondingSearchResource(ctx, log)
+	if search == nil {
+		log.Debugf("No MongoDBSearch resource found, skipping search overrides")
+		return false
+	}
+
+	log.Infof("Applying search overrides from MongoDBSearch %s", search.NamespacedName())
+
+	clusterDomain := sc.Spec.GetClusterDomain()
+	for shardIdx, shardConfiguration := range r.desiredShardsConfiguration {
+		// the mongot instances of the shards being removed are deleted by the MongoDBSearch controller, so the shards
+		// being drained are not pointed at them
+		if shardIdx >= sc.Spec.ShardCount {
+			continue
+		}
+		if shardConfiguration.AdditionalMongodConfig == nil {
+			shardConfiguration.AdditionalMongodConfig = mdbv1.NewEmptyAdditionalMongodConfig()
+		}
+		searchMongodConfig := searchcontroller.GetShardMongodConfigParameters(search, shardIdx, clusterDomain)
+		shardConfiguration.AdditionalMongodConfig.AddOption("setParameter", searchMongodConfig["setParameter"])
+	}
+
+	// The parameters are also added to the shard spec, so they are part of the last achieved spec and removed from
+	// the processes once the MongoDBSearch is deleted. The per-shard values above take precedence.
+	if sc.Spec.ShardSpec == nil {
+		sc.Spec.ShardSpec = &mdbv1.ShardedClusterComponentSpec{}
+	}
+	if sc.Spec.ShardSpec.AdditionalMongodConfig == nil {
+		sc.Spec.ShardSpec.AdditionalMongodConfig = mdbv1.NewEmptyAdditionalMongodConfig()
+	}
+	sc.Spec.ShardSpec.AdditionalMongodConfig.AddOption("setParameter", searchcontroller.GetShardMongodConfigParameters(search, 0, clusterDomain)["setParameter"])
+
+	searchMongosConfig := searchcontroller.GetMongosConfigParameters(search, clusterDomain)
+	if sc.Spec.MongosSpec == nil {
+		sc.Spec.MongosSpec = &mdbv1.ShardedClusterComponentSpec{}
+	}
+	if sc.Spec.MongosSpec.AdditionalMongodConfig == nil {
+		sc.Spec.MongosSpec.AdditionalMongodConfig = mdbv1.NewEmptyAdditionalMongodConfig()
+	}
+	sc.Spec.MongosSpec.AdditionalMongodConfig.AddOption("setParameter", searchMongosConfig["setParameter"])
+	if r.desiredMongosConfiguration.AdditionalMongodConfig == nil {
+		r.desiredMongosConfiguration.AdditionalMongodConfig = mdbv1.NewEmptyAdditionalMongodConfig()
+	}
+	r.desiredMongosConfiguration.AdditionalMongodConfig.AddOption("setParameter", searchMongosConfig["setParameter"])
+
+	return true
+}
+
+func (r *ShardedClusterReconcileHelper) lookupCorrespondingSearchResource(ctx context.Context, log *zap.SugaredLogger) *searchv1.MongoDBSearch {
+	sc := r.sc
+
+	var search *searchv1.MongoDBSearch
+	searchList := &searchv1.MongoDBSearchList{}
+	if err := r.commonController.client.List(ctx, searchList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(searchcontroller.MongoDBSearchIndexFieldName, sc.GetNamespace()+"/"+sc.GetName()),
+	}); err != nil {
+		log.Debugf("Failed to list MongoDBSearch resources: %v", err)
+	}
+	// this validates that there is exactly one MongoDBSearch pointing to this resource,
+	// and that this resource passes search validations. If either fails, proceed without a search target
+	// for the mongod automation config.
+	if len(searchList.Items) == 1 {
+		searchSource := searchcontroller.NewEnterpriseResourceSearchSource(sc)
+		if searchSource.Validate() == nil {
+			search = &searchList.Items[0]
+		}
+	}
+	return search
+}
+
+func (r *ShardedClusterReconcileHelper) mirrorKeyfileIntoSecretForMongot(ctx context.Context, d om.Deployment, log *zap.SugaredLogger) error {
+	sc := r.sc
+
+	keyfileContents := maputil.ReadMapValueAsString(d, "auth", "key")
+	keyfileSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", sc.Name, searchcontroller.MongotKeyfileFilename), Namespace: sc.Namespace}}
+
+	log.Infof("Mirroring the sharded cluster %s's keyfile into the secret %s", sc.ObjectKey(), kube.ObjectKeyFromApiObject(keyfileSecret))
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.commonController.client, keyfileSecret, func() error {
+		keyfileSecret.StringData = map[string]string{searchcontroller.MongotKeyfileFilename: keyfileContents}
+		return controllerutil.SetOwnerReference(sc, keyfileSecret, r.commonController.client.Scheme())
+	})
+	if err != nil {
+		return xerrors.Errorf("failed to mirror the sharded cluster's keyfile into a secret: %w", err)
+	}
+	return nil
+}
+
 func (r *ShardedClusterReconcileHelper) getConfigSrvHostnames(memberCluster multicluster.MemberCluster, replicas int) ([]string, []string) {
 	externalDomain := r.sc.Spec.ConfigSrvSpec.ClusterSpecList.GetExternalDomainForMemberCluster(memberCluster.Name)
 	if externalDomain == nil && r.sc.Spec.IsMultiCluster() {
@@ -1860,6 +1987,41 @@ func (r *ShardedClusterReconcileHelper) updateOmDeploymentShardedCluster(ctx con
 	return workflow.OK()
 }
 
+// planOmDeploymentShardedCluster publishes the changes updateOmDeploymentShardedCluster and the roles reconciliation
+// would make to the automation config without applying them. The plan shows the next update of the deployment, so
+// while the cluster is scaled one member at a time only the next step is included. If the authentication can only be
+// enabled in several steps, the plan includes the first one.
+func (r *ShardedClusterReconcileHelper) planOmDeploymentShardedCluster(ctx context.Context, conn om.Connection, sc *mdbv1.MongoDB, opts deploymentOptions, log *zap.SugaredLogger) workflow.Status {
+	// the keyfile is mirrored for mongot once the changes are applied
+	r.shouldMirrorKeyfileForMongot = false
+
+	return plan.Run(ctx, r.commonController.client, conn, sc, func(conn om.Connection) workflow.Status {
+		if workflowStatus := r.commonController.ensureRoles(ctx, sc.Spec.DbCommonSpec, r.enableClusterMongoDBRoles, conn, kube.ObjectKeyFromApiObject(sc), log); !workflowStatus.IsOK() {
+			return workflowStatus
+		}
+
+		dep, err := conn.ReadDeployment()
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		opts.processNames = dep.GetProcessNames(om.ShardedCluster{}, sc.Name)
+
+		// the removed shards are drained first and removed from the deployment in the second update
+		for _, finalizing := range []bool{false, true} {
+			opts.finalizing = finalizing
+			_, shardsRemoving, workflowStatus := r.publishDeployment(ctx, conn, sc, &opts, false, log)
+			// the pending status only reports that the authentication is enabled in several steps
+			if !workflowStatus.IsOK() && workflowStatus.Phase() != mdbstatus.PhasePending {
+				return workflowStatus
+			}
+			if !shardsRemoving {
+				break
+			}
+		}
+		return workflow.OK()
+	})
+}
+
 func (r *ShardedClusterReconcileHelper) publishDeployment(ctx context.Context, conn om.Connection, sc *mdbv1.MongoDB, opts *deploymentOptions, isRecovering bool, log *zap.SugaredLogger) ([]string, bool, workflow.Status) {
 	// Mongos
 	var mongosProcesses []om.Process
@@ -1941,6 +2103,12 @@ func (r *ShardedClusterReconcileHelper) publishDeployment(ctx context.Context, c
 				return xerrors.Errorf("cannot have more than 1 MongoDB Cluster per project (see https://docs.mongodb.com/kubernetes-operator/stable/tutorial/migrate-to-single-resource/)")
 			}
 
+			if r.shouldMirrorKeyfileForMongot {
+				if err := r.mirrorKeyfileIntoSecretForMongot(ctx, d, log); err != nil {
+					return err
+				}
+			}
+
 			lastConfigServerConf, err := mdbv1.GetLastAdditionalMongodConfigByType(r.deploymentState.LastAchievedSpec, mdbv1.ConfigServerConfig)
 			if err != nil {
 				return err
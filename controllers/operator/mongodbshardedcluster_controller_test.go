@@ -32,6 +32,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
@@ -39,6 +40,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	mcoConstruct "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
@@ -815,6 +817,43 @@ func TestShardedCluster_WithTLSEnabled_AndX509Enabled_Succeeds(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// TestShardedClusterPlanMode verifies that the changes to the sharded cluster deployment are only published while the
+// plan annotation is set, and applied once it is removed.
+func TestShardedClusterPlanMode(t *testing.T) {
+	ctx := context.Background()
+	sc := test.DefaultClusterBuilder().Build()
+
+	reconciler, _, kubeClient, omConnectionFactory, err := defaultShardedClusterReconciler(ctx, nil, "", "", sc, nil)
+	require.NoError(t, err)
+	checkReconcileSuccessful(ctx, t, reconciler, sc, kubeClient)
+	mockedOmConn := omConnectionFactory.GetConnection().(*om.MockedOmConnection)
+	mockedOmConn.CleanHistory()
+
+	sc.Annotations = map[string]string{util.PlanAnnotation: "true"}
+	sc.Spec.Version = "8.0.1"
+	require.NoError(t, kubeClient.Update(ctx, sc))
+	checkReconcilePending(ctx, t, reconciler, sc, "Plan mode is enabled", kubeClient, 60)
+
+	planText, err := configmap.ReadKey(ctx, kubeClient, plan.ConfigMapKey, kube.ObjectKey(sc.Namespace, plan.ConfigMapName(sc.Name)))
+	require.NoError(t, err)
+	assert.Contains(t, planText, fmt.Sprintf(`~ processes[%s-0-0].version:`, sc.Name))
+	assert.Contains(t, planText, fmt.Sprintf(`~ processes[%s-mongos-0].version:`, sc.Name))
+
+	mockedOmConn.CheckNumberOfUpdateRequests(t, 0)
+	mockedOmConn.CheckOperationsDidntHappen(t, reflect.ValueOf(mockedOmConn.UpdateControlledFeature))
+
+	delete(sc.Annotations, util.PlanAnnotation)
+	require.NoError(t, kubeClient.Update(ctx, sc))
+	checkReconcileSuccessful(ctx, t, reconciler, sc, kubeClient)
+
+	for _, process := range mockedOmConn.GetProcesses() {
+		assert.Equal(t, "8.0.1", process.Version())
+	}
+	exists, err := configmap.Exists(ctx, kubeClient, kube.ObjectKey(sc.Namespace, plan.ConfigMapName(sc.Name)))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestShardedCluster_NeedToPublishState(t *testing.T) {
 	ctx := context.Background()
 	sc := test.DefaultClusterBuilder().
@@ -1689,7 +1728,7 @@ func defaultShardedClusterReconciler(ctx context.Context, imageUrls images.Image
 }
 
 func newShardedClusterReconcilerFromResource(ctx context.Context, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, sc *mdbv1.MongoDB, globalMemberClustersMap map[string]client.Client, kubeClient kubernetesClient.Client, omConnectionFactory *om.CachedOMConnectionFactory) (*ReconcileMongoDbShardedCluster, *ShardedClusterReconcileHelper, error) {
-	r := newShardedClusterReconciler(ctx, kubeClient, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, false, false, globalMemberClustersMap, omConnectionFactory.GetConnectionFunc)
+	r := newShardedClusterReconciler(ctx, kubeClient, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, false, false, multicluster.NewClientRegistry(globalMemberClustersMap), omConnectionFactory.GetConnectionFunc)
 	reconcileHelper, err := NewShardedClusterReconcilerHelper(ctx, r.ReconcileCommonController, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, false, false, sc, globalMemberClustersMap, omConnectionFactory.GetConnectionFunc, zap.S())
 	if err != nil {
 		return nil, nil, err
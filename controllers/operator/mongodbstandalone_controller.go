@@ -32,6 +32,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/create"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/pem"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/plan"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
@@ -41,6 +42,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -149,7 +151,7 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return reconcileResult, err
 	}
 
-	if !architectures.IsRunningStaticArchitecture(s.Annotations) {
+	if !architectures.IsRunningStaticArchitecture(s.Annotations) && !plan.IsEnabled(s) {
 		agents.UpgradeAllIfNeeded(ctx, agents.ClientSecret{Client: r.client, SecretClient: r.SecretClient}, r.omConnectionFactory, GetWatchedNamespace(), false)
 	}
 
@@ -157,6 +159,10 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return r.updateStatus(ctx, s, workflow.Invalid("%s", err.Error()), log)
 	}
 
+	if err := r.validateReferences(ctx, s, r.enableClusterMongoDBRoles, log); err != nil {
+		return r.updateStatus(ctx, s, workflow.Invalid("%s", err.Error()), log)
+	}
+
 	log.Info("-> Standalone.Reconcile")
 	log.Infow("Standalone.Spec", "spec", s.Spec)
 	log.Infow("Standalone.Status", "status", s.Status)
@@ -166,7 +172,7 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return r.updateStatus(ctx, s, workflow.Failed(err), log)
 	}
 
-	conn, _, err := connection.PrepareOpsManagerConnection(ctx, r.SecretClient, projectConfig, credsConfig, r.omConnectionFactory, s.Namespace, log)
+	conn, _, err := prepareOpsManagerConnection(ctx, r.SecretClient, s, projectConfig, credsConfig, r.omConnectionFactory, log)
 	if err != nil {
 		return r.updateStatus(ctx, s, workflow.Failed(xerrors.Errorf("Failed to prepare Ops Manager connection: %w", err)), log)
 	}
@@ -182,10 +188,6 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return r.updateStatus(ctx, s, reconcileResult, log)
 	}
 
-	if status := controlledfeature.EnsureFeatureControls(*s, conn, conn.OpsManagerVersion(), log); !status.IsOK() {
-		return r.updateStatus(ctx, s, status, log)
-	}
-
 	// cannot have a non-tls deployment in an x509 environment
 	// TODO move to webhook validations
 	security := s.Spec.Security
@@ -204,6 +206,18 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 		return r.updateStatus(ctx, s, status, log)
 	}
 
+	// The changes are only planned before any of them is applied to Ops Manager
+	if plan.IsEnabled(s) {
+		return r.updateStatus(ctx, s, r.planOmDeployment(ctx, conn, s, log), log)
+	}
+	if err := plan.Remove(ctx, r.client, s); err != nil {
+		return r.updateStatus(ctx, s, workflow.Failed(err), log)
+	}
+
+	if status := controlledfeature.EnsureFeatureControls(*s, conn, conn.OpsManagerVersion(), log); !status.IsOK() {
+		return r.updateStatus(ctx, s, status, log)
+	}
+
 	if status := certs.EnsureSSLCertsForStatefulSet(ctx, r.SecretClient, r.SecretClient, *s.Spec.Security, certs.StandaloneConfig(*s), log); !status.IsOK() {
 		return r.updateStatus(ctx, s, status, log)
 	}
@@ -257,7 +271,7 @@ func (r *ReconcileMongoDbStandalone) Reconcile(ctx context.Context, request reco
 
 	sts := construct.DatabaseStatefulSet(*s, standaloneOpts, log)
 
-	workflowStatus := create.HandlePVCResize(ctx, r.client, &sts, log)
+	workflowStatus := pvcresize.HandlePVCResize(ctx, r.client, &sts, log)
 	if !workflowStatus.IsOK() {
 		return r.updateStatus(ctx, s, workflowStatus, log)
 	}
@@ -331,21 +345,7 @@ func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, con
 	standaloneOmObject := createProcess(r.imageUrls[mcoConstruct.MongodbImageEnv], r.forceEnterprise, set, util.DatabaseContainerName, s)
 	err := conn.ReadUpdateDeployment(
 		func(d om.Deployment) error {
-			excessProcesses := d.GetNumberOfExcessProcesses(s.Name)
-			if excessProcesses > 0 {
-				return xerrors.Errorf("cannot have more than 1 MongoDB Cluster per project (see https://docs.mongodb.com/kubernetes-operator/stable/tutorial/migrate-to-single-resource/)")
-			}
-
-			lastStandaloneConfig, err := s.GetLastAdditionalMongodConfigByType(mdbv1.StandaloneConfig)
-			if err != nil {
-				return err
-			}
-
-			d.MergeStandalone(standaloneOmObject, s.Spec.AdditionalMongodConfig.ToMap(), lastStandaloneConfig.ToMap(), nil)
-			// TODO change last argument in separate PR
-			d.AddMonitoringAndBackup(log, s.Spec.GetSecurity().IsTLSEnabled(), util.CAFilePathInContainer)
-			d.ConfigureTLS(s.Spec.GetSecurity(), util.CAFilePathInContainer)
-			return nil
+			return reconcileStandaloneAC(d, s, standaloneOmObject, log)
 		},
 		log,
 	)
@@ -365,6 +365,53 @@ func (r *ReconcileMongoDbStandalone) updateOmDeployment(ctx context.Context, con
 	return workflow.OK()
 }
 
+func reconcileStandaloneAC(d om.Deployment, s *mdbv1.MongoDB, standaloneOmObject om.Process, log *zap.SugaredLogger) error {
+	excessProcesses := d.GetNumberOfExcessProcesses(s.Name)
+	if excessProcesses > 0 {
+		return xerrors.Errorf("cannot have more than 1 MongoDB Cluster per project (see https://docs.mongodb.com/kubernetes-operator/stable/tutorial/migrate-to-single-resource/)")
+	}
+
+	lastStandaloneConfig, err := s.GetLastAdditionalMongodConfigByType(mdbv1.StandaloneConfig)
+	if err != nil {
+		return err
+	}
+
+	d.MergeStandalone(standaloneOmObject, s.Spec.AdditionalMongodConfig.ToMap(), lastStandaloneConfig.ToMap(), nil)
+	// TODO change last argument in separate PR
+	d.AddMonitoringAndBackup(log, s.Spec.GetSecurity().IsTLSEnabled(), util.CAFilePathInContainer)
+	d.ConfigureTLS(s.Spec.GetSecurity(), util.CAFilePathInContainer)
+	return nil
+}
+
+// planOmDeployment publishes the changes updateOmDeployment and the roles reconciliation would make to the automation
+// config without applying them. If the authentication can only be enabled in several steps, the plan includes the
+// first one.
+func (r *ReconcileMongoDbStandalone) planOmDeployment(ctx context.Context, conn om.Connection, s *mdbv1.MongoDB, log *zap.SugaredLogger) workflow.Status {
+	hostnames, _ := dns.GetDNSNames(s.Name, s.ServiceName(), s.Namespace, s.Spec.GetClusterDomain(), 1, nil)
+	standaloneOmObject := om.NewMongodProcess(s.Name, hostnames[0], r.imageUrls[mcoConstruct.MongodbImageEnv], r.forceEnterprise, s.Spec.GetAdditionalMongodConfig(), s.GetSpec(), "", s.Annotations, s.CalculateFeatureCompatibilityVersion())
+
+	var databaseSecretPath string
+	if r.VaultClient != nil {
+		databaseSecretPath = r.VaultClient.DatabaseSecretPath()
+	}
+	_, agentCertPath := r.agentCertHashAndPath(ctx, log, s.Namespace, s.GetSecurity().AgentClientCertificateSecretName(s.Name), databaseSecretPath)
+
+	return plan.Run(ctx, r.client, conn, s, func(conn om.Connection) workflow.Status {
+		if status := r.ensureRoles(ctx, s.Spec.DbCommonSpec, r.enableClusterMongoDBRoles, conn, kube.ObjectKeyFromApiObject(s), log); !status.IsOK() {
+			return status
+		}
+		if status, _ := r.updateOmAuthentication(ctx, conn, []string{s.Name}, s, agentCertPath, "", "", false, log); !status.IsOK() {
+			return status
+		}
+		if err := conn.ReadUpdateDeployment(func(d om.Deployment) error {
+			return reconcileStandaloneAC(d, s, standaloneOmObject, log)
+		}, log); err != nil {
+			return workflow.Failed(err)
+		}
+		return workflow.OK()
+	})
+}
+
 func (r *ReconcileMongoDbStandalone) OnDelete(ctx context.Context, obj runtime.Object, log *zap.SugaredLogger) error {
 	s := obj.(*mdbv1.MongoDB)
 
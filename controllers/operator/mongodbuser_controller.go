@@ -9,7 +9,6 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -21,17 +20,16 @@ import (
 
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/om"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/authentication"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connection"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/connectionstring"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/project"
-	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
-	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
@@ -42,27 +40,15 @@ import (
 
 type MongoDBUserReconciler struct {
 	*ReconcileCommonController
-	omConnectionFactory           om.ConnectionFactory
-	memberClusterClientsMap       map[string]kubernetesClient.Client
-	memberClusterSecretClientsMap map[string]secrets.SecretClient
+	omConnectionFactory om.ConnectionFactory
+	memberClusters      *multicluster.Registry
 }
 
-func newMongoDBUserReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory, memberClustersMap map[string]client.Client) *MongoDBUserReconciler {
-	clientsMap := make(map[string]kubernetesClient.Client)
-	secretClientsMap := make(map[string]secrets.SecretClient)
-
-	for k, v := range memberClustersMap {
-		clientsMap[k] = kubernetesClient.NewClient(v)
-		secretClientsMap[k] = secrets.SecretClient{
-			VaultClient: nil,
-			KubeClient:  clientsMap[k],
-		}
-	}
+func newMongoDBUserReconciler(ctx context.Context, kubeClient client.Client, omFunc om.ConnectionFactory, memberClusters *multicluster.Registry) *MongoDBUserReconciler {
 	return &MongoDBUserReconciler{
-		ReconcileCommonController:     NewReconcileCommonController(ctx, kubeClient),
-		omConnectionFactory:           omFunc,
-		memberClusterClientsMap:       clientsMap,
-		memberClusterSecretClientsMap: secretClientsMap,
+		ReconcileCommonController: NewReconcileCommonController(ctx, kubeClient),
+		omConnectionFactory:       omFunc,
+		memberClusters:            memberClusters,
 	}
 }
 
@@ -146,7 +132,7 @@ func (r *MongoDBUserReconciler) getShardedClusterHostnames(ctx context.Context,
 
 func (r *MongoDBUserReconciler) getK8sClientMap() map[string]client.Client {
 	result := make(map[string]client.Client)
-	for k, v := range r.memberClusterClientsMap {
+	for k, v := range r.memberClusters.KubeClients() {
 		result[k] = v
 	}
 
@@ -167,6 +153,15 @@ func (r *MongoDBUserReconciler) Reconcile(ctx context.Context, request reconcile
 	}
 
 	log.Infow("MongoDBUser.Spec", "spec", user.Spec)
+
+	// Reset warnings so that they are not stale, will populate accurate warnings in reconciliation
+	user.SetWarnings([]status.Warning{})
+
+	// the user doesn't reference any ClusterMongoDBRoles
+	if err := r.validateReferences(ctx, user, false, log); err != nil {
+		return r.updateStatus(ctx, user, workflow.Invalid("%s", err.Error()), log)
+	}
+
 	var mdb project.Reader
 
 	if user.Spec.MongoDBResourceRef.Name != "" {
@@ -291,7 +286,7 @@ func (r *MongoDBUserReconciler) updateConnectionStringSecret(ctx context.Context
 		SetOwnerReferences(user.GetOwnerReferences()).
 		Build()
 
-	for _, c := range r.memberClusterSecretClientsMap {
+	for _, c := range r.memberClusters.SecretClients() {
 		err = secret.CreateOrUpdate(ctx, c, connectionStringSecret)
 		if err != nil {
 			return err
@@ -300,8 +295,8 @@ func (r *MongoDBUserReconciler) updateConnectionStringSecret(ctx context.Context
 	return secret.CreateOrUpdate(ctx, r.SecretClient, connectionStringSecret)
 }
 
-func AddMongoDBUserController(ctx context.Context, mgr manager.Manager, memberClustersMap map[string]cluster.Cluster) error {
-	reconciler := newMongoDBUserReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection, multicluster.ClustersMapToClientMap(memberClustersMap))
+func AddMongoDBUserController(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry) error {
+	reconciler := newMongoDBUserReconciler(ctx, mgr.GetClient(), om.NewOpsManagerConnection, memberClusters)
 	c, err := controller.New(util.MongoDbUserController, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: env.ReadIntOrDefault(util.MaxConcurrentReconcilesEnv, 1)}) // nolint:forbidigo
 	if err != nil {
 		return err
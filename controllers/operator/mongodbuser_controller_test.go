@@ -25,6 +25,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/test"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/stringutil"
@@ -444,7 +445,7 @@ func TestUserReconciler_SavesConnectionStringForMultiShardedCluster(t *testing.T
 	omConnectionFactory := om.NewDefaultCachedOMConnectionFactory()
 	memberClusterMap := getFakeMultiClusterMapWithConfiguredInterceptor(memberClusters.ClusterNames, omConnectionFactory, true, true)
 
-	reconciler := newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, memberClusterMap)
+	reconciler := newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, multicluster.NewClientRegistry(memberClusterMap))
 
 	_ = kubeClient.Create(ctx, cluster)
 
@@ -579,13 +580,13 @@ func createMongoDBForUserWithAuth(ctx context.Context, client client.Client, use
 func defaultUserReconciler(ctx context.Context, user *userv1.MongoDBUser) (*MongoDBUserReconciler, client.Client, *om.CachedOMConnectionFactory) {
 	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient(user)
 	memberClusterMap := getFakeMultiClusterMap(omConnectionFactory)
-	return newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, memberClusterMap), kubeClient, omConnectionFactory
+	return newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, multicluster.NewClientRegistry(memberClusterMap)), kubeClient, omConnectionFactory
 }
 
 func userReconcilerWithAuthMode(ctx context.Context, user *userv1.MongoDBUser, authMode string) (*MongoDBUserReconciler, client.Client, *om.CachedOMConnectionFactory) {
 	kubeClient, omConnectionFactory := mock.NewDefaultFakeClient(user)
 	memberClusterMap := getFakeMultiClusterMap(omConnectionFactory)
-	reconciler := newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, memberClusterMap)
+	reconciler := newMongoDBUserReconciler(ctx, kubeClient, omConnectionFactory.GetConnectionFunc, multicluster.NewClientRegistry(memberClusterMap))
 	omConnectionFactory.SetPostCreateHook(func(connection om.Connection) {
 		_ = connection.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
 			ac.Auth.DeploymentAuthMechanisms = append(ac.Auth.DeploymentAuthMechanisms, authMode)
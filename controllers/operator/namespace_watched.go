@@ -53,3 +53,13 @@ func GetWatchedNamespace() []string {
 
 	return []string{watchNamespace}
 }
+
+// canaryNamespace returns the namespace the canary pods checking the member clusters are scheduled in. It's the first
+// namespace watched by the operator, which the operator has access to in the member clusters too, or the namespace of
+// the operator if it watches all namespaces.
+func canaryNamespace() string {
+	if namespace := GetWatchedNamespace()[0]; namespace != "" {
+		return namespace
+	}
+	return env.ReadOrPanic(util.CurrentNamespace) // nolint:forbidigo
+}
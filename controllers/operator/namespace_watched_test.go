@@ -30,3 +30,11 @@ func TestGetWatchedNamespace(t *testing.T) {
 	t.Setenv(util.WatchNamespace, "*,hi")
 	assert.Equal(t, []string{""}, GetWatchedNamespace())
 }
+
+func TestCanaryNamespace(t *testing.T) {
+	t.Setenv(util.WatchNamespace, "one-namespace, two-namespace")
+	assert.Equal(t, "one-namespace", canaryNamespace())
+
+	t.Setenv(util.WatchNamespace, "*")
+	assert.Equal(t, OperatorNamespace, canaryNamespace())
+}
@@ -0,0 +1,545 @@
+package plan
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/alert"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apikey"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/backup"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/projectsettings"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/team"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
+)
+
+// Connection is the connection to Ops Manager the changes are planned with. The deployment is read from Ops Manager
+// once, and all the updates of the deployment, the automation config, the monitoring and backup agent configs and the
+// agents log rotation are applied to their copies only. All agents are reported to be in goal state. The methods
+// reading Ops Manager are passed to the Ops Manager connection, all other methods changing Ops Manager fail with
+// ErrNotPlanned, so nothing is ever written to Ops Manager.
+type Connection struct {
+	conn om.Connection
+
+	current    om.Deployment
+	deployment om.Deployment
+
+	// the agent configs are only read from Ops Manager once they are updated, they are nil until then
+	currentMonitoringAgentConfig map[string]interface{}
+	monitoringAgentConfig        map[string]interface{}
+	currentBackupAgentConfig     map[string]interface{}
+	backupAgentConfig            map[string]interface{}
+}
+
+var _ om.Connection = &Connection{}
+
+// ErrNotPlanned is returned by the methods of the Connection changing Ops Manager outside the deployment and the
+// agent configs, as their changes can't be planned.
+var ErrNotPlanned = xerrors.New("the change can't be planned, it's only made once the plan mode is disabled")
+
+// NewConnection reads the current deployment and returns the connection the changes to it are planned with.
+func NewConnection(conn om.Connection) (*Connection, error) {
+	current, err := conn.ReadDeployment()
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := copyDeployment(current)
+	if err != nil {
+		return nil, err
+	}
+	return &Connection{conn: conn, current: current, deployment: deployment}, nil
+}
+
+// Changes returns the changes made to the deployment and the agent configs through the connection.
+func (c *Connection) Changes() []string {
+	changes := Diff(c.current, c.deployment)
+	diffValues("monitoringAgentConfig", c.currentMonitoringAgentConfig, c.monitoringAgentConfig, &changes)
+	diffValues("backupAgentConfig", c.currentBackupAgentConfig, c.backupAgentConfig, &changes)
+	return changes
+}
+
+func notPlanned(operation string) error {
+	return xerrors.Errorf("%s: %w", operation, ErrNotPlanned)
+}
+
+// Deployment and automation config
+
+func (c *Connection) ReadDeployment() (om.Deployment, error) {
+	return copyDeployment(c.deployment)
+}
+
+func (c *Connection) UpdateDeployment(deployment om.Deployment) ([]byte, error) {
+	updated, err := copyDeployment(deployment)
+	if err != nil {
+		return nil, err
+	}
+	c.deployment = updated
+	return deployment.Serialize()
+}
+
+func (c *Connection) ReadUpdateDeployment(depFunc func(om.Deployment) error, _ *zap.SugaredLogger) error {
+	deployment, err := c.ReadDeployment()
+	if err != nil {
+		return err
+	}
+	if err := depFunc(deployment); err != nil {
+		return err
+	}
+	c.deployment = deployment
+	return nil
+}
+
+func (c *Connection) ReadAutomationConfig() (*om.AutomationConfig, error) {
+	deployment, err := c.ReadDeployment()
+	if err != nil {
+		return nil, err
+	}
+	return om.BuildAutomationConfigFromDeployment(deployment)
+}
+
+func (c *Connection) UpdateAutomationConfig(ac *om.AutomationConfig, _ *zap.SugaredLogger) error {
+	if err := ac.Apply(); err != nil {
+		return err
+	}
+	_, err := c.UpdateDeployment(ac.Deployment)
+	return err
+}
+
+func (c *Connection) ReadUpdateAutomationConfig(acFunc func(ac *om.AutomationConfig) error, log *zap.SugaredLogger) error {
+	ac, err := c.ReadAutomationConfig()
+	if err != nil {
+		return err
+	}
+	if err := acFunc(ac); err != nil {
+		return err
+	}
+	return c.UpdateAutomationConfig(ac, log)
+}
+
+// GetAgentAuthMode returns the authentication mode of the agents in the planned deployment.
+func (c *Connection) GetAgentAuthMode() (string, error) {
+	ac, err := c.ReadAutomationConfig()
+	if err != nil {
+		return "", err
+	}
+	if ac.Auth == nil {
+		return "", nil
+	}
+	return ac.Auth.AutoAuthMechanism, nil
+}
+
+// ReadAutomationStatus returns the status without processes, which is the goal state for all processes.
+func (c *Connection) ReadAutomationStatus() (*om.AutomationStatus, error) {
+	return &om.AutomationStatus{}, nil
+}
+
+func (c *Connection) UpgradeAgentsToLatest() (string, error) {
+	return "", notPlanned("upgrading the agents")
+}
+
+// Agent configs
+
+func (c *Connection) ReadMonitoringAgentConfig() (*om.MonitoringAgentConfig, error) {
+	if c.monitoringAgentConfig == nil {
+		return c.conn.ReadMonitoringAgentConfig()
+	}
+	bytes, err := json.Marshal(c.monitoringAgentConfig)
+	if err != nil {
+		return nil, err
+	}
+	return om.BuildMonitoringAgentConfigFromBytes(bytes)
+}
+
+func (c *Connection) UpdateMonitoringAgentConfig(mat *om.MonitoringAgentConfig, _ *zap.SugaredLogger) ([]byte, error) {
+	if c.currentMonitoringAgentConfig == nil {
+		current, err := c.conn.ReadMonitoringAgentConfig()
+		if err != nil {
+			return nil, err
+		}
+		if c.currentMonitoringAgentConfig, err = copyConfig(current.BackingMap); err != nil {
+			return nil, err
+		}
+	}
+	if err := mat.Apply(); err != nil {
+		return nil, err
+	}
+	planned, err := copyConfig(mat.BackingMap)
+	if err != nil {
+		return nil, err
+	}
+	c.monitoringAgentConfig = planned
+	return json.Marshal(mat.BackingMap)
+}
+
+func (c *Connection) ReadUpdateMonitoringAgentConfig(matFunc func(*om.MonitoringAgentConfig) error, log *zap.SugaredLogger) error {
+	mat, err := c.ReadMonitoringAgentConfig()
+	if err != nil {
+		return err
+	}
+	if err := matFunc(mat); err != nil {
+		return err
+	}
+	_, err = c.UpdateMonitoringAgentConfig(mat, log)
+	return err
+}
+
+func (c *Connection) ReadBackupAgentConfig() (*om.BackupAgentConfig, error) {
+	if c.backupAgentConfig == nil {
+		return c.conn.ReadBackupAgentConfig()
+	}
+	bytes, err := json.Marshal(c.backupAgentConfig)
+	if err != nil {
+		return nil, err
+	}
+	return om.BuildBackupAgentConfigFromBytes(bytes)
+}
+
+func (c *Connection) UpdateBackupAgentConfig(bat *om.BackupAgentConfig, _ *zap.SugaredLogger) ([]byte, error) {
+	if c.currentBackupAgentConfig == nil {
+		current, err := c.conn.ReadBackupAgentConfig()
+		if err != nil {
+			return nil, err
+		}
+		if c.currentBackupAgentConfig, err = copyConfig(current.BackingMap); err != nil {
+			return nil, err
+		}
+	}
+	if err := bat.Apply(); err != nil {
+		return nil, err
+	}
+	planned, err := copyConfig(bat.BackingMap)
+	if err != nil {
+		return nil, err
+	}
+	c.backupAgentConfig = planned
+	return json.Marshal(bat.BackingMap)
+}
+
+func (c *Connection) ReadUpdateBackupAgentConfig(batFunc func(*om.BackupAgentConfig) error, log *zap.SugaredLogger) error {
+	bat, err := c.ReadBackupAgentConfig()
+	if err != nil {
+		return err
+	}
+	if err := batFunc(bat); err != nil {
+		return err
+	}
+	_, err = c.UpdateBackupAgentConfig(bat, log)
+	return err
+}
+
+// ReadUpdateAgentsLogRotation plans the log rotation the way Ops Manager applies it: the log rotation of the mongod
+// processes is set on all processes of the deployment, the one of the monitoring and backup agents in their configs.
+func (c *Connection) ReadUpdateAgentsLogRotation(logRotateSetting mdbv1.AgentConfig, log *zap.SugaredLogger) error {
+	if err := c.ReadUpdateDeployment(func(d om.Deployment) error {
+		return d.SetProcessesLogRotate(logRotateSetting.Mongod.LogRotate, logRotateSetting.Mongod.AuditLogRotate)
+	}, log); err != nil {
+		return err
+	}
+
+	if c.deployment.HasBackupVersions() && logRotateSetting.BackupAgent.LogRotate != nil {
+		if err := c.ReadUpdateBackupAgentConfig(func(config *om.BackupAgentConfig) error {
+			config.SetLogRotate(*logRotateSetting.BackupAgent.LogRotate)
+			return nil
+		}, log); err != nil {
+			return err
+		}
+	}
+
+	if c.deployment.HasMonitoringVersions() && logRotateSetting.MonitoringAgent.LogRotate != nil {
+		return c.ReadUpdateMonitoringAgentConfig(func(config *om.MonitoringAgentConfig) error {
+			config.SetLogRotate(*logRotateSetting.MonitoringAgent.LogRotate)
+			return nil
+		}, log)
+	}
+	return nil
+}
+
+// Methods reading Ops Manager
+
+func (c *Connection) ReadAutomationAgents(page int) (om.Paginated, error) {
+	return c.conn.ReadAutomationAgents(page)
+}
+
+func (c *Connection) ReadOrganizationsByName(name string) ([]*om.Organization, error) {
+	return c.conn.ReadOrganizationsByName(name)
+}
+
+func (c *Connection) ReadOrganizations(page int) (om.Paginated, error) {
+	return c.conn.ReadOrganizations(page)
+}
+
+func (c *Connection) ReadOrganization(orgID string) (*om.Organization, error) {
+	return c.conn.ReadOrganization(orgID)
+}
+
+func (c *Connection) ReadProjectsInOrganizationByName(orgID string, name string) ([]*om.Project, error) {
+	return c.conn.ReadProjectsInOrganizationByName(orgID, name)
+}
+
+func (c *Connection) ReadProjectsInOrganization(orgID string, page int) (om.Paginated, error) {
+	return c.conn.ReadProjectsInOrganization(orgID, page)
+}
+
+func (c *Connection) ReadAgentVersion() (om.AgentsVersionsResponse, error) {
+	return c.conn.ReadAgentVersion()
+}
+
+func (c *Connection) GetPreferredHostnames(agentApiKey string) ([]om.PreferredHostname, error) {
+	return c.conn.GetPreferredHostnames(agentApiKey)
+}
+
+func (c *Connection) ReadGroupBackupConfig() (backup.GroupBackupConfig, error) {
+	return c.conn.ReadGroupBackupConfig()
+}
+
+func (c *Connection) ReadHostCluster(clusterID string) (*backup.HostCluster, error) {
+	return c.conn.ReadHostCluster(clusterID)
+}
+
+func (c *Connection) ReadBackupConfigs() (*backup.ConfigsResponse, error) {
+	return c.conn.ReadBackupConfigs()
+}
+
+func (c *Connection) ReadBackupConfig(clusterID string) (*backup.Config, error) {
+	return c.conn.ReadBackupConfig(clusterID)
+}
+
+func (c *Connection) ReadSnapshotSchedule(clusterID string) (*backup.SnapshotSchedule, error) {
+	return c.conn.ReadSnapshotSchedule(clusterID)
+}
+
+func (c *Connection) ReadAlertConfig(alertConfigID string) (*alert.Config, error) {
+	return c.conn.ReadAlertConfig(alertConfigID)
+}
+
+func (c *Connection) ReadTeams() ([]*team.Team, error) {
+	return c.conn.ReadTeams()
+}
+
+func (c *Connection) ReadTeamUsers(teamID string) ([]*team.User, error) {
+	return c.conn.ReadTeamUsers(teamID)
+}
+
+func (c *Connection) ReadProjectTeams() ([]*team.ProjectTeam, error) {
+	return c.conn.ReadProjectTeams()
+}
+
+func (c *Connection) ReadOrganizationAPIKey(apiKeyID string) (*apikey.APIKey, error) {
+	return c.conn.ReadOrganizationAPIKey(apiKeyID)
+}
+
+func (c *Connection) ReadAPIKeyAccessList(apiKeyID string) ([]*apikey.AccessListEntry, error) {
+	return c.conn.ReadAPIKeyAccessList(apiKeyID)
+}
+
+func (c *Connection) ReadProjectSettings() (*projectsettings.ProjectSettings, error) {
+	return c.conn.ReadProjectSettings()
+}
+
+func (c *Connection) ReadProjectAccessList() ([]*apikey.AccessListEntry, error) {
+	return c.conn.ReadProjectAccessList()
+}
+
+func (c *Connection) GetHosts() (*host.Result, error) {
+	return c.conn.GetHosts()
+}
+
+func (c *Connection) ReadDiskSpacePercentUsed(hostID string, since time.Time) (map[string]float64, error) {
+	return c.conn.ReadDiskSpacePercentUsed(hostID, since)
+}
+
+func (c *Connection) GetControlledFeature() (*controlledfeature.ControlledFeature, error) {
+	return c.conn.GetControlledFeature()
+}
+
+func (c *Connection) OpsManagerVersion() versionutil.OpsManagerVersion {
+	return c.conn.OpsManagerVersion()
+}
+
+func (c *Connection) BaseURL() string {
+	return c.conn.BaseURL()
+}
+
+func (c *Connection) GroupID() string {
+	return c.conn.GroupID()
+}
+
+func (c *Connection) GroupName() string {
+	return c.conn.GroupName()
+}
+
+func (c *Connection) OrgID() string {
+	return c.conn.OrgID()
+}
+
+func (c *Connection) PublicKey() string {
+	return c.conn.PublicKey()
+}
+
+func (c *Connection) PrivateKey() string {
+	return c.conn.PrivateKey()
+}
+
+// ConfigureProject only changes the project the connection uses, nothing is written to Ops Manager.
+func (c *Connection) ConfigureProject(project *om.Project) {
+	c.conn.ConfigureProject(project)
+}
+
+// Methods changing Ops Manager outside the deployment and the agent configs
+
+func (c *Connection) MarkProjectAsBackingDatabase(_ om.BackingDatabaseType) error {
+	return notPlanned("marking the project as backing database")
+}
+
+func (c *Connection) CreateOrganization(_ *om.Organization) (*om.Organization, error) {
+	return nil, notPlanned("creating the organization")
+}
+
+func (c *Connection) CreateProject(_ *om.Project) (*om.Project, error) {
+	return nil, notPlanned("creating the project")
+}
+
+func (c *Connection) UpdateProject(_ *om.Project) (*om.Project, error) {
+	return nil, notPlanned("updating the project")
+}
+
+func (c *Connection) AddPreferredHostname(_ string, _ string, _ bool) error {
+	return notPlanned("adding the preferred hostname")
+}
+
+func (c *Connection) GenerateAgentKey() (string, error) {
+	return "", notPlanned("generating the agent API key")
+}
+
+func (c *Connection) UpdateGroupBackupConfig(_ backup.GroupBackupConfig) ([]byte, error) {
+	return nil, notPlanned("updating the backup config of the project")
+}
+
+func (c *Connection) UpdateBackupConfig(_ *backup.Config) (*backup.Config, error) {
+	return nil, notPlanned("updating the backup config")
+}
+
+func (c *Connection) UpdateBackupStatus(_ string, _ backup.Status) error {
+	return notPlanned("updating the backup status")
+}
+
+func (c *Connection) UpdateSnapshotSchedule(_ string, _ *backup.SnapshotSchedule) error {
+	return notPlanned("updating the snapshot schedule")
+}
+
+func (c *Connection) CreateAlertConfig(_ *alert.Config) (*alert.Config, error) {
+	return nil, notPlanned("creating the alert configuration")
+}
+
+func (c *Connection) UpdateAlertConfig(_ *alert.Config) (*alert.Config, error) {
+	return nil, notPlanned("updating the alert configuration")
+}
+
+func (c *Connection) DeleteAlertConfig(_ string) error {
+	return notPlanned("deleting the alert configuration")
+}
+
+func (c *Connection) CreateTeam(_ *team.Team) (*team.Team, error) {
+	return nil, notPlanned("creating the team")
+}
+
+func (c *Connection) DeleteTeam(_ string) error {
+	return notPlanned("deleting the team")
+}
+
+func (c *Connection) AddTeamUser(_ string, _ string) error {
+	return notPlanned("adding the team user")
+}
+
+func (c *Connection) RemoveTeamUser(_ string, _ string) error {
+	return notPlanned("removing the team user")
+}
+
+func (c *Connection) AssignTeamsToProject(_ []*team.ProjectTeam) error {
+	return notPlanned("assigning the teams to the project")
+}
+
+func (c *Connection) UpdateProjectTeamRoles(_ *team.ProjectTeam) error {
+	return notPlanned("updating the roles of the team")
+}
+
+func (c *Connection) RemoveTeamFromProject(_ string) error {
+	return notPlanned("removing the team from the project")
+}
+
+func (c *Connection) CreateProjectAPIKey(_ string, _ []string) (*apikey.APIKey, error) {
+	return nil, notPlanned("creating the API key")
+}
+
+func (c *Connection) UpdateProjectAPIKeyRoles(_ string, _ []string) error {
+	return notPlanned("updating the roles of the API key")
+}
+
+func (c *Connection) DeleteOrganizationAPIKey(_ string) error {
+	return notPlanned("deleting the API key")
+}
+
+func (c *Connection) AddAPIKeyAccessListEntries(_ string, _ []*apikey.AccessListEntry) error {
+	return notPlanned("adding the access list entries of the API key")
+}
+
+func (c *Connection) RemoveAPIKeyAccessListEntry(_ string, _ string) error {
+	return notPlanned("removing the access list entry of the API key")
+}
+
+func (c *Connection) UpdateProjectSettings(_ *projectsettings.ProjectSettings) error {
+	return notPlanned("updating the project settings")
+}
+
+func (c *Connection) AddProjectAccessListEntries(_ []*apikey.AccessListEntry) error {
+	return notPlanned("adding the access list entries of the project")
+}
+
+func (c *Connection) RemoveProjectAccessListEntry(_ string) error {
+	return notPlanned("removing the access list entry of the project")
+}
+
+func (c *Connection) AddHost(_ host.Host) error {
+	return notPlanned("adding the host")
+}
+
+func (c *Connection) RemoveHost(_ string) error {
+	return notPlanned("removing the host")
+}
+
+func (c *Connection) UpdateHost(_ host.Host) error {
+	return notPlanned("updating the host")
+}
+
+func (c *Connection) UpdateControlledFeature(_ *controlledfeature.ControlledFeature) error {
+	return notPlanned("updating the controlled features")
+}
+
+// copyDeployment returns the deep copy of the deployment, as the deployments are maps modified in place.
+func copyDeployment(deployment om.Deployment) (om.Deployment, error) {
+	bytes, err := deployment.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return om.BuildDeploymentFromBytes(bytes)
+}
+
+// copyConfig returns the deep copy of the agent config in the form it's read from Ops Manager, so that the configs
+// read and updated through the connection are compared by their values only.
+func copyConfig(config map[string]interface{}) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	copied := map[string]interface{}{}
+	if err := json.Unmarshal(bytes, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
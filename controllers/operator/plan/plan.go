@@ -0,0 +1,297 @@
+// Package plan implements the plan mode of MongoDB and MongoDBMultiCluster resources. While the resource is annotated
+// with util.PlanAnnotation, the changes to the Ops Manager deployment are computed but not applied, and published in a
+// ConfigMap in a human-readable form, similar to `terraform plan`.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const (
+	// ConfigMapKey is the key of the plan in the plan ConfigMap
+	ConfigMapKey = "plan"
+
+	// retryInSeconds is the interval the plan is refreshed in, as the deployment can also be changed in Ops Manager
+	retryInSeconds = 60
+)
+
+// listItemKeys are the candidate fields identifying the items of the lists in the deployment, e.g. the processes
+// are identified by "name" and the replica set members by "host". The first field which is set and unique in both
+// lists is used, the lists without such a field are compared as a whole.
+var listItemKeys = []string{"name", "_id", "host", "hostname"}
+
+// redactedFields are the fields of the automation config holding credentials, e.g. the keyfile contents and the
+// passwords of the agents and the users. Their values are not published in the plan, only that they change.
+var redactedFields = map[string]struct{}{
+	"key":               {},
+	"autoPwd":           {},
+	"newAutoPwd":        {},
+	"initPwd":           {},
+	"scramSha1Creds":    {},
+	"scramSha256Creds":  {},
+	"bindQueryPassword": {},
+	"password":          {},
+	"passwordHash":      {},
+	"passwordSalt":      {},
+}
+
+const redactedValue = "(redacted)"
+
+// IsEnabled returns true if the changes to the resource must only be planned.
+func IsEnabled(resource metav1.Object) bool {
+	return resource.GetAnnotations()[util.PlanAnnotation] == "true"
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the plan of the resource.
+func ConfigMapName(resourceName string) string {
+	return fmt.Sprintf("%s-plan", resourceName)
+}
+
+// Run publishes the changes the updateFunc makes to the deployment and the automation config through the planning
+// Connection, and returns the status of the resource in plan mode.
+func Run(ctx context.Context, cmClient configmap.GetUpdateCreator, conn om.Connection, resource v1.ObjectOwner, updateFunc func(om.Connection) workflow.Status) workflow.Status {
+	planConn, err := NewConnection(conn)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("failed to compute the plan: %w", err))
+	}
+	if status := updateFunc(planConn); !status.IsOK() {
+		return status.OnErrorPrepend("failed to compute the plan:")
+	}
+
+	changes := planConn.Changes()
+	if err := Publish(ctx, cmClient, resource, changes); err != nil {
+		return workflow.Failed(err)
+	}
+	return workflow.Pending("Plan mode is enabled: %d change(s) to the Ops Manager deployment are published in the ConfigMap %s and are not applied until the annotation %s is removed",
+		len(changes), ConfigMapName(resource.GetName()), util.PlanAnnotation).WithRetry(retryInSeconds)
+}
+
+// Diff returns the differences between the deployments, one line per changed field. The lines are prefixed with
+// "+" for added, "-" for removed and "~" for changed fields, the fields are identified by their path in the
+// deployment, e.g. "~ processes[my-rs-0].args2_6.net.tls.mode: "disabled" -> "requireTLS"".
+func Diff(current, desired om.Deployment) []string {
+	var changes []string
+	diffValues("", map[string]interface{}(current.ToCanonicalForm()), map[string]interface{}(desired.ToCanonicalForm()), &changes)
+	return changes
+}
+
+func diffValues(path string, current, desired interface{}, changes *[]string) {
+	if reflect.DeepEqual(current, desired) || isEmpty(current) && isEmpty(desired) {
+		return
+	}
+
+	currentMap, currentIsMap := current.(map[string]interface{})
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	if currentIsMap && desiredIsMap {
+		diffMaps(path, currentMap, desiredMap, changes)
+		return
+	}
+
+	currentList, currentIsList := current.([]interface{})
+	desiredList, desiredIsList := desired.([]interface{})
+	if currentIsList && desiredIsList {
+		if keyField := listItemKey(currentList, desiredList); keyField != "" {
+			diffKeyedLists(path, keyField, currentList, desiredList, changes)
+			return
+		}
+	}
+
+	appendChange(path, format(redact(current)), format(redact(desired)), current == nil, desired == nil, changes)
+}
+
+func appendChange(path, current, desired string, added, removed bool, changes *[]string) {
+	switch {
+	case added:
+		*changes = append(*changes, fmt.Sprintf("+ %s: %s", path, desired))
+	case removed:
+		*changes = append(*changes, fmt.Sprintf("- %s: %s", path, current))
+	default:
+		*changes = append(*changes, fmt.Sprintf("~ %s: %s -> %s", path, current, desired))
+	}
+}
+
+func diffMaps(path string, current, desired map[string]interface{}, changes *[]string) {
+	keys := map[string]struct{}{}
+	for key := range current {
+		keys[key] = struct{}{}
+	}
+	for key := range desired {
+		keys[key] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		if _, ok := redactedFields[key]; ok {
+			if !reflect.DeepEqual(current[key], desired[key]) {
+				appendChange(keyPath, redactedValue, redactedValue, current[key] == nil, desired[key] == nil, changes)
+			}
+			continue
+		}
+		diffValues(keyPath, current[key], desired[key], changes)
+	}
+}
+
+// diffKeyedLists reports the added and removed items of the lists by their key only, and the changes to the fields
+// of the items present in both lists.
+func diffKeyedLists(path, keyField string, current, desired []interface{}, changes *[]string) {
+	currentItems := map[string]interface{}{}
+	for _, item := range current {
+		currentItems[itemKey(item, keyField)] = item
+	}
+	desiredItems := map[string]interface{}{}
+	for _, item := range desired {
+		desiredItems[itemKey(item, keyField)] = item
+	}
+
+	for _, item := range current {
+		key := itemKey(item, keyField)
+		itemPath := fmt.Sprintf("%s[%s]", path, key)
+		if desiredItem, ok := desiredItems[key]; ok {
+			diffValues(itemPath, item, desiredItem, changes)
+		} else {
+			*changes = append(*changes, "- "+itemPath)
+		}
+	}
+	for _, item := range desired {
+		key := itemKey(item, keyField)
+		if _, ok := currentItems[key]; !ok {
+			*changes = append(*changes, fmt.Sprintf("+ %s[%s]", path, key))
+		}
+	}
+}
+
+// isEmpty returns true if the value is missing or an empty map, the fields with such values don't change the config.
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	valueMap, ok := value.(map[string]interface{})
+	return ok && len(valueMap) == 0
+}
+
+// listItemKey returns the field identifying the items of both lists, or an empty string if there is none.
+func listItemKey(current, desired []interface{}) string {
+	for _, keyField := range listItemKeys {
+		if isUniqueKey(current, keyField) && isUniqueKey(desired, keyField) {
+			return keyField
+		}
+	}
+	return ""
+}
+
+func isUniqueKey(list []interface{}, keyField string) bool {
+	keys := map[string]struct{}{}
+	for _, item := range list {
+		key := itemKey(item, keyField)
+		if key == "" {
+			return false
+		}
+		if _, ok := keys[key]; ok {
+			return false
+		}
+		keys[key] = struct{}{}
+	}
+	return true
+}
+
+func itemKey(item interface{}, keyField string) string {
+	itemMap, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	key, _ := itemMap[keyField].(string)
+	return key
+}
+
+// redact returns the copy of the value without the values of the redactedFields.
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			if _, ok := redactedFields[key]; ok && fieldValue != nil {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redact(fieldValue)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redact(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func format(value interface{}) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+// Publish writes the plan of the resource into the plan ConfigMap, which is owned by the resource.
+func Publish(ctx context.Context, getUpdateCreator configmap.GetUpdateCreator, resource v1.ObjectOwner, changes []string) error {
+	text := "No changes to the Ops Manager deployment.\n"
+	if len(changes) > 0 {
+		text = fmt.Sprintf("%d change(s) to the Ops Manager deployment:\n%s\n", len(changes), strings.Join(changes, "\n"))
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ConfigMapName(resource.GetName()),
+			Namespace:       resource.GetNamespace(),
+			OwnerReferences: kube.BaseOwnerReference(resource),
+		},
+		Data: map[string]string{ConfigMapKey: text},
+	}
+	if err := configmap.CreateOrUpdate(ctx, getUpdateCreator, cm); err != nil {
+		return xerrors.Errorf("failed to publish the plan in the ConfigMap %s: %w", cm.Name, err)
+	}
+	return nil
+}
+
+// Remove deletes the plan ConfigMap of the resource if it exists, so no outdated plan is left once the plan mode
+// is disabled.
+func Remove(ctx context.Context, cmClient configmap.GetUpdateCreateDeleter, resource v1.ObjectOwner) error {
+	name := kube.ObjectKey(resource.GetNamespace(), ConfigMapName(resource.GetName()))
+	exists, err := configmap.Exists(ctx, cmClient, name)
+	if err != nil || !exists {
+		return err
+	}
+	if err := cmClient.DeleteConfigMap(ctx, name); err != nil && !apiErrors.IsNotFound(err) {
+		return xerrors.Errorf("failed to remove the plan ConfigMap %s: %w", name.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/controlledfeature"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func replicaSetDeployment(members int) om.Deployment {
+	return replicaSetDeploymentVersioned(members, "8.0.0")
+}
+
+func replicaSetDeploymentVersioned(members int, version string) om.Deployment {
+	rs := mdbv1.NewReplicaSetBuilder().SetVersion(version).Build()
+	processes := make([]om.Process, members)
+	for i := range processes {
+		name := fmt.Sprintf("my-rs-%d", i)
+		processes[i] = om.NewMongodProcess(name, name+".some.host", "fake-mongoDBImage", false, &mdbv1.AdditionalMongodConfig{}, &rs.Spec, "", nil, "")
+	}
+
+	d := om.NewDeployment()
+	d.MergeReplicaSet(om.NewReplicaSetWithProcesses(om.NewReplicaSet("my-rs", "8.0.0"), processes, nil), nil, nil, zap.S())
+	d.AddMonitoringAndBackup(zap.S(), false, "")
+	return d
+}
+
+func TestIsEnabled(t *testing.T) {
+	mdb := mdbv1.NewReplicaSetBuilder().Build()
+	assert.False(t, IsEnabled(mdb))
+
+	mdb.Annotations = map[string]string{util.PlanAnnotation: "false"}
+	assert.False(t, IsEnabled(mdb))
+
+	mdb.Annotations[util.PlanAnnotation] = "true"
+	assert.True(t, IsEnabled(mdb))
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	assert.Empty(t, Diff(replicaSetDeployment(3), replicaSetDeployment(3)))
+}
+
+func TestDiff_ScaleUp(t *testing.T) {
+	changes := Diff(replicaSetDeployment(3), replicaSetDeployment(4))
+
+	// the agent configs share the same name, so they are identified by their hostname
+	assert.Equal(t, []string{
+		"+ backupVersions[my-rs-3.some.host]",
+		"+ monitoringVersions[my-rs-3.some.host]",
+		"+ processes[my-rs-3]",
+		"+ replicaSets[my-rs].members[my-rs-3]",
+	}, changes)
+}
+
+func TestDiff_ScaleDown(t *testing.T) {
+	changes := Diff(replicaSetDeployment(3), replicaSetDeployment(2))
+
+	assert.Equal(t, []string{
+		"- backupVersions[my-rs-2.some.host]",
+		"- monitoringVersions[my-rs-2.some.host]",
+		"- processes[my-rs-2]",
+		"- replicaSets[my-rs].members[my-rs-2]",
+	}, changes)
+}
+
+func TestDiff_ChangedFields(t *testing.T) {
+	current := replicaSetDeployment(1)
+	desired := replicaSetDeploymentVersioned(1, "8.0.1")
+	desired.DisableProcesses([]string{"my-rs-0"})
+	desired.ConfigureTLS(&mdbv1.Security{TLSConfig: &mdbv1.TLSConfig{Enabled: true}}, "/ca.pem")
+
+	changes := Diff(current, desired)
+
+	assert.Equal(t, []string{
+		`+ processes[my-rs-0].disabled: true`,
+		`~ processes[my-rs-0].version: "8.0.0" -> "8.0.1"`,
+		`~ tls.CAFilePath: "/mongodb-automation/ca.pem" -> "/ca.pem"`,
+	}, changes)
+}
+
+func TestDiff_RedactsCredentials(t *testing.T) {
+	current := replicaSetDeployment(1)
+	current["auth"] = map[string]interface{}{"autoPwd": "old-password", "key": "old-keyfile"}
+	desired := replicaSetDeployment(1)
+	desired["auth"] = map[string]interface{}{
+		"autoPwd":     "new-password",
+		"key":         "old-keyfile",
+		"usersWanted": []interface{}{map[string]interface{}{"user": "my-user", "db": "admin", "initPwd": "user-password"}},
+	}
+
+	changes := Diff(current, desired)
+
+	assert.Equal(t, []string{
+		`~ auth.autoPwd: (redacted) -> (redacted)`,
+		`+ auth.usersWanted: [{"db":"admin","initPwd":"(redacted)","user":"my-user"}]`,
+	}, changes)
+}
+
+func TestConnection_DoesNotChangeDeployment(t *testing.T) {
+	conn := om.NewMockedOmConnection(replicaSetDeployment(3))
+
+	planConn, err := NewConnection(conn)
+	require.NoError(t, err)
+	require.NoError(t, planConn.ReadUpdateDeployment(func(d om.Deployment) error {
+		return d.RemoveProcessByName("my-rs-2", zap.S())
+	}, zap.S()))
+	require.NoError(t, planConn.ReadUpdateAutomationConfig(func(ac *om.AutomationConfig) error {
+		ac.Auth.Disabled = false
+		ac.Auth.AutoUser = "mms-automation-agent"
+		return nil
+	}, zap.S()))
+
+	deployment, err := planConn.ReadDeployment()
+	require.NoError(t, err)
+	assert.Len(t, deployment.ProcessesCopy(), 2)
+
+	changes := planConn.Changes()
+	assert.Contains(t, changes, "- processes[my-rs-2]")
+	assert.Contains(t, changes, `+ auth.autoUser: "mms-automation-agent"`)
+
+	assert.Len(t, conn.GetProcesses(), 3)
+	conn.CheckNumberOfUpdateRequests(t, 0)
+}
+
+func TestConnection_PlansAgentsLogRotation(t *testing.T) {
+	conn := om.NewMockedOmConnection(replicaSetDeployment(1))
+
+	planConn, err := NewConnection(conn)
+	require.NoError(t, err)
+	require.NoError(t, planConn.ReadUpdateAgentsLogRotation(mdbv1.AgentConfig{
+		Mongod:          mdbv1.AgentLoggingMongodConfig{LogRotate: &automationconfig.CrdLogRotate{SizeThresholdMB: "100", LogRotate: automationconfig.LogRotate{TimeThresholdHrs: 24}}},
+		MonitoringAgent: mdbv1.MonitoringAgent{LogRotate: &mdbv1.LogRotateForBackupAndMonitoring{SizeThresholdMB: 10, TimeThresholdHrs: 12}},
+	}, zap.S()))
+
+	assert.Equal(t, []string{
+		`+ processes[my-rs-0].logRotate: {"sizeThresholdMB":100,"timeThresholdHrs":24}`,
+		`+ monitoringAgentConfig.logRotate: {"sizeThresholdMB":10,"timeThresholdHrs":12}`,
+	}, planConn.Changes())
+
+	// the planned agent config is read back through the connection
+	mat, err := planConn.ReadMonitoringAgentConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sizeThresholdMB": float64(10), "timeThresholdHrs": float64(12)}, mat.BackingMap["logRotate"])
+
+	conn.CheckNumberOfUpdateRequests(t, 0)
+	conn.CheckOperationsDidntHappen(t, reflect.ValueOf(conn.UpdateMonitoringAgentConfig), reflect.ValueOf(conn.ReadUpdateAgentsLogRotation))
+}
+
+func TestConnection_RefusesChangesOutsideTheDeployment(t *testing.T) {
+	conn := om.NewMockedOmConnection(replicaSetDeployment(1))
+
+	planConn, err := NewConnection(conn)
+	require.NoError(t, err)
+
+	_, err = planConn.UpdateProject(&om.Project{})
+	assert.ErrorIs(t, err, ErrNotPlanned)
+	_, err = planConn.UpgradeAgentsToLatest()
+	assert.ErrorIs(t, err, ErrNotPlanned)
+	assert.ErrorIs(t, planConn.UpdateControlledFeature(&controlledfeature.ControlledFeature{}), ErrNotPlanned)
+	_, err = planConn.GenerateAgentKey()
+	assert.ErrorIs(t, err, ErrNotPlanned)
+
+	conn.CheckOperationsDidntHappen(t,
+		reflect.ValueOf(conn.UpdateProject),
+		reflect.ValueOf(conn.UpgradeAgentsToLatest),
+		reflect.ValueOf(conn.UpdateControlledFeature),
+		reflect.ValueOf(conn.GenerateAgentKey),
+	)
+}
+
+func TestPublishAndRemove(t *testing.T) {
+	ctx := context.Background()
+	mdb := mdbv1.NewReplicaSetBuilder().SetName("my-rs").Build()
+	client := kubernetesClient.NewClient(mock.NewEmptyFakeClientBuilder().Build())
+
+	require.NoError(t, Publish(ctx, client, mdb, []string{"+ processes[my-rs-3]"}))
+	text, err := configmap.ReadKey(ctx, client, ConfigMapKey, kube.ObjectKey(mdb.Namespace, "my-rs-plan"))
+	require.NoError(t, err)
+	assert.Equal(t, "1 change(s) to the Ops Manager deployment:\n+ processes[my-rs-3]\n", text)
+
+	require.NoError(t, Publish(ctx, client, mdb, nil))
+	text, err = configmap.ReadKey(ctx, client, ConfigMapKey, kube.ObjectKey(mdb.Namespace, "my-rs-plan"))
+	require.NoError(t, err)
+	assert.Equal(t, "No changes to the Ops Manager deployment.\n", text)
+
+	require.NoError(t, Remove(ctx, client, mdb))
+	exists, err := configmap.Exists(ctx, client, kube.ObjectKey(mdb.Namespace, "my-rs-plan"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// removing a missing plan is a no-op
+	assert.NoError(t, Remove(ctx, client, mdb))
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/apierror"
+)
+
+// NewOrganizationConnection returns the connection to Ops Manager used to manage the organization with the id. The
+// connection is not bound to any project.
+func NewOrganizationConnection(config mdbv1.ProjectConfig, credentials mdbv1.Credentials, orgID string, connectionFactory om.ConnectionFactory) om.Connection {
+	return connectionFactory(&om.OMContext{
+		OrgID:                      orgID,
+		BaseURL:                    config.BaseURL,
+		PublicKey:                  credentials.PublicAPIKey,
+		PrivateKey:                 credentials.PrivateAPIKey,
+		AllowInvalidSSLCertificate: !config.SSLRequireValidMMSServerCertificates,
+		CACertificate:              config.SSLMMSCAConfigMapContents,
+	})
+}
+
+// ReadOrCreateOrganization returns the organization with the id, or the one with the name if the id is not known yet.
+// The organization is created if none of them exists. The connection is configured to use the organization.
+func ReadOrCreateOrganization(conn om.Connection, orgID string, name string, log *zap.SugaredLogger) (*om.Organization, error) {
+	mutex := om.GetMutex("", name)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if orgID != "" {
+		organization, err := conn.ReadOrganization(orgID)
+		if err == nil {
+			conn.ConfigureProject(&om.Project{OrgID: organization.ID})
+			return organization, nil
+		}
+		if !apierror.NewNonNil(err).ErrorOrganizationIsNotFound() {
+			return nil, xerrors.Errorf("could not read organization %s: %w", orgID, err)
+		}
+		log.Warnf("The organization %s doesn't exist in Ops Manager anymore", orgID)
+	}
+
+	foundID, err := findOrganizationByName(conn, name, log)
+	if err != nil {
+		return nil, err
+	}
+	if foundID != "" {
+		log.Debugf("Found the organization \"%s\" with id %s", name, foundID)
+		conn.ConfigureProject(&om.Project{OrgID: foundID})
+		return &om.Organization{ID: foundID, Name: name}, nil
+	}
+
+	log.Infof("Creating the organization \"%s\" as it doesn't exist", name)
+	organization, err := conn.CreateOrganization(&om.Organization{Name: name})
+	if err != nil {
+		return nil, xerrors.Errorf("error creating organization \"%s\" in Ops Manager: %w", name, err)
+	}
+	conn.ConfigureProject(&om.Project{OrgID: organization.ID})
+	return organization, nil
+}
@@ -58,6 +58,16 @@ configMap are created in parallel - this function will be invoked sequentially a
 created on the first call
 */
 func ReadOrCreateProject(config mdbv1.ProjectConfig, credentials mdbv1.Credentials, connectionFactory om.ConnectionFactory, log *zap.SugaredLogger) (*om.Project, om.Connection, error) {
+	return readProject(config, credentials, connectionFactory, true, log)
+}
+
+// ReadProject returns the existing project and the connection to it. Unlike ReadOrCreateProject, nothing is created
+// in Ops Manager and an error is returned if the project doesn't exist.
+func ReadProject(config mdbv1.ProjectConfig, credentials mdbv1.Credentials, connectionFactory om.ConnectionFactory, log *zap.SugaredLogger) (*om.Project, om.Connection, error) {
+	return readProject(config, credentials, connectionFactory, false, log)
+}
+
+func readProject(config mdbv1.ProjectConfig, credentials mdbv1.Credentials, connectionFactory om.ConnectionFactory, create bool, log *zap.SugaredLogger) (*om.Project, om.Connection, error) {
 	projectName := config.ProjectName
 	mutex := om.GetMutex(projectName, config.OrgID)
 	mutex.Lock()
@@ -98,6 +108,10 @@ func ReadOrCreateProject(config mdbv1.ProjectConfig, credentials mdbv1.Credentia
 		}
 	}
 
+	if project == nil && !create {
+		return nil, nil, xerrors.Errorf("project %s doesn't exist in Ops Manager", projectName)
+	}
+
 	if project == nil {
 		project, err = tryCreateProject(org, projectName, config.OrgID, conn, log)
 		if err != nil {
@@ -13,14 +13,12 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
 )
 
-func validateProjectConfig(ctx context.Context, cmGetter configmap.Getter, projectConfigMap client.ObjectKey) (map[string]string, error) {
+func validateProjectConfig(ctx context.Context, cmGetter configmap.Getter, projectConfigMap client.ObjectKey, requiredFields ...string) (map[string]string, error) {
 	data, err := configmap.ReadData(ctx, cmGetter, projectConfigMap)
 	if err != nil {
 		return nil, err
 	}
 
-	requiredFields := []string{util.OmBaseUrl, util.OmOrgId}
-
 	for _, requiredField := range requiredFields {
 		if _, ok := data[requiredField]; !ok {
 			return nil, xerrors.Errorf(`property "%s" is not specified in ConfigMap %s`, requiredField, projectConfigMap)
@@ -33,11 +31,26 @@ func validateProjectConfig(ctx context.Context, cmGetter configmap.Getter, proje
 // like `projectName`, `baseUrl` and a series of attributes related to SSL.
 // If configMap doesn't have a projectName defined - the name of MongoDB resource is used as a name of project
 func ReadProjectConfig(ctx context.Context, cmGetter configmap.Getter, projectConfigMap client.ObjectKey, mdbName string) (mdbv1.ProjectConfig, error) {
-	data, err := validateProjectConfig(ctx, cmGetter, projectConfigMap)
+	data, err := validateProjectConfig(ctx, cmGetter, projectConfigMap, util.OmBaseUrl, util.OmOrgId)
 	if err != nil {
 		return mdbv1.ProjectConfig{}, err
 	}
 
+	return projectConfigFromData(ctx, cmGetter, projectConfigMap, data, mdbName)
+}
+
+// ReadOpsManagerConfig returns the connection details of Ops Manager from a ConfigMap of the same format as the project
+// one, but which doesn't specify an organization. It's used to manage organizations and projects declaratively.
+func ReadOpsManagerConfig(ctx context.Context, cmGetter configmap.Getter, configMap client.ObjectKey) (mdbv1.ProjectConfig, error) {
+	data, err := validateProjectConfig(ctx, cmGetter, configMap, util.OmBaseUrl)
+	if err != nil {
+		return mdbv1.ProjectConfig{}, err
+	}
+
+	return projectConfigFromData(ctx, cmGetter, configMap, data, "")
+}
+
+func projectConfigFromData(ctx context.Context, cmGetter configmap.Getter, projectConfigMap client.ObjectKey, data map[string]string, mdbName string) (mdbv1.ProjectConfig, error) {
 	baseURL := data[util.OmBaseUrl]
 	orgID := data[util.OmOrgId]
 
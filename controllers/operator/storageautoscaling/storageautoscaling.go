@@ -0,0 +1,264 @@
+// Package storageautoscaling expands the data volume of a database StatefulSet when the disk usage reported by the
+// monitoring agents goes above the configured threshold. The expansion itself is performed by the PVC resize workflow.
+package storageautoscaling
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const (
+	// CheckInterval is how often the disk usage is checked while storage autoscaling is enabled.
+	CheckInterval = 5 * time.Minute
+	// LastExpansionAnnotation is the StatefulSet annotation with the time the data volume was last expanded at. The
+	// disk usage measured before it is ignored, as it doesn't reflect the size of the expanded volume.
+	LastExpansionAnnotation = "mongodb.com/v1.storageAutoscalingLastExpansion"
+)
+
+// EnsureDataVolumeSize sets the storage of the data volume claim template of the desired StatefulSet to the size it
+// has been autoscaled to, expanding it by the increment if the disk usage of any of the hosts is above the threshold.
+// The autoscaled size is derived from the existing StatefulSet and PVCs, so it is kept across reconciliations and an
+// interrupted expansion is resumed. The disk usage isn't checked while the data volume is being resized.
+// If autoscaling is disabled after the data volume was expanded, the expanded size is kept, as volumes can't shrink.
+func EnsureDataVolumeSize(ctx context.Context, kubeClient kubernetesClient.Client, diskUsageReader host.GetDiskUsageReader, autoscaling *mdbv1.StorageAutoscaling, desiredSts *appsv1.StatefulSet, hostnames []string, log *zap.SugaredLogger) error {
+	templateIdx := dataVolumeClaimTemplateIndex(desiredSts)
+	if templateIdx == -1 {
+		return nil
+	}
+
+	existingSts, err := kubeClient.GetStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return err
+	}
+	lastExpansion := time.Time{}
+	if err == nil {
+		lastExpansion = lastExpansionTime(existingSts)
+	}
+
+	if autoscaling == nil && lastExpansion.IsZero() {
+		// the data volume has never been autoscaled, its size is the one in the spec
+		return nil
+	}
+
+	pvcs, err := dataVolumePVCs(ctx, kubeClient, desiredSts)
+	if err != nil {
+		return err
+	}
+
+	currentSize := currentDataVolumeSize(desiredSts, templateIdx, existingSts, pvcs)
+	setStorage(desiredSts, templateIdx, currentSize)
+	if !lastExpansion.IsZero() {
+		setLastExpansionTime(desiredSts, lastExpansion)
+	}
+
+	if autoscaling == nil {
+		return nil
+	}
+
+	increment, err := resource.ParseQuantity(autoscaling.Increment)
+	if err != nil {
+		return xerrors.Errorf("invalid storage autoscaling increment %q: %w", autoscaling.Increment, err)
+	}
+	maxSize, err := resource.ParseQuantity(autoscaling.MaxSize)
+	if err != nil {
+		return xerrors.Errorf("invalid storage autoscaling max size %q: %w", autoscaling.MaxSize, err)
+	}
+
+	if isResizeInProgress(currentSize, pvcs) {
+		log.Debugf("The data volume is being resized to %s, skipping storage autoscaling", currentSize.String())
+		return nil
+	}
+
+	usedPercent, err := maxDiskSpacePercentUsed(diskUsageReader, hostnames, lastExpansion)
+	if err != nil {
+		// a failure to read the measurements must not block the reconciliation, the disk usage is checked again on the next one
+		log.Warnf("Failed to read the disk usage of the hosts from Ops Manager, skipping storage autoscaling: %s", err)
+		return nil
+	}
+
+	if usedPercent < float64(autoscaling.GetThresholdPercent()) {
+		return nil
+	}
+
+	if currentSize.Cmp(maxSize) >= 0 {
+		log.Warnf("The disk usage of %.1f%% is above the autoscaling threshold of %d%%, but the data volume has already reached the max size of %s", usedPercent, autoscaling.GetThresholdPercent(), maxSize.String())
+		return nil
+	}
+
+	newSize := currentSize.DeepCopy()
+	newSize.Add(increment)
+	if newSize.Cmp(maxSize) > 0 {
+		newSize = maxSize
+	}
+
+	log.Infof("The disk usage of %.1f%% is above the autoscaling threshold of %d%%, expanding the data volume from %s to %s", usedPercent, autoscaling.GetThresholdPercent(), currentSize.String(), newSize.String())
+	setStorage(desiredSts, templateIdx, newSize)
+	setLastExpansionTime(desiredSts, time.Now())
+	return nil
+}
+
+func dataVolumeClaimTemplateIndex(sts *appsv1.StatefulSet) int {
+	for i, template := range sts.Spec.VolumeClaimTemplates {
+		if template.Name == util.PvcNameData {
+			return i
+		}
+	}
+	return -1
+}
+
+// dataVolumePVCs returns the existing PVCs created from the data volume claim template of the StatefulSet.
+func dataVolumePVCs(ctx context.Context, kubeClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet) ([]corev1.PersistentVolumeClaim, error) {
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := kubeClient.List(ctx, &pvcList, client.InNamespace(desiredSts.Namespace)); err != nil {
+		return nil, err
+	}
+	pvcPrefix := fmt.Sprintf("%s-%s-", util.PvcNameData, desiredSts.Name)
+	var pvcs []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcList.Items {
+		if isDataVolumePVC(pvc.Name, pvcPrefix) {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+	return pvcs, nil
+}
+
+// currentDataVolumeSize returns the biggest of the sizes of the data volume in the desired StatefulSet, the existing
+// StatefulSet and the existing PVCs, which are expanded first during a resize.
+func currentDataVolumeSize(desiredSts *appsv1.StatefulSet, templateIdx int, existingSts appsv1.StatefulSet, pvcs []corev1.PersistentVolumeClaim) resource.Quantity {
+	currentSize := desiredSts.Spec.VolumeClaimTemplates[templateIdx].Spec.Resources.Requests.Storage().DeepCopy()
+	if idx := dataVolumeClaimTemplateIndex(&existingSts); idx != -1 {
+		currentSize = maxQuantity(currentSize, *existingSts.Spec.VolumeClaimTemplates[idx].Spec.Resources.Requests.Storage())
+	}
+	for _, pvc := range pvcs {
+		currentSize = maxQuantity(currentSize, *pvc.Spec.Resources.Requests.Storage())
+	}
+	return currentSize
+}
+
+// isResizeInProgress returns true if any of the PVCs hasn't been expanded to the current size of the data volume yet,
+// either because the resize workflow hasn't requested it or because the storage provider hasn't completed it.
+func isResizeInProgress(currentSize resource.Quantity, pvcs []corev1.PersistentVolumeClaim) bool {
+	for _, pvc := range pvcs {
+		if pvc.Spec.Resources.Requests.Storage().Cmp(currentSize) < 0 {
+			return true
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(currentSize) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func lastExpansionTime(sts appsv1.StatefulSet) time.Time {
+	lastExpansion, err := time.Parse(time.RFC3339, sts.Annotations[LastExpansionAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return lastExpansion
+}
+
+func setLastExpansionTime(sts *appsv1.StatefulSet, lastExpansion time.Time) {
+	if sts.Annotations == nil {
+		sts.Annotations = map[string]string{}
+	}
+	sts.Annotations[LastExpansionAnnotation] = lastExpansion.UTC().Format(time.RFC3339)
+}
+
+// isDataVolumePVC checks that the PVC name is the prefix followed by the ordinal of a pod.
+func isDataVolumePVC(pvcName string, prefix string) bool {
+	ordinal, found := strings.CutPrefix(pvcName, prefix)
+	if !found || ordinal == "" {
+		return false
+	}
+	for _, c := range ordinal {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// maxDiskSpacePercentUsed returns the highest percentage of used space of the partitions backing the data volume of
+// the hosts, ignoring the measurements collected before since.
+func maxDiskSpacePercentUsed(diskUsageReader host.GetDiskUsageReader, hostnames []string, since time.Time) (float64, error) {
+	hosts, err := diskUsageReader.GetHosts()
+	if err != nil {
+		return 0, err
+	}
+
+	usedPercent := math.Inf(-1)
+	for _, h := range hosts.Results {
+		if !slices.Contains(hostnames, h.Hostname) {
+			continue
+		}
+		partitions, err := diskUsageReader.ReadDiskSpacePercentUsed(h.Id, since)
+		if err != nil {
+			return 0, err
+		}
+		if len(partitions) == 0 {
+			continue
+		}
+		partitionUsedPercent, ok := dataPartitionUsedPercent(partitions)
+		if !ok {
+			return 0, xerrors.Errorf("failed to identify the disk partition backing the data volume of host %s among %v", h.Hostname, slices.Sorted(maps.Keys(partitions)))
+		}
+		usedPercent = math.Max(usedPercent, partitionUsedPercent)
+	}
+
+	if math.IsInf(usedPercent, -1) {
+		return 0, xerrors.New("no disk usage has been reported for the hosts yet")
+	}
+	return usedPercent, nil
+}
+
+// dataPartitionUsedPercent returns the used space of the partition backing the data volume, which is either reported
+// under the name of the data volume or mount path, or is the only partition reported for the host. The partitions
+// of the journal and logs volumes must not trigger the expansion of the data volume.
+func dataPartitionUsedPercent(partitions map[string]float64) (float64, bool) {
+	for _, name := range []string{util.PvcNameData, util.PvcMountPathData} {
+		if usedPercent, ok := partitions[name]; ok {
+			return usedPercent, true
+		}
+	}
+	if len(partitions) == 1 {
+		for _, usedPercent := range partitions {
+			return usedPercent, true
+		}
+	}
+	return 0, false
+}
+
+func setStorage(sts *appsv1.StatefulSet, templateIdx int, storage resource.Quantity) {
+	template := &sts.Spec.VolumeClaimTemplates[templateIdx]
+	if template.Spec.Resources.Requests == nil {
+		template.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	template.Spec.Resources.Requests[corev1.ResourceStorage] = storage
+}
+
+func maxQuantity(a, b resource.Quantity) resource.Quantity {
+	if b.Cmp(a) > 0 {
+		return b.DeepCopy()
+	}
+	return a
+}
@@ -0,0 +1,212 @@
+package storageautoscaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om"
+	"github.com/mongodb/mongodb-kubernetes/controllers/om/host"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+)
+
+var testHostnames = []string{"my-rs-0.my-rs-svc.ns.svc.cluster.local", "my-rs-1.my-rs-svc.ns.svc.cluster.local"}
+
+func newTestStatefulSet(storage string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-rs", Namespace: "ns"},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func newTestPVC(name string, storage string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)},
+			},
+		},
+	}
+}
+
+func newTestPVCWithCapacity(name string, storage string, capacity string) *corev1.PersistentVolumeClaim {
+	pvc := newTestPVC(name, storage)
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)}
+	return pvc
+}
+
+func newTestConnection(usedPercent ...float64) *om.MockedOmConnection {
+	conn := om.NewMockedOmConnection(nil)
+	conn.DiskSpacePercentUsed = map[string]map[string]float64{}
+	for i, hostname := range testHostnames {
+		_ = conn.AddHost(host.Host{Id: hostname, Hostname: hostname})
+		if i < len(usedPercent) {
+			conn.DiskSpacePercentUsed[hostname] = map[string]float64{"sdb": usedPercent[i]}
+		}
+	}
+	return conn
+}
+
+func dataVolumeStorage(sts *appsv1.StatefulSet) string {
+	return sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()
+}
+
+func TestEnsureDataVolumeSize(t *testing.T) {
+	autoscaling := &mdbv1.StorageAutoscaling{ThresholdPercent: 80, Increment: "10Gi", MaxSize: "35Gi"}
+
+	tests := []struct {
+		name            string
+		usedPercent     []float64
+		existingPVCs    []*corev1.PersistentVolumeClaim
+		existingSts     *appsv1.StatefulSet
+		expectedStorage string
+	}{
+		{
+			name:            "disk usage below the threshold",
+			usedPercent:     []float64{40, 79.9},
+			expectedStorage: "10Gi",
+		},
+		{
+			name:            "disk usage of one host above the threshold",
+			usedPercent:     []float64{40, 80},
+			expectedStorage: "20Gi",
+		},
+		{
+			name:            "no disk usage reported yet",
+			expectedStorage: "10Gi",
+		},
+		{
+			name:            "autoscaled size of the existing StatefulSet is kept",
+			usedPercent:     []float64{40, 50},
+			existingSts:     newTestStatefulSet("20Gi"),
+			expectedStorage: "20Gi",
+		},
+		{
+			name:            "expansion in progress on the PVCs is kept",
+			usedPercent:     []float64{40, 50},
+			existingSts:     newTestStatefulSet("10Gi"),
+			existingPVCs:    []*corev1.PersistentVolumeClaim{newTestPVC("data-my-rs-0", "20Gi"), newTestPVC("data-my-rs-1", "20Gi"), newTestPVC("data-other-rs-0", "50Gi")},
+			expectedStorage: "20Gi",
+		},
+		{
+			name:            "disk usage is not checked while the PVCs are being expanded",
+			usedPercent:     []float64{90, 90},
+			existingSts:     newTestStatefulSet("20Gi"),
+			existingPVCs:    []*corev1.PersistentVolumeClaim{newTestPVCWithCapacity("data-my-rs-0", "20Gi", "20Gi"), newTestPVCWithCapacity("data-my-rs-1", "20Gi", "10Gi")},
+			expectedStorage: "20Gi",
+		},
+		{
+			name:            "disk usage is checked once the PVCs have been expanded",
+			usedPercent:     []float64{90, 90},
+			existingSts:     newTestStatefulSet("20Gi"),
+			existingPVCs:    []*corev1.PersistentVolumeClaim{newTestPVCWithCapacity("data-my-rs-0", "20Gi", "20Gi"), newTestPVCWithCapacity("data-my-rs-1", "20Gi", "20Gi")},
+			expectedStorage: "30Gi",
+		},
+		{
+			name:            "expansion is capped at the max size",
+			usedPercent:     []float64{90, 90},
+			existingSts:     newTestStatefulSet("30Gi"),
+			expectedStorage: "35Gi",
+		},
+		{
+			name:            "no expansion beyond the max size",
+			usedPercent:     []float64{95, 95},
+			existingSts:     newTestStatefulSet("35Gi"),
+			expectedStorage: "35Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClientBuilder := mock.NewEmptyFakeClientBuilder()
+			if tt.existingSts != nil {
+				fakeClientBuilder.WithObjects(tt.existingSts)
+			}
+			for _, pvc := range tt.existingPVCs {
+				fakeClientBuilder.WithObjects(pvc)
+			}
+			fakeClient := kubernetesClient.NewClient(fakeClientBuilder.Build())
+
+			desiredSts := newTestStatefulSet("10Gi")
+			err := EnsureDataVolumeSize(ctx, fakeClient, newTestConnection(tt.usedPercent...), autoscaling, desiredSts, testHostnames, zap.S())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStorage, dataVolumeStorage(desiredSts))
+		})
+	}
+}
+
+func TestEnsureDataVolumeSize_DefaultThreshold(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := kubernetesClient.NewClient(mock.NewEmptyFakeClientBuilder().Build())
+	autoscaling := &mdbv1.StorageAutoscaling{Increment: "5Gi", MaxSize: "100Gi"}
+
+	desiredSts := newTestStatefulSet("10Gi")
+	require.NoError(t, EnsureDataVolumeSize(ctx, fakeClient, newTestConnection(79), autoscaling, desiredSts, testHostnames, zap.S()))
+	assert.Equal(t, "10Gi", dataVolumeStorage(desiredSts))
+
+	require.NoError(t, EnsureDataVolumeSize(ctx, fakeClient, newTestConnection(81), autoscaling, desiredSts, testHostnames, zap.S()))
+	assert.Equal(t, "15Gi", dataVolumeStorage(desiredSts))
+	assert.False(t, lastExpansionTime(*desiredSts).IsZero())
+}
+
+func TestDataPartitionUsedPercent(t *testing.T) {
+	usedPercent, ok := dataPartitionUsedPercent(map[string]float64{"sdb": 42})
+	assert.True(t, ok)
+	assert.Equal(t, 42.0, usedPercent)
+
+	usedPercent, ok = dataPartitionUsedPercent(map[string]float64{"journal": 95, "data": 42, "logs": 90})
+	assert.True(t, ok)
+	assert.Equal(t, 42.0, usedPercent)
+
+	_, ok = dataPartitionUsedPercent(map[string]float64{"sdb": 42, "sdc": 95})
+	assert.False(t, ok)
+}
+
+func TestIsDataVolumePVC(t *testing.T) {
+	assert.True(t, isDataVolumePVC("data-my-rs-0", "data-my-rs-"))
+	assert.True(t, isDataVolumePVC("data-my-rs-12", "data-my-rs-"))
+	assert.False(t, isDataVolumePVC("data-my-rs-", "data-my-rs-"))
+	assert.False(t, isDataVolumePVC("data-my-rs-config-0", "data-my-rs-"))
+	assert.False(t, isDataVolumePVC("journal-my-rs-0", "data-my-rs-"))
+}
+
+func TestEnsureDataVolumeSize_AutoscalingDisabled(t *testing.T) {
+	ctx := context.Background()
+	autoscaledSts := newTestStatefulSet("20Gi")
+	setLastExpansionTime(autoscaledSts, time.Now())
+	fakeClient := kubernetesClient.NewClient(mock.NewEmptyFakeClientBuilder().WithObjects(autoscaledSts, newTestPVC("data-my-rs-0", "30Gi")).Build())
+
+	// the size the data volume was expanded to is kept after autoscaling is disabled
+	desiredSts := newTestStatefulSet("10Gi")
+	require.NoError(t, EnsureDataVolumeSize(ctx, fakeClient, newTestConnection(90, 90), nil, desiredSts, testHostnames, zap.S()))
+	assert.Equal(t, "30Gi", dataVolumeStorage(desiredSts))
+	assert.False(t, lastExpansionTime(*desiredSts).IsZero())
+
+	// the size of a data volume which was never autoscaled is the one in the spec
+	fakeClient = kubernetesClient.NewClient(mock.NewEmptyFakeClientBuilder().WithObjects(newTestStatefulSet("20Gi")).Build())
+	desiredSts = newTestStatefulSet("10Gi")
+	require.NoError(t, EnsureDataVolumeSize(ctx, fakeClient, newTestConnection(90, 90), nil, desiredSts, testHostnames, zap.S()))
+	assert.Equal(t, "10Gi", dataVolumeStorage(desiredSts))
+}
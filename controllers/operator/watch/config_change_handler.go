@@ -24,6 +24,9 @@ const (
 	Secret             Type = "Secret"
 	MongoDB            Type = "MongoDB"
 	ClusterMongoDBRole Type = "ClusterMongoDBRole"
+	MongoDBSearch      Type = "MongoDBSearch"
+
+	MongoDBOrganization Type = "MongoDBOrganization"
 )
 
 // the Object watched by controller. Includes its type and namespace+name
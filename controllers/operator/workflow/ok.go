@@ -31,6 +31,12 @@ func (o *okStatus) WithAdditionalOptions(options ...status.Option) *okStatus {
 	return o
 }
 
+// WithRequeueAfter overrides the default requeue interval, for resources that need to refresh their status periodically.
+func (o *okStatus) WithRequeueAfter(requeueAfter time.Duration) *okStatus {
+	o.requeueAfter = requeueAfter
+	return o
+}
+
 func (o *okStatus) ReconcileResult() (reconcile.Result, error) {
 	return reconcile.Result{Requeue: o.requeue, RequeueAfter: o.requeueAfter}, nil
 }
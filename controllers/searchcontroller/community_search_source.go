@@ -33,6 +33,10 @@ func (r *CommunitySearchSource) HostSeeds() []string {
 	return seeds
 }
 
+func (r *CommunitySearchSource) ClusterDomain() string {
+	return r.Spec.GetClusterDomain()
+}
+
 func (r *CommunitySearchSource) KeyfileSecretName() string {
 	return r.MongoDBCommunity.GetAgentKeyfileSecretNamespacedName().Name
 }
@@ -2,6 +2,7 @@ package searchcontroller
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver"
@@ -23,10 +24,44 @@ func NewEnterpriseResourceSearchSource(mdb *mdbv1.MongoDB) SearchSourceDBResourc
 }
 
 func (r EnterpriseResourceSearchSource) HostSeeds() []string {
-	seeds := make([]string, r.Spec.Members)
-	clusterDomain := r.Spec.GetClusterDomain()
+	if r.GetResourceType() == mdbv1.ShardedCluster {
+		return r.MongosHostSeeds()
+	}
+
+	return hostSeeds(r.Name, r.ServiceName(), r.Namespace, r.Spec.GetClusterDomain(), r.Spec.Members, r.Spec.GetAdditionalMongodConfig().GetPortOrDefault())
+}
+
+func (r EnterpriseResourceSearchSource) IsSharded() bool {
+	return r.GetResourceType() == mdbv1.ShardedCluster
+}
+
+func (r EnterpriseResourceSearchSource) ShardCount() int {
+	return r.Spec.ShardCount
+}
+
+func (r EnterpriseResourceSearchSource) ShardHostSeeds(shardIdx int) []string {
+	return hostSeeds(r.ShardRsName(shardIdx), r.ShardServiceName(), r.Namespace, r.Spec.GetClusterDomain(), r.shardMembers(shardIdx), r.Spec.ShardSpec.GetAdditionalMongodConfig().GetPortOrDefault())
+}
+
+// shardMembers returns the number of members of the shard, which can be set for the shard in spec.shardOverrides.
+func (r EnterpriseResourceSearchSource) shardMembers(shardIdx int) int {
+	shardName := r.ShardRsName(shardIdx)
+	for _, shardOverride := range r.Spec.ShardOverrides {
+		if shardOverride.Members != nil && slices.Contains(shardOverride.ShardNames, shardName) {
+			return *shardOverride.Members
+		}
+	}
+	return r.Spec.MongodsPerShardCount
+}
+
+func (r EnterpriseResourceSearchSource) MongosHostSeeds() []string {
+	return hostSeeds(r.MongosRsName(), r.ServiceName(), r.Namespace, r.Spec.GetClusterDomain(), r.Spec.MongosCount, r.Spec.MongosSpec.GetAdditionalMongodConfig().GetPortOrDefault())
+}
+
+func hostSeeds(stsName, serviceName, namespace, clusterDomain string, members int, port int32) []string {
+	seeds := make([]string, members)
 	for i := range seeds {
-		seeds[i] = fmt.Sprintf("%s-%d.%s.%s.svc.%s:%d", r.Name, i, r.ServiceName(), r.Namespace, clusterDomain, r.Spec.GetAdditionalMongodConfig().GetPortOrDefault())
+		seeds[i] = fmt.Sprintf("%s-%d.%s.%s.svc.%s:%d", stsName, i, serviceName, namespace, clusterDomain, port)
 	}
 	return seeds
 }
@@ -47,27 +82,41 @@ func (r EnterpriseResourceSearchSource) TLSConfig() *TLSSourceConfig {
 	}
 }
 
+func (r EnterpriseResourceSearchSource) ClusterDomain() string {
+	return r.Spec.GetClusterDomain()
+}
+
 func (r EnterpriseResourceSearchSource) KeyfileSecretName() string {
 	return fmt.Sprintf("%s-%s", r.Name, MongotKeyfileFilename)
 }
 
 func (r EnterpriseResourceSearchSource) Validate() error {
-	version, err := semver.ParseTolerant(util.StripEnt(r.Spec.GetMongoDBVersion()))
-	if err != nil {
-		return xerrors.Errorf("error parsing MongoDB version '%s': %w", r.Spec.GetMongoDBVersion(), err)
-	} else if version.LT(semver.MustParse("8.2.0")) {
-		return xerrors.New("MongoDB version must be 8.2.0 or higher")
+	if err := validateEnterpriseMongoDBVersion(r.Spec.GetMongoDBVersion()); err != nil {
+		return err
 	}
 
 	if r.Spec.GetTopology() != mdbv1.ClusterTopologySingleCluster {
 		return xerrors.Errorf("MongoDBSearch is only supported for %s topology", mdbv1.ClusterTopologySingleCluster)
 	}
 
-	if r.GetResourceType() != mdbv1.ReplicaSet {
-		return xerrors.Errorf("MongoDBSearch is only supported for %s resources", mdbv1.ReplicaSet)
+	if r.GetResourceType() != mdbv1.ReplicaSet && r.GetResourceType() != mdbv1.ShardedCluster {
+		return xerrors.Errorf("MongoDBSearch is only supported for %s and %s resources", mdbv1.ReplicaSet, mdbv1.ShardedCluster)
 	}
 
-	authModes := r.Spec.GetSecurityAuthenticationModes()
+	return validateScramAuthentication(r.Spec.GetSecurityAuthenticationModes())
+}
+
+func validateEnterpriseMongoDBVersion(mongoDBVersion string) error {
+	version, err := semver.ParseTolerant(util.StripEnt(mongoDBVersion))
+	if err != nil {
+		return xerrors.Errorf("error parsing MongoDB version '%s': %w", mongoDBVersion, err)
+	} else if version.LT(semver.MustParse("8.2.0")) {
+		return xerrors.New("MongoDB version must be 8.2.0 or higher")
+	}
+	return nil
+}
+
+func validateScramAuthentication(authModes []string) error {
 	foundScram := false
 	for _, authMode := range authModes {
 		// Check for SCRAM, SCRAM-SHA-1, or SCRAM-SHA-256
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -138,16 +139,15 @@ func TestEnterpriseResourceSearchSource_Validate(t *testing.T) {
 			resourceType:   mdbv1.Standalone,
 			authModes:      []string{},
 			expectError:    true,
-			expectedErrMsg: "MongoDBSearch is only supported for ReplicaSet resources",
+			expectedErrMsg: "MongoDBSearch is only supported for ReplicaSet and ShardedCluster resources",
 		},
 		{
-			name:           "Invalid resource type - ShardedCluster",
-			version:        "8.2.0",
-			topology:       mdbv1.ClusterTopologySingleCluster,
-			resourceType:   mdbv1.ShardedCluster,
-			authModes:      []string{},
-			expectError:    true,
-			expectedErrMsg: "MongoDBSearch is only supported for ReplicaSet resources",
+			name:         "Valid resource type - ShardedCluster",
+			version:      "8.2.0",
+			topology:     mdbv1.ClusterTopologySingleCluster,
+			resourceType: mdbv1.ShardedCluster,
+			authModes:    []string{},
+			expectError:  false,
 		},
 		{
 			name:         "Valid resource type - ReplicaSet",
@@ -269,7 +269,7 @@ func TestEnterpriseResourceSearchSource_Validate(t *testing.T) {
 			resourceType:   mdbv1.Standalone,
 			authModes:      []string{},
 			expectError:    true,
-			expectedErrMsg: "MongoDBSearch is only supported for ReplicaSet resources",
+			expectedErrMsg: "MongoDBSearch is only supported for ReplicaSet and ShardedCluster resources",
 		},
 	}
 
@@ -287,3 +287,31 @@ func TestEnterpriseResourceSearchSource_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestEnterpriseResourceSearchSource_ShardedHostSeeds(t *testing.T) {
+	src := newEnterpriseSearchSource("8.2.0", mdbv1.ClusterTopologySingleCluster, mdbv1.ShardedCluster, []string{}, "")
+	src.Spec.ShardCount = 2
+	src.Spec.MongodsPerShardCount = 3
+	src.Spec.MongosCount = 2
+
+	assert.True(t, src.IsSharded())
+	assert.Equal(t, 2, src.ShardCount())
+	assert.Equal(t, []string{
+		"test-mongodb-1-0.test-mongodb-sh.test-namespace.svc.cluster.local:27017",
+		"test-mongodb-1-1.test-mongodb-sh.test-namespace.svc.cluster.local:27017",
+		"test-mongodb-1-2.test-mongodb-sh.test-namespace.svc.cluster.local:27017",
+	}, src.ShardHostSeeds(1))
+
+	src.Spec.ShardOverrides = []mdbv1.ShardOverride{{ShardNames: []string{"test-mongodb-1"}, Members: ptr.To(1)}}
+	assert.Equal(t, []string{
+		"test-mongodb-1-0.test-mongodb-sh.test-namespace.svc.cluster.local:27017",
+	}, src.ShardHostSeeds(1))
+	assert.Len(t, src.ShardHostSeeds(0), 3)
+
+	mongosSeeds := []string{
+		"test-mongodb-mongos-0.test-mongodb-svc.test-namespace.svc.cluster.local:27017",
+		"test-mongodb-mongos-1.test-mongodb-svc.test-namespace.svc.cluster.local:27017",
+	}
+	assert.Equal(t, mongosSeeds, src.MongosHostSeeds())
+	assert.Equal(t, mongosSeeds, src.HostSeeds())
+}
@@ -50,3 +50,7 @@ func (r *externalSearchResource) KeyfileSecretName() string {
 }
 
 func (r *externalSearchResource) HostSeeds() []string { return r.spec.HostAndPorts }
+
+// ClusterDomain returns an empty string so that the default cluster domain is used, the external deployment doesn't
+// configure the one of the mongot instances.
+func (r *externalSearchResource) ClusterDomain() string { return "" }
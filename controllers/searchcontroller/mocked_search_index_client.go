@@ -0,0 +1,89 @@
+package searchcontroller
+
+import (
+	"context"
+	"sync"
+)
+
+// MockedSearchIndexClient is an in-memory SearchIndexClient used in tests. Created indexes are reported in
+// the CreatedIndexStatus until changed by the test.
+type MockedSearchIndexClient struct {
+	mutex              sync.Mutex
+	Indexes            map[string]*MockedSearchIndex
+	CreatedIndexStatus string
+	LastOptions        SearchIndexClientOptions
+}
+
+type MockedSearchIndex struct {
+	SearchIndexDescription
+	Database   string
+	Collection string
+	Definition map[string]interface{}
+}
+
+var _ SearchIndexClient = &MockedSearchIndexClient{}
+
+func NewMockedSearchIndexClient() *MockedSearchIndexClient {
+	return &MockedSearchIndexClient{Indexes: map[string]*MockedSearchIndex{}, CreatedIndexStatus: "PENDING"}
+}
+
+// Factory returns a SearchIndexClientFactory always returning this client.
+func (c *MockedSearchIndexClient) Factory() SearchIndexClientFactory {
+	return func(_ context.Context, opts SearchIndexClientOptions) (SearchIndexClient, error) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.LastOptions = opts
+		return c, nil
+	}
+}
+
+func (c *MockedSearchIndexClient) GetSearchIndex(_ context.Context, database, collection, name string) (*SearchIndexDescription, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	index, ok := c.Indexes[mockedSearchIndexKey(database, collection, name)]
+	if !ok {
+		return nil, nil
+	}
+	description := index.SearchIndexDescription
+	return &description, nil
+}
+
+func (c *MockedSearchIndexClient) CreateSearchIndex(_ context.Context, database, collection, name, indexType string, definition map[string]interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.Indexes[mockedSearchIndexKey(database, collection, name)] = &MockedSearchIndex{
+		SearchIndexDescription: SearchIndexDescription{Name: name, Type: indexType, Status: c.CreatedIndexStatus},
+		Database:               database,
+		Collection:             collection,
+		Definition:             definition,
+	}
+	return nil
+}
+
+func (c *MockedSearchIndexClient) UpdateSearchIndex(_ context.Context, database, collection, name string, definition map[string]interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if index, ok := c.Indexes[mockedSearchIndexKey(database, collection, name)]; ok {
+		index.Definition = definition
+	}
+	return nil
+}
+
+func (c *MockedSearchIndexClient) DropSearchIndex(_ context.Context, database, collection, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.Indexes, mockedSearchIndexKey(database, collection, name))
+	return nil
+}
+
+func (c *MockedSearchIndexClient) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func mockedSearchIndexKey(database, collection, name string) string {
+	return database + "." + collection + "." + name
+}
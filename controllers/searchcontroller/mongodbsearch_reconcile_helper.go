@@ -4,13 +4,19 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base32"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,18 +27,25 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/container"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/podtemplatespec"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/service"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/mongot"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/tls"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/merge"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
 	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
+	mekoService "github.com/mongodb/mongodb-kubernetes/pkg/kube/service"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/timeutil"
 )
 
 const (
@@ -55,6 +68,10 @@ type MongoDBSearchReconcileHelper struct {
 	mdbSearch            *searchv1.MongoDBSearch
 	db                   SearchSourceDBResource
 	operatorSearchConfig OperatorSearchConfig
+	metricsReader        MongotMetricsReader
+	// memberClusterClients are the clients of the member clusters the mongot instances of multi-cluster sources are
+	// deployed in
+	memberClusterClients map[string]kubernetesClient.Client
 }
 
 func NewMongoDBSearchReconcileHelper(
@@ -62,12 +79,15 @@ func NewMongoDBSearchReconcileHelper(
 	mdbSearch *searchv1.MongoDBSearch,
 	db SearchSourceDBResource,
 	operatorSearchConfig OperatorSearchConfig,
+	memberClusterClients map[string]kubernetesClient.Client,
 ) *MongoDBSearchReconcileHelper {
 	return &MongoDBSearchReconcileHelper{
 		client:               client,
 		operatorSearchConfig: operatorSearchConfig,
 		mdbSearch:            mdbSearch,
 		db:                   db,
+		metricsReader:        ReadMongotMetrics,
+		memberClusterClients: memberClusterClients,
 	}
 }
 
@@ -97,6 +117,10 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 		return workflow.Failed(err)
 	}
 
+	if err := r.validateClusterSpecList(); err != nil {
+		return workflow.Invalid("%s", err.Error())
+	}
+
 	keyfileStsModification := statefulset.NOOP()
 	if r.mdbSearch.IsWireprotoEnabled() {
 		var err error
@@ -108,10 +132,6 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 		}
 	}
 
-	if err := r.ensureSearchService(ctx, r.mdbSearch); err != nil {
-		return workflow.Failed(err)
-	}
-
 	ingressTlsMongotModification, ingressTlsStsModification, err := r.ensureIngressTlsConfig(ctx)
 	if err != nil {
 		return workflow.Failed(err)
@@ -119,27 +139,99 @@ func (r *MongoDBSearchReconcileHelper) reconcile(ctx context.Context, log *zap.S
 
 	egressTlsMongotModification, egressTlsStsModification := r.ensureEgressTlsConfig(ctx)
 
-	// the egress TLS modification needs to always be applied after the ingress one, because it toggles mTLS based on the mode set by the ingress modification
-	configHash, err := r.ensureMongotConfig(ctx, log, createMongotConfig(r.mdbSearch, r.db), ingressTlsMongotModification, egressTlsMongotModification)
+	prometheusMongotModification, prometheusStsModification, err := r.ensurePrometheusConfig(ctx)
 	if err != nil {
 		return workflow.Failed(err)
 	}
 
-	configHashModification := statefulset.WithPodSpecTemplate(podtemplatespec.WithAnnotations(
-		map[string]string{
-			"mongotConfigHash": configHash,
-		},
-	))
+	// replica set sources are indexed by a single group of mongot instances, sharded sources by one group per shard and
+	// multi-cluster sources by one group per member cluster
+	groups := MongotGroups(r.mdbSearch, r.db)
+	for _, group := range groups {
+		groupClient, err := r.groupClient(group)
+		if err != nil {
+			return workflow.Failed(err)
+		}
 
-	if err := r.createOrUpdateStatefulSet(ctx, log, CreateSearchStatefulSetFunc(r.mdbSearch, r.db, r.buildImageString()), configHashModification, keyfileStsModification, ingressTlsStsModification, egressTlsStsModification); err != nil {
-		return workflow.Failed(err)
+		if group.ClusterName != "" {
+			if err := r.ensureMemberClusterSecrets(ctx, groupClient, group.ClusterName); err != nil {
+				return workflow.Failed(err)
+			}
+		}
+
+		if err := r.ensureSearchService(ctx, groupClient, group); err != nil {
+			return workflow.Failed(err)
+		}
+
+		if err := r.ensureMetricsService(ctx, groupClient, group, log); err != nil {
+			return workflow.Failed(err)
+		}
+
+		// the egress TLS modification needs to always be applied after the ingress one, because it toggles mTLS based on the mode set by the ingress modification
+		configHash, err := r.ensureMongotConfig(ctx, groupClient, log, group, createMongotConfig(r.mdbSearch, group), ingressTlsMongotModification, egressTlsMongotModification, prometheusMongotModification)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+
+		configHashModification := statefulset.WithPodSpecTemplate(podtemplatespec.WithAnnotations(
+			map[string]string{
+				"mongotConfigHash": configHash,
+			},
+		))
+
+		if workflowStatus := r.createOrUpdateStatefulSet(ctx, groupClient, log, group.StatefulSetName, CreateSearchStatefulSetFunc(r.mdbSearch, group, r.buildImageString()), configHashModification, keyfileStsModification, ingressTlsStsModification, egressTlsStsModification, prometheusStsModification); !workflowStatus.IsOK() {
+			return workflowStatus
+		}
+	}
+
+	if sharded, ok := r.db.(ShardedSearchSource); ok && sharded.IsSharded() {
+		if err := r.deleteRemovedShardGroups(ctx, sharded.ShardCount(), log); err != nil {
+			return workflow.Failed(err)
+		}
+	} else if !r.mdbSearch.IsMultiCluster() {
+		// the source is not sharded (anymore), so the mongot instances of all its former shards are removed
+		if err := r.deleteRemovedShardGroups(ctx, 0, log); err != nil {
+			return workflow.Failed(err)
+		}
+	}
+
+	if r.mdbSearch.IsMultiCluster() {
+		if err := r.deleteRemovedClusterGroups(ctx, groups, log); err != nil {
+			return workflow.Failed(err)
+		}
 	}
 
-	if statefulSetStatus := statefulset.GetStatefulSetStatus(ctx, r.mdbSearch.Namespace, r.mdbSearch.StatefulSetNamespacedName().Name, r.client); !statefulSetStatus.IsOK() {
-		return statefulSetStatus
+	for _, group := range groups {
+		groupClient, err := r.groupClient(group)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		if statefulSetStatus := statefulset.GetStatefulSetStatus(ctx, group.StatefulSetName.Namespace, group.StatefulSetName.Name, groupClient); !statefulSetStatus.IsOK() {
+			return statefulSetStatus
+		}
 	}
 
-	return workflow.OK().WithAdditionalOptions(searchv1.NewMongoDBSearchVersionOption(version))
+	statusOptions := []status.Option{searchv1.NewMongoDBSearchVersionOption(version)}
+	if len(r.mdbSearch.Status.PVCs) > 0 {
+		statusOptions = append(statusOptions, status.NewPVCsStatusOptionEmptyStatus())
+	}
+	if r.mdbSearch.GetPrometheus() == nil {
+		if r.mdbSearch.Status.IndexLag != nil {
+			statusOptions = append(statusOptions, searchv1.MongoDBSearchIndexLagOption{})
+		}
+		return workflow.OK().WithAdditionalOptions(statusOptions...)
+	}
+
+	if r.shouldRefreshIndexLag() {
+		if maxLagSeconds, err := r.readIndexLag(ctx, groups, log); err != nil {
+			log.Warnf("Failed to read the index lag from the mongot metrics endpoints: %s", err)
+		} else {
+			statusOptions = append(statusOptions, searchv1.NewMongoDBSearchIndexLagOption(maxLagSeconds, timeutil.Now()))
+		}
+	}
+
+	// the index lag in the status is refreshed periodically while the metrics endpoint is enabled
+	return workflow.OK().WithAdditionalOptions(statusOptions...).WithRequeueAfter(IndexLagRefreshInterval)
 }
 
 // This is called only if the wireproto server is enabled, to set up they keyfile necessary for authentication.
@@ -169,29 +261,219 @@ func (r *MongoDBSearchReconcileHelper) buildImageString() string {
 	return fmt.Sprintf("%s/%s:%s", r.operatorSearchConfig.SearchRepo, r.operatorSearchConfig.SearchName, imageVersion)
 }
 
-func (r *MongoDBSearchReconcileHelper) createOrUpdateStatefulSet(ctx context.Context, log *zap.SugaredLogger, modifications ...statefulset.Modification) error {
-	stsName := r.mdbSearch.StatefulSetNamespacedName()
+// groupClient returns the client of the cluster the group of mongot instances is deployed in.
+func (r *MongoDBSearchReconcileHelper) groupClient(group MongotGroup) (kubernetesClient.Client, error) {
+	if group.ClusterName == "" {
+		return r.client, nil
+	}
+
+	memberClient, ok := r.memberClusterClients[group.ClusterName]
+	if !ok {
+		return nil, xerrors.Errorf("member cluster %s is not registered in the operator", group.ClusterName)
+	}
+	return memberClient, nil
+}
+
+// validateClusterSpecList checks that the mongot instances are deployed in the member clusters of the source when, and
+// only when, the source is a multi-cluster resource.
+func (r *MongoDBSearchReconcileHelper) validateClusterSpecList() error {
+	multiCluster, ok := r.db.(MultiClusterSearchSource)
+	if !ok {
+		if r.mdbSearch.IsMultiCluster() {
+			return xerrors.New("spec.clusterSpecList is only supported for MongoDBMultiCluster sources")
+		}
+		return nil
+	}
+
+	if !r.mdbSearch.IsMultiCluster() {
+		return xerrors.New("spec.clusterSpecList is required for MongoDBMultiCluster sources")
+	}
+
+	// the keyfile used by the wireproto server is not copied to the member clusters
+	if r.mdbSearch.IsWireprotoEnabled() {
+		return xerrors.New("the mongot wireproto server is not supported for MongoDBMultiCluster sources")
+	}
+
+	clusterNames := map[string]struct{}{}
+	for _, item := range r.mdbSearch.Spec.ClusterSpecList {
+		if _, ok := clusterNames[item.ClusterName]; ok {
+			return xerrors.Errorf("member cluster %s is listed more than once in spec.clusterSpecList", item.ClusterName)
+		}
+		clusterNames[item.ClusterName] = struct{}{}
+
+		if !slices.Contains(multiCluster.MemberClusterNames(), item.ClusterName) {
+			return xerrors.Errorf("member cluster %s in spec.clusterSpecList is not a member cluster of the source", item.ClusterName)
+		}
+	}
+
+	// the mongod processes can only reach the mongot instances deployed in their own member cluster
+	for _, clusterName := range multiCluster.MemberClusterNames() {
+		if _, ok := clusterNames[clusterName]; !ok && len(multiCluster.ClusterHostSeeds(clusterName)) > 0 {
+			return xerrors.Errorf("member cluster %s of the source has mongod members but is missing in spec.clusterSpecList", clusterName)
+		}
+	}
+
+	return nil
+}
+
+// ensureMemberClusterSecrets copies the secrets mounted in the mongot pods from the operator's cluster to the member
+// cluster.
+func (r *MongoDBSearchReconcileHelper) ensureMemberClusterSecrets(ctx context.Context, memberClient kubernetesClient.Client, clusterName string) error {
+	secretNames := []types.NamespacedName{
+		{Name: r.mdbSearch.SourceUserPasswordSecretRef().Name, Namespace: r.mdbSearch.Namespace},
+	}
+	if r.mdbSearch.Spec.Security.TLS != nil {
+		secretNames = append(secretNames, r.mdbSearch.TLSOperatorSecretNamespacedName())
+	}
+	if prometheus := r.mdbSearch.GetPrometheus(); prometheus != nil {
+		if prometheus.IsTLSEnabled() {
+			secretNames = append(secretNames, r.mdbSearch.PrometheusTLSOperatorSecretNamespacedName())
+		}
+		if prometheus.IsBasicAuthEnabled() {
+			secretNames = append(secretNames, r.mdbSearch.PrometheusPasswordSecretNamespacedName())
+		}
+	}
+
+	for _, secretName := range secretNames {
+		s, err := r.client.GetSecret(ctx, secretName)
+		if err != nil {
+			return xerrors.Errorf("error reading secret %v to copy it to member cluster %s: %w", secretName, clusterName, err)
+		}
+
+		memberSecret := secret.Builder().
+			SetName(secretName.Name).
+			SetNamespace(secretName.Namespace).
+			SetLabels(r.mdbSearch.GetOwnerLabels()).
+			SetByteData(s.Data).
+			SetDataType(s.Type).
+			Build()
+		if err := secret.CreateOrUpdate(ctx, memberClient, memberSecret); err != nil {
+			return xerrors.Errorf("error copying secret %v to member cluster %s: %w", secretName, clusterName, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteRemovedShardGroups deletes the mongot instances of the shards removed from a sharded source. Shards are always
+// removed from the end, so the groups are deleted starting from shardCount until no StatefulSet is left. The StatefulSet
+// is deleted last, so that the remaining objects are cleaned up on the next reconciliation if any deletion fails.
+func (r *MongoDBSearchReconcileHelper) deleteRemovedShardGroups(ctx context.Context, shardCount int, log *zap.SugaredLogger) error {
+	for shardIdx := shardCount; ; shardIdx++ {
+		stsName := r.mdbSearch.ShardStatefulSetNamespacedName(shardIdx)
+		if _, err := r.client.GetStatefulSet(ctx, stsName); apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return xerrors.Errorf("error reading search statefulset %v: %w", stsName, err)
+		}
+
+		for _, svcName := range []types.NamespacedName{r.mdbSearch.ShardSearchServiceNamespacedName(shardIdx), r.mdbSearch.ShardMetricsServiceNamespacedName(shardIdx)} {
+			if err := mekoService.DeleteServiceIfItExists(ctx, r.client, svcName); err != nil {
+				return xerrors.Errorf("error deleting search service %v of removed shard %d: %w", svcName, shardIdx, err)
+			}
+		}
+
+		cmName := r.mdbSearch.ShardMongotConfigConfigMapNamespacedName(shardIdx)
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace}}
+		if err := r.client.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+			return xerrors.Errorf("error deleting mongot config map %v of removed shard %d: %w", cmName, shardIdx, err)
+		}
+
+		sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: stsName.Name, Namespace: stsName.Namespace}}
+		if err := r.client.Delete(ctx, sts); client.IgnoreNotFound(err) != nil {
+			return xerrors.Errorf("error deleting search statefulset %v of removed shard %d: %w", stsName, shardIdx, err)
+		}
+		log.Infof("Removed the mongot instances of removed shard %d", shardIdx)
+	}
+}
+
+// deleteRemovedClusterGroups deletes the mongot instances of the member clusters removed from spec.clusterSpecList.
+func (r *MongoDBSearchReconcileHelper) deleteRemovedClusterGroups(ctx context.Context, groups []MongotGroup, log *zap.SugaredLogger) error {
+	for clusterName, memberClient := range r.memberClusterClients {
+		if slices.ContainsFunc(groups, func(group MongotGroup) bool { return group.ClusterName == clusterName }) {
+			continue
+		}
+		if err := DeleteMemberClusterObjects(ctx, memberClient, r.mdbSearch); err != nil {
+			return xerrors.Errorf("error deleting the mongot instances in removed member cluster %s: %w", clusterName, err)
+		}
+		log.Debugf("Removed the mongot instances in member cluster %s if there were any", clusterName)
+	}
+	return nil
+}
+
+// DeleteMemberClusterObjects deletes the objects of the MongoDBSearch resource in a member cluster. They are found by
+// the owner labels, as they can't have owner references to the MongoDBSearch resource.
+func DeleteMemberClusterObjects(ctx context.Context, memberClient kubernetesClient.Client, mdbSearch *searchv1.MongoDBSearch) error {
+	cleanupOptions := mdbv1.MongodbCleanUpOptions{
+		Namespace: mdbSearch.Namespace,
+		Labels:    mdbSearch.GetOwnerLabels(),
+	}
+	for _, obj := range []client.Object{&appsv1.StatefulSet{}, &corev1.Service{}, &corev1.ConfigMap{}, &corev1.Secret{}} {
+		if err := memberClient.DeleteAllOf(ctx, obj, &cleanupOptions); err != nil {
+			return xerrors.Errorf("failed deleting %T resources: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// setMemberClusterOwner replaces the owner references of an object created in a member cluster, which can't point to
+// the MongoDBSearch resource, with the owner labels.
+func (r *MongoDBSearchReconcileHelper) setMemberClusterOwner(obj metav1.Object) {
+	obj.SetOwnerReferences(nil)
+	obj.SetLabels(merge.StringToStringMap(obj.GetLabels(), r.mdbSearch.GetOwnerLabels()))
+}
+
+// createOrUpdateStatefulSet creates or updates the mongot StatefulSet. Storage increases are rolled out through the PVC
+// resize workflow, as the volume claim templates of an existing StatefulSet can't be updated.
+func (r *MongoDBSearchReconcileHelper) createOrUpdateStatefulSet(ctx context.Context, stsClient kubernetesClient.Client, log *zap.SugaredLogger, stsName types.NamespacedName, modifications ...statefulset.Modification) workflow.Status {
+	desiredSts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: stsName.Name, Namespace: stsName.Namespace}}
+	statefulset.Apply(modifications...)(desiredSts)
+	if workflowStatus := r.handlePVCResize(ctx, stsClient, log, desiredSts); !workflowStatus.IsOK() {
+		return workflowStatus
+	}
+
 	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: stsName.Name, Namespace: stsName.Namespace}}
-	op, err := controllerutil.CreateOrUpdate(ctx, r.client, sts, func() error {
+	op, err := controllerutil.CreateOrUpdate(ctx, stsClient, sts, func() error {
 		statefulset.Apply(modifications...)(sts)
+		// the annotation with the PVC sizes restarts the pods once the StatefulSet has been recreated with the new storage
+		if pvcSizes, ok := desiredSts.Spec.Template.Annotations[statefulset.PVCSizeAnnotation]; ok {
+			podtemplatespec.WithAnnotations(map[string]string{statefulset.PVCSizeAnnotation: pvcSizes})(&sts.Spec.Template)
+		}
 		return nil
 	})
 	if err != nil {
-		return xerrors.Errorf("error creating/updating search statefulset %v: %w", stsName, err)
+		return workflow.Failed(xerrors.Errorf("error creating/updating search statefulset %v: %w", stsName, err))
 	}
 
 	log.Debugf("Search statefulset %s CreateOrUpdate result: %s", stsName, op)
 
-	return nil
+	return workflow.OK()
+}
+
+func (r *MongoDBSearchReconcileHelper) handlePVCResize(ctx context.Context, stsClient kubernetesClient.Client, log *zap.SugaredLogger, desiredSts *appsv1.StatefulSet) workflow.Status {
+	workflowStatus := pvcresize.HandlePVCResize(ctx, stsClient, desiredSts, log)
+	if !workflowStatus.IsOK() {
+		return workflowStatus
+	}
+
+	if workflow.ContainsPVCOption(workflowStatus.StatusOptions()) {
+		if _, err := commoncontroller.UpdateStatus(ctx, r.client, r.mdbSearch, workflow.Pending(""), log, workflowStatus.StatusOptions()...); err != nil {
+			return workflow.Failed(xerrors.Errorf("error updating status: %w", err))
+		}
+	}
+	return workflow.OK()
 }
 
-func (r *MongoDBSearchReconcileHelper) ensureSearchService(ctx context.Context, search *searchv1.MongoDBSearch) error {
-	svcName := search.SearchServiceNamespacedName()
+func (r *MongoDBSearchReconcileHelper) ensureSearchService(ctx context.Context, svcClient kubernetesClient.Client, group MongotGroup) error {
+	svcName := group.ServiceName
 	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: svcName.Name, Namespace: svcName.Namespace}}
-	op, err := controllerutil.CreateOrUpdate(ctx, r.client, svc, func() error {
+	op, err := controllerutil.CreateOrUpdate(ctx, svcClient, svc, func() error {
 		resourceVersion := svc.ResourceVersion
-		*svc = buildSearchHeadlessService(search)
+		*svc = buildSearchHeadlessService(r.mdbSearch, svcName)
 		svc.ResourceVersion = resourceVersion
+		if group.ClusterName != "" {
+			r.setMemberClusterOwner(svc)
+		}
 		return nil
 	})
 	if err != nil {
@@ -203,7 +485,8 @@ func (r *MongoDBSearchReconcileHelper) ensureSearchService(ctx context.Context,
 	return nil
 }
 
-func (r *MongoDBSearchReconcileHelper) ensureMongotConfig(ctx context.Context, log *zap.SugaredLogger, modifications ...mongot.Modification) (string, error) {
+func (r *MongoDBSearchReconcileHelper) ensureMongotConfig(ctx context.Context, cmClient kubernetesClient.Client, log *zap.SugaredLogger, group MongotGroup, modifications ...mongot.Modification) (string, error) {
+	cmName := group.ConfigMapName
 	mongotConfig := mongot.Config{}
 	mongot.Apply(modifications...)(&mongotConfig)
 	configData, err := yaml.Marshal(mongotConfig)
@@ -211,15 +494,18 @@ func (r *MongoDBSearchReconcileHelper) ensureMongotConfig(ctx context.Context, l
 		return "", err
 	}
 
-	cmName := r.mdbSearch.MongotConfigConfigMapNamespacedName()
 	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmName.Name, Namespace: cmName.Namespace}, Data: map[string]string{}}
-	op, err := controllerutil.CreateOrUpdate(ctx, r.client, cm, func() error {
+	op, err := controllerutil.CreateOrUpdate(ctx, cmClient, cm, func() error {
 		resourceVersion := cm.ResourceVersion
 
 		cm.Data[MongotConfigFilename] = string(configData)
 
 		cm.ResourceVersion = resourceVersion
 
+		if group.ClusterName != "" {
+			r.setMemberClusterOwner(cm)
+			return nil
+		}
 		return controllerutil.SetOwnerReference(r.mdbSearch, cm, r.client.Scheme())
 	})
 	if err != nil {
@@ -274,6 +560,9 @@ func (r *MongoDBSearchReconcileHelper) ensureEgressTlsConfig(ctx context.Context
 
 	mongotModification := func(config *mongot.Config) {
 		config.SyncSource.ReplicaSet.TLS = ptr.To(true)
+		if config.SyncSource.Router != nil {
+			config.SyncSource.Router.TLS = ptr.To(true)
+		}
 		config.SyncSource.CertificateAuthorityFile = ptr.To(tls.CAMountPath + tlsSourceConfig.CAFileName)
 
 		// if the gRPC server is configured to accept TLS connections then toggle mTLS as well
@@ -296,14 +585,202 @@ func (r *MongoDBSearchReconcileHelper) ensureEgressTlsConfig(ctx context.Context
 	return mongotModification, statefulsetModification
 }
 
+// prometheusTLSResource exposes the metrics endpoint certificate secrets of the MongoDBSearch to tls.EnsureTLSSecret.
+type prometheusTLSResource struct {
+	*searchv1.MongoDBSearch
+}
+
+func (p prometheusTLSResource) TLSSecretNamespacedName() types.NamespacedName {
+	return p.PrometheusTLSSecretNamespacedName()
+}
+
+func (p prometheusTLSResource) TLSOperatorSecretNamespacedName() types.NamespacedName {
+	return p.PrometheusTLSOperatorSecretNamespacedName()
+}
+
+func (r *MongoDBSearchReconcileHelper) ensurePrometheusConfig(ctx context.Context) (mongot.Modification, statefulset.Modification, error) {
+	prometheus := r.mdbSearch.GetPrometheus()
+	if prometheus == nil {
+		return mongot.NOOP(), statefulset.NOOP(), nil
+	}
+
+	var mongotModifications []mongot.Modification
+	var stsModifications []statefulset.Modification
+
+	if prometheus.IsTLSEnabled() {
+		certFileName, err := tls.EnsureTLSSecret(ctx, r.client, prometheusTLSResource{r.mdbSearch})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		mongotModifications = append(mongotModifications, func(config *mongot.Config) {
+			config.Metrics.TLS = &mongot.ConfigMetricsTLS{
+				Mode:               mongot.ConfigTLSModeTLS,
+				CertificateKeyFile: ptr.To(MongotPrometheusTLSMountPath + certFileName),
+			}
+		})
+
+		tlsVolume := statefulset.CreateVolumeFromSecret("prometheus-tls", r.mdbSearch.PrometheusTLSOperatorSecretNamespacedName().Name)
+		tlsVolumeMount := statefulset.CreateVolumeMount(tlsVolume.Name, MongotPrometheusTLSMountPath, statefulset.WithReadOnly(true))
+		stsModifications = append(stsModifications, statefulset.WithPodSpecTemplate(podtemplatespec.Apply(
+			podtemplatespec.WithVolume(tlsVolume),
+			podtemplatespec.WithContainer(MongotContainerName, container.Apply(
+				container.WithVolumeMounts([]corev1.VolumeMount{tlsVolumeMount}),
+			)),
+		)))
+	}
+
+	if prometheus.IsBasicAuthEnabled() {
+		mongotModifications = append(mongotModifications, func(config *mongot.Config) {
+			config.Metrics.Authentication = &mongot.ConfigMetricsAuthentication{
+				Mode:         "basic",
+				Username:     prometheus.Username,
+				PasswordFile: TempPrometheusPasswordPath,
+			}
+		})
+
+		passwordVolume := statefulset.CreateVolumeFromSecret("prometheus-password", prometheus.PasswordSecretRef.Name)
+		passwordVolumeMount := statefulset.CreateVolumeMount(passwordVolume.Name, MongotPrometheusPasswordPath, statefulset.WithReadOnly(true), statefulset.WithSubPath(prometheus.GetPasswordKey()))
+		stsModifications = append(stsModifications, statefulset.WithPodSpecTemplate(podtemplatespec.Apply(
+			podtemplatespec.WithVolume(passwordVolume),
+			podtemplatespec.WithContainer(MongotContainerName, container.Apply(
+				container.WithVolumeMounts([]corev1.VolumeMount{passwordVolumeMount}),
+				prependCommand(sensitiveFilePermissionsWorkaround(MongotPrometheusPasswordPath, TempPrometheusPasswordPath)),
+			)),
+		)))
+	}
+
+	return mongot.Apply(mongotModifications...), statefulset.Apply(stsModifications...), nil
+}
+
+// ensureMetricsService creates the Service exposing only the metrics endpoint of the group, or deletes it when it's not
+// requested anymore.
+func (r *MongoDBSearchReconcileHelper) ensureMetricsService(ctx context.Context, svcClient kubernetesClient.Client, group MongotGroup, log *zap.SugaredLogger) error {
+	prometheus := r.mdbSearch.GetPrometheus()
+	if prometheus == nil || prometheus.Service == nil {
+		return mekoService.DeleteServiceIfItExists(ctx, svcClient, group.MetricsServiceName)
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: group.MetricsServiceName.Name, Namespace: group.MetricsServiceName.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, svcClient, svc, func() error {
+		resourceVersion := svc.ResourceVersion
+		clusterIP := svc.Spec.ClusterIP
+		*svc = buildSearchMetricsService(r.mdbSearch, group)
+		svc.ResourceVersion = resourceVersion
+		svc.Spec.ClusterIP = clusterIP
+		if group.ClusterName != "" {
+			r.setMemberClusterOwner(svc)
+		}
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("error creating/updating search metrics service %v: %w", group.MetricsServiceName, err)
+	}
+
+	log.Debugf("Updated search metrics service %v: %s", group.MetricsServiceName, op)
+
+	return nil
+}
+
+func (r *MongoDBSearchReconcileHelper) shouldRefreshIndexLag() bool {
+	indexLag := r.mdbSearch.Status.IndexLag
+	if indexLag == nil {
+		return true
+	}
+
+	lastUpdated, err := time.Parse(time.RFC3339, indexLag.LastUpdated)
+	if err != nil {
+		return true
+	}
+
+	// leave some slack so that the periodic requeue always refreshes the lag
+	return time.Since(lastUpdated) >= IndexLagRefreshInterval-metricsReadTimeout
+}
+
+// readIndexLag reads the index lag from the metrics endpoint of every mongot instance and returns the highest one.
+// The endpoints are read concurrently, and the ones that can't be reached are skipped so that a single unavailable
+// mongot instance doesn't hide the lag of the others. An error is returned only if no endpoint could be read.
+func (r *MongoDBSearchReconcileHelper) readIndexLag(ctx context.Context, groups []MongotGroup, log *zap.SugaredLogger) (int64, error) {
+	prometheus := r.mdbSearch.GetPrometheus()
+
+	endpoint := MongotMetricsEndpoint{}
+	if prometheus.IsBasicAuthEnabled() {
+		password, err := secret.ReadKey(ctx, r.client, prometheus.GetPasswordKey(), r.mdbSearch.PrometheusPasswordSecretNamespacedName())
+		if err != nil {
+			return 0, xerrors.Errorf("error reading the metrics endpoint password from secret %s: %w", r.mdbSearch.PrometheusPasswordSecretNamespacedName(), err)
+		}
+		endpoint.Username = prometheus.Username
+		endpoint.Password = password
+	}
+	if prometheus.IsTLSEnabled() {
+		// the CA is optional, the system pool is used to verify the endpoint if it's missing
+		if ca, err := secret.ReadKey(ctx, r.client, "ca.crt", r.mdbSearch.PrometheusTLSSecretNamespacedName()); err == nil {
+			endpoint.CA = []byte(ca)
+		}
+	}
+
+	var endpoints []MongotMetricsEndpoint
+	for _, group := range groups {
+		groupClient, err := r.groupClient(group)
+		if err != nil {
+			return 0, err
+		}
+
+		sts := &appsv1.StatefulSet{}
+		if err := groupClient.Get(ctx, group.StatefulSetName, sts); err != nil {
+			return 0, xerrors.Errorf("error getting search statefulset %v: %w", group.StatefulSetName, err)
+		}
+
+		for podIdx := range int(ptr.Deref(sts.Spec.Replicas, 1)) {
+			podName := fmt.Sprintf("%s-%d", group.StatefulSetName.Name, podIdx)
+			podEndpoint := endpoint
+			podEndpoint.URL = mongotMetricsURL(podName, group.ServiceName.Name, group.ServiceName.Namespace, r.db.ClusterDomain(), prometheus.GetPort(), prometheus.IsTLSEnabled())
+			endpoints = append(endpoints, podEndpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return 0, xerrors.New("no mongot instances to read the index lag from")
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+	maxLag := 0.0
+	for _, podEndpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			families, err := r.metricsReader(ctx, podEndpoint)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Debugf("Skipping the index lag of an unreachable mongot instance: %s", err)
+				errs = append(errs, err)
+				return
+			}
+			if lag, ok := maxIndexLagSeconds(families); ok {
+				maxLag = math.Max(maxLag, lag)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == len(endpoints) {
+		return 0, xerrors.Errorf("none of the mongot metrics endpoints could be read: %w", errors.Join(errs...))
+	}
+
+	return int64(math.Ceil(maxLag)), nil
+}
+
 func hashBytes(bytes []byte) string {
 	hashBytes := sha256.Sum256(bytes)
 	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(hashBytes[:])
 }
 
-func buildSearchHeadlessService(search *searchv1.MongoDBSearch) corev1.Service {
+func buildSearchHeadlessService(search *searchv1.MongoDBSearch, svcName types.NamespacedName) corev1.Service {
 	labels := map[string]string{}
-	name := search.SearchServiceNamespacedName().Name
+	name := svcName.Name
 
 	labels["app"] = name
 
@@ -352,13 +829,39 @@ func buildSearchHeadlessService(search *searchv1.MongoDBSearch) corev1.Service {
 	return serviceBuilder.Build()
 }
 
-func createMongotConfig(search *searchv1.MongoDBSearch, db SearchSourceDBResource) mongot.Modification {
-	return func(config *mongot.Config) {
-		hostAndPorts := db.HostSeeds()
+// buildSearchMetricsService builds the ClusterIP Service exposing only the metrics endpoint of the group's mongot
+// instances, so that it can be selected by a ServiceMonitor without selecting the search endpoints.
+func buildSearchMetricsService(search *searchv1.MongoDBSearch, group MongotGroup) corev1.Service {
+	prometheus := search.GetPrometheus()
+
+	labels := map[string]string{}
+	for k, v := range prometheus.Service.Labels {
+		labels[k] = v
+	}
+	labels["app"] = group.MetricsServiceName.Name
+
+	return service.Builder().
+		SetName(group.MetricsServiceName.Name).
+		SetNamespace(group.MetricsServiceName.Namespace).
+		SetSelector(map[string]string{"app": group.ServiceName.Name}).
+		SetLabels(labels).
+		SetAnnotations(prometheus.Service.Annotations).
+		SetServiceType(corev1.ServiceTypeClusterIP).
+		SetOwnerReferences(search.GetOwnerReferences()).
+		AddPort(&corev1.ServicePort{
+			Name:       "prometheus",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       prometheus.GetPort(),
+			TargetPort: intstr.FromInt32(prometheus.GetPort()),
+		}).
+		Build()
+}
 
+func createMongotConfig(search *searchv1.MongoDBSearch, group MongotGroup) mongot.Modification {
+	return func(config *mongot.Config) {
 		config.SyncSource = mongot.ConfigSyncSource{
 			ReplicaSet: mongot.ConfigReplicaSet{
-				HostAndPort:    hostAndPorts,
+				HostAndPort:    group.HostSeeds,
 				Username:       search.SourceUsername(),
 				PasswordFile:   TempSourceUserPasswordPath,
 				TLS:            ptr.To(false),
@@ -366,6 +869,15 @@ func createMongotConfig(search *searchv1.MongoDBSearch, db SearchSourceDBResourc
 				AuthSource:     ptr.To("admin"),
 			},
 		}
+		if len(group.RouterHostSeeds) > 0 {
+			config.SyncSource.Router = &mongot.ConfigRouter{
+				HostAndPort:  group.RouterHostSeeds,
+				Username:     search.SourceUsername(),
+				PasswordFile: TempSourceUserPasswordPath,
+				TLS:          ptr.To(false),
+				AuthSource:   ptr.To("admin"),
+			}
+		}
 		config.Storage = mongot.ConfigStorage{
 			DataPath: MongotDataPath,
 		}
@@ -408,6 +920,28 @@ func createMongotConfig(search *searchv1.MongoDBSearch, db SearchSourceDBResourc
 }
 
 func GetMongodConfigParameters(search *searchv1.MongoDBSearch, clusterDomain string) map[string]any {
+	return mongodConfigParameters(search, mongotHostAndPort(search, search.SearchServiceNamespacedName(), clusterDomain))
+}
+
+// GetShardMongodConfigParameters returns the mongod parameters for the members of the shard with the given index,
+// pointing them to the mongot instances indexing that shard.
+func GetShardMongodConfigParameters(search *searchv1.MongoDBSearch, shardIdx int, clusterDomain string) map[string]any {
+	return mongodConfigParameters(search, mongotHostAndPort(search, search.ShardSearchServiceNamespacedName(shardIdx), clusterDomain))
+}
+
+// GetClusterMongodConfigParameters returns the mongod parameters for the members of a multi-cluster source deployed in
+// the member cluster with the given index, pointing them to the mongot instances deployed in the same cluster.
+func GetClusterMongodConfigParameters(search *searchv1.MongoDBSearch, clusterIdx int, clusterDomain string) map[string]any {
+	return mongodConfigParameters(search, mongotHostAndPort(search, search.ClusterSearchServiceNamespacedName(clusterIdx), clusterDomain))
+}
+
+// GetMongosConfigParameters returns the mongos parameters of a sharded search source. mongos only needs a mongot endpoint
+// to forward search index management commands to, so it's pointed to the mongot instances of the first shard.
+func GetMongosConfigParameters(search *searchv1.MongoDBSearch, clusterDomain string) map[string]any {
+	return GetShardMongodConfigParameters(search, 0, clusterDomain)
+}
+
+func mongodConfigParameters(search *searchv1.MongoDBSearch, hostAndPort string) map[string]any {
 	searchTLSMode := automationconfig.TLSModeDisabled
 	if search.Spec.Security.TLS != nil {
 		searchTLSMode = automationconfig.TLSModeRequired
@@ -415,8 +949,8 @@ func GetMongodConfigParameters(search *searchv1.MongoDBSearch, clusterDomain str
 
 	return map[string]any{
 		"setParameter": map[string]any{
-			"mongotHost":                                      mongotHostAndPort(search, clusterDomain),
-			"searchIndexManagementHostAndPort":                mongotHostAndPort(search, clusterDomain),
+			"mongotHost":                                      hostAndPort,
+			"searchIndexManagementHostAndPort":                hostAndPort,
 			"skipAuthenticationToSearchIndexManagementServer": false,
 			"searchTLSMode":                                   string(searchTLSMode),
 			"useGrpcForSearch":                                !search.IsWireprotoEnabled(),
@@ -424,8 +958,7 @@ func GetMongodConfigParameters(search *searchv1.MongoDBSearch, clusterDomain str
 	}
 }
 
-func mongotHostAndPort(search *searchv1.MongoDBSearch, clusterDomain string) string {
-	svcName := search.SearchServiceNamespacedName()
+func mongotHostAndPort(search *searchv1.MongoDBSearch, svcName types.NamespacedName, clusterDomain string) string {
 	port := search.GetEffectiveMongotPort()
 	return fmt.Sprintf("%s.%s.svc.%s:%d", svcName.Name, svcName.Namespace, clusterDomain, port)
 }
@@ -6,20 +6,34 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	dto "github.com/prometheus/client_model/go"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status/pvc"
 	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/mock"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/mongot"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 )
 
 func init() {
@@ -94,6 +108,7 @@ func reconcileMongoDBSearch(ctx context.Context, fakeClient kubernetesClient.Cli
 		mdbSearch,
 		NewCommunityResourceSearchSource(mdbc),
 		operatorConfig,
+		nil,
 	)
 
 	return helper.Reconcile(ctx, zap.S())
@@ -169,7 +184,7 @@ func TestMongoDBSearchReconcileHelper_ValidateSingleMongoDBSearchForSearchSource
 				clientBuilder.WithObjects(v)
 			}
 
-			helper := NewMongoDBSearchReconcileHelper(kubernetesClient.NewClient(clientBuilder.Build()), mdbSearch, NewCommunityResourceSearchSource(mdbc), OperatorSearchConfig{})
+			helper := NewMongoDBSearchReconcileHelper(kubernetesClient.NewClient(clientBuilder.Build()), mdbSearch, NewCommunityResourceSearchSource(mdbc), OperatorSearchConfig{}, nil)
 			err := helper.ValidateSingleMongoDBSearchForSearchSource(t.Context())
 			if c.expectedError == "" {
 				assert.NoError(t, err)
@@ -235,6 +250,283 @@ func TestGetMongodConfigParameters_TransportAndPorts(t *testing.T) {
 	}
 }
 
+func TestGetShardMongodConfigParameters(t *testing.T) {
+	search := &searchv1.MongoDBSearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-mongodb-search",
+			Namespace: "test",
+		},
+	}
+
+	shardParams := GetShardMongodConfigParameters(search, 1, "cluster.local")["setParameter"].(map[string]any)
+	assert.Equal(t, "test-mongodb-search-search-1-svc.test.svc.cluster.local:27028", shardParams["mongotHost"])
+	assert.Equal(t, "test-mongodb-search-search-1-svc.test.svc.cluster.local:27028", shardParams["searchIndexManagementHostAndPort"])
+
+	mongosParams := GetMongosConfigParameters(search, "cluster.local")["setParameter"].(map[string]any)
+	assert.Equal(t, "test-mongodb-search-search-0-svc.test.svc.cluster.local:27028", mongosParams["mongotHost"])
+}
+
+func TestGetClusterMongodConfigParameters(t *testing.T) {
+	search := &searchv1.MongoDBSearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-mongodb-search",
+			Namespace: "test",
+		},
+	}
+
+	clusterParams := GetClusterMongodConfigParameters(search, 2, "cluster.local")["setParameter"].(map[string]any)
+	assert.Equal(t, "test-mongodb-search-search-cluster-2-svc.test.svc.cluster.local:27028", clusterParams["mongotHost"])
+	assert.Equal(t, "test-mongodb-search-search-cluster-2-svc.test.svc.cluster.local:27028", clusterParams["searchIndexManagementHostAndPort"])
+}
+
+func TestMongotGroups(t *testing.T) {
+	search := newTestMongoDBSearch("test-mongodb-search", "test")
+
+	t.Run("replica set source", func(t *testing.T) {
+		rs := newEnterpriseSearchSource("8.2.0", mdbv1.ClusterTopologySingleCluster, mdbv1.ReplicaSet, []string{}, "")
+		rs.Spec.Members = 3
+
+		groups := MongotGroups(search, rs)
+		require.Len(t, groups, 1)
+		assert.Equal(t, search.StatefulSetNamespacedName(), groups[0].StatefulSetName)
+		assert.Equal(t, search.SearchServiceNamespacedName(), groups[0].ServiceName)
+		assert.Equal(t, search.MongotConfigConfigMapNamespacedName(), groups[0].ConfigMapName)
+		assert.Equal(t, rs.HostSeeds(), groups[0].HostSeeds)
+		assert.Empty(t, groups[0].RouterHostSeeds)
+	})
+
+	t.Run("sharded source", func(t *testing.T) {
+		sc := newEnterpriseSearchSource("8.2.0", mdbv1.ClusterTopologySingleCluster, mdbv1.ShardedCluster, []string{}, "")
+		sc.Spec.ShardCount = 3
+		sc.Spec.MongodsPerShardCount = 3
+		sc.Spec.MongosCount = 1
+
+		groups := MongotGroups(search, sc)
+		require.Len(t, groups, 3)
+		for shardIdx, group := range groups {
+			assert.Equal(t, search.ShardStatefulSetNamespacedName(shardIdx), group.StatefulSetName)
+			assert.Equal(t, search.ShardSearchServiceNamespacedName(shardIdx), group.ServiceName)
+			assert.Equal(t, search.ShardMongotConfigConfigMapNamespacedName(shardIdx), group.ConfigMapName)
+			assert.Equal(t, sc.ShardHostSeeds(shardIdx), group.HostSeeds)
+			assert.Equal(t, sc.MongosHostSeeds(), group.RouterHostSeeds)
+		}
+
+		config := mongot.Config{}
+		createMongotConfig(search, groups[2])(&config)
+		assert.Equal(t, sc.ShardHostSeeds(2), config.SyncSource.ReplicaSet.HostAndPort)
+		require.NotNil(t, config.SyncSource.Router)
+		assert.Equal(t, sc.MongosHostSeeds(), config.SyncSource.Router.HostAndPort)
+		assert.Equal(t, search.SourceUsername(), config.SyncSource.Router.Username)
+	})
+
+	t.Run("multi-cluster source", func(t *testing.T) {
+		mrs := newMultiClusterSearchSource("8.2.0", []mdbv1.AuthMode{"SCRAM-SHA-256"})
+		multiClusterSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+			search.Spec.ClusterSpecList = []searchv1.ClusterSpecItem{{ClusterName: "cluster-b"}}
+		})
+
+		groups := MongotGroups(multiClusterSearch, mrs)
+		require.Len(t, groups, 1)
+		assert.Equal(t, multiClusterSearch.ClusterStatefulSetNamespacedName(1), groups[0].StatefulSetName)
+		assert.Equal(t, multiClusterSearch.ClusterSearchServiceNamespacedName(1), groups[0].ServiceName)
+		assert.Equal(t, multiClusterSearch.ClusterMongotConfigConfigMapNamespacedName(1), groups[0].ConfigMapName)
+		assert.Equal(t, mrs.ClusterHostSeeds("cluster-b"), groups[0].HostSeeds)
+		assert.Equal(t, "cluster-b", groups[0].ClusterName)
+	})
+}
+
+func TestMongoDBSearchReconcileHelper_RemovedShards(t *testing.T) {
+	ctx := t.Context()
+	sc := newEnterpriseSearchSource("8.2.0", mdbv1.ClusterTopologySingleCluster, mdbv1.ShardedCluster, []string{}, "")
+	sc.Spec.ShardCount = 3
+	sc.Spec.MongodsPerShardCount = 3
+	sc.Spec.MongosCount = 1
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test-namespace")
+	fakeClient := newTestFakeClient(mdbSearch)
+
+	helper := NewMongoDBSearchReconcileHelper(fakeClient, mdbSearch, sc, newTestOperatorSearchConfig(), nil)
+	helper.Reconcile(ctx, zap.S())
+	for shardIdx := 0; shardIdx < 3; shardIdx++ {
+		_, err := fakeClient.GetStatefulSet(ctx, mdbSearch.ShardStatefulSetNamespacedName(shardIdx))
+		require.NoError(t, err)
+	}
+
+	sc.Spec.ShardCount = 1
+	helper = NewMongoDBSearchReconcileHelper(fakeClient, mdbSearch, sc, newTestOperatorSearchConfig(), nil)
+	helper.Reconcile(ctx, zap.S())
+
+	_, err := fakeClient.GetStatefulSet(ctx, mdbSearch.ShardStatefulSetNamespacedName(0))
+	require.NoError(t, err)
+	for shardIdx := 1; shardIdx < 3; shardIdx++ {
+		_, err := fakeClient.GetStatefulSet(ctx, mdbSearch.ShardStatefulSetNamespacedName(shardIdx))
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = fakeClient.GetService(ctx, mdbSearch.ShardSearchServiceNamespacedName(shardIdx))
+		assert.True(t, apierrors.IsNotFound(err))
+		err = fakeClient.Get(ctx, mdbSearch.ShardMongotConfigConfigMapNamespacedName(shardIdx), &corev1.ConfigMap{})
+		assert.True(t, apierrors.IsNotFound(err))
+	}
+
+	// the source is turned into a replica set
+	rs := newEnterpriseSearchSource("8.2.0", mdbv1.ClusterTopologySingleCluster, mdbv1.ReplicaSet, []string{}, "")
+	helper = NewMongoDBSearchReconcileHelper(fakeClient, mdbSearch, rs, newTestOperatorSearchConfig(), nil)
+	helper.Reconcile(ctx, zap.S())
+
+	_, err = fakeClient.GetStatefulSet(ctx, mdbSearch.ShardStatefulSetNamespacedName(0))
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = fakeClient.GetStatefulSet(ctx, mdbSearch.StatefulSetNamespacedName())
+	require.NoError(t, err)
+}
+
+func TestMongoDBSearchReconcileHelper_MultiCluster(t *testing.T) {
+	ctx := t.Context()
+	mrs := newMultiClusterSearchSource("8.2.0", []mdbv1.AuthMode{"SCRAM-SHA-256"})
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.ClusterSpecList = []searchv1.ClusterSpecItem{
+			{ClusterName: "cluster-a"},
+			{
+				ClusterName:          "cluster-b",
+				ResourceRequirements: &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}},
+			},
+		}
+	})
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: mdbSearch.SourceUserPasswordSecretRef().Name, Namespace: "test"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	centralClient := newTestFakeClient(mdbSearch, passwordSecret)
+	memberClients := map[string]kubernetesClient.Client{
+		"cluster-a": newTestFakeClient(),
+		"cluster-b": newTestFakeClient(),
+	}
+
+	helper := NewMongoDBSearchReconcileHelper(centralClient, mdbSearch, mrs, newTestOperatorSearchConfig(), memberClients)
+	workflowStatus := helper.Reconcile(ctx, zap.S())
+	assert.Equal(t, status.PhasePending, workflowStatus.Phase())
+
+	// the mongot instances are deployed next to the mongod members, and not in the operator's cluster
+	_, err := centralClient.GetStatefulSet(ctx, mdbSearch.StatefulSetNamespacedName())
+	assert.True(t, apierrors.IsNotFound(err))
+
+	for clusterName, memberClient := range memberClients {
+		clusterIdx := mrs.ClusterNum(clusterName)
+
+		sts, err := memberClient.GetStatefulSet(ctx, mdbSearch.ClusterStatefulSetNamespacedName(clusterIdx))
+		require.NoError(t, err)
+		assert.Empty(t, sts.OwnerReferences)
+		assert.Equal(t, mdbSearch.GetOwnerLabels()[searchv1.LabelResourceOwner], sts.Labels[searchv1.LabelResourceOwner])
+
+		svc, err := memberClient.GetService(ctx, mdbSearch.ClusterSearchServiceNamespacedName(clusterIdx))
+		require.NoError(t, err)
+		assert.Equal(t, mdbSearch.GetOwnerLabels()[searchv1.LabelResourceOwner], svc.Labels[searchv1.LabelResourceOwner])
+
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, memberClient.Get(ctx, mdbSearch.ClusterMongotConfigConfigMapNamespacedName(clusterIdx), cm))
+		assert.Empty(t, cm.OwnerReferences)
+		config := mongot.Config{}
+		require.NoError(t, yaml.Unmarshal([]byte(cm.Data[MongotConfigFilename]), &config))
+		assert.Equal(t, mrs.ClusterHostSeeds(clusterName), config.SyncSource.ReplicaSet.HostAndPort)
+
+		copiedSecret, err := memberClient.GetSecret(ctx, types.NamespacedName{Name: passwordSecret.Name, Namespace: "test"})
+		require.NoError(t, err)
+		assert.Equal(t, passwordSecret.Data, copiedSecret.Data)
+	}
+
+	sts, err := memberClients["cluster-b"].GetStatefulSet(ctx, mdbSearch.ClusterStatefulSetNamespacedName(1))
+	require.NoError(t, err)
+	assert.Equal(t, "4", sts.Spec.Template.Spec.Containers[0].Resources.Limits.Cpu().String())
+}
+
+func TestMongoDBSearchReconcileHelper_MultiClusterRemovedCluster(t *testing.T) {
+	ctx := t.Context()
+	mrs := newMultiClusterSearchSource("8.2.0", []mdbv1.AuthMode{"SCRAM-SHA-256"})
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.ClusterSpecList = []searchv1.ClusterSpecItem{{ClusterName: "cluster-a"}, {ClusterName: "cluster-b"}}
+	})
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: mdbSearch.SourceUserPasswordSecretRef().Name, Namespace: "test"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	centralClient := newTestFakeClient(mdbSearch, passwordSecret)
+	memberClients := map[string]kubernetesClient.Client{
+		"cluster-a": newTestFakeClient(),
+		"cluster-b": newTestFakeClient(),
+		"cluster-c": newTestFakeClient(),
+	}
+	helper := NewMongoDBSearchReconcileHelper(centralClient, mdbSearch, mrs, newTestOperatorSearchConfig(), memberClients)
+	helper.Reconcile(ctx, zap.S())
+
+	// cluster-b is removed from the source and from the MongoDBSearch
+	mrs.Spec.ClusterSpecList = mrs.Spec.ClusterSpecList[:1]
+	mdbSearch.Spec.ClusterSpecList = mdbSearch.Spec.ClusterSpecList[:1]
+	helper = NewMongoDBSearchReconcileHelper(centralClient, mdbSearch, mrs, newTestOperatorSearchConfig(), memberClients)
+	helper.Reconcile(ctx, zap.S())
+
+	_, err := memberClients["cluster-a"].GetStatefulSet(ctx, mdbSearch.ClusterStatefulSetNamespacedName(0))
+	require.NoError(t, err)
+	_, err = memberClients["cluster-b"].GetStatefulSet(ctx, mdbSearch.ClusterStatefulSetNamespacedName(1))
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = memberClients["cluster-b"].GetService(ctx, mdbSearch.ClusterSearchServiceNamespacedName(1))
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = memberClients["cluster-b"].GetSecret(ctx, types.NamespacedName{Name: passwordSecret.Name, Namespace: "test"})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestMongoDBSearchReconcileHelper_MultiClusterValidation(t *testing.T) {
+	cases := []struct {
+		name            string
+		clusterSpecList []searchv1.ClusterSpecItem
+		multiCluster    bool
+		expectedMessage string
+	}{
+		{
+			name:            "cluster spec list for a single cluster source",
+			clusterSpecList: []searchv1.ClusterSpecItem{{ClusterName: "cluster-a"}},
+			expectedMessage: "Spec.clusterSpecList is only supported for MongoDBMultiCluster sources",
+		},
+		{
+			name:            "no cluster spec list for a multi-cluster source",
+			multiCluster:    true,
+			expectedMessage: "Spec.clusterSpecList is required for MongoDBMultiCluster sources",
+		},
+		{
+			name:            "cluster which isn't a member cluster of the source",
+			clusterSpecList: []searchv1.ClusterSpecItem{{ClusterName: "cluster-c"}},
+			multiCluster:    true,
+			expectedMessage: "Member cluster cluster-c in spec.clusterSpecList is not a member cluster of the source",
+		},
+		{
+			name:            "member cluster of the source without mongot instances",
+			clusterSpecList: []searchv1.ClusterSpecItem{{ClusterName: "cluster-a"}},
+			multiCluster:    true,
+			expectedMessage: "Member cluster cluster-b of the source has mongod members but is missing in spec.clusterSpecList",
+		},
+		{
+			name:            "duplicate cluster",
+			clusterSpecList: []searchv1.ClusterSpecItem{{ClusterName: "cluster-a"}, {ClusterName: "cluster-a"}},
+			multiCluster:    true,
+			expectedMessage: "Member cluster cluster-a is listed more than once in spec.clusterSpecList",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+				search.Spec.ClusterSpecList = c.clusterSpecList
+			})
+
+			var source SearchSourceDBResource = NewCommunityResourceSearchSource(newTestMongoDBCommunity("test-mongodb", "test"))
+			if c.multiCluster {
+				source = newMultiClusterSearchSource("8.2.0", []mdbv1.AuthMode{"SCRAM-SHA-256"})
+			}
+
+			helper := NewMongoDBSearchReconcileHelper(newTestFakeClient(mdbSearch), mdbSearch, source, newTestOperatorSearchConfig(), nil)
+			workflowStatus := helper.Reconcile(t.Context(), zap.S())
+			assert.Equal(t, status.PhaseFailed, workflowStatus.Phase())
+			assert.Contains(t, mdbSearch.Status.Message, c.expectedMessage)
+		})
+	}
+}
+
 func assertServiceBasicProperties(t *testing.T, svc corev1.Service, mdbSearch *searchv1.MongoDBSearch) {
 	t.Helper()
 	svcName := mdbSearch.SearchServiceNamespacedName()
@@ -316,3 +608,213 @@ func TestMongoDBSearchReconcileHelper_ServiceCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestMongoDBSearchReconcileHelper_MetricsServiceCreation(t *testing.T) {
+	ctx := t.Context()
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.Prometheus = &searchv1.Prometheus{
+			Port: 9999,
+			Service: &searchv1.PrometheusService{
+				Labels:      map[string]string{"release": "prometheus"},
+				Annotations: map[string]string{"team": "search"},
+			},
+		}
+	})
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	fakeClient := newTestFakeClient(mdbSearch, mdbc)
+
+	reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+
+	svc, err := fakeClient.GetService(ctx, mdbSearch.MetricsServiceNamespacedName())
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	assert.Equal(t, map[string]string{"app": mdbSearch.SearchServiceNamespacedName().Name}, svc.Spec.Selector)
+	assert.Equal(t, "prometheus", svc.Labels["release"])
+	assert.Equal(t, "search", svc.Annotations["team"])
+	assertServicePorts(t, svc, map[string]int32{"prometheus": 9999})
+
+	mdbSearch.Spec.Prometheus.Service = nil
+	require.NoError(t, fakeClient.Update(ctx, mdbSearch))
+	reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+
+	_, err = fakeClient.GetService(ctx, mdbSearch.MetricsServiceNamespacedName())
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestMongoDBSearchReconcileHelper_PrometheusTLSAndBasicAuth(t *testing.T) {
+	ctx := t.Context()
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.Prometheus = &searchv1.Prometheus{
+			Username:          "prometheus",
+			PasswordSecretRef: &userv1.SecretKeyRef{Name: "prometheus-password"},
+			TLSSecretRef:      &corev1.LocalObjectReference{Name: "prometheus-tls"},
+		}
+	})
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-tls", Namespace: "test"},
+		Data:       map[string][]byte{"tls.crt": []byte("CERT"), "tls.key": []byte("KEY")},
+	}
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-password", Namespace: "test"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	fakeClient := newTestFakeClient(mdbSearch, mdbc, tlsSecret, passwordSecret)
+
+	reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+
+	operatorSecret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, mdbSearch.PrometheusTLSOperatorSecretNamespacedName(), operatorSecret))
+	require.Len(t, operatorSecret.Data, 1)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, mdbSearch.MongotConfigConfigMapNamespacedName(), cm))
+	config := mongot.Config{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[MongotConfigFilename]), &config))
+
+	require.NotNil(t, config.Metrics.TLS)
+	assert.Equal(t, mongot.ConfigTLSModeTLS, config.Metrics.TLS.Mode)
+	for certFileName := range operatorSecret.Data {
+		assert.Equal(t, MongotPrometheusTLSMountPath+certFileName, *config.Metrics.TLS.CertificateKeyFile)
+	}
+	require.NotNil(t, config.Metrics.Authentication)
+	assert.Equal(t, "prometheus", config.Metrics.Authentication.Username)
+	assert.Equal(t, TempPrometheusPasswordPath, config.Metrics.Authentication.PasswordFile)
+
+	sts := &appsv1.StatefulSet{}
+	require.NoError(t, fakeClient.Get(ctx, mdbSearch.StatefulSetNamespacedName(), sts))
+	volumeSources := map[string]string{}
+	for _, volume := range sts.Spec.Template.Spec.Volumes {
+		if volume.Secret != nil {
+			volumeSources[volume.Name] = volume.Secret.SecretName
+		}
+	}
+	assert.Equal(t, mdbSearch.PrometheusTLSOperatorSecretNamespacedName().Name, volumeSources["prometheus-tls"])
+	assert.Equal(t, "prometheus-password", volumeSources["prometheus-password"])
+	assert.Contains(t, sts.Spec.Template.Spec.Containers[0].Args[1], TempPrometheusPasswordPath)
+}
+
+func TestMongoDBSearchReconcileHelper_IndexLag(t *testing.T) {
+	ctx := t.Context()
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.Prometheus = &searchv1.Prometheus{Port: 9946}
+	})
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	fakeClient := newTestFakeClient(mdbSearch, mdbc)
+
+	var readURLs []string
+	helper := NewMongoDBSearchReconcileHelper(fakeClient, mdbSearch, NewCommunityResourceSearchSource(mdbc), newTestOperatorSearchConfig(), nil)
+	helper.metricsReader = func(_ context.Context, endpoint MongotMetricsEndpoint) (map[string]*dto.MetricFamily, error) {
+		readURLs = append(readURLs, endpoint.URL)
+		return parseTestMetrics(t, testMongotMetrics), nil
+	}
+
+	// the lag is only read once the mongot instances are running
+	helper.Reconcile(ctx, zap.S())
+	assert.Empty(t, readURLs)
+	require.NoError(t, mock.MarkAllStatefulSetsAsReady(ctx, "test", fakeClient))
+
+	workflowStatus := helper.Reconcile(ctx, zap.S())
+	require.True(t, workflowStatus.IsOK())
+	result, _ := workflowStatus.ReconcileResult()
+	assert.Equal(t, IndexLagRefreshInterval, result.RequeueAfter)
+
+	assert.Equal(t, []string{"http://test-mongodb-search-search-0.test-mongodb-search-search-svc.test.svc.cluster.local:9946/metrics"}, readURLs)
+	require.NotNil(t, mdbSearch.Status.IndexLag)
+	assert.Equal(t, int64(13), mdbSearch.Status.IndexLag.MaxLagSeconds)
+
+	// the lag is not read again until the refresh interval has passed
+	helper.Reconcile(ctx, zap.S())
+	assert.Len(t, readURLs, 1)
+	assert.Equal(t, int64(13), mdbSearch.Status.IndexLag.MaxLagSeconds)
+}
+
+func TestMongoDBSearchReconcileHelper_IndexLagUnreachableInstance(t *testing.T) {
+	ctx := t.Context()
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.Prometheus = &searchv1.Prometheus{Port: 9946}
+	})
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	fakeClient := newTestFakeClient(mdbSearch, mdbc)
+
+	reachable := map[string]bool{"test-mongodb-search-search-0": true, "test-mongodb-search-search-2": true}
+	helper := NewMongoDBSearchReconcileHelper(fakeClient, mdbSearch, NewCommunityResourceSearchSource(mdbc), newTestOperatorSearchConfig(), nil)
+	helper.metricsReader = func(_ context.Context, endpoint MongotMetricsEndpoint) (map[string]*dto.MetricFamily, error) {
+		for podName, ok := range reachable {
+			if ok && strings.Contains(endpoint.URL, podName+".") {
+				return parseTestMetrics(t, testMongotMetrics), nil
+			}
+		}
+		return nil, xerrors.Errorf("error reading metrics from %s: connection refused", endpoint.URL)
+	}
+
+	helper.Reconcile(ctx, zap.S())
+	require.NoError(t, mock.MarkAllStatefulSetsAsReady(ctx, "test", fakeClient))
+	// scale the mongot statefulset so that one of its instances is unreachable
+	sts := &appsv1.StatefulSet{}
+	require.NoError(t, fakeClient.Get(ctx, mdbSearch.StatefulSetNamespacedName(), sts))
+	sts.Spec.Replicas = ptr.To(int32(3))
+	require.NoError(t, fakeClient.Update(ctx, sts))
+
+	// the lag of the reachable instances is reported even though one of them is unavailable
+	lag, err := helper.readIndexLag(ctx, MongotGroups(mdbSearch, helper.db), zap.S())
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), lag)
+
+	// nothing is reported if none of them can be read
+	reachable = map[string]bool{}
+	_, err = helper.readIndexLag(ctx, MongotGroups(mdbSearch, helper.db), zap.S())
+	assert.Error(t, err)
+}
+
+func TestMongoDBSearchReconcileHelper_PVCResize(t *testing.T) {
+	ctx := t.Context()
+	mdbSearch := newTestMongoDBSearch("test-mongodb-search", "test", func(search *searchv1.MongoDBSearch) {
+		search.Spec.Persistence = &common.Persistence{SingleConfig: &common.PersistenceConfig{Storage: "10G"}}
+	})
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	pvcName := types.NamespacedName{Name: "data-test-mongodb-search-search-0", Namespace: "test"}
+	fakeClient := newTestFakeClient(mdbSearch, mdbc, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: pvcName.Name, Namespace: pvcName.Namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10G")}},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10G")},
+		},
+	})
+
+	reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+	require.NoError(t, mock.MarkAllStatefulSetsAsReady(ctx, "test", fakeClient))
+
+	mdbSearch.Spec.Persistence.SingleConfig.Storage = "20G"
+	require.NoError(t, fakeClient.Update(ctx, mdbSearch))
+
+	// the StatefulSet is left unchanged while the PVCs are being expanded
+	workflowStatus := reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+	assert.Equal(t, status.PhasePending, workflowStatus.Phase())
+	require.Len(t, mdbSearch.Status.PVCs, 1)
+	assert.Equal(t, pvc.PhasePVCResize, mdbSearch.Status.PVCs[0].Phase)
+
+	sts, err := fakeClient.GetStatefulSet(ctx, mdbSearch.StatefulSetNamespacedName())
+	require.NoError(t, err)
+	assert.Equal(t, "10G", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+
+	existingPVC := &corev1.PersistentVolumeClaim{}
+	require.NoError(t, fakeClient.Get(ctx, pvcName, existingPVC))
+	assert.Equal(t, "20G", existingPVC.Spec.Resources.Requests.Storage().String())
+	existingPVC.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20G")
+	require.NoError(t, fakeClient.Status().Update(ctx, existingPVC))
+
+	// once the PVCs have been resized the StatefulSet is recreated with the new storage
+	reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+	sts, err = fakeClient.GetStatefulSet(ctx, mdbSearch.StatefulSetNamespacedName())
+	require.NoError(t, err)
+	assert.Equal(t, "20G", sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+	assert.Contains(t, sts.Spec.Template.Annotations, statefulset.PVCSizeAnnotation)
+
+	require.NoError(t, mock.MarkAllStatefulSetsAsReady(ctx, "test", fakeClient))
+	workflowStatus = reconcileMongoDBSearch(ctx, fakeClient, mdbSearch, mdbc, newTestOperatorSearchConfig())
+	assert.True(t, workflowStatus.IsOK())
+	assert.Empty(t, mdbSearch.Status.PVCs)
+}
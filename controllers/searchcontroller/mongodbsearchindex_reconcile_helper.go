@@ -0,0 +1,200 @@
+package searchcontroller
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/configmap"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube/commoncontroller"
+)
+
+const (
+	// LastAppliedSearchIndexDefinition stores the index definition last sent to the database, so that the index is
+	// only updated when its definition in the MongoDBSearchIndex changes.
+	LastAppliedSearchIndexDefinition = "mongodb.com/v1.lastAppliedSearchIndexDefinition"
+
+	searchIndexStatusReady  = "READY"
+	searchIndexStatusFailed = "FAILED"
+)
+
+type MongoDBSearchIndexReconcileHelper struct {
+	client             kubernetesClient.Client
+	index              *searchv1.MongoDBSearchIndex
+	db                 SearchSourceDBResource
+	indexClientFactory SearchIndexClientFactory
+}
+
+func NewMongoDBSearchIndexReconcileHelper(
+	client kubernetesClient.Client,
+	index *searchv1.MongoDBSearchIndex,
+	db SearchSourceDBResource,
+	indexClientFactory SearchIndexClientFactory,
+) *MongoDBSearchIndexReconcileHelper {
+	return &MongoDBSearchIndexReconcileHelper{
+		client:             client,
+		index:              index,
+		db:                 db,
+		indexClientFactory: indexClientFactory,
+	}
+}
+
+// Reconcile creates or updates the search index and reports its build status in the MongoDBSearchIndex status.
+func (r *MongoDBSearchIndexReconcileHelper) Reconcile(ctx context.Context, log *zap.SugaredLogger) workflow.Status {
+	workflowStatus := r.reconcile(ctx, log)
+	if _, err := commoncontroller.UpdateStatus(ctx, r.client, r.index, workflowStatus, log); err != nil {
+		return workflow.Failed(err)
+	}
+	return workflowStatus
+}
+
+func (r *MongoDBSearchIndexReconcileHelper) reconcile(ctx context.Context, log *zap.SugaredLogger) workflow.Status {
+	log = log.With("MongoDBSearchIndex", r.index.NamespacedName())
+	log.Infof("Reconciling MongoDBSearchIndex")
+
+	if r.index.GetIndexType() == searchv1.SearchIndexTypeVectorSearch && (r.index.Spec.Analyzer != "" || r.index.Spec.SearchAnalyzer != "") {
+		return workflow.Invalid("analyzer and searchAnalyzer are only supported for indexes of type %s", searchv1.SearchIndexTypeSearch)
+	}
+
+	definition := r.index.GetIndexDefinition()
+	definitionJSON, err := json.Marshal(definition)
+	if err != nil {
+		return workflow.Failed(xerrors.Errorf("error serializing the search index definition: %w", err))
+	}
+
+	indexClient, err := r.connect(ctx)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+	defer disconnectSearchIndexClient(ctx, indexClient, log)
+
+	database, collection, name := r.index.Spec.Database, r.index.Spec.Collection, r.index.GetIndexName()
+	existingIndex, err := indexClient.GetSearchIndex(ctx, database, collection, name)
+	if err != nil {
+		return workflow.Failed(err)
+	}
+
+	if existingIndex == nil {
+		log.Infof("Creating search index %s on %s.%s", name, database, collection)
+		if err := indexClient.CreateSearchIndex(ctx, database, collection, name, string(r.index.GetIndexType()), definition); err != nil {
+			return workflow.Failed(err)
+		}
+		if err := r.updateLastAppliedDefinition(ctx, string(definitionJSON)); err != nil {
+			return workflow.Failed(err)
+		}
+		return workflow.Pending("Waiting for search index %s to be created", name).
+			WithAdditionalOptions(searchv1.NewMongoDBSearchIndexStatusOption("", false))
+	}
+
+	if existingIndex.Type != "" && existingIndex.Type != string(r.index.GetIndexType()) {
+		return workflow.Failed(xerrors.Errorf("search index %s already exists with type %s, changing the type of a search index is not supported", name, existingIndex.Type))
+	}
+
+	if annotations.GetAnnotation(r.index, LastAppliedSearchIndexDefinition) != string(definitionJSON) {
+		log.Infof("Updating definition of search index %s on %s.%s", name, database, collection)
+		if err := indexClient.UpdateSearchIndex(ctx, database, collection, name, definition); err != nil {
+			return workflow.Failed(err)
+		}
+		if err := r.updateLastAppliedDefinition(ctx, string(definitionJSON)); err != nil {
+			return workflow.Failed(err)
+		}
+		return workflow.Pending("Waiting for search index %s to be updated", name).
+			WithAdditionalOptions(searchv1.NewMongoDBSearchIndexStatusOption(existingIndex.Status, existingIndex.Queryable))
+	}
+
+	return searchIndexWorkflowStatus(existingIndex)
+}
+
+// searchIndexWorkflowStatus maps the status of the index reported by the database to the phase of the resource.
+func searchIndexWorkflowStatus(index *SearchIndexDescription) workflow.Status {
+	statusOption := searchv1.NewMongoDBSearchIndexStatusOption(index.Status, index.Queryable)
+
+	switch {
+	case index.Status == searchIndexStatusFailed:
+		return workflow.Failed(xerrors.Errorf("search index %s failed to build", index.Name)).
+			WithAdditionalOptions([]status.Option{statusOption})
+	case index.Status != searchIndexStatusReady || !index.Queryable:
+		return workflow.Pending("Search index %s is not ready yet, current status: %s", index.Name, index.Status).
+			WithAdditionalOptions(statusOption)
+	default:
+		return workflow.OK().WithAdditionalOptions(statusOption)
+	}
+}
+
+// DropIndex removes the search index from the database. It is called before the MongoDBSearchIndex is deleted.
+func (r *MongoDBSearchIndexReconcileHelper) DropIndex(ctx context.Context, log *zap.SugaredLogger) error {
+	indexClient, err := r.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer disconnectSearchIndexClient(ctx, indexClient, log)
+
+	log.Infof("Dropping search index %s on %s.%s", r.index.GetIndexName(), r.index.Spec.Database, r.index.Spec.Collection)
+	return indexClient.DropSearchIndex(ctx, r.index.Spec.Database, r.index.Spec.Collection, r.index.GetIndexName())
+}
+
+func (r *MongoDBSearchIndexReconcileHelper) connect(ctx context.Context) (SearchIndexClient, error) {
+	password, err := secret.ReadKey(ctx, r.client, r.index.PasswordSecretKey(), r.index.PasswordSecretNamespacedName())
+	if err != nil {
+		return nil, xerrors.Errorf("error reading password of search index user from secret %s: %w", r.index.PasswordSecretNamespacedName(), err)
+	}
+
+	ca, err := r.readSourceCA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.indexClientFactory(ctx, SearchIndexClientOptions{
+		Hosts:    r.db.HostSeeds(),
+		Username: r.index.Spec.Username,
+		Password: password,
+		CA:       ca,
+	})
+}
+
+// readSourceCA reads the CA certificate of the search source database, or returns nil if TLS is disabled.
+func (r *MongoDBSearchIndexReconcileHelper) readSourceCA(ctx context.Context) ([]byte, error) {
+	tlsSourceConfig := r.db.TLSConfig()
+	if tlsSourceConfig == nil {
+		return nil, nil
+	}
+
+	for wType, resources := range tlsSourceConfig.ResourcesToWatch {
+		for _, resource := range resources {
+			ca, err := r.readCAFrom(ctx, wType, resource, tlsSourceConfig.CAFileName)
+			if err != nil {
+				return nil, xerrors.Errorf("error reading CA certificate of the search source from %s %s: %w", wType, resource, err)
+			}
+			return []byte(ca), nil
+		}
+	}
+
+	return nil, xerrors.New("no CA certificate configured for the search source")
+}
+
+func (r *MongoDBSearchIndexReconcileHelper) readCAFrom(ctx context.Context, wType watch.Type, resource types.NamespacedName, key string) (string, error) {
+	if wType == watch.ConfigMap {
+		return configmap.ReadKey(ctx, r.client, key, resource)
+	}
+	return secret.ReadKey(ctx, r.client, key, resource)
+}
+
+func (r *MongoDBSearchIndexReconcileHelper) updateLastAppliedDefinition(ctx context.Context, definitionJSON string) error {
+	return annotations.SetAnnotations(ctx, r.index, map[string]string{LastAppliedSearchIndexDefinition: definitionJSON}, r.client)
+}
+
+func disconnectSearchIndexClient(ctx context.Context, indexClient SearchIndexClient, log *zap.SugaredLogger) {
+	if err := indexClient.Disconnect(ctx); err != nil {
+		log.Warnf("Failed to disconnect from the database: %s", err)
+	}
+}
@@ -0,0 +1,185 @@
+package searchcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+)
+
+func newTestMongoDBSearchIndex(name, namespace string, modifications ...func(*searchv1.MongoDBSearchIndex)) *searchv1.MongoDBSearchIndex {
+	definition := mdbcv1.NewMapWrapper()
+	definition.Object["mappings"] = map[string]interface{}{"dynamic": true}
+
+	index := &searchv1.MongoDBSearchIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: searchv1.MongoDBSearchIndexSpec{
+			SearchRef:         corev1.LocalObjectReference{Name: "test-search"},
+			Database:          "sample_mflix",
+			Collection:        "movies",
+			Definition:        definition,
+			Username:          "index-admin",
+			PasswordSecretRef: userv1.SecretKeyRef{Name: "index-admin-password"},
+		},
+	}
+
+	for _, modify := range modifications {
+		modify(index)
+	}
+
+	return index
+}
+
+func newTestSearchIndexFakeClient(index *searchv1.MongoDBSearchIndex) kubernetesClient.Client {
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: index.Spec.PasswordSecretRef.Name, Namespace: index.Namespace},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	return newTestFakeClient(index, passwordSecret)
+}
+
+func TestMongoDBSearchIndexReconcileHelper_CreatesIndex(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	index := newTestMongoDBSearchIndex("movies-index", "test", func(index *searchv1.MongoDBSearchIndex) {
+		index.Spec.Analyzer = "lucene.english"
+	})
+	fakeClient := newTestSearchIndexFakeClient(index)
+	indexClient := NewMockedSearchIndexClient()
+
+	helper := NewMongoDBSearchIndexReconcileHelper(fakeClient, index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+	workflowStatus := helper.Reconcile(ctx, zap.S())
+
+	assert.Equal(t, status.PhasePending, workflowStatus.Phase())
+	require.Contains(t, indexClient.Indexes, "sample_mflix.movies.movies-index")
+	createdIndex := indexClient.Indexes["sample_mflix.movies.movies-index"]
+	assert.Equal(t, string(searchv1.SearchIndexTypeSearch), createdIndex.Type)
+	assert.Equal(t, "lucene.english", createdIndex.Definition["analyzer"])
+	assert.Equal(t, map[string]interface{}{"dynamic": true}, createdIndex.Definition["mappings"])
+
+	assert.Equal(t, "index-admin", indexClient.LastOptions.Username)
+	assert.Equal(t, "secret", indexClient.LastOptions.Password)
+	assert.Equal(t, NewCommunityResourceSearchSource(mdbc).HostSeeds(), indexClient.LastOptions.Hosts)
+	assert.Empty(t, indexClient.LastOptions.CA)
+
+	assert.NotEmpty(t, index.Annotations[LastAppliedSearchIndexDefinition])
+}
+
+func TestMongoDBSearchIndexReconcileHelper_ReportsIndexStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		indexStatus   string
+		queryable     bool
+		expectedPhase status.Phase
+	}{
+		{name: "Building index", indexStatus: "BUILDING", queryable: false, expectedPhase: status.PhasePending},
+		{name: "Ready index", indexStatus: "READY", queryable: true, expectedPhase: status.PhaseRunning},
+		{name: "Failed index", indexStatus: "FAILED", queryable: false, expectedPhase: status.PhaseFailed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+			index := newTestMongoDBSearchIndex("movies-index", "test")
+			fakeClient := newTestSearchIndexFakeClient(index)
+			indexClient := NewMockedSearchIndexClient()
+
+			helper := NewMongoDBSearchIndexReconcileHelper(fakeClient, index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+			helper.Reconcile(ctx, zap.S())
+
+			createdIndex := indexClient.Indexes["sample_mflix.movies.movies-index"]
+			createdIndex.Status = tc.indexStatus
+			createdIndex.Queryable = tc.queryable
+
+			workflowStatus := helper.Reconcile(ctx, zap.S())
+			assert.Equal(t, tc.expectedPhase, workflowStatus.Phase())
+
+			updatedIndex := &searchv1.MongoDBSearchIndex{}
+			require.NoError(t, fakeClient.Get(ctx, index.NamespacedName(), updatedIndex))
+			assert.Equal(t, tc.expectedPhase, updatedIndex.Status.Phase)
+			assert.Equal(t, tc.indexStatus, updatedIndex.Status.IndexStatus)
+			assert.Equal(t, tc.queryable, updatedIndex.Status.Queryable)
+		})
+	}
+}
+
+func TestMongoDBSearchIndexReconcileHelper_UpdatesChangedDefinition(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	index := newTestMongoDBSearchIndex("movies-index", "test")
+	fakeClient := newTestSearchIndexFakeClient(index)
+	indexClient := NewMockedSearchIndexClient()
+
+	helper := NewMongoDBSearchIndexReconcileHelper(fakeClient, index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+	helper.Reconcile(ctx, zap.S())
+
+	createdIndex := indexClient.Indexes["sample_mflix.movies.movies-index"]
+	createdIndex.Status = "READY"
+	createdIndex.Queryable = true
+
+	assert.Equal(t, status.PhaseRunning, helper.Reconcile(ctx, zap.S()).Phase())
+
+	index.Spec.Definition.Object["mappings"] = map[string]interface{}{"dynamic": false}
+	require.NoError(t, fakeClient.Update(ctx, index))
+	assert.Equal(t, status.PhasePending, helper.Reconcile(ctx, zap.S()).Phase())
+	assert.Equal(t, map[string]interface{}{"dynamic": false}, createdIndex.Definition["mappings"])
+
+	assert.Equal(t, status.PhaseRunning, helper.Reconcile(ctx, zap.S()).Phase())
+}
+
+func TestMongoDBSearchIndexReconcileHelper_Validation(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+
+	t.Run("Analyzer is not supported for vector search indexes", func(t *testing.T) {
+		index := newTestMongoDBSearchIndex("movies-index", "test", func(index *searchv1.MongoDBSearchIndex) {
+			index.Spec.Type = searchv1.SearchIndexTypeVectorSearch
+			index.Spec.Analyzer = "lucene.english"
+		})
+		indexClient := NewMockedSearchIndexClient()
+		helper := NewMongoDBSearchIndexReconcileHelper(newTestSearchIndexFakeClient(index), index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+
+		assert.Equal(t, status.PhaseFailed, helper.Reconcile(ctx, zap.S()).Phase())
+		assert.Empty(t, indexClient.Indexes)
+	})
+
+	t.Run("Index type cannot be changed", func(t *testing.T) {
+		index := newTestMongoDBSearchIndex("movies-index", "test")
+		indexClient := NewMockedSearchIndexClient()
+		helper := NewMongoDBSearchIndexReconcileHelper(newTestSearchIndexFakeClient(index), index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+		helper.Reconcile(ctx, zap.S())
+
+		index.Spec.Type = searchv1.SearchIndexTypeVectorSearch
+		assert.Equal(t, status.PhaseFailed, helper.Reconcile(ctx, zap.S()).Phase())
+	})
+}
+
+func TestMongoDBSearchIndexReconcileHelper_DropIndex(t *testing.T) {
+	ctx := t.Context()
+	mdbc := newTestMongoDBCommunity("test-mongodb", "test")
+	index := newTestMongoDBSearchIndex("movies-index", "test", func(index *searchv1.MongoDBSearchIndex) {
+		index.Spec.IndexName = "default"
+	})
+	indexClient := NewMockedSearchIndexClient()
+	helper := NewMongoDBSearchIndexReconcileHelper(newTestSearchIndexFakeClient(index), index, NewCommunityResourceSearchSource(mdbc), indexClient.Factory())
+
+	helper.Reconcile(ctx, zap.S())
+	require.Contains(t, indexClient.Indexes, "sample_mflix.movies.default")
+
+	require.NoError(t, helper.DropIndex(ctx, zap.S()))
+	assert.Empty(t, indexClient.Indexes)
+}
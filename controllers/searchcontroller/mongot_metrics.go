@@ -0,0 +1,107 @@
+package searchcontroller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"golang.org/x/xerrors"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// MongotIndexLagMetric is the gauge exposed by mongot with the replication lag of each of its indexes, in seconds.
+	MongotIndexLagMetric = "mongot_index_replication_lag_seconds"
+	// IndexLagRefreshInterval is how often the index lag is read from the mongot instances.
+	IndexLagRefreshInterval = 60 * time.Second
+	metricsReadTimeout      = 5 * time.Second
+)
+
+// MongotMetricsEndpoint describes how to reach the metrics endpoint of a single mongot instance.
+type MongotMetricsEndpoint struct {
+	URL      string
+	Username string
+	Password string
+	// CA is the PEM encoded CA certificate used to verify https endpoints. The system pool is used if empty.
+	CA []byte
+}
+
+// MongotMetricsReader reads all the metrics exposed by the mongot metrics endpoint.
+type MongotMetricsReader func(ctx context.Context, endpoint MongotMetricsEndpoint) (map[string]*dto.MetricFamily, error)
+
+// ReadMongotMetrics is the MongotMetricsReader reading the Prometheus text format over HTTP.
+func ReadMongotMetrics(ctx context.Context, endpoint MongotMetricsEndpoint) (map[string]*dto.MetricFamily, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(endpoint.CA) > 0 {
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(endpoint.CA) {
+			return nil, xerrors.New("failed to parse the CA certificate of the metrics endpoint")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: caPool, MinVersion: tls.VersionTLS12}
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: metricsReadTimeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Username != "" {
+		req.SetBasicAuth(endpoint.Username, endpoint.Password)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("error reading metrics from %s: %w", endpoint.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("error reading metrics from %s: unexpected status %s", endpoint.URL, resp.Status)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, xerrors.Errorf("error parsing metrics from %s: %w", endpoint.URL, err)
+	}
+
+	return families, nil
+}
+
+// maxIndexLagSeconds returns the highest index lag in the metrics, or false if mongot doesn't report any.
+func maxIndexLagSeconds(families map[string]*dto.MetricFamily) (float64, bool) {
+	family, ok := families[MongotIndexLagMetric]
+	if !ok || len(family.GetMetric()) == 0 {
+		return 0, false
+	}
+
+	maxLag := math.Inf(-1)
+	for _, metric := range family.GetMetric() {
+		if gauge := metric.GetGauge(); gauge != nil {
+			maxLag = math.Max(maxLag, gauge.GetValue())
+		}
+	}
+
+	if math.IsInf(maxLag, -1) {
+		return 0, false
+	}
+
+	return maxLag, true
+}
+
+func mongotMetricsURL(podName string, serviceName, namespace, clusterDomain string, port int32, tlsEnabled bool) string {
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s.%s.%s:%d/metrics", scheme, podName, serviceName, dns.GetServiceDomain(namespace, clusterDomain, nil), port)
+}
@@ -0,0 +1,63 @@
+package searchcontroller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const testMongotMetrics = `# HELP mongot_index_replication_lag_seconds Replication lag of the index
+# TYPE mongot_index_replication_lag_seconds gauge
+mongot_index_replication_lag_seconds{index="default"} 1.5
+mongot_index_replication_lag_seconds{index="movies"} 12.2
+# HELP mongot_queries_total Number of queries
+# TYPE mongot_queries_total counter
+mongot_queries_total 42
+`
+
+func parseTestMetrics(t *testing.T, metrics string) map[string]*dto.MetricFamily {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(strings.NewReader(metrics))
+	require.NoError(t, err)
+	return families
+}
+
+func TestMaxIndexLagSeconds(t *testing.T) {
+	lag, ok := maxIndexLagSeconds(parseTestMetrics(t, testMongotMetrics))
+	assert.True(t, ok)
+	assert.Equal(t, 12.2, lag)
+
+	_, ok = maxIndexLagSeconds(parseTestMetrics(t, "mongot_queries_total 42\n"))
+	assert.False(t, ok)
+}
+
+func TestReadMongotMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "prometheus" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(testMongotMetrics))
+	}))
+	defer server.Close()
+
+	families, err := ReadMongotMetrics(t.Context(), MongotMetricsEndpoint{URL: server.URL, Username: "prometheus", Password: "secret"})
+	require.NoError(t, err)
+	assert.Contains(t, families, MongotIndexLagMetric)
+
+	_, err = ReadMongotMetrics(t.Context(), MongotMetricsEndpoint{URL: server.URL, Username: "prometheus", Password: "wrong"})
+	assert.Error(t, err)
+}
+
+func TestMongotMetricsURL(t *testing.T) {
+	assert.Equal(t, "https://search-0.search-svc.ns.svc.cluster.local:9946/metrics", mongotMetricsURL("search-0", "search-svc", "ns", "", 9946, true))
+	assert.Equal(t, "http://search-0.search-svc.ns.svc.example.org:9946/metrics", mongotMetricsURL("search-0", "search-svc", "ns", "example.org", 9946, false))
+}
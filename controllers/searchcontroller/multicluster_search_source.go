@@ -0,0 +1,84 @@
+package searchcontroller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/watch"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+)
+
+type MultiClusterResourceSearchSource struct {
+	*mdbmulti.MongoDBMultiCluster
+}
+
+func NewMultiClusterResourceSearchSource(mrs *mdbmulti.MongoDBMultiCluster) SearchSourceDBResource {
+	return MultiClusterResourceSearchSource{mrs}
+}
+
+func (r MultiClusterResourceSearchSource) HostSeeds() []string {
+	var seeds []string
+	for _, clusterName := range r.MemberClusterNames() {
+		seeds = append(seeds, r.ClusterHostSeeds(clusterName)...)
+	}
+	return seeds
+}
+
+func (r MultiClusterResourceSearchSource) MemberClusterNames() []string {
+	clusterNames := make([]string, len(r.Spec.ClusterSpecList))
+	for i, item := range r.Spec.ClusterSpecList {
+		clusterNames[i] = item.ClusterName
+	}
+	return clusterNames
+}
+
+func (r MultiClusterResourceSearchSource) ClusterHostSeeds(clusterName string) []string {
+	item := r.GetClusterSpecByName(clusterName)
+	if item == nil {
+		return nil
+	}
+
+	port := r.Spec.GetAdditionalMongodConfig().GetPortOrDefault()
+	hostnames := r.GetProcessHostnames(clusterName, item.Members)
+	seeds := make([]string, len(hostnames))
+	for i, hostname := range hostnames {
+		seeds[i] = fmt.Sprintf("%s:%d", hostname, port)
+	}
+	return seeds
+}
+
+// TLSConfig returns the CA of the source. The operator copies the CA ConfigMap to all the member clusters of the
+// source, so it can be mounted in the mongot pods of any member cluster.
+func (r MultiClusterResourceSearchSource) TLSConfig() *TLSSourceConfig {
+	if !r.Spec.Security.IsTLSEnabled() {
+		return nil
+	}
+
+	return &TLSSourceConfig{
+		CAFileName: "ca-pem",
+		CAVolume:   statefulset.CreateVolumeFromConfigMap("ca", r.Spec.Security.TLSConfig.CA),
+		ResourcesToWatch: map[watch.Type][]types.NamespacedName{
+			watch.ConfigMap: {
+				{Namespace: r.Namespace, Name: r.Spec.Security.TLSConfig.CA},
+			},
+		},
+	}
+}
+
+func (r MultiClusterResourceSearchSource) ClusterDomain() string {
+	return r.Spec.GetClusterDomain()
+}
+
+func (r MultiClusterResourceSearchSource) KeyfileSecretName() string {
+	return fmt.Sprintf("%s-%s", r.Name, MongotKeyfileFilename)
+}
+
+func (r MultiClusterResourceSearchSource) Validate() error {
+	if err := validateEnterpriseMongoDBVersion(r.Spec.GetMongoDBVersion()); err != nil {
+		return err
+	}
+
+	return validateScramAuthentication(r.Spec.GetSecurityAuthenticationModes())
+}
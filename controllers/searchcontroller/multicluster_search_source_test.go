@@ -0,0 +1,73 @@
+package searchcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+)
+
+func newMultiClusterSearchSource(version string, authModes []mdbv1.AuthMode) MultiClusterResourceSearchSource {
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().SetVersion(version).SetName("test-mongodb").Build()
+	mrs.Namespace = "test"
+	mrs.Spec.Security.Authentication = &mdbv1.Authentication{Enabled: len(authModes) > 0, Modes: authModes}
+	mrs.Spec.ClusterSpecList = mdbv1.ClusterSpecList{
+		{ClusterName: "cluster-a", Members: 2},
+		{ClusterName: "cluster-b", Members: 1},
+	}
+	mrs.Spec.Mapping = map[string]int{"cluster-a": 0, "cluster-b": 1}
+	return MultiClusterResourceSearchSource{mrs}
+}
+
+func TestMultiClusterResourceSearchSource_Validate(t *testing.T) {
+	cases := []struct {
+		name           string
+		version        string
+		authModes      []mdbv1.AuthMode
+		expectedErrMsg string
+	}{
+		{
+			name:      "Valid source",
+			version:   "8.2.0",
+			authModes: []mdbv1.AuthMode{"SCRAM-SHA-256"},
+		},
+		{
+			name:           "Version too old",
+			version:        "7.0.0",
+			authModes:      []mdbv1.AuthMode{"SCRAM-SHA-256"},
+			expectedErrMsg: "MongoDB version must be 8.2.0 or higher",
+		},
+		{
+			name:           "No SCRAM authentication",
+			version:        "8.2.0",
+			authModes:      []mdbv1.AuthMode{"X509"},
+			expectedErrMsg: "MongoDBSearch requires SCRAM authentication to be enabled",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := newMultiClusterSearchSource(c.version, c.authModes).Validate()
+			if c.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, c.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestMultiClusterResourceSearchSource_HostSeeds(t *testing.T) {
+	src := newMultiClusterSearchSource("8.2.0", []mdbv1.AuthMode{"SCRAM-SHA-256"})
+
+	assert.Equal(t, []string{"cluster-a", "cluster-b"}, src.MemberClusterNames())
+	assert.Equal(t, []string{
+		"test-mongodb-0-0-svc.test.svc.cluster.local:27017",
+		"test-mongodb-0-1-svc.test.svc.cluster.local:27017",
+	}, src.ClusterHostSeeds("cluster-a"))
+	assert.Equal(t, []string{"test-mongodb-1-0-svc.test.svc.cluster.local:27017"}, src.ClusterHostSeeds("cluster-b"))
+	assert.Empty(t, src.ClusterHostSeeds("cluster-c"))
+	assert.Equal(t, append(src.ClusterHostSeeds("cluster-a"), src.ClusterHostSeeds("cluster-b")...), src.HostSeeds())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/container"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/podtemplatespec"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/probes"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/merge"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 )
@@ -31,6 +32,9 @@ const (
 	TempKeyfilePath              = tempVolumePath + "/" + MongotKeyfileFilename
 	MongotSourceUserPasswordPath = "/mongot/sourceUserPassword" // #nosec G101 -- This is not a hardcoded password, just a path to a file containing the password
 	TempSourceUserPasswordPath   = tempVolumePath + "/" + "sourceUserPassword"
+	MongotPrometheusPasswordPath = "/mongot/prometheusPassword" // #nosec G101 -- This is not a hardcoded password, just a path to a file containing the password
+	TempPrometheusPasswordPath   = tempVolumePath + "/" + "prometheusPassword"
+	MongotPrometheusTLSMountPath = "/var/lib/tls/prometheus/"
 	SearchLivenessProbePath      = "/health"
 	SearchReadinessProbePath     = "/health" // Todo: Update this when search GA is available
 )
@@ -43,9 +47,90 @@ type SearchSourceDBResource interface {
 	KeyfileSecretName() string
 	TLSConfig() *TLSSourceConfig
 	HostSeeds() []string
+	// ClusterDomain returns the cluster domain of the Kubernetes cluster the mongot instances are deployed in.
+	ClusterDomain() string
 	Validate() error
 }
 
+// ShardedSearchSource is implemented by search sources that can be backed by a sharded cluster. For sharded sources,
+// a separate group of mongot instances is deployed for every shard, each synchronizing from its own shard and
+// connecting to the mongos routers of the cluster.
+type ShardedSearchSource interface {
+	IsSharded() bool
+	ShardCount() int
+	ShardHostSeeds(shardIdx int) []string
+	MongosHostSeeds() []string
+}
+
+// MultiClusterSearchSource is implemented by search sources spread over several member clusters. A separate group of
+// mongot instances is deployed in every member cluster listed in the MongoDBSearch clusterSpecList, synchronizing
+// from the mongod members of the same cluster.
+type MultiClusterSearchSource interface {
+	MemberClusterNames() []string
+	ClusterNum(clusterName string) int
+	ClusterHostSeeds(clusterName string) []string
+}
+
+// MongotGroup identifies the Kubernetes objects of a group of mongot instances synchronizing from a single
+// replica set: the whole source for replica set sources, a single shard for sharded ones or the members in a single
+// member cluster for multi-cluster ones.
+type MongotGroup struct {
+	StatefulSetName types.NamespacedName
+	ServiceName     types.NamespacedName
+	ConfigMapName   types.NamespacedName
+	// MetricsServiceName is the Service exposing the metrics endpoint, only created if requested in the prometheus settings.
+	MetricsServiceName types.NamespacedName
+	HostSeeds          []string
+	// RouterHostSeeds are the mongos hosts of a sharded source, empty for replica set sources.
+	RouterHostSeeds []string
+	// ClusterName is the member cluster the group is deployed in, empty for the operator's cluster.
+	ClusterName string
+}
+
+// MongotGroups returns the groups of mongot instances required to index the given source.
+func MongotGroups(mdbSearch *searchv1.MongoDBSearch, db SearchSourceDBResource) []MongotGroup {
+	if sharded, ok := db.(ShardedSearchSource); ok && sharded.IsSharded() {
+		groups := make([]MongotGroup, sharded.ShardCount())
+		for shardIdx := range groups {
+			groups[shardIdx] = MongotGroup{
+				StatefulSetName:    mdbSearch.ShardStatefulSetNamespacedName(shardIdx),
+				ServiceName:        mdbSearch.ShardSearchServiceNamespacedName(shardIdx),
+				ConfigMapName:      mdbSearch.ShardMongotConfigConfigMapNamespacedName(shardIdx),
+				MetricsServiceName: mdbSearch.ShardMetricsServiceNamespacedName(shardIdx),
+				HostSeeds:          sharded.ShardHostSeeds(shardIdx),
+				RouterHostSeeds:    sharded.MongosHostSeeds(),
+			}
+		}
+		return groups
+	}
+
+	if multiCluster, ok := db.(MultiClusterSearchSource); ok && mdbSearch.IsMultiCluster() {
+		groups := make([]MongotGroup, len(mdbSearch.Spec.ClusterSpecList))
+		for i, item := range mdbSearch.Spec.ClusterSpecList {
+			clusterIdx := multiCluster.ClusterNum(item.ClusterName)
+			groups[i] = MongotGroup{
+				StatefulSetName:    mdbSearch.ClusterStatefulSetNamespacedName(clusterIdx),
+				ServiceName:        mdbSearch.ClusterSearchServiceNamespacedName(clusterIdx),
+				ConfigMapName:      mdbSearch.ClusterMongotConfigConfigMapNamespacedName(clusterIdx),
+				MetricsServiceName: mdbSearch.ClusterMetricsServiceNamespacedName(clusterIdx),
+				HostSeeds:          multiCluster.ClusterHostSeeds(item.ClusterName),
+				ClusterName:        item.ClusterName,
+			}
+		}
+		return groups
+	}
+
+	return []MongotGroup{
+		{
+			StatefulSetName:    mdbSearch.StatefulSetNamespacedName(),
+			ServiceName:        mdbSearch.SearchServiceNamespacedName(),
+			ConfigMapName:      mdbSearch.MongotConfigConfigMapNamespacedName(),
+			MetricsServiceName: mdbSearch.MetricsServiceNamespacedName(),
+			HostSeeds:          db.HostSeeds(),
+		},
+	}
+}
+
 type TLSSourceConfig struct {
 	CAFileName       string
 	CAVolume         corev1.Volume
@@ -53,9 +138,9 @@ type TLSSourceConfig struct {
 }
 
 // ReplicaSetOptions returns a set of options which will configure a ReplicaSet StatefulSet
-func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBResource SearchSourceDBResource, searchImage string) statefulset.Modification {
+func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, group MongotGroup, searchImage string) statefulset.Modification {
 	labels := map[string]string{
-		"app": mdbSearch.SearchServiceNamespacedName().Name,
+		"app": group.ServiceName.Name,
 	}
 
 	tmpVolume := statefulset.CreateVolumeFromEmptyDir("tmp")
@@ -71,7 +156,7 @@ func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBReso
 	sourceUserPasswordVolume := statefulset.CreateVolumeFromSecret(sourceUserPasswordVolumeName, sourceUserPasswordSecretKey.Name)
 	sourceUserPasswordVolumeMount := statefulset.CreateVolumeMount(sourceUserPasswordVolumeName, MongotSourceUserPasswordPath, statefulset.WithReadOnly(true), statefulset.WithSubPath(sourceUserPasswordSecretKey.Key))
 
-	mongotConfigVolume := statefulset.CreateVolumeFromConfigMap(mongotConfigVolumeName, mdbSearch.MongotConfigConfigMapNamespacedName().Name)
+	mongotConfigVolume := statefulset.CreateVolumeFromConfigMap(mongotConfigVolumeName, group.ConfigMapName.Name)
 	mongotConfigVolumeMount := statefulset.CreateVolumeMount(mongotConfigVolumeName, MongotConfigPath, statefulset.WithReadOnly(true), statefulset.WithSubPath(MongotConfigFilename))
 
 	var persistenceConfig *common.PersistenceConfig
@@ -84,6 +169,19 @@ func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBReso
 
 	podSecurityContext, _ := podtemplatespec.WithDefaultSecurityContextsModifications()
 
+	resourceRequirements := mdbSearch.Spec.ResourceRequirements
+	stsLabels := labels
+	ownerModification := statefulset.WithOwnerReference(mdbSearch.GetOwnerReferences())
+	clusterSpecItem := mdbSearch.GetClusterSpecItem(group.ClusterName)
+	if group.ClusterName != "" {
+		// owner references can't point to the MongoDBSearch from a member cluster, the StatefulSet is labelled instead
+		stsLabels = merge.StringToStringMap(labels, mdbSearch.GetOwnerLabels())
+		ownerModification = statefulset.NOOP()
+		if clusterSpecItem != nil && clusterSpecItem.ResourceRequirements != nil {
+			resourceRequirements = clusterSpecItem.ResourceRequirements
+		}
+	}
+
 	volumeMounts := []corev1.VolumeMount{
 		pvcVolumeMount,
 		tmpVolumeMount,
@@ -98,11 +196,11 @@ func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBReso
 	}
 
 	stsModifications := []statefulset.Modification{
-		statefulset.WithName(mdbSearch.StatefulSetNamespacedName().Name),
-		statefulset.WithNamespace(mdbSearch.StatefulSetNamespacedName().Namespace),
-		statefulset.WithServiceName(mdbSearch.SearchServiceNamespacedName().Name),
-		statefulset.WithLabels(labels),
-		statefulset.WithOwnerReference(mdbSearch.GetOwnerReferences()),
+		statefulset.WithName(group.StatefulSetName.Name),
+		statefulset.WithNamespace(group.StatefulSetName.Namespace),
+		statefulset.WithServiceName(group.ServiceName.Name),
+		statefulset.WithLabels(stsLabels),
+		ownerModification,
 		statefulset.WithMatchLabels(labels),
 		statefulset.WithReplicas(1),
 		statefulset.WithUpdateStrategyType(appsv1.RollingUpdateStatefulSetStrategyType),
@@ -113,7 +211,7 @@ func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBReso
 				podtemplatespec.WithPodLabels(labels),
 				podtemplatespec.WithVolumes(volumes),
 				podtemplatespec.WithServiceAccount(util.MongoDBServiceAccount),
-				podtemplatespec.WithContainer(MongotContainerName, mongodbSearchContainer(mdbSearch, volumeMounts, searchImage)),
+				podtemplatespec.WithContainer(MongotContainerName, mongodbSearchContainer(mdbSearch, resourceRequirements, volumeMounts, searchImage)),
 			),
 		),
 	}
@@ -126,6 +224,14 @@ func CreateSearchStatefulSetFunc(mdbSearch *searchv1.MongoDBSearch, sourceDBReso
 		))
 	}
 
+	if group.ClusterName != "" && clusterSpecItem != nil && clusterSpecItem.StatefulSetConfiguration != nil {
+		stsModifications = append(stsModifications, statefulset.WithCustomSpecs(clusterSpecItem.StatefulSetConfiguration.SpecWrapper.Spec))
+		stsModifications = append(stsModifications, statefulset.WithObjectMetadata(
+			clusterSpecItem.StatefulSetConfiguration.MetadataWrapper.Labels,
+			clusterSpecItem.StatefulSetConfiguration.MetadataWrapper.Annotations,
+		))
+	}
+
 	return statefulset.Apply(stsModifications...)
 }
 
@@ -149,7 +255,7 @@ func CreateKeyfileModificationFunc(keyfileSecretName string) statefulset.Modific
 	)
 }
 
-func mongodbSearchContainer(mdbSearch *searchv1.MongoDBSearch, volumeMounts []corev1.VolumeMount, searchImage string) container.Modification {
+func mongodbSearchContainer(mdbSearch *searchv1.MongoDBSearch, resourceRequirements *corev1.ResourceRequirements, volumeMounts []corev1.VolumeMount, searchImage string) container.Modification {
 	_, containerSecurityContext := podtemplatespec.WithDefaultSecurityContextsModifications()
 	return container.Apply(
 		container.WithName(MongotContainerName),
@@ -157,7 +263,7 @@ func mongodbSearchContainer(mdbSearch *searchv1.MongoDBSearch, volumeMounts []co
 		container.WithImagePullPolicy(corev1.PullAlways),
 		container.WithLivenessProbe(mongotLivenessProbe(mdbSearch)),
 		container.WithReadinessProbe(mongotReadinessProbe(mdbSearch)),
-		container.WithResourceRequirements(createSearchResourceRequirements(mdbSearch.Spec.ResourceRequirements)),
+		container.WithResourceRequirements(createSearchResourceRequirements(resourceRequirements)),
 		container.WithVolumeMounts(volumeMounts),
 		container.WithCommand([]string{"sh"}),
 		container.WithArgs([]string{
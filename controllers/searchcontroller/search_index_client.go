@@ -0,0 +1,133 @@
+package searchcontroller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/xerrors"
+)
+
+// searchIndexNotFoundCode is the server error code returned when dropping a search index that doesn't exist.
+const searchIndexNotFoundCode = 27
+
+// SearchIndexDescription is the subset of a $listSearchIndexes result the operator cares about.
+type SearchIndexDescription struct {
+	Name      string `bson:"name"`
+	Type      string `bson:"type"`
+	Status    string `bson:"status"`
+	Queryable bool   `bson:"queryable"`
+}
+
+// SearchIndexClient manages the search indexes of a MongoDB deployment.
+type SearchIndexClient interface {
+	// GetSearchIndex returns the description of the index with the given name or nil if it doesn't exist.
+	GetSearchIndex(ctx context.Context, database, collection, name string) (*SearchIndexDescription, error)
+	CreateSearchIndex(ctx context.Context, database, collection, name, indexType string, definition map[string]interface{}) error
+	UpdateSearchIndex(ctx context.Context, database, collection, name string, definition map[string]interface{}) error
+	// DropSearchIndex drops the index with the given name. Dropping an index that doesn't exist is not an error.
+	DropSearchIndex(ctx context.Context, database, collection, name string) error
+	Disconnect(ctx context.Context) error
+}
+
+// SearchIndexClientOptions contains the parameters required to connect to the database managing the search indexes.
+type SearchIndexClientOptions struct {
+	Hosts    []string
+	Username string
+	Password string
+	// CA is the PEM encoded CA certificate used to verify the database's certificate. TLS is disabled if empty.
+	CA []byte
+}
+
+// SearchIndexClientFactory creates a SearchIndexClient connected to the database described by the options.
+type SearchIndexClientFactory func(ctx context.Context, opts SearchIndexClientOptions) (SearchIndexClient, error)
+
+type driverSearchIndexClient struct {
+	client *mongo.Client
+}
+
+var _ SearchIndexClient = &driverSearchIndexClient{}
+
+// NewSearchIndexClient is the SearchIndexClientFactory connecting to the database using the MongoDB Go driver.
+func NewSearchIndexClient(ctx context.Context, opts SearchIndexClientOptions) (SearchIndexClient, error) {
+	clientOptions := options.Client().
+		SetHosts(opts.Hosts).
+		SetAuth(options.Credential{Username: opts.Username, Password: opts.Password, AuthSource: "admin"})
+
+	if len(opts.CA) > 0 {
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(opts.CA) {
+			return nil, xerrors.New("failed to parse the CA certificate of the database")
+		}
+		clientOptions.SetTLSConfig(&tls.Config{RootCAs: caPool, MinVersion: tls.VersionTLS12})
+	}
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, xerrors.Errorf("error connecting to the database: %w", err)
+	}
+
+	return &driverSearchIndexClient{client: client}, nil
+}
+
+func (c *driverSearchIndexClient) GetSearchIndex(ctx context.Context, database, collection, name string) (*SearchIndexDescription, error) {
+	cursor, err := c.client.Database(database).Collection(collection).SearchIndexes().List(ctx, options.SearchIndexes().SetName(name))
+	if err != nil {
+		return nil, xerrors.Errorf("error listing search indexes of %s.%s: %w", database, collection, err)
+	}
+
+	var indexes []SearchIndexDescription
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, xerrors.Errorf("error reading search indexes of %s.%s: %w", database, collection, err)
+	}
+
+	for _, index := range indexes {
+		if index.Name == name {
+			return &index, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *driverSearchIndexClient) CreateSearchIndex(ctx context.Context, database, collection, name, indexType string, definition map[string]interface{}) error {
+	model := mongo.SearchIndexModel{
+		Definition: bson.M(definition),
+		Options:    options.SearchIndexes().SetName(name).SetType(indexType),
+	}
+
+	if _, err := c.client.Database(database).Collection(collection).SearchIndexes().CreateOne(ctx, model); err != nil {
+		return xerrors.Errorf("error creating search index %s on %s.%s: %w", name, database, collection, err)
+	}
+
+	return nil
+}
+
+func (c *driverSearchIndexClient) UpdateSearchIndex(ctx context.Context, database, collection, name string, definition map[string]interface{}) error {
+	if err := c.client.Database(database).Collection(collection).SearchIndexes().UpdateOne(ctx, name, bson.M(definition)); err != nil {
+		return xerrors.Errorf("error updating search index %s on %s.%s: %w", name, database, collection, err)
+	}
+
+	return nil
+}
+
+func (c *driverSearchIndexClient) DropSearchIndex(ctx context.Context, database, collection, name string) error {
+	err := c.client.Database(database).Collection(collection).SearchIndexes().DropOne(ctx, name)
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(searchIndexNotFoundCode) {
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("error dropping search index %s on %s.%s: %w", name, database, collection, err)
+	}
+
+	return nil
+}
+
+func (c *driverSearchIndexClient) Disconnect(ctx context.Context) error {
+	return c.client.Disconnect(ctx)
+}
@@ -28,12 +28,13 @@ import (
 
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	golog "log"
 	localruntime "runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
-	runtime_cluster "sigs.k8s.io/controller-runtime/pkg/cluster"
 	kubelog "sigs.k8s.io/controller-runtime/pkg/log"
 	metricsServer "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	crWebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -42,6 +43,9 @@ import (
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	mdbmultiv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
 	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	mdbv2 "github.com/mongodb/mongodb-kubernetes/api/v2/mdb"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator"
 	"github.com/mongodb/mongodb-kubernetes/controllers/operator/construct"
 	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
@@ -52,6 +56,7 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/pprof"
+	"github.com/mongodb/mongodb-kubernetes/pkg/references"
 	"github.com/mongodb/mongodb-kubernetes/pkg/telemetry"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
@@ -61,13 +66,18 @@ import (
 )
 
 const (
-	mongoDBCRDPlural             = "mongodb"
-	mongoDBUserCRDPlural         = "mongodbusers"
-	mongoDBOpsManagerCRDPlural   = "opsmanagers"
-	mongoDBMultiClusterCRDPlural = "mongodbmulticluster"
-	mongoDBCommunityCRDPlural    = "mongodbcommunity"
-	mongoDBSearchCRDPlural       = "mongodbsearch"
-	clusterMongoDBRoleCRDPlural  = "clustermongodbroles"
+	mongoDBCRDPlural              = "mongodb"
+	mongoDBUserCRDPlural          = "mongodbusers"
+	mongoDBOpsManagerCRDPlural    = "opsmanagers"
+	mongoDBMultiClusterCRDPlural  = "mongodbmulticluster"
+	mongoDBCommunityCRDPlural     = "mongodbcommunity"
+	mongoDBSearchCRDPlural        = "mongodbsearch"
+	mongoDBSearchIndexCRDPlural   = "mongodbsearchindexes"
+	mongoDBAlertConfigCRDPlural   = "mongodbalertconfigs"
+	mongoDBOrganizationCRDPlural  = "mongodborganizations"
+	mongoDBProjectCRDPlural       = "mongodbprojects"
+	clusterMongoDBRoleCRDPlural   = "clustermongodbroles"
+	mongoDBMemberClusterCRDPlural = "mongodbmemberclusters"
 )
 
 var (
@@ -87,8 +97,10 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(mdbv2.AddToScheme(scheme))
 	utilruntime.Must(mcov1.AddToScheme(scheme))
 	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 
 	flag.Var(&crds, "watch-resource", "A Watch Resource specifies if the Operator should watch the given resource")
@@ -117,12 +129,20 @@ func main() {
 			mongoDBOpsManagerCRDPlural,
 			mongoDBCommunityCRDPlural,
 			mongoDBSearchCRDPlural,
+			mongoDBSearchIndexCRDPlural,
+			mongoDBAlertConfigCRDPlural,
+			mongoDBOrganizationCRDPlural,
+			mongoDBProjectCRDPlural,
 			clusterMongoDBRoleCRDPlural,
 		}
 	}
 
 	ctx := signals.SetupSignalHandler()
 	operator.OmUpdateChannel = make(chan event.GenericEvent)
+	if slices.Contains(crds, mongoDBMultiClusterCRDPlural) && slices.Contains(crds, mongoDBOpsManagerCRDPlural) {
+		// the multi-cluster MongoDBOpsManager resources are failed over by the member cluster health checker
+		operator.OpsManagerFailoverChannel = make(chan event.GenericEvent)
+	}
 
 	klog.InitFlags(nil)
 	initializeEnvironment()
@@ -204,13 +224,17 @@ func main() {
 		log.Fatal(err)
 	}
 
-	// memberClusterObjectsMap is a map of clusterName -> clusterObject
-	memberClusterObjectsMap := make(map[string]runtime_cluster.Cluster)
+	// memberClusters holds the member clusters from the KubeConfig file and the ones registered later with
+	// MongoDBMemberCluster resources
+	memberClusters := multicluster.NewRegistry()
 
 	if slices.Contains(crds, mongoDBMultiClusterCRDPlural) {
 		memberClustersNames, err := getMemberClusters(ctx, cfg, currentNamespace)
 		if err != nil {
-			log.Fatal(err)
+			// the member clusters can be registered with MongoDBMemberCluster resources only
+			if !apiErrors.IsNotFound(err) || !slices.Contains(crds, mongoDBMemberClusterCRDPlural) {
+				log.Fatal(err)
+			}
 		}
 
 		log.Infof("Watching Member clusters: %s", memberClustersNames)
@@ -226,19 +250,7 @@ func main() {
 
 		// Add the cluster object to the manager corresponding to each member clusters.
 		for k, v := range memberClusterClients {
-			var cluster runtime_cluster.Cluster
-
-			cluster, err := runtime_cluster.New(v, func(options *runtime_cluster.Options) {
-				if len(namespacesToWatch) > 1 || namespacesToWatch[0] != "" {
-					defaultNamespaces := make(map[string]cache.Config)
-					for _, namespace := range namespacesToWatch {
-						defaultNamespaces[namespace] = cache.Config{}
-					}
-					options.Cache = cache.Options{
-						DefaultNamespaces: defaultNamespaces,
-					}
-				}
-			})
+			cluster, err := multicluster.NewCluster(v, namespacesToWatch)
 			if err != nil {
 				// don't panic here but rather log the error, for example, error might happen when one of the cluster is
 				// unreachable, we would still like the operator to continue reconciliation on the other clusters.
@@ -247,36 +259,66 @@ func main() {
 			}
 
 			log.Infof("Adding cluster %s to cluster map.", k)
-			memberClusterObjectsMap[k] = cluster
+			if err := memberClusters.Add(k, cluster, v, nil); err != nil {
+				log.Fatal(err)
+			}
 			if err = mgr.Add(cluster); err != nil {
 				log.Fatal(err)
 			}
 		}
 	}
 
+	// the references of the resources are validated with the cached client
+	referenceValidator := references.NewValidator(mgr.GetClient(), enableClusterMongoDBRoles)
+
 	// Setup all Controllers
 	if slices.Contains(crds, mongoDBCRDPlural) {
-		if err := setupMongoDBCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusterObjectsMap); err != nil {
+		if err := setupMongoDBCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusters, referenceValidator); err != nil {
 			log.Fatal(err)
 		}
 	}
 	if slices.Contains(crds, mongoDBOpsManagerCRDPlural) {
-		if err := setupMongoDBOpsManagerCRD(ctx, mgr, memberClusterObjectsMap, imageUrls, initAppdbVersion, initOpsManagerImageVersion); err != nil {
+		if err := setupMongoDBOpsManagerCRD(ctx, mgr, memberClusters, imageUrls, initAppdbVersion, initOpsManagerImageVersion); err != nil {
 			log.Fatal(err)
 		}
 	}
 	if slices.Contains(crds, mongoDBUserCRDPlural) {
-		if err := setupMongoDBUserCRD(ctx, mgr, memberClusterObjectsMap); err != nil {
+		if err := setupMongoDBUserCRD(ctx, mgr, memberClusters, referenceValidator); err != nil {
 			log.Fatal(err)
 		}
 	}
 	if slices.Contains(crds, mongoDBMultiClusterCRDPlural) {
-		if err := setupMongoDBMultiClusterCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusterObjectsMap); err != nil {
+		if err := setupMongoDBMultiClusterCRD(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusters, referenceValidator); err != nil {
 			log.Fatal(err)
 		}
 	}
 	if slices.Contains(crds, mongoDBSearchCRDPlural) {
-		if err := setupMongoDBSearchCRD(ctx, mgr); err != nil {
+		if err := setupMongoDBSearchCRD(ctx, mgr, memberClusters, referenceValidator); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slices.Contains(crds, mongoDBSearchIndexCRDPlural) {
+		if err := operator.AddMongoDBSearchIndexController(mgr); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slices.Contains(crds, mongoDBAlertConfigCRDPlural) {
+		if err := operator.AddMongoDBAlertConfigController(ctx, mgr); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slices.Contains(crds, mongoDBOrganizationCRDPlural) {
+		if err := operator.AddMongoDBOrganizationController(ctx, mgr); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slices.Contains(crds, mongoDBProjectCRDPlural) {
+		if err := operator.AddMongoDBProjectController(ctx, mgr); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if slices.Contains(crds, mongoDBMemberClusterCRDPlural) {
+		if err := operator.AddMongoDBMemberClusterController(ctx, mgr, memberClusters); err != nil {
 			log.Fatal(err)
 		}
 	}
@@ -297,6 +339,7 @@ func main() {
 			env.ReadOrPanic(util.MongodbCommunityAgentImageEnv),
 			env.ReadOrPanic(mcoConstruct.VersionUpgradeHookImageEnv),
 			env.ReadOrPanic(mcoConstruct.ReadinessProbeImageEnv),
+			memberClusters,
 		); err != nil {
 			log.Fatal(err)
 		}
@@ -304,7 +347,7 @@ func main() {
 
 	if telemetry.IsTelemetryActivated() {
 		log.Info("Running telemetry component!")
-		telemetryRunnable, err := telemetry.NewLeaderRunnable(mgr, memberClusterObjectsMap, currentNamespace, imageUrls[mcoConstruct.MongodbImageEnv], imageUrls[util.NonStaticDatabaseEnterpriseImage], getOperatorEnv())
+		telemetryRunnable, err := telemetry.NewLeaderRunnable(mgr, memberClusters, currentNamespace, imageUrls[mcoConstruct.MongodbImageEnv], imageUrls[util.NonStaticDatabaseEnterpriseImage], getOperatorEnv())
 		if err != nil {
 			log.Errorf("Unable to enable telemetry; err: %s", err)
 		}
@@ -359,43 +402,62 @@ func shutdownTracerProvider(signalCtx context.Context, tp *sdktrace.TracerProvid
 	}
 }
 
-func setupMongoDBCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusterObjectsMap map[string]runtime_cluster.Cluster) error {
+func setupMongoDBCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusters *multicluster.Registry, referenceValidator *references.Validator) error {
 	if err := operator.AddStandaloneController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles); err != nil {
 		return err
 	}
 	if err := operator.AddReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles); err != nil {
 		return err
 	}
-	if err := operator.AddShardedClusterController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusterObjectsMap); err != nil {
+	if err := operator.AddShardedClusterController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusters); err != nil {
 		return err
 	}
-	return ctrl.NewWebhookManagedBy(mgr).For(&mdbv1.MongoDB{}).Complete()
+	if err := webhook.RegisterDefaultingWebhook(mgr.GetWebhookServer(), "MongoDB"); err != nil {
+		return err
+	}
+	// MongoDB is convertible to the other versions in the scheme, so the builder also registers the conversion webhook
+	return ctrl.NewWebhookManagedBy(mgr).For(&mdbv1.MongoDB{}).WithValidator(webhook.NewReferenceValidator(referenceValidator)).Complete()
 }
 
-func setupMongoDBOpsManagerCRD(ctx context.Context, mgr manager.Manager, memberClusterObjectsMap map[string]runtime_cluster.Cluster, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string) error {
-	if err := operator.AddOpsManagerController(ctx, mgr, memberClusterObjectsMap, imageUrls, initAppdbVersion, initOpsManagerImageVersion); err != nil {
+func setupMongoDBOpsManagerCRD(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry, imageUrls images.ImageUrls, initAppdbVersion, initOpsManagerImageVersion string) error {
+	if err := operator.AddOpsManagerController(ctx, mgr, memberClusters, imageUrls, initAppdbVersion, initOpsManagerImageVersion); err != nil {
+		return err
+	}
+	if err := webhook.RegisterDefaultingWebhook(mgr.GetWebhookServer(), "MongoDBOpsManager"); err != nil {
 		return err
 	}
 	return ctrl.NewWebhookManagedBy(mgr).For(&omv1.MongoDBOpsManager{}).Complete()
 }
 
-func setupMongoDBUserCRD(ctx context.Context, mgr manager.Manager, memberClusterObjectsMap map[string]runtime_cluster.Cluster) error {
-	return operator.AddMongoDBUserController(ctx, mgr, memberClusterObjectsMap)
+func setupMongoDBUserCRD(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry, referenceValidator *references.Validator) error {
+	if err := operator.AddMongoDBUserController(ctx, mgr, memberClusters); err != nil {
+		return err
+	}
+	if err := webhook.RegisterDefaultingWebhook(mgr.GetWebhookServer(), "MongoDBUser"); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&userv1.MongoDBUser{}).WithValidator(webhook.NewReferenceValidator(referenceValidator)).Complete()
 }
 
-func setupMongoDBMultiClusterCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusterObjectsMap map[string]runtime_cluster.Cluster) error {
-	if err := operator.AddMultiReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusterObjectsMap); err != nil {
+func setupMongoDBMultiClusterCRD(ctx context.Context, mgr manager.Manager, imageUrls images.ImageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion string, forceEnterprise bool, enableClusterMongoDBRoles bool, memberClusters *multicluster.Registry, referenceValidator *references.Validator) error {
+	if err := operator.AddMultiReplicaSetController(ctx, mgr, imageUrls, initDatabaseNonStaticImageVersion, databaseNonStaticImageVersion, forceEnterprise, enableClusterMongoDBRoles, memberClusters); err != nil {
 		return err
 	}
-	return ctrl.NewWebhookManagedBy(mgr).For(&mdbmultiv1.MongoDBMultiCluster{}).Complete()
+	if err := webhook.RegisterDefaultingWebhook(mgr.GetWebhookServer(), "MongoDBMultiCluster"); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&mdbmultiv1.MongoDBMultiCluster{}).WithValidator(webhook.NewReferenceValidator(referenceValidator)).Complete()
 }
 
-func setupMongoDBSearchCRD(ctx context.Context, mgr manager.Manager) error {
-	return operator.AddMongoDBSearchController(ctx, mgr, searchcontroller.OperatorSearchConfig{
+func setupMongoDBSearchCRD(ctx context.Context, mgr manager.Manager, memberClusters *multicluster.Registry, referenceValidator *references.Validator) error {
+	if err := operator.AddMongoDBSearchController(ctx, mgr, searchcontroller.OperatorSearchConfig{
 		SearchRepo:    env.ReadOrPanic("MDB_SEARCH_REPO_URL"),
 		SearchName:    env.ReadOrPanic("MDB_SEARCH_NAME"),
 		SearchVersion: env.ReadOrPanic("MDB_SEARCH_VERSION"),
-	})
+	}, memberClusters); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&searchv1.MongoDBSearch{}).WithValidator(webhook.NewReferenceValidator(referenceValidator)).Complete()
 }
 
 func setupCommunityController(
@@ -407,6 +469,7 @@ func setupCommunityController(
 	agentImage string,
 	versionUpgradeHookImage string,
 	readinessProbeImage string,
+	memberClusters *multicluster.Registry,
 ) error {
 	return mcoController.NewReconciler(
 		mgr,
@@ -416,6 +479,7 @@ func setupCommunityController(
 		agentImage,
 		versionUpgradeHookImage,
 		readinessProbeImage,
+		memberClusters,
 	).SetupWithManager(mgr)
 }
 
@@ -445,7 +509,9 @@ func isInLocalMode() bool {
 }
 
 // setupWebhook sets up the validation webhook for MongoDB resources in order
-// to give people early warning when their MongoDB resources are wrong.
+// to give people early warning when their MongoDB resources are wrong, the
+// mutating webhook writing the defaults into the resources and the conversion
+// webhook of the MongoDB CRD.
 func setupWebhook(ctx context.Context, cfg *rest.Config, log *zap.SugaredLogger, svcSelector string, currentNamespace string) crWebhook.Options {
 	// set webhook port — 1993 is chosen as Ben's birthday
 	webhookPort := env.ReadIntOrDefault(util.MdbWebhookPortEnv, 1993)
@@ -460,7 +526,7 @@ func setupWebhook(ctx context.Context, cfg *rest.Config, log *zap.SugaredLogger,
 
 	// create a kubernetes client that the webhook server can use. We can't reuse
 	// the one from the manager as it is not initialised yet.
-	webhookClient, err := client.New(cfg, client.Options{})
+	webhookClient, err := client.New(cfg, client.Options{Scheme: scheme})
 	if err != nil {
 		panic(err)
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/stretchr/objx"
@@ -16,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1/common"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/authentication/authtypes"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
@@ -53,6 +55,21 @@ const (
 	defaultClusterDomain = "cluster.local"
 )
 
+const (
+	// LastClusterNumMapping stores the stable index assigned to each member cluster of a multi-cluster deployment.
+	LastClusterNumMapping = "mongodb.com/v1.lastClusterNumMapping"
+	// MemberClustersFinalizer keeps a multi-cluster resource until the resources created in its member clusters are
+	// removed.
+	MemberClustersFinalizer = "mongodbcommunity.mongodb.com/member-clusters-cleanup"
+	// LabelResourceOwner labels the resources created in the member clusters, as owner references can't cross
+	// clusters.
+	LabelResourceOwner = "mongodbcommunity"
+	// labelOperatorName and labelOperatorValue label the resources created in the member clusters as managed by the
+	// operator, with the same label as the resources of the multi-cluster MongoDB resources.
+	labelOperatorName  = "controller"
+	labelOperatorValue = "mongodb-enterprise-operator"
+)
+
 // Connection string options that should be ignored as they are set through other means.
 var (
 	protectedConnectionStringOptions = map[string]struct{}{
@@ -80,6 +97,12 @@ type MongoDBCommunitySpec struct {
 	// +optional
 	Arbiters int `json:"arbiters"`
 
+	// ClusterSpecList distributes the members of the replica set across the member clusters registered in the
+	// operator. When set, Members must be equal to the sum of the members of every cluster, and the automation
+	// config is replicated to each of the member clusters.
+	// +optional
+	ClusterSpecList []ClusterSpecItem `json:"clusterSpecList,omitempty"`
+
 	// FeatureCompatibilityVersion configures the feature compatibility version that will
 	// be set for the deployment
 	// +optional
@@ -138,6 +161,16 @@ type MongoDBCommunitySpec struct {
 	MemberConfig []automationconfig.MemberOptions `json:"memberConfig,omitempty"`
 }
 
+// ClusterSpecItem defines how many members of the replica set are deployed in a member cluster.
+type ClusterSpecItem struct {
+	// ClusterName is the name of the member cluster, as registered in the operator.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+	// Members is the number of replica set members deployed in the member cluster.
+	// +kubebuilder:validation:Minimum=0
+	Members int `json:"members"`
+}
+
 // MapWrapper is a wrapper for a map to be used by other structs.
 // The CRD generator does not support map[string]interface{}
 // on the top level and hence we need to work around this with
@@ -645,6 +678,14 @@ type MongoDBCommunityStatus struct {
 	CurrentMongoDBArbiters             int `json:"currentMongoDBArbiters,omitempty"`
 
 	Message string `json:"message,omitempty"`
+
+	// PVCs reports the progress of the resize of the persistent volumes of the StatefulSets.
+	// +optional
+	PVCs status.PVCS `json:"pvc,omitempty"`
+
+	// ClusterMembers is the number of replica set members currently deployed in each member cluster.
+	// +optional
+	ClusterMembers map[string]int `json:"clusterMembers,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -828,6 +869,16 @@ func (m *MongoDBCommunitySpec) IsAgentX509() bool {
 // IsStillScaling returns true if this resource is currently scaling,
 // considering both arbiters and regular members.
 func (m *MongoDBCommunity) IsStillScaling() bool {
+	if m.IsMultiCluster() {
+		desired := m.desiredClusterMembers()
+		for _, item := range m.ClusterMembersThisReconciliation() {
+			if item.Members != desired[item.ClusterName] {
+				return true
+			}
+		}
+		return false
+	}
+
 	arbiters := automationConfigReplicasScaler{
 		current:                m.CurrentArbiters(),
 		desired:                m.DesiredArbiters(),
@@ -841,6 +892,10 @@ func (m *MongoDBCommunity) IsStillScaling() bool {
 // automation config replica set members based on our desired number, and our
 // current number.
 func (m *MongoDBCommunity) AutomationConfigMembersThisReconciliation() int {
+	if m.IsMultiCluster() {
+		return m.clusterMembersThisReconciliationCount()
+	}
+
 	return scale.ReplicasThisReconciliation(automationConfigReplicasScaler{
 		current: m.Status.CurrentMongoDBMembers,
 		desired: m.Spec.Members,
@@ -966,6 +1021,20 @@ func (m *MongoDBCommunity) MongoAuthUserSRVURI(user authtypes.User, password str
 }
 
 func (m *MongoDBCommunity) Hosts() []string {
+	if m.IsMultiCluster() {
+		var hosts []string
+		for _, item := range m.Spec.ClusterSpecList {
+			clusterNum, ok := m.ClusterNum(item.ClusterName)
+			if !ok {
+				continue
+			}
+			for _, hostname := range m.ClusterHostnames(clusterNum, item.Members) {
+				hosts = append(hosts, fmt.Sprintf("%s:%d", hostname, m.GetMongodConfiguration().GetDBPort()))
+			}
+		}
+		return hosts
+	}
+
 	hosts := make([]string, m.Spec.Members)
 
 	for i := 0; i < m.Spec.Members; i++ {
@@ -988,6 +1057,143 @@ func (m *MongoDBCommunity) ServiceName() string {
 	return m.Name + "-svc"
 }
 
+// IsMultiCluster returns true if the members of the replica set are distributed across member clusters.
+func (m *MongoDBCommunity) IsMultiCluster() bool {
+	return len(m.Spec.ClusterSpecList) > 0
+}
+
+// ClusterMapping returns the indexes assigned to the member clusters, which are stored in the
+// LastClusterNumMapping annotation.
+func (m *MongoDBCommunity) ClusterMapping() map[string]int {
+	mapping := map[string]int{}
+	if bytes, ok := m.Annotations[LastClusterNumMapping]; ok {
+		_ = json.Unmarshal([]byte(bytes), &mapping)
+	}
+	return mapping
+}
+
+// ClusterNum returns the index assigned to the member cluster. The indexes are never reused, so that a cluster that
+// is removed and added back doesn't clash with the resources of another cluster.
+func (m *MongoDBCommunity) ClusterNum(clusterName string) (int, bool) {
+	clusterNum, ok := m.ClusterMapping()[clusterName]
+	return clusterNum, ok
+}
+
+// ClusterStatefulSetNamespacedName returns the name of the StatefulSet deployed in the member cluster with the
+// given index.
+func (m *MongoDBCommunity) ClusterStatefulSetNamespacedName(clusterNum int) types.NamespacedName {
+	return types.NamespacedName{Name: fmt.Sprintf("%s-%d", m.Name, clusterNum), Namespace: m.Namespace}
+}
+
+// ClusterServiceName returns the name of the headless Service of the StatefulSet deployed in the member cluster with
+// the given index.
+func (m *MongoDBCommunity) ClusterServiceName(clusterNum int) string {
+	return fmt.Sprintf("%s-%d-svc", m.Name, clusterNum)
+}
+
+// PodServiceName returns the name of the Service which exposes a single member of the replica set. The members
+// reach each other across clusters through these Services.
+func (m *MongoDBCommunity) PodServiceName(clusterNum, podNum int) string {
+	return fmt.Sprintf("%s-%d-%d-svc", m.Name, clusterNum, podNum)
+}
+
+// ClusterHostnames returns the hostnames of the members deployed in the member cluster with the given index.
+func (m *MongoDBCommunity) ClusterHostnames(clusterNum, members int) []string {
+	hostnames := make([]string, members)
+	for podNum := 0; podNum < members; podNum++ {
+		hostnames[podNum] = fmt.Sprintf("%s.%s.svc.%s", m.PodServiceName(clusterNum, podNum), m.Namespace, m.Spec.GetClusterDomain())
+	}
+	return hostnames
+}
+
+// GetOwnerLabels returns the labels identifying the resources created for this resource in the member clusters.
+func (m *MongoDBCommunity) GetOwnerLabels() map[string]string {
+	return OwnerLabels(m.NamespacedName())
+}
+
+// OwnerLabels returns the labels identifying the resources created in the member clusters for the MongoDBCommunity
+// resource with the given name. It doesn't require the resource itself, so it can be used once it's been deleted.
+func OwnerLabels(name types.NamespacedName) map[string]string {
+	return map[string]string{
+		labelOperatorName:  labelOperatorValue,
+		LabelResourceOwner: fmt.Sprintf("%s-%s", name.Namespace, name.Name),
+	}
+}
+
+// ClusterMembersThisReconciliation returns the number of members each member cluster should have in this
+// reconciliation, starting with the clusters of the spec followed by the removed clusters that still have members.
+//
+// As with a single cluster, a new deployment is created with all of its members at once. Afterwards, members are
+// added or removed one at a time, and members are added before any are removed so that moving members between
+// clusters doesn't shrink the replica set.
+func (m *MongoDBCommunity) ClusterMembersThisReconciliation() []ClusterSpecItem {
+	if m.currentClusterMembersCount() == 0 {
+		return m.Spec.ClusterSpecList
+	}
+
+	desired := m.desiredClusterMembers()
+	var items []ClusterSpecItem
+	for _, item := range m.Spec.ClusterSpecList {
+		items = append(items, ClusterSpecItem{ClusterName: item.ClusterName, Members: m.Status.ClusterMembers[item.ClusterName]})
+	}
+	var removedClusters []string
+	for clusterName, members := range m.Status.ClusterMembers {
+		if _, ok := desired[clusterName]; !ok && members > 0 {
+			removedClusters = append(removedClusters, clusterName)
+		}
+	}
+	sort.Strings(removedClusters)
+	for _, clusterName := range removedClusters {
+		items = append(items, ClusterSpecItem{ClusterName: clusterName, Members: m.Status.ClusterMembers[clusterName]})
+	}
+
+	for i := range items {
+		if items[i].Members < desired[items[i].ClusterName] {
+			items[i].Members++
+			return items
+		}
+	}
+	for i := range items {
+		if items[i].Members > desired[items[i].ClusterName] {
+			items[i].Members--
+			return items
+		}
+	}
+	return items
+}
+
+// ClusterMembersStatus returns the number of members by member cluster, as reported in the status.
+func ClusterMembersStatus(items []ClusterSpecItem) map[string]int {
+	if len(items) == 0 {
+		return nil
+	}
+	clusterMembers := map[string]int{}
+	for _, item := range items {
+		clusterMembers[item.ClusterName] = item.Members
+	}
+	return clusterMembers
+}
+
+func (m *MongoDBCommunity) desiredClusterMembers() map[string]int {
+	return ClusterMembersStatus(m.Spec.ClusterSpecList)
+}
+
+func (m *MongoDBCommunity) currentClusterMembersCount() int {
+	count := 0
+	for _, members := range m.Status.ClusterMembers {
+		count += members
+	}
+	return count
+}
+
+func (m *MongoDBCommunity) clusterMembersThisReconciliationCount() int {
+	count := 0
+	for _, item := range m.ClusterMembersThisReconciliation() {
+		count += item.Members
+	}
+	return count
+}
+
 func (m *MongoDBCommunity) ArbiterNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: m.Namespace, Name: m.Name + "-arb"}
 }
@@ -1080,6 +1286,10 @@ func (m *MongoDBCommunity) GetMongoDBVersionForAnnotation() string {
 }
 
 func (m *MongoDBCommunity) StatefulSetReplicasThisReconciliation() int {
+	if m.IsMultiCluster() {
+		return m.clusterMembersThisReconciliationCount()
+	}
+
 	return scale.ReplicasThisReconciliation(automationConfigReplicasScaler{
 		desired:                m.DesiredReplicas(),
 		current:                m.CurrentReplicas(),
@@ -705,3 +705,58 @@ func TestMongoDBCommunitySpec_GetAgentAuthMode(t *testing.T) {
 		})
 	}
 }
+
+func newMultiClusterReplicaSet(clusterMembers ...ClusterSpecItem) MongoDBCommunity {
+	members := 0
+	for _, item := range clusterMembers {
+		members += item.Members
+	}
+	mdb := newReplicaSet(members, "my-rs", "my-namespace")
+	mdb.Spec.ClusterSpecList = clusterMembers
+	mdb.Annotations = map[string]string{LastClusterNumMapping: `{"cluster-a":0,"cluster-b":1,"cluster-c":2}`}
+	return mdb
+}
+
+func TestMongoDBCommunity_ClusterMembersThisReconciliation(t *testing.T) {
+	t.Run("New deployment is created at once", func(t *testing.T) {
+		mdb := newMultiClusterReplicaSet(ClusterSpecItem{ClusterName: "cluster-a", Members: 2}, ClusterSpecItem{ClusterName: "cluster-b", Members: 1})
+		assert.Equal(t, mdb.Spec.ClusterSpecList, mdb.ClusterMembersThisReconciliation())
+		assert.Equal(t, 3, mdb.AutomationConfigMembersThisReconciliation())
+		assert.False(t, mdb.IsStillScaling())
+	})
+	t.Run("Members are added before they are removed", func(t *testing.T) {
+		mdb := newMultiClusterReplicaSet(ClusterSpecItem{ClusterName: "cluster-a", Members: 1}, ClusterSpecItem{ClusterName: "cluster-b", Members: 2})
+		mdb.Status.ClusterMembers = map[string]int{"cluster-a": 2, "cluster-b": 1}
+
+		assert.Equal(t, []ClusterSpecItem{{ClusterName: "cluster-a", Members: 2}, {ClusterName: "cluster-b", Members: 2}}, mdb.ClusterMembersThisReconciliation())
+		assert.Equal(t, 4, mdb.AutomationConfigMembersThisReconciliation())
+		assert.True(t, mdb.IsStillScaling())
+
+		mdb.Status.ClusterMembers = map[string]int{"cluster-a": 2, "cluster-b": 2}
+		assert.Equal(t, []ClusterSpecItem{{ClusterName: "cluster-a", Members: 1}, {ClusterName: "cluster-b", Members: 2}}, mdb.ClusterMembersThisReconciliation())
+		assert.False(t, mdb.IsStillScaling())
+	})
+	t.Run("Removed clusters are scaled down one member at a time", func(t *testing.T) {
+		mdb := newMultiClusterReplicaSet(ClusterSpecItem{ClusterName: "cluster-a", Members: 2})
+		mdb.Status.ClusterMembers = map[string]int{"cluster-a": 2, "cluster-c": 2}
+
+		assert.Equal(t, []ClusterSpecItem{{ClusterName: "cluster-a", Members: 2}, {ClusterName: "cluster-c", Members: 1}}, mdb.ClusterMembersThisReconciliation())
+		assert.Equal(t, 3, mdb.StatefulSetReplicasThisReconciliation())
+
+		mdb.Status.ClusterMembers = map[string]int{"cluster-a": 2, "cluster-c": 1}
+		assert.Equal(t, []ClusterSpecItem{{ClusterName: "cluster-a", Members: 2}, {ClusterName: "cluster-c", Members: 0}}, mdb.ClusterMembersThisReconciliation())
+
+		mdb.Status.ClusterMembers = map[string]int{"cluster-a": 2, "cluster-c": 0}
+		assert.Equal(t, []ClusterSpecItem{{ClusterName: "cluster-a", Members: 2}}, mdb.ClusterMembersThisReconciliation())
+		assert.False(t, mdb.IsStillScaling())
+	})
+}
+
+func TestMongoDBCommunity_MultiClusterHosts(t *testing.T) {
+	mdb := newMultiClusterReplicaSet(ClusterSpecItem{ClusterName: "cluster-a", Members: 2}, ClusterSpecItem{ClusterName: "cluster-c", Members: 1})
+	assert.Equal(t, []string{
+		"my-rs-0-0-svc.my-namespace.svc.cluster.local:27017",
+		"my-rs-0-1-svc.my-namespace.svc.cluster.local:27017",
+		"my-rs-2-0-svc.my-namespace.svc.cluster.local:27017",
+	}, mdb.Hosts())
+}
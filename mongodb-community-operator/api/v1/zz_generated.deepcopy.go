@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -134,6 +135,21 @@ func (in *AutomationConfigOverride) DeepCopy() *AutomationConfigOverride {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecItem) DeepCopyInto(out *ClusterSpecItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecItem.
+func (in *ClusterSpecItem) DeepCopy() *ClusterSpecItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomRole) DeepCopyInto(out *CustomRole) {
 	*out = *in
@@ -180,7 +196,7 @@ func (in *MongoDBCommunity) DeepCopyInto(out *MongoDBCommunity) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBCommunity.
@@ -236,6 +252,11 @@ func (in *MongoDBCommunityList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBCommunitySpec) DeepCopyInto(out *MongoDBCommunitySpec) {
 	*out = *in
+	if in.ClusterSpecList != nil {
+		in, out := &in.ClusterSpecList, &out.ClusterSpecList
+		*out = make([]ClusterSpecItem, len(*in))
+		copy(*out, *in)
+	}
 	if in.ReplicaSetHorizons != nil {
 		in, out := &in.ReplicaSetHorizons, &out.ReplicaSetHorizons
 		*out = make(ReplicaSetHorizonConfiguration, len(*in))
@@ -293,6 +314,18 @@ func (in *MongoDBCommunitySpec) DeepCopy() *MongoDBCommunitySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MongoDBCommunityStatus) DeepCopyInto(out *MongoDBCommunityStatus) {
 	*out = *in
+	if in.PVCs != nil {
+		in, out := &in.PVCs, &out.PVCs
+		*out = make(status.PVCS, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterMembers != nil {
+		in, out := &in.ClusterMembers, &out.ClusterMembers
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MongoDBCommunityStatus.
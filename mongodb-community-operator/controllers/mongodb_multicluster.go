@@ -0,0 +1,530 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/agent"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/podtemplatespec"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/secret"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/service"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/functions"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/merge"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/result"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+)
+
+const podNameLabel = "statefulset.kubernetes.io/pod-name"
+
+// ensureClusterMapping checks that the member clusters of the resource are registered in the operator and assigns
+// an index to the new ones. The indexes are saved in the LastClusterNumMapping annotation, as they name the
+// resources created in the member clusters.
+func (r *ReplicaSetReconciler) ensureClusterMapping(ctx context.Context, mdb *mdbv1.MongoDBCommunity) error {
+	var clusterNames []string
+	for _, item := range mdb.Spec.ClusterSpecList {
+		if !r.memberClusters.Has(item.ClusterName) {
+			return fmt.Errorf("member cluster %s is not registered in the operator", item.ClusterName)
+		}
+		clusterNames = append(clusterNames, item.ClusterName)
+	}
+
+	existingMapping := mdb.ClusterMapping()
+	mapping := multicluster.AssignIndexesForMemberClusterNames(existingMapping, clusterNames)
+	if reflect.DeepEqual(existingMapping, mapping) {
+		return nil
+	}
+
+	bytes, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	return annotations.SetAnnotations(ctx, mdb, map[string]string{mdbv1.LastClusterNumMapping: string(bytes)}, r.client)
+}
+
+// memberClusterClient returns the client of a member cluster of the resource.
+func (r *ReplicaSetReconciler) memberClusterClient(clusterName string) (kubernetesClient.Client, error) {
+	memberClient, ok := r.memberClusters.KubeClient(clusterName)
+	if !ok {
+		return nil, fmt.Errorf("member cluster %s is not registered in the operator", clusterName)
+	}
+	return memberClient, nil
+}
+
+// ensureMultiClusterServices creates, in every member cluster, the headless Service of the StatefulSet and a Service
+// for each of the members, through which the members of the other clusters reach it.
+func (r *ReplicaSetReconciler) ensureMultiClusterServices(ctx context.Context, mdb mdbv1.MongoDBCommunity, portManager *agent.ReplicaSetPortManager) error {
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		memberClient, err := r.memberClusterClient(item.ClusterName)
+		if err != nil {
+			return err
+		}
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+
+		services := []corev1.Service{buildMultiClusterService(mdb, mdb.ClusterServiceName(clusterNum), map[string]string{"app": mdb.ServiceName()}, portManager, true)}
+		stsName := mdb.ClusterStatefulSetNamespacedName(clusterNum).Name
+		for podNum := 0; podNum < item.Members; podNum++ {
+			selector := map[string]string{podNameLabel: fmt.Sprintf("%s-%d", stsName, podNum)}
+			services = append(services, buildMultiClusterService(mdb, mdb.PodServiceName(clusterNum, podNum), selector, portManager, false))
+		}
+
+		for i := range services {
+			desired := services[i]
+			svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+			if _, err := controllerutil.CreateOrUpdate(ctx, memberClient, svc, func() error {
+				svc.Labels = desired.Labels
+				svc.Spec.Selector = desired.Spec.Selector
+				svc.Spec.Ports = desired.Spec.Ports
+				svc.Spec.PublishNotReadyAddresses = desired.Spec.PublishNotReadyAddresses
+				if svc.CreationTimestamp.IsZero() {
+					svc.Spec.Type = desired.Spec.Type
+					svc.Spec.ClusterIP = desired.Spec.ClusterIP
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("error creating/updating service %s in member cluster %s: %w", desired.Name, item.ClusterName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func buildMultiClusterService(mdb mdbv1.MongoDBCommunity, name string, selector map[string]string, portManager *agent.ReplicaSetPortManager, headless bool) corev1.Service {
+	serviceBuilder := service.Builder().
+		SetName(name).
+		SetNamespace(mdb.Namespace).
+		SetSelector(selector).
+		SetLabels(merge.StringToStringMap(map[string]string{"app": mdb.ServiceName()}, mdb.GetOwnerLabels())).
+		SetServiceType(corev1.ServiceTypeClusterIP).
+		SetPublishNotReadyAddresses(true)
+	if headless {
+		serviceBuilder.SetClusterIP("None")
+	}
+
+	for _, servicePort := range portManager.GetServicePorts() {
+		tmpServicePort := servicePort
+		serviceBuilder.AddPort(&tmpServicePort)
+	}
+	serviceBuilder.AddPort(prometheusPort(mdb))
+
+	return serviceBuilder.Build()
+}
+
+// getMultiClusterPodStates returns the state of the members of every member cluster.
+func (r *ReplicaSetReconciler) getMultiClusterPodStates(ctx context.Context, mdb mdbv1.MongoDBCommunity, targetConfigVersion int) ([]agent.PodState, error) {
+	var podStates []agent.PodState
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		memberClient, err := r.memberClusterClient(item.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+
+		clusterPodStates, err := agent.GetAllDesiredMembersAndArbitersPodState(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum), memberClient, item.Members, 0, targetConfigVersion, r.log)
+		if err != nil {
+			return nil, err
+		}
+		podStates = append(podStates, clusterPodStates...)
+	}
+	return podStates, nil
+}
+
+// ensureMemberClusterSecrets copies the automation config and the secrets mounted by the pods to a member cluster.
+func (r *ReplicaSetReconciler) ensureMemberClusterSecrets(ctx context.Context, mdb mdbv1.MongoDBCommunity, memberClient kubernetesClient.Client, clusterName string) error {
+	secretNames := []types.NamespacedName{{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace}}
+	if mdb.Spec.Security.TLS.Enabled {
+		secretNames = append(secretNames, mdb.TLSOperatorCASecretNamespacedName(), mdb.TLSOperatorSecretNamespacedName())
+		if mdb.Spec.IsAgentX509() {
+			secretNames = append(secretNames, mdb.AgentCertificatePemSecretNamespacedName())
+		}
+	}
+	if mdb.Spec.Prometheus != nil && mdb.Spec.Prometheus.TLSSecretRef.Name != "" {
+		secretNames = append(secretNames, mdb.PrometheusTLSOperatorSecretNamespacedName())
+	}
+
+	for _, secretName := range secretNames {
+		s, err := r.client.GetSecret(ctx, secretName)
+		if err != nil {
+			return fmt.Errorf("error reading secret %v to copy it to member cluster %s: %w", secretName, clusterName, err)
+		}
+
+		memberSecret := secret.Builder().
+			SetName(secretName.Name).
+			SetNamespace(secretName.Namespace).
+			SetLabels(mdb.GetOwnerLabels()).
+			SetByteData(s.Data).
+			SetDataType(s.Type).
+			Build()
+		if err := secret.CreateOrUpdateIfNeeded(ctx, memberClient, memberSecret); err != nil {
+			return fmt.Errorf("error copying secret %v to member cluster %s: %w", secretName, clusterName, err)
+		}
+	}
+	return nil
+}
+
+// deployMultiClusterAutomationConfig builds the automation config in the operator's cluster and replicates it to every
+// member cluster. The returned boolean indicates whether the agents of all the member clusters have reached goal state.
+func (r *ReplicaSetReconciler) deployMultiClusterAutomationConfig(ctx context.Context, mdb mdbv1.MongoDBCommunity, lastAppliedSpec *mdbv1.MongoDBCommunitySpec) (bool, error) {
+	r.log.Infof("Creating/Updating AutomationConfig")
+
+	ac, err := r.ensureAutomationConfig(mdb, ctx, lastAppliedSpec)
+	if err != nil {
+		return false, fmt.Errorf("failed to ensure AutomationConfig: %s", err)
+	}
+
+	allReachedGoalState := true
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		memberClient, err := r.memberClusterClient(item.ClusterName)
+		if err != nil {
+			return false, err
+		}
+		if err := r.ensureMemberClusterSecrets(ctx, mdb, memberClient, item.ClusterName); err != nil {
+			return false, err
+		}
+
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+		sts, err := memberClient.GetStatefulSet(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum))
+		if err != nil {
+			if apiErrors.IsNotFound(err) {
+				// the StatefulSet is created in the next stage of the reconciliation
+				continue
+			}
+			return false, fmt.Errorf("failed to get StatefulSet in member cluster %s: %s", item.ClusterName, err)
+		}
+
+		r.log.Debugf("Waiting for agents of member cluster %s to reach version %d", item.ClusterName, ac.Version)
+		ready, err := agent.AllReachedGoalState(ctx, sts, memberClient, item.Members, ac.Version, r.log)
+		if err != nil {
+			return false, fmt.Errorf("failed to ensure agents of member cluster %s have reached goal state: %s", item.ClusterName, err)
+		}
+		allReachedGoalState = allReachedGoalState && ready
+	}
+
+	return allReachedGoalState, nil
+}
+
+// deployMultiClusterStatefulSets deploys a StatefulSet in every member cluster. The returned boolean indicates that
+// all the StatefulSets are ready.
+func (r *ReplicaSetReconciler) deployMultiClusterStatefulSets(ctx context.Context, mdb *mdbv1.MongoDBCommunity) (bool, error) {
+	allReady := true
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		memberClient, err := r.memberClusterClient(item.ClusterName)
+		if err != nil {
+			return false, err
+		}
+		// the pods can't start before the secrets they mount exist in the member cluster
+		if err := r.ensureMemberClusterSecrets(ctx, *mdb, memberClient, item.ClusterName); err != nil {
+			return false, err
+		}
+
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+		r.log.Infof("Creating/Updating StatefulSet in member cluster %s", item.ClusterName)
+		if updated, err := r.createOrUpdateClusterStatefulSet(ctx, mdb, memberClient, clusterNum, item.Members); err != nil {
+			return false, fmt.Errorf("error creating/updating StatefulSet in member cluster %s: %s", item.ClusterName, err)
+		} else if !updated {
+			return false, nil
+		}
+
+		currentSts, err := memberClient.GetStatefulSet(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum))
+		if err != nil {
+			return false, fmt.Errorf("error getting StatefulSet in member cluster %s: %s", item.ClusterName, err)
+		}
+		isReady := statefulset.IsReady(currentSts, item.Members)
+		allReady = allReady && (isReady || currentSts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType)
+	}
+	return allReady, nil
+}
+
+// createOrUpdateClusterStatefulSet creates or updates the StatefulSet of a member cluster. The returned boolean is
+// false if the StatefulSet can't be updated yet because its persistent volumes are still being resized.
+func (r *ReplicaSetReconciler) createOrUpdateClusterStatefulSet(ctx context.Context, mdb *mdbv1.MongoDBCommunity, memberClient kubernetesClient.Client, clusterNum, members int) (bool, error) {
+	name := mdb.ClusterStatefulSetNamespacedName(clusterNum)
+	set := appsv1.StatefulSet{}
+	if err := k8sClient.IgnoreNotFound(memberClient.Get(ctx, name, &set)); err != nil {
+		return false, fmt.Errorf("error getting StatefulSet: %s", err)
+	}
+
+	mongodbImage := getMongoDBImage(r.mongodbRepoUrl, r.mongodbImage, r.mongodbImageType, mdb.GetMongoDBVersion())
+	buildMultiClusterStatefulSetModificationFunction(*mdb, clusterNum, members, mongodbImage, r.agentImage, r.versionUpgradeHookImage, r.readinessProbeImage)(&set)
+
+	workflowStatus := pvcresize.HandlePVCResize(ctx, memberClient, &set, r.log)
+	if option, exists := mdbstatus.GetOption(workflowStatus.StatusOptions(), mdbstatus.PVCStatusOption{}); exists {
+		mdb.Status.PVCs.Merge(*option.(mdbstatus.PVCStatusOption).PVC)
+	}
+	if !workflowStatus.IsOK() {
+		if workflowStatus.Phase() == mdbstatus.PhaseFailed {
+			return false, fmt.Errorf("error resizing the persistent volumes: %s", workflowStatusMessage(workflowStatus))
+		}
+		r.log.Infof("Waiting for the persistent volumes of StatefulSet %s to be resized", name)
+		return false, nil
+	}
+
+	if _, err := statefulset.CreateOrUpdate(ctx, memberClient, set); err != nil {
+		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
+	}
+	return true, nil
+}
+
+// buildMultiClusterStatefulSetModificationFunction builds the StatefulSet of the member cluster with the given index.
+// As owner references can't cross clusters, the StatefulSet is labelled with the owner labels instead.
+func buildMultiClusterStatefulSetModificationFunction(mdb mdbv1.MongoDBCommunity, clusterNum, members int, mongodbImage, agentImage, versionUpgradeHookImage, readinessProbeImage string) statefulset.Modification {
+	return statefulset.Apply(
+		buildStatefulSetModificationFunction(mdb, mongodbImage, agentImage, versionUpgradeHookImage, readinessProbeImage),
+		statefulset.WithName(mdb.ClusterStatefulSetNamespacedName(clusterNum).Name),
+		statefulset.WithServiceName(mdb.ClusterServiceName(clusterNum)),
+		statefulset.WithReplicas(members),
+		statefulset.WithPodSpecTemplate(
+			podtemplatespec.WithContainer(construct.AgentName, withOverrideLocalHost(mdb.Spec.GetClusterDomain())),
+		),
+		func(sts *appsv1.StatefulSet) {
+			sts.OwnerReferences = nil
+			sts.Labels = merge.StringToStringMap(sts.Labels, mdb.GetOwnerLabels())
+		},
+	)
+}
+
+// withOverrideLocalHost makes the agent identify itself with the hostname of the Service of its pod, which is the
+// hostname of the process in the automation config.
+func withOverrideLocalHost(clusterDomain string) func(*corev1.Container) {
+	return func(c *corev1.Container) {
+		if len(c.Command) == 0 {
+			return
+		}
+		c.Command[len(c.Command)-1] += fmt.Sprintf(" -overrideLocalHost=$(hostname)-svc.${POD_NAMESPACE}.svc.%s", clusterDomain)
+	}
+}
+
+// shouldRunInOrderMultiCluster is the multi-cluster counterpart of shouldRunInOrder.
+func (r *ReplicaSetReconciler) shouldRunInOrderMultiCluster(ctx context.Context, mdb mdbv1.MongoDBCommunity) bool {
+	current := 0
+	for _, members := range mdb.Status.ClusterMembers {
+		current += members
+	}
+	thisReconciliation := mdb.StatefulSetReplicasThisReconciliation()
+
+	if thisReconciliation > current {
+		if current == 0 {
+			r.log.Debug("Scaling up the ReplicaSet when there is no replicas, the Automation Config must be updated first")
+			return true
+		}
+		r.log.Debug("Scaling up the ReplicaSet, the StatefulSets must be updated first")
+		return false
+	}
+
+	if thisReconciliation < current {
+		r.log.Debug("Scaling down the ReplicaSet, the Automation Config must be updated first")
+		return true
+	}
+
+	if mdb.IsChangingVersion() {
+		r.log.Debug("Version change in progress, the StatefulSets must be updated first")
+		return false
+	}
+
+	if mdb.Spec.Security.TLS.Enabled {
+		for _, item := range mdb.ClusterMembersThisReconciliation() {
+			memberClient, err := r.memberClusterClient(item.ClusterName)
+			if err != nil {
+				continue
+			}
+			clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+			sts, err := memberClient.GetStatefulSet(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum))
+			if err == nil && statefulset.IsReady(sts, item.Members) {
+				r.log.Debug("Enabling TLS on an existing deployment, the StatefulSets must be updated first")
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// deployMultiClusterReplicaSet is the multi-cluster counterpart of deployMongoDBReplicaSet.
+func (r *ReplicaSetReconciler) deployMultiClusterReplicaSet(ctx context.Context, mdb *mdbv1.MongoDBCommunity, lastAppliedSpec *mdbv1.MongoDBCommunitySpec) (bool, error) {
+	return functions.RunSequentially(r.shouldRunInOrderMultiCluster(ctx, *mdb),
+		func() (bool, error) {
+			return r.deployMultiClusterAutomationConfig(ctx, *mdb, lastAppliedSpec)
+		},
+		func() (bool, error) {
+			return r.deployMultiClusterStatefulSets(ctx, mdb)
+		})
+}
+
+// resetMultiClusterUpdateStrategy resets the UpdateStrategy of the StatefulSets of every member cluster once a version
+// change is complete.
+func (r *ReplicaSetReconciler) resetMultiClusterUpdateStrategy(ctx context.Context, mdb mdbv1.MongoDBCommunity) error {
+	if !mdb.IsChangingVersion() {
+		return nil
+	}
+
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		memberClient, err := r.memberClusterClient(item.ClusterName)
+		if err != nil {
+			return err
+		}
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+		if _, err := statefulset.GetAndUpdate(ctx, memberClient, mdb.ClusterStatefulSetNamespacedName(clusterNum), func(sts *appsv1.StatefulSet) {
+			sts.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureMemberClustersFinalizer adds the MemberClustersFinalizer to the resource, so the resources of its member
+// clusters can be removed before it's deleted.
+func (r *ReplicaSetReconciler) ensureMemberClustersFinalizer(ctx context.Context, mdb *mdbv1.MongoDBCommunity) error {
+	if !controllerutil.AddFinalizer(mdb, mdbv1.MemberClustersFinalizer) {
+		return nil
+	}
+	return r.client.Update(ctx, mdb)
+}
+
+// finalizeMemberClusters removes the resources created in the member clusters of a resource being deleted, and then
+// its MemberClustersFinalizer so the deletion completes. The removal is retried until it succeeds.
+func (r *ReplicaSetReconciler) finalizeMemberClusters(ctx context.Context, mdb *mdbv1.MongoDBCommunity) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(mdb, mdbv1.MemberClustersFinalizer) {
+		return result.OK()
+	}
+
+	r.log.Infof("Removing the resources of the member clusters")
+	if err := r.cleanupMemberClusters(ctx, mdb.NamespacedName()); err != nil {
+		r.log.Errorf("Error removing the resources of the member clusters, retrying: %s", err)
+		return result.Retry(10)
+	}
+
+	controllerutil.RemoveFinalizer(mdb, mdbv1.MemberClustersFinalizer)
+	if err := r.client.Update(ctx, mdb); err != nil {
+		r.log.Errorf("Error removing the finalizer of the member clusters: %s", err)
+		return result.Failed()
+	}
+	return result.OK()
+}
+
+// cleanupMemberClusters removes the resources created in the member clusters for a deleted resource. Unlike the
+// resources of the operator's cluster, they aren't garbage collected through owner references.
+func (r *ReplicaSetReconciler) cleanupMemberClusters(ctx context.Context, name types.NamespacedName) error {
+	var errs []error
+	for clusterName, memberClient := range r.memberClusters.KubeClients() {
+		if err := r.deleteMemberClusterResources(ctx, memberClient, name); err != nil {
+			errs = append(errs, fmt.Errorf("member cluster %s: %w", clusterName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cleanupRemovedMemberClusters removes the resources created in the member clusters that were removed from
+// spec.clusterSpecList, once all of their members have been moved to the other clusters.
+func (r *ReplicaSetReconciler) cleanupRemovedMemberClusters(ctx context.Context, mdb mdbv1.MongoDBCommunity) {
+	// the clusters of the spec and the removed clusters that still have members
+	inUse := map[string]bool{}
+	for _, item := range mdb.Spec.ClusterSpecList {
+		inUse[item.ClusterName] = true
+	}
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		inUse[item.ClusterName] = inUse[item.ClusterName] || item.Members > 0
+	}
+
+	for clusterName, clusterNum := range mdb.ClusterMapping() {
+		if inUse[clusterName] {
+			continue
+		}
+		memberClient, ok := r.memberClusters.KubeClient(clusterName)
+		if !ok {
+			r.log.Warnf("Can't clean up the resources of removed member cluster %s as it isn't registered in the operator", clusterName)
+			continue
+		}
+		// the StatefulSet is deleted last, so it only remains if the previous cleanup didn't complete
+		if err := memberClient.Get(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum), &appsv1.StatefulSet{}); apiErrors.IsNotFound(err) {
+			continue
+		}
+		r.log.Infof("Removing the resources of removed member cluster %s", clusterName)
+		if err := r.deleteMemberClusterResources(ctx, memberClient, mdb.NamespacedName()); err != nil {
+			r.log.Warnf("Failed removing the resources of removed member cluster %s: %s", clusterName, err)
+		}
+	}
+}
+
+// deleteMemberClusterResources deletes the Services, Secrets and StatefulSet created in a member cluster for the
+// resource with the given name.
+func (r *ReplicaSetReconciler) deleteMemberClusterResources(ctx context.Context, memberClient k8sClient.Client, name types.NamespacedName) error {
+	options := []k8sClient.DeleteAllOfOption{k8sClient.InNamespace(name.Namespace), k8sClient.MatchingLabels(mdbv1.OwnerLabels(name))}
+	var errs []error
+	for _, obj := range []k8sClient.Object{&corev1.Service{}, &corev1.Secret{}, &appsv1.StatefulSet{}} {
+		if err := memberClient.DeleteAllOf(ctx, obj, options...); err != nil {
+			errs = append(errs, fmt.Errorf("failed deleting %T resources of %s: %w", obj, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// preserveReplicaSetMemberIds keeps the ids of the existing replica set members, as the members of a multi-cluster
+// deployment don't keep their position in the process list when the other clusters are scaled.
+func preserveReplicaSetMemberIds(currentAc automationconfig.AutomationConfig) automationconfig.Modification {
+	nextId := 0
+	existingIds := map[string]int{}
+	if len(currentAc.ReplicaSets) == 1 {
+		for _, member := range currentAc.ReplicaSets[0].Members {
+			existingIds[member.Host] = member.Id
+			if member.Id >= nextId {
+				nextId = member.Id + 1
+			}
+		}
+	}
+
+	return func(ac *automationconfig.AutomationConfig) {
+		if len(ac.ReplicaSets) != 1 {
+			return
+		}
+		for i, member := range ac.ReplicaSets[0].Members {
+			if id, ok := existingIds[member.Host]; ok {
+				ac.ReplicaSets[0].Members[i].Id = id
+			} else {
+				ac.ReplicaSets[0].Members[i].Id = nextId
+				nextId++
+			}
+		}
+	}
+}
+
+// multiClusterProcessModification names the processes after the pods of the member clusters, and uses the hostnames
+// of the Services of the pods.
+func multiClusterProcessModification(mdb mdbv1.MongoDBCommunity) func(int, *automationconfig.Process) {
+	var names, hostnames []string
+	for _, item := range mdb.ClusterMembersThisReconciliation() {
+		clusterNum, _ := mdb.ClusterNum(item.ClusterName)
+		stsName := mdb.ClusterStatefulSetNamespacedName(clusterNum).Name
+		for podNum, hostname := range mdb.ClusterHostnames(clusterNum, item.Members) {
+			names = append(names, fmt.Sprintf("%s-%d", stsName, podNum))
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	return func(i int, p *automationconfig.Process) {
+		if i < len(names) {
+			p.Name = names[i]
+			p.HostName = hostnames[i]
+		}
+	}
+}
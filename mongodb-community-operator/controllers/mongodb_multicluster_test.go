@@ -0,0 +1,329 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/container"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+)
+
+func newTestMultiClusterReplicaSet() mdbv1.MongoDBCommunity {
+	mdb := newTestReplicaSet()
+	mdb.Spec.ClusterSpecList = []mdbv1.ClusterSpecItem{
+		{ClusterName: "cluster-a", Members: 2},
+		{ClusterName: "cluster-b", Members: 1},
+	}
+	return mdb
+}
+
+func newTestMemberClusters() (*multicluster.Registry, map[string]k8sClient.Client) {
+	memberClients := map[string]k8sClient.Client{
+		"cluster-a": client.NewMockedClient(),
+		"cluster-b": client.NewMockedClient(),
+		"cluster-c": client.NewMockedClient(),
+	}
+	return multicluster.NewClientRegistry(memberClients), memberClients
+}
+
+func makeClusterStatefulSetReady(ctx context.Context, t *testing.T, c k8sClient.Client, name types.NamespacedName) {
+	sts := appsv1.StatefulSet{}
+	require.NoError(t, c.Get(ctx, name, &sts))
+	sts.Status.ReadyReplicas = *sts.Spec.Replicas
+	sts.Status.UpdatedReplicas = *sts.Spec.Replicas
+	require.NoError(t, c.Update(ctx, &sts))
+}
+
+func readMemberClusterAutomationConfig(ctx context.Context, t *testing.T, c k8sClient.Client, mdb mdbv1.MongoDBCommunity) automationconfig.AutomationConfig {
+	ac, err := automationconfig.ReadFromSecret(ctx, client.NewClient(c), types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace})
+	require.NoError(t, err)
+	return ac
+}
+
+func TestMultiCluster_ResourcesAreCreatedInMemberClusters(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestMultiClusterReplicaSet()
+	memberClusters, memberClients := newTestMemberClusters()
+
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Equal(t, map[string]int{"cluster-a": 0, "cluster-b": 1}, mdb.ClusterMapping())
+	assert.Equal(t, map[string]int{"cluster-a": 2, "cluster-b": 1}, mdb.Status.ClusterMembers)
+	assert.Equal(t, 3, mdb.Status.CurrentMongoDBMembers)
+	assert.Equal(t, "mongodb://my-rs-0-0-svc.my-ns.svc.cluster.local:27017,my-rs-0-1-svc.my-ns.svc.cluster.local:27017,my-rs-1-0-svc.my-ns.svc.cluster.local:27017/?replicaSet=my-rs", mdb.Status.MongoURI)
+
+	// the operator's cluster doesn't run any member
+	err = mgr.GetClient().Get(ctx, mdb.NamespacedName(), &appsv1.StatefulSet{})
+	assert.Error(t, err)
+
+	for clusterName, expectedMembers := range map[string]int{"cluster-a": 2, "cluster-b": 1} {
+		clusterNum, _ := mdb.ClusterNum(clusterName)
+		memberClient := memberClients[clusterName]
+
+		sts := appsv1.StatefulSet{}
+		require.NoError(t, memberClient.Get(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum), &sts))
+		assert.Equal(t, int32(expectedMembers), *sts.Spec.Replicas)
+		assert.Equal(t, mdb.ClusterServiceName(clusterNum), sts.Spec.ServiceName)
+		assert.Empty(t, sts.OwnerReferences)
+		assert.Equal(t, mdb.GetOwnerLabels()[mdbv1.LabelResourceOwner], sts.Labels[mdbv1.LabelResourceOwner])
+
+		agentContainer := container.GetByName(construct.AgentName, sts.Spec.Template.Spec.Containers)
+		require.NotNil(t, agentContainer)
+		assert.Contains(t, agentContainer.Command[len(agentContainer.Command)-1], "-overrideLocalHost=$(hostname)-svc.${POD_NAMESPACE}.svc.cluster.local")
+
+		svc := corev1.Service{}
+		require.NoError(t, memberClient.Get(ctx, types.NamespacedName{Name: mdb.ClusterServiceName(clusterNum), Namespace: mdb.Namespace}, &svc))
+		assert.Equal(t, "None", svc.Spec.ClusterIP)
+		for podNum := 0; podNum < expectedMembers; podNum++ {
+			require.NoError(t, memberClient.Get(ctx, types.NamespacedName{Name: mdb.PodServiceName(clusterNum, podNum), Namespace: mdb.Namespace}, &svc))
+			assert.Equal(t, mdb.ClusterStatefulSetNamespacedName(clusterNum).Name+"-"+string(rune('0'+podNum)), svc.Spec.Selector[podNameLabel])
+		}
+
+		ac := readMemberClusterAutomationConfig(ctx, t, memberClient, mdb)
+		assert.Len(t, ac.Processes, 3)
+	}
+
+	// the member clusters which are not part of the deployment are left untouched
+	err = memberClients["cluster-c"].Get(ctx, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace}, &corev1.Secret{})
+	assert.Error(t, err)
+
+	ac := readMemberClusterAutomationConfig(ctx, t, memberClients["cluster-b"], mdb)
+	var hostnames []string
+	for _, p := range ac.Processes {
+		hostnames = append(hostnames, p.HostName)
+	}
+	assert.Equal(t, []string{
+		"my-rs-0-0-svc.my-ns.svc.cluster.local",
+		"my-rs-0-1-svc.my-ns.svc.cluster.local",
+		"my-rs-1-0-svc.my-ns.svc.cluster.local",
+	}, hostnames)
+	assert.Equal(t, "my-rs-1-0", ac.ReplicaSets[0].Members[2].Host)
+}
+
+func TestMultiCluster_MembersAreMovedOneAtATime(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestMultiClusterReplicaSet()
+	memberClusters, memberClients := newTestMemberClusters()
+
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	initialIds := map[string]int{}
+	for _, member := range readMemberClusterAutomationConfig(ctx, t, memberClients["cluster-a"], mdb).ReplicaSets[0].Members {
+		initialIds[member.Host] = member.Id
+	}
+
+	// move a member from cluster-a to cluster-b
+	mdb.Spec.ClusterSpecList = []mdbv1.ClusterSpecItem{
+		{ClusterName: "cluster-a", Members: 1},
+		{ClusterName: "cluster-b", Members: 2},
+	}
+	require.NoError(t, mgr.GetClient().Update(ctx, &mdb))
+
+	res, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+	assert.True(t, res.Requeue || res.RequeueAfter > 0)
+
+	// the member is added to cluster-b before it's removed from cluster-a
+	sts := appsv1.StatefulSet{}
+	require.NoError(t, memberClients["cluster-b"].Get(ctx, mdb.ClusterStatefulSetNamespacedName(1), &sts))
+	assert.Equal(t, int32(2), *sts.Spec.Replicas)
+	require.NoError(t, memberClients["cluster-a"].Get(ctx, mdb.ClusterStatefulSetNamespacedName(0), &sts))
+	assert.Equal(t, int32(2), *sts.Spec.Replicas)
+
+	makeClusterStatefulSetReady(ctx, t, memberClients["cluster-b"], mdb.ClusterStatefulSetNamespacedName(1))
+	res, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+	assert.True(t, res.Requeue || res.RequeueAfter > 0)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Equal(t, map[string]int{"cluster-a": 2, "cluster-b": 2}, mdb.Status.ClusterMembers)
+	assert.Equal(t, 4, mdb.Status.CurrentMongoDBMembers)
+
+	ac := readMemberClusterAutomationConfig(ctx, t, memberClients["cluster-a"], mdb)
+	require.Len(t, ac.ReplicaSets[0].Members, 4)
+	for _, member := range ac.ReplicaSets[0].Members {
+		if id, ok := initialIds[member.Host]; ok {
+			assert.Equal(t, id, member.Id)
+		} else {
+			assert.Equal(t, "my-rs-1-1", member.Host)
+			assert.Equal(t, 3, member.Id)
+		}
+	}
+
+	for i := 0; i < 3 && mdb.Status.CurrentMongoDBMembers != 3; i++ {
+		makeClusterStatefulSetReady(ctx, t, memberClients["cluster-a"], mdb.ClusterStatefulSetNamespacedName(0))
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+		require.NoError(t, err)
+		require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	}
+
+	assert.Equal(t, map[string]int{"cluster-a": 1, "cluster-b": 2}, mdb.Status.ClusterMembers)
+	assert.Equal(t, 3, mdb.Status.CurrentMongoDBMembers)
+	require.NoError(t, memberClients["cluster-a"].Get(ctx, mdb.ClusterStatefulSetNamespacedName(0), &sts))
+	assert.Equal(t, int32(1), *sts.Spec.Replicas)
+
+	ac = readMemberClusterAutomationConfig(ctx, t, memberClients["cluster-b"], mdb)
+	var hosts []string
+	for _, member := range ac.ReplicaSets[0].Members {
+		hosts = append(hosts, member.Host)
+	}
+	assert.Equal(t, []string{"my-rs-0-0", "my-rs-1-0", "my-rs-1-1"}, hosts)
+	assert.Equal(t, 3, ac.ReplicaSets[0].Members[2].Id)
+}
+
+func TestMultiCluster_UnregisteredMemberCluster(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestMultiClusterReplicaSet()
+	mdb.Spec.ClusterSpecList[1].ClusterName = "unknown-cluster"
+	memberClusters, _ := newTestMemberClusters()
+
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+	assert.Contains(t, mdb.Status.Message, "member cluster unknown-cluster is not registered in the operator")
+}
+
+func TestMultiCluster_DeletedResourceIsCleanedUp(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestMultiClusterReplicaSet()
+	memberClusters, memberClients := newTestMemberClusters()
+
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.Contains(t, mdb.Finalizers, mdbv1.MemberClustersFinalizer)
+
+	// the resource is being deleted, the finalizer keeps it until the member clusters are cleaned up
+	now := metav1.Now()
+	mdb.DeletionTimestamp = &now
+	require.NoError(t, mgr.GetClient().Update(ctx, &mdb))
+	res, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	for clusterName, clusterNum := range map[string]int{"cluster-a": 0, "cluster-b": 1} {
+		memberClient := memberClients[clusterName]
+		err = memberClient.Get(ctx, mdb.ClusterStatefulSetNamespacedName(clusterNum), &appsv1.StatefulSet{})
+		assert.True(t, apiErrors.IsNotFound(err))
+		err = memberClient.Get(ctx, types.NamespacedName{Name: mdb.ClusterServiceName(clusterNum), Namespace: mdb.Namespace}, &corev1.Service{})
+		assert.True(t, apiErrors.IsNotFound(err))
+		err = memberClient.Get(ctx, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace}, &corev1.Secret{})
+		assert.True(t, apiErrors.IsNotFound(err))
+	}
+
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	assert.NotContains(t, mdb.Finalizers, mdbv1.MemberClustersFinalizer)
+}
+
+func TestMultiCluster_InvalidClusterSpecList(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		modify          func(*mdbv1.MongoDBCommunity)
+		expectedMessage string
+	}{
+		"Members don't add up": {
+			modify:          func(mdb *mdbv1.MongoDBCommunity) { mdb.Spec.Members = 5 },
+			expectedMessage: "the sum of the members of spec.clusterSpecList (3) has to be equal to spec.members (5)",
+		},
+		"Duplicate cluster": {
+			modify: func(mdb *mdbv1.MongoDBCommunity) {
+				mdb.Spec.ClusterSpecList[1].ClusterName = "cluster-a"
+			},
+			expectedMessage: "cluster cluster-a is declared twice or more in spec.clusterSpecList",
+		},
+		"Arbiters": {
+			modify: func(mdb *mdbv1.MongoDBCommunity) {
+				mdb.Spec.Arbiters = 1
+			},
+			expectedMessage: "arbiters are not supported when spec.clusterSpecList is set",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mdb := newTestMultiClusterReplicaSet()
+			tc.modify(&mdb)
+			memberClusters, _ := newTestMemberClusters()
+
+			mgr := client.NewManager(ctx, &mdb)
+			r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+			require.NoError(t, err)
+
+			require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+			assert.Equal(t, mdbv1.Failed, mdb.Status.Phase)
+			assert.Contains(t, mdb.Status.Message, tc.expectedMessage)
+		})
+	}
+}
+
+func TestMultiCluster_RemovedClusterIsCleanedUp(t *testing.T) {
+	ctx := context.Background()
+	mdb := newTestMultiClusterReplicaSet()
+	memberClusters, memberClients := newTestMemberClusters()
+
+	mgr := client.NewManager(ctx, &mdb)
+	r := NewReconciler(mgr, "fake-mongodbRepoUrl", "fake-mongodbImage", "ubi8", AgentImage, "fake-versionUpgradeHookImage", "fake-readinessProbeImage", memberClusters)
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+	assertReconciliationSuccessful(t, res, err)
+
+	// move the member of cluster-b to cluster-a
+	require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	mdb.Spec.ClusterSpecList = []mdbv1.ClusterSpecItem{{ClusterName: "cluster-a", Members: 3}}
+	require.NoError(t, mgr.GetClient().Update(ctx, &mdb))
+
+	for i := 0; i < 5 && mdb.Status.Phase != mdbv1.Running || mdb.Status.ClusterMembers["cluster-b"] > 0; i++ {
+		makeClusterStatefulSetReady(ctx, t, memberClients["cluster-a"], mdb.ClusterStatefulSetNamespacedName(0))
+		makeClusterStatefulSetReady(ctx, t, memberClients["cluster-b"], mdb.ClusterStatefulSetNamespacedName(1))
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: mdb.NamespacedName()})
+		require.NoError(t, err)
+		require.NoError(t, mgr.GetClient().Get(ctx, mdb.NamespacedName(), &mdb))
+	}
+	assert.Equal(t, mdbv1.Running, mdb.Status.Phase)
+	assert.Equal(t, 3, mdb.Status.CurrentMongoDBMembers)
+
+	// the StatefulSet, Services and Secrets of cluster-b are deleted, while the ones of cluster-a are kept
+	memberClient := memberClients["cluster-b"]
+	err = memberClient.Get(ctx, mdb.ClusterStatefulSetNamespacedName(1), &appsv1.StatefulSet{})
+	assert.True(t, apiErrors.IsNotFound(err))
+	for _, name := range []string{mdb.ClusterServiceName(1), mdb.PodServiceName(1, 0)} {
+		err = memberClient.Get(ctx, types.NamespacedName{Name: name, Namespace: mdb.Namespace}, &corev1.Service{})
+		assert.True(t, apiErrors.IsNotFound(err))
+	}
+	err = memberClient.Get(ctx, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace}, &corev1.Secret{})
+	assert.True(t, apiErrors.IsNotFound(err))
+
+	require.NoError(t, memberClients["cluster-a"].Get(ctx, mdb.ClusterStatefulSetNamespacedName(0), &appsv1.StatefulSet{}))
+	require.NoError(t, memberClients["cluster-a"].Get(ctx, types.NamespacedName{Name: mdb.AutomationConfigSecretName(), Namespace: mdb.Namespace}, &corev1.Secret{}))
+
+	// the index of the removed cluster isn't reused
+	assert.Equal(t, map[string]int{"cluster-a": 0, "cluster-b": 1}, mdb.ClusterMapping())
+}
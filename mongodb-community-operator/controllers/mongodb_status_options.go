@@ -146,6 +146,20 @@ func (o *optionBuilder) withStatefulSetArbiters(arbiters int) *optionBuilder {
 	return o
 }
 
+// withClusterMembers reports the number of members deployed in each member cluster.
+func (o *optionBuilder) withClusterMembers(clusterMembers []mdbv1.ClusterSpecItem) *optionBuilder {
+	o.options = append(o.options, clusterMembersOption{
+		clusterMembers: mdbv1.ClusterMembersStatus(clusterMembers),
+	})
+	return o
+}
+
+// withPVCsCleared removes the PVC resize progress from the status once the resize has been completed.
+func (o *optionBuilder) withPVCsCleared() *optionBuilder {
+	o.options = append(o.options, pvcsClearedOption{})
+	return o
+}
+
 func (o *optionBuilder) withMessage(severityLevel severity, msg string) *optionBuilder {
 	if apierrors.IsTransientMessage(msg) {
 		severityLevel = Debug
@@ -241,3 +255,25 @@ func (s statefulSetArbitersOption) ApplyOption(mdb *mdbv1.MongoDBCommunity) {
 func (s statefulSetArbitersOption) GetResult() (reconcile.Result, error) {
 	return result.OK()
 }
+
+type pvcsClearedOption struct{}
+
+func (p pvcsClearedOption) ApplyOption(mdb *mdbv1.MongoDBCommunity) {
+	mdb.Status.PVCs = nil
+}
+
+func (p pvcsClearedOption) GetResult() (reconcile.Result, error) {
+	return result.OK()
+}
+
+type clusterMembersOption struct {
+	clusterMembers map[string]int
+}
+
+func (c clusterMembersOption) ApplyOption(mdb *mdbv1.MongoDBCommunity) {
+	mdb.Status.ClusterMembers = c.clusterMembers
+}
+
+func (c clusterMembersOption) GetResult() (reconcile.Result, error) {
+	return result.OK()
+}
@@ -12,14 +12,16 @@ import (
 // OnlyOnSpecChange returns a set of predicates indicating
 // that reconciliations should only happen on changes to the Spec of the resource.
 // any other changes won't trigger a reconciliation. This allows us to freely update the annotations
-// of the resource without triggering unintentional reconciliations.
+// of the resource without triggering unintentional reconciliations. The deletion of a resource with finalizers
+// triggers a reconciliation too, so the finalizers can be removed.
 func OnlyOnSpecChange() predicate.Funcs {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldResource := e.ObjectOld.(*mdbv1.MongoDBCommunity)
 			newResource := e.ObjectNew.(*mdbv1.MongoDBCommunity)
 			specChanged := !reflect.DeepEqual(oldResource.Spec, newResource.Spec)
-			return specChanged
+			deletionStarted := oldResource.GetDeletionTimestamp().IsZero() && !newResource.GetDeletionTimestamp().IsZero()
+			return specChanged || deletionStarted
 		},
 	}
 }
@@ -29,6 +29,8 @@ import (
 	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	mdbstatus "github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
 	"github.com/mongodb/mongodb-kubernetes/controllers/searchcontroller"
 	mdbv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
@@ -48,6 +50,8 @@ import (
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/result"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/scale"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/status"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/pvcresize"
 	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
 )
 
@@ -64,16 +68,22 @@ func init() {
 	zap.ReplaceGlobals(logger)
 }
 
-func NewReconciler(mgr manager.Manager, mongodbRepoUrl, mongodbImage, mongodbImageType, agentImage, versionUpgradeHookImage, readinessProbeImage string) *ReplicaSetReconciler {
+// NewReconciler creates the MongoDBCommunity reconciler. memberClusters holds the member clusters the replica set
+// members can be distributed to, if nil, only single cluster deployments can be created.
+func NewReconciler(mgr manager.Manager, mongodbRepoUrl, mongodbImage, mongodbImageType, agentImage, versionUpgradeHookImage, readinessProbeImage string, memberClusters *multicluster.Registry) *ReplicaSetReconciler {
 	mgrClient := mgr.GetClient()
 	secretWatcher := watch.New()
 	configMapWatcher := watch.New()
+	if memberClusters == nil {
+		memberClusters = multicluster.NewRegistry()
+	}
 	return &ReplicaSetReconciler{
 		client:           kubernetesClient.NewClient(mgrClient),
 		scheme:           mgr.GetScheme(),
 		log:              zap.S(),
 		secretWatcher:    &secretWatcher,
 		configMapWatcher: &configMapWatcher,
+		memberClusters:   memberClusters,
 
 		mongodbRepoUrl:          mongodbRepoUrl,
 		mongodbImage:            mongodbImage,
@@ -115,6 +125,8 @@ type ReplicaSetReconciler struct {
 	log              *zap.SugaredLogger
 	secretWatcher    *watch.ResourceWatcher
 	configMapWatcher *watch.ResourceWatcher
+	// memberClusters holds the clients of the member clusters of multi-cluster deployments
+	memberClusters *multicluster.Registry
 
 	mongodbRepoUrl          string
 	mongodbImage            string
@@ -155,6 +167,10 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 	r.log = zap.S().With("ReplicaSet", request.NamespacedName)
 	r.log.Infof("Reconciling MongoDB")
 
+	if !mdb.GetDeletionTimestamp().IsZero() {
+		return r.finalizeMemberClusters(ctx, &mdb)
+	}
+
 	r.log.Debug("Validating MongoDB.Spec")
 	lastAppliedSpec, err := r.validateSpec(mdb)
 	if err != nil {
@@ -163,6 +179,20 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 			withFailedPhase())
 	}
 
+	if mdb.IsMultiCluster() {
+		r.log.Debug("Ensuring the member clusters are registered")
+		if err := r.ensureMemberClustersFinalizer(ctx, &mdb); err != nil {
+			return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
+				withMessage(Error, fmt.Sprintf("Error adding the finalizer of the member clusters: %s", err)).
+				withFailedPhase())
+		}
+		if err := r.ensureClusterMapping(ctx, &mdb); err != nil {
+			return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
+				withMessage(Error, fmt.Sprintf("Error configuring the member clusters: %s", err)).
+				withFailedPhase())
+		}
+	}
+
 	r.log.Debug("Ensuring the service exists")
 	if err := r.ensureService(ctx, mdb); err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
@@ -201,7 +231,7 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 			withFailedPhase())
 	}
 
-	ready, err := r.deployMongoDBReplicaSet(ctx, mdb, lastAppliedSpec)
+	ready, err := r.deployMongoDBReplicaSet(ctx, &mdb, lastAppliedSpec)
 	if err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMessage(Error, fmt.Sprintf("Error deploying MongoDB ReplicaSet: %s", err)).
@@ -215,7 +245,7 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	r.log.Debug("Resetting StatefulSet UpdateStrategy to RollingUpdate")
-	if err := statefulset.ResetUpdateStrategy(ctx, &mdb, r.client); err != nil {
+	if err := r.resetUpdateStrategy(ctx, mdb); err != nil {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMessage(Error, fmt.Sprintf("Error resetting StatefulSet UpdateStrategyType: %s", err)).
 			withFailedPhase())
@@ -224,6 +254,7 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 	if mdb.IsStillScaling() {
 		return status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 			withMongoDBMembers(mdb.AutomationConfigMembersThisReconciliation()).
+			withClusterMembers(mdb.ClusterMembersThisReconciliation()).
 			withMessage(Info, fmt.Sprintf("Performing scaling operation, currentMembers=%d, desiredMembers=%d",
 				mdb.CurrentReplicas(), mdb.DesiredReplicas())).
 			withStatefulSetReplicas(mdb.StatefulSetReplicasThisReconciliation()).
@@ -235,10 +266,12 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 	res, err := status.Update(ctx, r.client.Status(), &mdb, statusOptions().
 		withMongoURI(mdb.MongoURI()). // nolint:forbidigo
 		withMongoDBMembers(mdb.AutomationConfigMembersThisReconciliation()).
+		withClusterMembers(mdb.ClusterMembersThisReconciliation()).
 		withStatefulSetReplicas(mdb.StatefulSetReplicasThisReconciliation()).
 		withStatefulSetArbiters(mdb.StatefulSetArbitersThisReconciliation()).
 		withMongoDBArbiters(mdb.AutomationConfigArbitersThisReconciliation()).
 		withMessage(None, "").
+		withPVCsCleared().
 		withRunningPhase().
 		withVersion(mdb.GetMongoDBVersion()))
 	if err != nil {
@@ -246,6 +279,10 @@ func (r ReplicaSetReconciler) Reconcile(ctx context.Context, request reconcile.R
 		return res, err
 	}
 
+	if mdb.IsMultiCluster() {
+		r.cleanupRemovedMemberClusters(ctx, mdb)
+	}
+
 	if err := r.updateConnectionStringSecrets(ctx, mdb); err != nil { // nolint:forbidigo
 		r.log.Errorf("Could not update connection string secrets: %s", err)
 	}
@@ -335,15 +372,19 @@ func (r *ReplicaSetReconciler) ensurePrometheusTLSResources(ctx context.Context,
 // of Pods corresponding to the amount of expected arbiters.
 //
 // The returned boolean indicates that the StatefulSet is ready.
-func (r *ReplicaSetReconciler) deployStatefulSet(ctx context.Context, mdb mdbv1.MongoDBCommunity) (bool, error) {
+func (r *ReplicaSetReconciler) deployStatefulSet(ctx context.Context, mdb *mdbv1.MongoDBCommunity) (bool, error) {
 	r.log.Info("Creating/Updating StatefulSet")
-	if err := r.createOrUpdateStatefulSet(ctx, mdb, false); err != nil {
+	if updated, err := r.createOrUpdateStatefulSet(ctx, mdb, false); err != nil {
 		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
+	} else if !updated {
+		return false, nil
 	}
 
 	r.log.Info("Creating/Updating StatefulSet for Arbiters")
-	if err := r.createOrUpdateStatefulSet(ctx, mdb, true); err != nil {
+	if updated, err := r.createOrUpdateStatefulSet(ctx, mdb, true); err != nil {
 		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
+	} else if !updated {
+		return false, nil
 	}
 
 	currentSts, err := r.client.GetStatefulSet(ctx, mdb.NamespacedName())
@@ -437,16 +478,27 @@ func (r *ReplicaSetReconciler) shouldRunInOrder(ctx context.Context, mdb mdbv1.M
 // deployMongoDBReplicaSet will ensure that both the AutomationConfig secret and backing StatefulSet
 // have been successfully created. A boolean is returned indicating if the process is complete
 // and an error if there was one.
-func (r *ReplicaSetReconciler) deployMongoDBReplicaSet(ctx context.Context, mdb mdbv1.MongoDBCommunity, lastAppliedSpec *mdbv1.MongoDBCommunitySpec) (bool, error) {
-	return functions.RunSequentially(r.shouldRunInOrder(ctx, mdb),
+func (r *ReplicaSetReconciler) deployMongoDBReplicaSet(ctx context.Context, mdb *mdbv1.MongoDBCommunity, lastAppliedSpec *mdbv1.MongoDBCommunitySpec) (bool, error) {
+	if mdb.IsMultiCluster() {
+		return r.deployMultiClusterReplicaSet(ctx, mdb, lastAppliedSpec)
+	}
+	return functions.RunSequentially(r.shouldRunInOrder(ctx, *mdb),
 		func() (bool, error) {
-			return r.deployAutomationConfig(ctx, mdb, lastAppliedSpec)
+			return r.deployAutomationConfig(ctx, *mdb, lastAppliedSpec)
 		},
 		func() (bool, error) {
 			return r.deployStatefulSet(ctx, mdb)
 		})
 }
 
+// resetUpdateStrategy resets the UpdateStrategy of the StatefulSets to RollingUpdate once a version change is complete.
+func (r *ReplicaSetReconciler) resetUpdateStrategy(ctx context.Context, mdb mdbv1.MongoDBCommunity) error {
+	if mdb.IsMultiCluster() {
+		return r.resetMultiClusterUpdateStrategy(ctx, mdb)
+	}
+	return statefulset.ResetUpdateStrategy(ctx, &mdb, r.client)
+}
+
 // ensureService creates a Service unless it already exists.
 //
 // The Service definition is built from the `mdb` resource. If `isArbiter` is set to true, the Service
@@ -457,6 +509,10 @@ func (r *ReplicaSetReconciler) ensureService(ctx context.Context, mdb mdbv1.Mong
 		return err
 	}
 
+	if mdb.IsMultiCluster() {
+		return r.ensureMultiClusterServices(ctx, mdb, processPortManager)
+	}
+
 	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: mdb.ServiceName(), Namespace: mdb.Namespace}}
 	op, err := controllerutil.CreateOrUpdate(ctx, r.client, svc, func() error {
 		resourceVersion := svc.ResourceVersion // Save resourceVersion for later
@@ -483,7 +539,12 @@ func (r *ReplicaSetReconciler) createProcessPortManager(ctx context.Context, mdb
 		return nil, fmt.Errorf("could not read existing automation config: %s", err)
 	}
 
-	currentPodStates, err := agent.GetAllDesiredMembersAndArbitersPodState(ctx, mdb.NamespacedName(), r.client, mdb.StatefulSetReplicasThisReconciliation(), mdb.StatefulSetArbitersThisReconciliation(), currentAC.Version, r.log)
+	var currentPodStates []agent.PodState
+	if mdb.IsMultiCluster() {
+		currentPodStates, err = r.getMultiClusterPodStates(ctx, mdb, currentAC.Version)
+	} else {
+		currentPodStates, err = agent.GetAllDesiredMembersAndArbitersPodState(ctx, mdb.NamespacedName(), r.client, mdb.StatefulSetReplicasThisReconciliation(), mdb.StatefulSetArbitersThisReconciliation(), currentAC.Version, r.log)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("cannot get all pods goal state: %w", err)
 	}
@@ -491,7 +552,9 @@ func (r *ReplicaSetReconciler) createProcessPortManager(ctx context.Context, mdb
 	return agent.NewReplicaSetPortManager(r.log, mdb.Spec.AdditionalMongodConfig.GetDBPort(), currentPodStates, currentAC.Processes), nil
 }
 
-func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, mdb mdbv1.MongoDBCommunity, isArbiter bool) error {
+// createOrUpdateStatefulSet creates or updates the StatefulSet of the members or of the arbiters. The returned boolean
+// is false if the StatefulSet can't be updated yet because its persistent volumes are still being resized.
+func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, mdb *mdbv1.MongoDBCommunity, isArbiter bool) (bool, error) {
 	set := appsv1.StatefulSet{}
 
 	name := mdb.NamespacedName()
@@ -502,19 +565,39 @@ func (r *ReplicaSetReconciler) createOrUpdateStatefulSet(ctx context.Context, md
 	err := r.client.Get(ctx, name, &set)
 	err = k8sClient.IgnoreNotFound(err)
 	if err != nil {
-		return fmt.Errorf("error getting StatefulSet: %s", err)
+		return false, fmt.Errorf("error getting StatefulSet: %s", err)
 	}
 
 	mongodbImage := getMongoDBImage(r.mongodbRepoUrl, r.mongodbImage, r.mongodbImageType, mdb.GetMongoDBVersion())
-	buildStatefulSetModificationFunction(mdb, mongodbImage, r.agentImage, r.versionUpgradeHookImage, r.readinessProbeImage)(&set)
+	buildStatefulSetModificationFunction(*mdb, mongodbImage, r.agentImage, r.versionUpgradeHookImage, r.readinessProbeImage)(&set)
 	if isArbiter {
-		buildArbitersModificationFunction(mdb)(&set)
+		buildArbitersModificationFunction(*mdb)(&set)
+	}
+
+	// the size of the volume claim templates can't be changed in place, the PVCs are resized before the StatefulSet is recreated
+	workflowStatus := pvcresize.HandlePVCResize(ctx, r.client, &set, r.log)
+	if option, exists := mdbstatus.GetOption(workflowStatus.StatusOptions(), mdbstatus.PVCStatusOption{}); exists {
+		mdb.Status.PVCs.Merge(*option.(mdbstatus.PVCStatusOption).PVC)
+	}
+	if !workflowStatus.IsOK() {
+		if workflowStatus.Phase() == mdbstatus.PhaseFailed {
+			return false, fmt.Errorf("error resizing the persistent volumes: %s", workflowStatusMessage(workflowStatus))
+		}
+		r.log.Infof("Waiting for the persistent volumes of StatefulSet %s to be resized", name)
+		return false, nil
 	}
 
 	if _, err = statefulset.CreateOrUpdate(ctx, r.client, set); err != nil {
-		return fmt.Errorf("error creating/updating StatefulSet: %s", err)
+		return false, fmt.Errorf("error creating/updating StatefulSet: %s", err)
 	}
-	return nil
+	return true, nil
+}
+
+func workflowStatusMessage(workflowStatus workflow.Status) string {
+	if option, exists := mdbstatus.GetOption(workflowStatus.StatusOptions(), mdbstatus.MessageOption{}); exists {
+		return option.(mdbstatus.MessageOption).Message
+	}
+	return ""
 }
 
 // ensureAutomationConfig makes sure the AutomationConfig secret has been successfully created. The automation config
@@ -547,7 +630,7 @@ func buildAutomationConfig(mdb mdbv1.MongoDBCommunity, isEnterprise bool, auth a
 		acReplicaSetId = mdb.Spec.AutomationConfigOverride.ReplicaSet.Id
 	}
 
-	return automationconfig.NewBuilder().
+	builder := automationconfig.NewBuilder().
 		IsEnterprise(isEnterprise).
 		SetTopology(automationconfig.ReplicaSetTopology).
 		SetName(mdb.Name).
@@ -569,8 +652,14 @@ func buildAutomationConfig(mdb mdbv1.MongoDBCommunity, isEnterprise bool, auth a
 		AddModifications(modifications...).
 		AddProcessModification(func(_ int, p *automationconfig.Process) {
 			automationconfig.ConfigureAgentConfiguration(mdb.Spec.AgentConfiguration.SystemLog, mdb.Spec.AgentConfiguration.LogRotate, mdb.Spec.AgentConfiguration.AuditLogRotate, p)
-		}).
-		Build()
+		})
+
+	if mdb.IsMultiCluster() {
+		builder.AddProcessModification(multiClusterProcessModification(mdb)).
+			AddModifications(preserveReplicaSetMemberIds(currentAc))
+	}
+
+	return builder.Build()
 }
 
 func guessEnterprise(mdb mdbv1.MongoDBCommunity, mongodbImage string) bool {
@@ -22,6 +22,9 @@ func ValidateUpdate(mdb mdbv1.MongoDBCommunity, oldSpec mdbv1.MongoDBCommunitySp
 	if oldSpec.Security.TLS.Enabled && !mdb.Spec.Security.TLS.Enabled {
 		return errors.New("TLS can't be set to disabled after it has been enabled")
 	}
+	if (len(oldSpec.ClusterSpecList) > 0) != mdb.IsMultiCluster() {
+		return errors.New("a single cluster deployment can't be changed to a multi-cluster deployment or vice versa")
+	}
 	return validateSpec(mdb, log)
 }
 
@@ -47,6 +50,10 @@ func validateSpec(mdb mdbv1.MongoDBCommunity, log *zap.SugaredLogger) error {
 		return err
 	}
 
+	if err := validateClusterSpecList(mdb); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -207,3 +214,39 @@ func validateStatefulSet(mdb mdbv1.MongoDBCommunity) error {
 
 	return nil
 }
+
+// validateClusterSpecList checks that the members of a multi-cluster deployment are distributed across distinct
+// member clusters and add up to spec.members.
+func validateClusterSpecList(mdb mdbv1.MongoDBCommunity) error {
+	if !mdb.IsMultiCluster() {
+		return nil
+	}
+
+	clusterNames := map[string]struct{}{}
+	members := 0
+	for _, item := range mdb.Spec.ClusterSpecList {
+		if item.ClusterName == "" {
+			return fmt.Errorf("spec.clusterSpecList contains a cluster without a name")
+		}
+		if _, ok := clusterNames[item.ClusterName]; ok {
+			return fmt.Errorf("cluster %s is declared twice or more in spec.clusterSpecList", item.ClusterName)
+		}
+		if item.Members < 0 {
+			return fmt.Errorf("number of members of cluster %s must be greater or equal than 0", item.ClusterName)
+		}
+		clusterNames[item.ClusterName] = struct{}{}
+		members += item.Members
+	}
+
+	if members != mdb.Spec.Members {
+		return fmt.Errorf("the sum of the members of spec.clusterSpecList (%d) has to be equal to spec.members (%d)", members, mdb.Spec.Members)
+	}
+	if mdb.Spec.Arbiters > 0 {
+		return fmt.Errorf("arbiters are not supported when spec.clusterSpecList is set")
+	}
+	if mdb.Spec.StatefulSetConfiguration.SpecWrapper.Spec.Replicas != nil {
+		return fmt.Errorf("spec.statefulset.spec.replicas can't be set when spec.clusterSpecList is set")
+	}
+
+	return nil
+}
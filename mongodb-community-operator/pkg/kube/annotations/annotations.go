@@ -75,6 +75,44 @@ func SetAnnotations(ctx context.Context, object client.Object, annotations map[s
 	return nil
 }
 
+// RemoveAnnotations removes the annotations with the supplied keys from the object and from the object backed in kubernetes.
+// The keys which are not set are ignored.
+func RemoveAnnotations(ctx context.Context, object client.Object, keys []string, kubeClient client.Client) error {
+	currentObject := object.DeepCopyObject().(client.Object)
+	err := kubeClient.Get(ctx, types.NamespacedName{Name: object.GetName(), Namespace: object.GetNamespace()}, currentObject)
+	if err != nil {
+		return err
+	}
+
+	var payload []patchValue
+	for _, key := range keys {
+		if _, ok := currentObject.GetAnnotations()[key]; !ok {
+			continue
+		}
+		payload = append(payload, patchValue{
+			Op: "remove",
+			// every "/" in the value needs to be replaced with ~1 when patching
+			Path: "/metadata/annotations/" + strings.Replace(key, "/", "~1", 1),
+		})
+	}
+	if len(payload) == 0 {
+		object.SetAnnotations(currentObject.GetAnnotations())
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	patch := client.RawPatch(types.JSONPatchType, data)
+	if err = kubeClient.Patch(ctx, currentObject, patch); err != nil {
+		return err
+	}
+	object.SetAnnotations(currentObject.GetAnnotations())
+	return nil
+}
+
 func UpdateLastAppliedMongoDBVersion(ctx context.Context, mdb Versioned, kubeClient client.Client) error {
 	annotations := map[string]string{
 		LastAppliedMongoDBVersion: mdb.GetMongoDBVersionForAnnotation(),
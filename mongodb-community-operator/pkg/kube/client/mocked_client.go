@@ -9,11 +9,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -174,7 +176,19 @@ func makeStatefulSetReady(set *appsv1.StatefulSet) {
 	set.Status.ReadyReplicas = *set.Spec.Replicas
 }
 
-func (m mockedClient) List(_ context.Context, _ k8sClient.ObjectList, _ ...k8sClient.ListOption) error {
+// List is only implemented for PersistentVolumeClaims, other lists are left empty.
+func (m mockedClient) List(_ context.Context, list k8sClient.ObjectList, opts ...k8sClient.ListOption) error {
+	listOpts := k8sClient.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	switch v := list.(type) {
+	case *corev1.PersistentVolumeClaimList:
+		for _, obj := range m.ensureMapFor(&corev1.PersistentVolumeClaim{}) {
+			if listOpts.Namespace == "" || obj.GetNamespace() == listOpts.Namespace {
+				v.Items = append(v.Items, *obj.(*corev1.PersistentVolumeClaim).DeepCopy())
+			}
+		}
+	}
 	return nil
 }
 
@@ -185,7 +199,21 @@ func (m mockedClient) Delete(_ context.Context, obj k8sClient.Object, _ ...k8sCl
 	return nil
 }
 
-func (m mockedClient) DeleteAllOf(_ context.Context, _ k8sClient.Object, _ ...k8sClient.DeleteAllOfOption) error {
+// DeleteAllOf deletes the objects of the given type matching the namespace and the labels of the options.
+func (m mockedClient) DeleteAllOf(_ context.Context, obj k8sClient.Object, opts ...k8sClient.DeleteAllOfOption) error {
+	deleteAllOfOpts := k8sClient.DeleteAllOfOptions{}
+	deleteAllOfOpts.ApplyOptions(opts)
+
+	relevantMap := m.ensureMapFor(obj)
+	for key, existing := range relevantMap {
+		if deleteAllOfOpts.Namespace != "" && existing.GetNamespace() != deleteAllOfOpts.Namespace {
+			continue
+		}
+		if deleteAllOfOpts.LabelSelector != nil && !deleteAllOfOpts.LabelSelector.Matches(labels.Set(existing.GetLabels())) {
+			continue
+		}
+		delete(relevantMap, key)
+	}
 	return nil
 }
 
@@ -25,6 +25,7 @@ type Config struct {
 
 type ConfigSyncSource struct {
 	ReplicaSet               ConfigReplicaSet `json:"replicaSet"`
+	Router                   *ConfigRouter    `json:"router,omitempty"`
 	CertificateAuthorityFile *string          `json:"caFile,omitempty"`
 }
 
@@ -37,6 +38,15 @@ type ConfigReplicaSet struct {
 	AuthSource     *string  `json:"authSource,omitempty"`
 }
 
+// ConfigRouter configures the mongos routers mongot connects to when its sync source is a shard of a sharded cluster.
+type ConfigRouter struct {
+	HostAndPort  []string `json:"hostAndPort"`
+	Username     string   `json:"username"`
+	PasswordFile string   `json:"passwordFile"`
+	TLS          *bool    `json:"tls,omitempty"`
+	AuthSource   *string  `json:"authSource,omitempty"`
+}
+
 type ConfigStorage struct {
 	DataPath string `json:"dataPath"`
 }
@@ -82,8 +92,22 @@ type ConfigGrpcTLS struct {
 }
 
 type ConfigMetrics struct {
-	Enabled bool   `json:"enabled"`
-	Address string `json:"address"`
+	Enabled        bool                         `json:"enabled"`
+	Address        string                       `json:"address"`
+	TLS            *ConfigMetricsTLS            `json:"tls,omitempty"`
+	Authentication *ConfigMetricsAuthentication `json:"authentication,omitempty"`
+}
+
+type ConfigMetricsTLS struct {
+	Mode               ConfigTLSMode `json:"mode"`
+	CertificateKeyFile *string       `json:"certificateKeyFile,omitempty"`
+}
+
+// ConfigMetricsAuthentication configures HTTP Basic Auth for the metrics endpoint.
+type ConfigMetricsAuthentication struct {
+	Mode         string `json:"mode"`
+	Username     string `json:"username"`
+	PasswordFile string `json:"passwordFile"`
 }
 
 type ConfigHealthCheck struct {
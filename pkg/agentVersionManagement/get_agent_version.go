@@ -107,11 +107,16 @@ func InitializeAgentVersionManager(mappingFilePath string) (*AgentVersionManager
 	return newAgentVersionManager(m, cmVersion), nil
 }
 
+// MappingFilePath returns the path of the version mapping file (release.json) shipped with the operator.
+func MappingFilePath() string {
+	return env.ReadOrDefault(MappingFilePathEnv, mappingFileDefaultPath) // nolint:forbidigo
+}
+
 // GetAgentVersionManager returns the an instance of AgentVersionManager.
 func GetAgentVersionManager() (*AgentVersionManager, error) {
 	initializationMutex.Lock()
 	defer initializationMutex.Unlock()
-	mappingFilePath := env.ReadOrDefault(MappingFilePathEnv, mappingFileDefaultPath) // nolint:forbidigo
+	mappingFilePath := MappingFilePath()
 	if lastUsedMappingPath != mappingFilePath {
 		lastUsedMappingPath = mappingFilePath
 		var err error
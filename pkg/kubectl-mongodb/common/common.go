@@ -58,6 +58,10 @@ type Flags struct {
 	SourceCluster                 string
 	CreateServiceAccountSecrets   bool
 	ImagePullSecrets              string
+	ClusterDomain                 string
+	SkipDNSCheck                  bool
+	DNSProbeImage                 string
+	DNSProbeTimeout               time.Duration
 }
 
 const (
@@ -383,6 +387,10 @@ func getCentralRules() []rbacv1.PolicyRule {
 				"opsmanagers", "opsmanagers/finalizers", "opsmanagers/status",
 				"mongodb", "mongodb/finalizers", "mongodb/status",
 				"mongodbsearch", "mongodbsearch/finalizers", "mongodbsearch/status",
+				"mongodbsearchindexes", "mongodbsearchindexes/finalizers", "mongodbsearchindexes/status",
+				"mongodbalertconfigs", "mongodbalertconfigs/finalizers", "mongodbalertconfigs/status",
+				"mongodborganizations", "mongodborganizations/finalizers", "mongodborganizations/status",
+				"mongodbprojects", "mongodbprojects/finalizers", "mongodbprojects/status",
 			},
 			APIGroups: []string{"mongodb.com"},
 		},
@@ -446,7 +454,7 @@ func getMemberRules() []rbacv1.PolicyRule {
 			APIGroups: []string{""},
 		},
 		{
-			Verbs:     []string{"get", "list", "watch", "delete", "deletecollection"},
+			Verbs:     []string{"get", "list", "watch", "create", "delete", "deletecollection"},
 			Resources: []string{"pods"},
 			APIGroups: []string{""},
 		},
@@ -472,7 +480,7 @@ func buildMemberEntityClusterRole() rbacv1.ClusterRole {
 	})
 
 	rules = append(rules, rbacv1.PolicyRule{
-		Verbs:     []string{"get"},
+		Verbs:     []string{"get", "list"},
 		Resources: []string{"nodes"},
 		APIGroups: []string{""},
 	})
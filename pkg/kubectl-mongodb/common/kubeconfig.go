@@ -1,20 +1,25 @@
 package common
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"time"
 
 	"golang.org/x/xerrors"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 const (
-	kubeConfigEnv = "KUBECONFIG"
+	kubeConfigEnv         = "KUBECONFIG"
+	operatorClientTimeout = 10 * time.Second
 )
 
 // LoadKubeConfigFilePath returns the path of the local KubeConfig file.
@@ -70,6 +75,41 @@ func GetKubernetesClient(context, kubeConfigPath string) (KubeClient, error) {
 		return nil, xerrors.Errorf("failed to create client config: %w", err)
 	}
 
+	return newKubeClient(config)
+}
+
+// CreateOperatorClientMap creates the clients of the member clusters from the KubeConfig secret in the central
+// cluster, so they act with the same permissions as the operator. The member clusters without context in the
+// KubeConfig are left out.
+func CreateOperatorClientMap(ctx context.Context, centralClusterClient KubeClient, flags Flags) (map[string]KubeClient, error) {
+	secret, err := centralClusterClient.CoreV1().Secrets(flags.CentralClusterNamespace).Get(ctx, KubeConfigSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read the KubeConfig secret %s/%s: %w", flags.CentralClusterNamespace, KubeConfigSecretName, err)
+	}
+	kubeConfig, err := clientcmd.Load(secret.Data[KubeConfigSecretKey])
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load the KubeConfig from secret %s/%s: %w", flags.CentralClusterNamespace, KubeConfigSecretName, err)
+	}
+
+	clientMap := map[string]KubeClient{}
+	for _, cluster := range flags.MemberClusters {
+		if _, ok := kubeConfig.Contexts[cluster]; !ok {
+			continue
+		}
+		config, err := clientcmd.NewNonInteractiveClientConfig(*kubeConfig, cluster, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to create client config for cluster %s: %w", cluster, err)
+		}
+		// an unreachable member cluster must not block the checks of the other ones
+		config.Timeout = operatorClientTimeout
+		if clientMap[cluster], err = newKubeClient(config); err != nil {
+			return nil, err
+		}
+	}
+	return clientMap, nil
+}
+
+func newKubeClient(config *rest.Config) (KubeClient, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create kubernetes clientset: %w", err)
@@ -0,0 +1,373 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/dns"
+)
+
+// The checks performed by verify, in the order they are printed.
+const (
+	checkKubeConfig         = "kubeconfig context"
+	checkAPIServer          = "api server reachable"
+	checkNamespaces         = "namespaces exist"
+	checkMemberPermissions  = "member permissions"
+	checkCentralPermissions = "central permissions"
+	checkDNS                = "pod FQDN resolution"
+)
+
+const (
+	// verifyResourceName is the name the probe Services and Pods of the DNS check are derived from, like the
+	// Services of the pods of a MongoDBMultiCluster resource are derived from its name.
+	verifyResourceName = "mongodb-verify"
+	probeContainerName = "dns-probe"
+
+	resolvedMarker   = "RESOLVED"
+	unresolvedMarker = "UNRESOLVED"
+)
+
+var allChecks = []string{checkKubeConfig, checkAPIServer, checkNamespaces, checkMemberPermissions, checkCentralPermissions, checkDNS}
+
+// VerifyReport holds the results of the checks by check and cluster. A check without result for a cluster doesn't
+// apply to it.
+type VerifyReport struct {
+	Clusters []string
+	results  map[string]map[string]error
+}
+
+func newVerifyReport(flags Flags) *VerifyReport {
+	clusters := append([]string{}, flags.MemberClusters...)
+	if !slices.Contains(clusters, flags.CentralCluster) {
+		clusters = append(clusters, flags.CentralCluster)
+	}
+	return &VerifyReport{Clusters: clusters, results: map[string]map[string]error{}}
+}
+
+func (r *VerifyReport) add(check, cluster string, err error) {
+	if r.results[check] == nil {
+		r.results[check] = map[string]error{}
+	}
+	r.results[check][cluster] = err
+}
+
+// Result returns whether the check was performed for the cluster and its error, nil if the check passed.
+func (r *VerifyReport) Result(check, cluster string) (bool, error) {
+	err, ok := r.results[check][cluster]
+	return ok, err
+}
+
+// Failed returns true if any of the checks failed.
+func (r *VerifyReport) Failed() bool {
+	for _, clusterResults := range r.results {
+		for _, err := range clusterResults {
+			if err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Print writes the pass/fail matrix of the checks and clusters, followed by the details of the failed checks.
+func (r *VerifyReport) Print(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "CHECK\t%s\n", strings.Join(r.Clusters, "\t"))
+	var failures []string
+	for _, check := range allChecks {
+		if _, ok := r.results[check]; !ok {
+			continue
+		}
+		cells := make([]string, len(r.Clusters))
+		for i, cluster := range r.Clusters {
+			performed, err := r.Result(check, cluster)
+			switch {
+			case !performed:
+				cells[i] = "-"
+			case err != nil:
+				cells[i] = "FAIL"
+				failures = append(failures, fmt.Sprintf("%s [%s]: %s", cluster, check, err))
+			default:
+				cells[i] = "PASS"
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", check, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+
+	if len(failures) > 0 {
+		_, _ = fmt.Fprintln(out, "\nFailed checks:")
+		for _, failure := range failures {
+			_, _ = fmt.Fprintf(out, "  %s\n", failure)
+		}
+	}
+}
+
+// VerifyMultiClusterResources checks that the multicluster environment configured by setup works. The clientMap holds
+// the clients of the user for all clusters, the operatorClientMap the clients of the member clusters created from
+// the KubeConfig secret, which act with the permissions of the operator.
+func VerifyMultiClusterResources(ctx context.Context, flags Flags, clientMap map[string]KubeClient, operatorClientMap map[string]KubeClient) *VerifyReport {
+	report := newVerifyReport(flags)
+
+	for _, cluster := range flags.MemberClusters {
+		report.add(checkNamespaces, cluster, verifyNamespaces(ctx, clientMap[cluster], memberClusterNamespaces(flags)))
+
+		operatorClient, ok := operatorClientMap[cluster]
+		if !ok {
+			report.add(checkKubeConfig, cluster, xerrors.Errorf("no context for the cluster in the KubeConfig secret %s/%s", flags.CentralClusterNamespace, KubeConfigSecretName))
+			continue
+		}
+		report.add(checkKubeConfig, cluster, nil)
+
+		if _, err := operatorClient.Discovery().ServerVersion(); err != nil {
+			report.add(checkAPIServer, cluster, xerrors.Errorf("failed to reach the API server: %w", err))
+			continue
+		}
+		report.add(checkAPIServer, cluster, nil)
+
+		report.add(checkMemberPermissions, cluster, verifyPermissions(getMemberRules(), memberClusterNamespaces(flags), func(attributes *authorizationv1.ResourceAttributes) (bool, error) {
+			review := &authorizationv1.SelfSubjectAccessReview{Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes}}
+			result, err := operatorClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+			if err != nil {
+				return false, err
+			}
+			return result.Status.Allowed, nil
+		}))
+	}
+
+	centralClient := clientMap[flags.CentralCluster]
+	if !slices.Contains(flags.MemberClusters, flags.CentralCluster) {
+		report.add(checkNamespaces, flags.CentralCluster, verifyNamespaces(ctx, centralClient, []string{flags.CentralClusterNamespace}))
+	}
+	// the operator doesn't use the KubeConfig secret in the central cluster, but the token of its own ServiceAccount
+	centralRules := append(getCentralRules(), getMemberRules()...)
+	report.add(checkCentralPermissions, flags.CentralCluster, verifyPermissions(centralRules, memberClusterNamespaces(flags), func(attributes *authorizationv1.ResourceAttributes) (bool, error) {
+		review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               fmt.Sprintf("system:serviceaccount:%s:%s", flags.CentralClusterNamespace, flags.ServiceAccount),
+			Groups:             []string{"system:serviceaccounts", "system:serviceaccounts:" + flags.CentralClusterNamespace, "system:authenticated"},
+		}}
+		result, err := centralClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		return result.Status.Allowed, nil
+	}))
+
+	if !flags.SkipDNSCheck {
+		verifyDNS(ctx, flags, clientMap, report)
+	}
+
+	return report
+}
+
+// memberClusterNamespaces returns the namespaces setup grants the operator permissions in.
+func memberClusterNamespaces(flags Flags) []string {
+	if flags.CentralClusterNamespace == flags.MemberClusterNamespace {
+		return []string{flags.MemberClusterNamespace}
+	}
+	return []string{flags.MemberClusterNamespace, flags.CentralClusterNamespace}
+}
+
+func verifyNamespaces(ctx context.Context, c KubeClient, namespaces []string) error {
+	var missing []string
+	for _, namespace := range namespaces {
+		if _, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return xerrors.Errorf("failed to get namespace %s: %w", namespace, err)
+			}
+			missing = append(missing, namespace)
+		}
+	}
+	if len(missing) > 0 {
+		return xerrors.Errorf("missing namespaces: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// verifyPermissions checks that each verb of the rules is allowed on each resource in all the namespaces.
+func verifyPermissions(rules []rbacv1.PolicyRule, namespaces []string, isAllowed func(attributes *authorizationv1.ResourceAttributes) (bool, error)) error {
+	var denied []string
+	for _, namespace := range namespaces {
+		for _, rule := range rules {
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					resourceName, subresource, _ := strings.Cut(resource, "/")
+					for _, verb := range rule.Verbs {
+						allowed, err := isAllowed(&authorizationv1.ResourceAttributes{
+							Namespace:   namespace,
+							Verb:        verb,
+							Group:       group,
+							Resource:    resourceName,
+							Subresource: subresource,
+						})
+						if err != nil {
+							return xerrors.Errorf("failed to review access: %w", err)
+						}
+						if !allowed {
+							denied = append(denied, fmt.Sprintf("%s %s in %s", verb, qualifiedResource(resource, group), namespace))
+						}
+					}
+				}
+			}
+		}
+	}
+	if len(denied) > 0 {
+		return xerrors.Errorf("missing permissions: %s", strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+func qualifiedResource(resource, group string) string {
+	if group == "" {
+		return resource
+	}
+	return resource + "." + group
+}
+
+// verifyDNS checks that the FQDNs of the pods in each member cluster resolve from all member clusters. A probe
+// Service, named like the Service of the first pod of a MongoDBMultiCluster resource, is created in each member
+// cluster only, so the check passes only if the DNS or the service mesh resolves the names across clusters. The
+// names are resolved by a probe Pod in each member cluster.
+func verifyDNS(ctx context.Context, flags Flags, clientMap map[string]KubeClient, report *VerifyReport) {
+	namespace := flags.MemberClusterNamespace
+	var hostnames []string
+	for clusterIdx := range flags.MemberClusters {
+		hostnames = append(hostnames, dns.GetMultiServiceFQDN(verifyResourceName, namespace, clusterIdx, 0, flags.ClusterDomain))
+	}
+
+	defer deleteDNSProbes(context.WithoutCancel(ctx), flags, clientMap)
+
+	for clusterIdx, cluster := range flags.MemberClusters {
+		c := clientMap[cluster]
+		if _, err := c.CoreV1().Services(namespace).Create(ctx, buildProbeService(namespace, clusterIdx), metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			report.add(checkDNS, cluster, xerrors.Errorf("failed to create the probe service: %w", err))
+			continue
+		}
+		if _, err := c.CoreV1().Pods(namespace).Create(ctx, buildProbePod(namespace, clusterIdx, flags.DNSProbeImage, hostnames), metav1.CreateOptions{}); err != nil {
+			report.add(checkDNS, cluster, xerrors.Errorf("failed to create the probe pod: %w", err))
+		}
+	}
+
+	for clusterIdx, cluster := range flags.MemberClusters {
+		if performed, _ := report.Result(checkDNS, cluster); performed {
+			continue
+		}
+		report.add(checkDNS, cluster, waitForDNSProbe(ctx, clientMap[cluster], namespace, probePodName(clusterIdx), flags.DNSProbeTimeout, hostnames))
+	}
+}
+
+func waitForDNSProbe(ctx context.Context, c KubeClient, namespace, podName string, timeout time.Duration, hostnames []string) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return xerrors.Errorf("the probe pod %s didn't complete: %w", podName, err)
+	}
+
+	logs, err := c.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: probeContainerName}).Do(ctx).Raw()
+	if err != nil {
+		return xerrors.Errorf("failed to read the logs of the probe pod %s: %w", podName, err)
+	}
+	return parseDNSProbeLogs(string(logs), hostnames)
+}
+
+// parseDNSProbeLogs returns an error listing the hostnames the probe didn't resolve.
+func parseDNSProbeLogs(logs string, hostnames []string) error {
+	resolved := map[string]bool{}
+	for _, line := range strings.Split(logs, "\n") {
+		if hostname, found := strings.CutSuffix(strings.TrimSpace(line), " "+resolvedMarker); found {
+			resolved[hostname] = true
+		}
+	}
+
+	var unresolved []string
+	for _, hostname := range hostnames {
+		if !resolved[hostname] {
+			unresolved = append(unresolved, hostname)
+		}
+	}
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return xerrors.Errorf("failed to resolve %s", strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+func deleteDNSProbes(ctx context.Context, flags Flags, clientMap map[string]KubeClient) {
+	namespace := flags.MemberClusterNamespace
+	for clusterIdx, cluster := range flags.MemberClusters {
+		c := clientMap[cluster]
+		if err := c.CoreV1().Pods(namespace).Delete(ctx, probePodName(clusterIdx), metav1.DeleteOptions{GracePeriodSeconds: ptr.To[int64](0)}); err != nil && !errors.IsNotFound(err) {
+			fmt.Printf("failed to delete the probe pod in cluster %s: %s\n", cluster, err)
+		}
+		if err := c.CoreV1().Services(namespace).Delete(ctx, dns.GetMultiServiceName(verifyResourceName, clusterIdx, 0), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			fmt.Printf("failed to delete the probe service in cluster %s: %s\n", cluster, err)
+		}
+	}
+}
+
+func probePodName(clusterIdx int) string {
+	return fmt.Sprintf("%s-%s-%d", verifyResourceName, probeContainerName, clusterIdx)
+}
+
+func buildProbeService(namespace string, clusterIdx int) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dns.GetMultiServiceName(verifyResourceName, clusterIdx, 0),
+			Namespace: namespace,
+			Labels:    multiClusterLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "mongodb", Port: 27017}},
+		},
+	}
+}
+
+func buildProbePod(namespace string, clusterIdx int, image string, hostnames []string) *corev1.Pod {
+	script := fmt.Sprintf(`for host in "$@"; do if nslookup "$host" > /dev/null 2>&1; then echo "$host %s"; else echo "$host %s"; fi; done`, resolvedMarker, unresolvedMarker)
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      probePodName(clusterIdx),
+			Namespace: namespace,
+			Labels:    multiClusterLabels(),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:    probeContainerName,
+				Image:   image,
+				Command: append([]string{"sh", "-c", script, probeContainerName}, hostnames...),
+				// the restricted Pod Security Standard is satisfied, so the probe can run in any namespace
+				SecurityContext: &corev1.SecurityContext{
+					RunAsNonRoot:             ptr.To(true),
+					RunAsUser:                ptr.To[int64](65534),
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+			}},
+		},
+	}
+}
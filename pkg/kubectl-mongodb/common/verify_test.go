@@ -0,0 +1,177 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func verifyTestFlags() Flags {
+	return Flags{
+		MemberClusters:          []string{"member-cluster-0", "member-cluster-1"},
+		CentralCluster:          "central-cluster",
+		MemberClusterNamespace:  "member-namespace",
+		CentralClusterNamespace: "central-namespace",
+		ServiceAccount:          "test-service-account",
+		SkipDNSCheck:            true,
+	}
+}
+
+func namespaces(names ...string) []runtime.Object {
+	var objects []runtime.Object
+	for _, name := range names {
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return objects
+}
+
+// newReviewingClientset returns a fake clientset answering the access reviews with isAllowed.
+func newReviewingClientset(isAllowed func(attributes *authorizationv1.ResourceAttributes) bool, objects ...runtime.Object) *fake.Clientset {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = isAllowed(review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = isAllowed(review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+	return clientset
+}
+
+func allowAll(*authorizationv1.ResourceAttributes) bool {
+	return true
+}
+
+func verifyTestClientMaps(flags Flags, isAllowed func(attributes *authorizationv1.ResourceAttributes) bool) (map[string]KubeClient, map[string]KubeClient) {
+	clientMap := map[string]KubeClient{}
+	operatorClientMap := map[string]KubeClient{}
+	for _, cluster := range flags.MemberClusters {
+		clientMap[cluster] = NewKubeClientContainer(nil, fake.NewSimpleClientset(namespaces(flags.MemberClusterNamespace, flags.CentralClusterNamespace)...), nil)
+		operatorClientMap[cluster] = NewKubeClientContainer(nil, newReviewingClientset(isAllowed), nil)
+	}
+	clientMap[flags.CentralCluster] = NewKubeClientContainer(nil, newReviewingClientset(isAllowed, namespaces(flags.CentralClusterNamespace)...), nil)
+	return clientMap, operatorClientMap
+}
+
+func TestVerify_AllChecksPass(t *testing.T) {
+	flags := verifyTestFlags()
+	clientMap, operatorClientMap := verifyTestClientMaps(flags, allowAll)
+
+	report := VerifyMultiClusterResources(context.Background(), flags, clientMap, operatorClientMap)
+
+	assert.False(t, report.Failed())
+	assert.Equal(t, []string{"member-cluster-0", "member-cluster-1", "central-cluster"}, report.Clusters)
+	for _, cluster := range flags.MemberClusters {
+		for _, check := range []string{checkKubeConfig, checkAPIServer, checkNamespaces, checkMemberPermissions} {
+			performed, err := report.Result(check, cluster)
+			assert.True(t, performed, "%s in %s", check, cluster)
+			assert.NoError(t, err)
+		}
+		performed, _ := report.Result(checkCentralPermissions, cluster)
+		assert.False(t, performed)
+	}
+	performed, err := report.Result(checkCentralPermissions, "central-cluster")
+	assert.True(t, performed)
+	assert.NoError(t, err)
+
+	performed, _ = report.Result(checkDNS, "member-cluster-0")
+	assert.False(t, performed)
+}
+
+func TestVerify_ReportsFailures(t *testing.T) {
+	flags := verifyTestFlags()
+	denyPodDeletion := func(attributes *authorizationv1.ResourceAttributes) bool {
+		return !(attributes.Resource == "pods" && attributes.Verb == "delete" && attributes.Namespace == flags.MemberClusterNamespace)
+	}
+	clientMap, operatorClientMap := verifyTestClientMaps(flags, denyPodDeletion)
+	// member-cluster-1 is missing from the KubeConfig secret and its member namespace doesn't exist
+	delete(operatorClientMap, "member-cluster-1")
+	require.NoError(t, clientMap["member-cluster-1"].CoreV1().Namespaces().Delete(context.Background(), flags.MemberClusterNamespace, metav1.DeleteOptions{}))
+
+	report := VerifyMultiClusterResources(context.Background(), flags, clientMap, operatorClientMap)
+
+	assert.True(t, report.Failed())
+
+	_, err := report.Result(checkMemberPermissions, "member-cluster-0")
+	assert.EqualError(t, err, "missing permissions: delete pods in member-namespace")
+	_, err = report.Result(checkCentralPermissions, "central-cluster")
+	assert.EqualError(t, err, "missing permissions: delete pods in member-namespace")
+
+	_, err = report.Result(checkKubeConfig, "member-cluster-1")
+	assert.Error(t, err)
+	_, err = report.Result(checkNamespaces, "member-cluster-1")
+	assert.EqualError(t, err, "missing namespaces: member-namespace")
+	// the checks requiring the KubeConfig are not performed
+	performed, _ := report.Result(checkMemberPermissions, "member-cluster-1")
+	assert.False(t, performed)
+}
+
+func TestVerifyPermissions_Subresources(t *testing.T) {
+	rules := getCentralRules()[1:]
+	var reviewed []authorizationv1.ResourceAttributes
+	err := verifyPermissions(rules, []string{"ns"}, func(attributes *authorizationv1.ResourceAttributes) (bool, error) {
+		reviewed = append(reviewed, *attributes)
+		return attributes.Subresource != "status", nil
+	})
+
+	assert.EqualError(t, err, "missing permissions: * mongodbcommunity/status.mongodbcommunity.mongodb.com in ns")
+	assert.Contains(t, reviewed, authorizationv1.ResourceAttributes{Namespace: "ns", Verb: "*", Group: "mongodbcommunity.mongodb.com", Resource: "mongodbcommunity", Subresource: "finalizers"})
+
+	err = verifyPermissions(rules, []string{"ns"}, func(*authorizationv1.ResourceAttributes) (bool, error) {
+		return false, xerrors.New("forbidden")
+	})
+	assert.EqualError(t, err, "failed to review access: forbidden")
+}
+
+func TestParseDNSProbeLogs(t *testing.T) {
+	hostnames := []string{"a.ns.svc.cluster.local", "b.ns.svc.cluster.local", "c.ns.svc.cluster.local"}
+
+	logs := "a.ns.svc.cluster.local RESOLVED\nb.ns.svc.cluster.local RESOLVED\nc.ns.svc.cluster.local RESOLVED\n"
+	assert.NoError(t, parseDNSProbeLogs(logs, hostnames))
+
+	// a hostname missing from the logs counts as unresolved
+	logs = "a.ns.svc.cluster.local RESOLVED\nb.ns.svc.cluster.local UNRESOLVED\n"
+	assert.EqualError(t, parseDNSProbeLogs(logs, hostnames), "failed to resolve b.ns.svc.cluster.local, c.ns.svc.cluster.local")
+}
+
+func TestBuildProbePod(t *testing.T) {
+	pod := buildProbePod("ns", 1, "busybox:1.36", []string{"a", "b"})
+
+	assert.Equal(t, "mongodb-verify-dns-probe-1", pod.Name)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.Equal(t, []string{"dns-probe", "a", "b"}, pod.Spec.Containers[0].Command[3:])
+	assert.Equal(t, "mongodb-verify-1-0-svc", buildProbeService("ns", 1).Name)
+}
+
+func TestVerifyReport_Print(t *testing.T) {
+	report := newVerifyReport(Flags{MemberClusters: []string{"cluster-1", "cluster-2"}, CentralCluster: "cluster-1"})
+	report.add(checkAPIServer, "cluster-1", nil)
+	report.add(checkAPIServer, "cluster-2", xerrors.New("connection refused"))
+	report.add(checkCentralPermissions, "cluster-1", nil)
+
+	out := bytes.Buffer{}
+	report.Print(&out)
+
+	expected := `CHECK                 cluster-1  cluster-2
+api server reachable  PASS       FAIL
+central permissions   PASS       -
+
+Failed checks:
+  cluster-2 [api server reachable]: connection refused
+`
+	assert.Equal(t, expected, out.String())
+}
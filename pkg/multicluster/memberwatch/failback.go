@@ -0,0 +1,264 @@
+package memberwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+const (
+	// FailbackReason is the reason of the events recorded for each step of the failback of a resource
+	FailbackReason = "Failback"
+)
+
+// failbackStep is a single change of the failed clusters or of the cluster spec override made while the members are
+// moved back to the recovered clusters.
+type failbackStep struct {
+	// failedClusters are the clusters still marked as failed after the step
+	failedClusters []failedcluster.FailedCluster
+	// clusterSpecList is the cluster spec override after the step, it's nil once the distribution of
+	// spec.clusterSpecList is restored
+	clusterSpecList mdb.ClusterSpecList
+	message         string
+}
+
+// markHealth records since when the member cluster is healthy, which is used to detect the sustained recovery of the
+// failed clusters.
+func (m *MemberClusterHealthChecker) markHealth(clusterName string, healthy bool, now time.Time) {
+	if m.healthySince == nil {
+		m.healthySince = map[string]time.Time{}
+	}
+	if !healthy {
+		delete(m.healthySince, clusterName)
+		return
+	}
+	if _, ok := m.healthySince[clusterName]; !ok {
+		m.healthySince[clusterName] = now
+	}
+}
+
+// recoveredFor returns a function telling whether the member cluster has been healthy for at least the given time.
+func (m *MemberClusterHealthChecker) recoveredFor(stableFor time.Duration, now time.Time) func(clusterName string) bool {
+	return func(clusterName string) bool {
+		since, ok := m.healthySince[clusterName]
+		return ok && now.Sub(since) >= stableFor
+	}
+}
+
+// goalStateReached returns true if the resource reached the distribution of the members it currently targets, so the
+// next member can be moved.
+func goalStateReached(mrs mdbmulti.MongoDBMultiCluster) bool {
+	if mrs.Status.Phase != status.PhaseRunning {
+		return false
+	}
+	lastAchievedSpec, err := mrs.ReadLastAchievedSpec()
+	if err != nil || lastAchievedSpec == nil {
+		return false
+	}
+	return reachedMembers(lastAchievedSpec.ClusterSpecList, mrs.GetDesiredSpecList())
+}
+
+// reachedMembers returns true if each desired cluster was reconciled with the desired members, including the clusters
+// without members, and the other reconciled clusters have no members.
+func reachedMembers(reconciled, desired mdb.ClusterSpecList) bool {
+	for _, item := range desired {
+		reconciledItem := getClusterSpecItem(reconciled, item.ClusterName)
+		if reconciledItem == nil || reconciledItem.Members != item.Members {
+			return false
+		}
+	}
+	for _, item := range reconciled {
+		if getClusterSpecItem(desired, item.ClusterName) == nil && item.Members > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sameMembers returns true if the clusters have the same number of members, the clusters without members are
+// ignored.
+func sameMembers(clusters, other mdb.ClusterSpecList) bool {
+	return membersByCluster(clusters) == membersByCluster(other)
+}
+
+func membersByCluster(clusters mdb.ClusterSpecList) string {
+	members := map[string]int{}
+	for _, item := range clusters {
+		if item.Members > 0 {
+			members[item.ClusterName] = item.Members
+		}
+	}
+	// json sorts the keys of the maps
+	bytes, _ := json.Marshal(members)
+	return string(bytes)
+}
+
+// planFailback returns the next step moving the members back to the distribution of spec.clusterSpecList, or false
+// if there is nothing to do. The recovered clusters are first unmarked as failed and added without members, then a
+// single member is moved from the cluster with the most surplus members to the healthy cluster missing the most
+// members each time the resource reached its goal state. The cluster spec override is removed once the distribution
+// of spec.clusterSpecList is restored.
+func planFailback(mrs mdbmulti.MongoDBMultiCluster, healthy func(clusterName string) bool, recovered func(clusterName string) bool) (failbackStep, bool) {
+	if !mrs.IsFailbackEnabled() {
+		return failbackStep{}, false
+	}
+	failedClusters := readFailedClusterAnnotation(mrs.Annotations)
+	_, hasOverride := mdbmulti.HasClustersToFailOver(mrs.Annotations)
+	if len(failedClusters) == 0 && !hasOverride {
+		return failbackStep{}, false
+	}
+
+	current := currentClusterSpecList(mrs)
+	for n, failed := range failedClusters {
+		if !recovered(failed.ClusterName) {
+			continue
+		}
+		step := failbackStep{
+			failedClusters:  append(append([]failedcluster.FailedCluster{}, failedClusters[:n]...), failedClusters[n+1:]...),
+			clusterSpecList: current,
+			message:         fmt.Sprintf("Cluster %s recovered, it is not marked as failed anymore", failed.ClusterName),
+		}
+		if hasOverride && getClusterSpecItem(current, failed.ClusterName) == nil {
+			if item := getClusterSpecItem(mrs.Spec.ClusterSpecList, failed.ClusterName); item != nil {
+				recoveredItem := *item.DeepCopy()
+				recoveredItem.Members = 0
+				step.clusterSpecList = append(step.clusterSpecList, recoveredItem)
+			}
+		}
+		if !hasOverride {
+			step.clusterSpecList = nil
+		}
+		return step, true
+	}
+	// the members are moved one at a time, once the previous move is completed
+	if !hasOverride || !goalStateReached(mrs) {
+		return failbackStep{}, false
+	}
+
+	failedClusterNames := map[string]struct{}{}
+	for _, failed := range failedClusters {
+		failedClusterNames[failed.ClusterName] = struct{}{}
+	}
+	from, to := -1, -1
+	mostSurplus, mostMissing := 0, 0
+	for n, item := range current {
+		if _, failed := failedClusterNames[item.ClusterName]; failed {
+			continue
+		}
+		original := 0
+		if originalItem := getClusterSpecItem(mrs.Spec.ClusterSpecList, item.ClusterName); originalItem != nil {
+			original = originalItem.Members
+		}
+		if surplus := item.Members - original; surplus > mostSurplus {
+			from, mostSurplus = n, surplus
+		}
+		if missing := original - item.Members; missing > mostMissing && healthy(item.ClusterName) {
+			to, mostMissing = n, missing
+		}
+	}
+
+	if from >= 0 && to >= 0 {
+		current[from].Members -= 1
+		current[to].Members += 1
+		return failbackStep{
+			failedClusters:  failedClusters,
+			clusterSpecList: current,
+			message:         fmt.Sprintf("Moving a member back from cluster %s to cluster %s", current[from].ClusterName, current[to].ClusterName),
+		}, true
+	}
+
+	if len(failedClusters) == 0 && sameMembers(current, mrs.Spec.ClusterSpecList) {
+		return failbackStep{message: "The members are distributed as in spec.clusterSpecList again, failback completed"}, true
+	}
+	return failbackStep{}, false
+}
+
+func getClusterSpecItem(clusters mdb.ClusterSpecList, clusterName string) *mdb.ClusterSpecItem {
+	for n := range clusters {
+		if clusters[n].ClusterName == clusterName {
+			return &clusters[n]
+		}
+	}
+	return nil
+}
+
+// applyFailbackStep updates the failed clusters and the cluster spec override annotations of the resource.
+func applyFailbackStep(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, step failbackStep, client kubernetesClient.Client) error {
+	annotationsToSet := map[string]string{}
+	var annotationsToRemove []string
+
+	if len(step.failedClusters) > 0 {
+		failedClustersBytes, err := json.Marshal(step.failedClusters)
+		if err != nil {
+			return err
+		}
+		annotationsToSet[failedcluster.FailedClusterAnnotation] = string(failedClustersBytes)
+	} else {
+		annotationsToRemove = append(annotationsToRemove, failedcluster.FailedClusterAnnotation)
+	}
+
+	if step.clusterSpecList != nil {
+		clusterSpecBytes, err := json.Marshal(step.clusterSpecList)
+		if err != nil {
+			return err
+		}
+		annotationsToSet[failedcluster.ClusterSpecOverrideAnnotation] = string(clusterSpecBytes)
+	} else {
+		annotationsToRemove = append(annotationsToRemove, failedcluster.ClusterSpecOverrideAnnotation)
+	}
+
+	if len(annotationsToSet) > 0 {
+		if err := annotations.SetAnnotations(ctx, &mrs, annotationsToSet, client); err != nil {
+			return err
+		}
+	}
+	return annotations.RemoveAnnotations(ctx, &mrs, annotationsToRemove, client)
+}
+
+// failback moves the members of the resource back to its recovered clusters, one step at a time.
+func (m *MemberClusterHealthChecker) failback(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, healthy map[string]bool, now time.Time, centralClient kubernetesClient.Client, log *zap.SugaredLogger) bool {
+	step, ok := planFailback(mrs, func(clusterName string) bool { return healthy[clusterName] }, m.recoveredFor(mrs.GetFailbackStableFor(), now))
+	if !ok {
+		return false
+	}
+
+	log.Infof("Failback of resource %s: %s", mrs.Name, step.message)
+	if m.Recorder != nil {
+		m.Recorder.Event(&mrs, corev1.EventTypeNormal, FailbackReason, step.message)
+	}
+	if err := applyFailbackStep(ctx, mrs, step, centralClient); err != nil {
+		log.Errorf("Failed to update the failover annotations of resource %s: %s", mrs.Name, err)
+		return false
+	}
+	return true
+}
+
+// failbackResources moves the members of the resources back to their recovered clusters and returns the resources
+// which were updated. Each resource is read again first, as the failover of another cluster in the same health check
+// may have changed its annotations since the resources were listed.
+func (m *MemberClusterHealthChecker) failbackResources(ctx context.Context, resources []mdbmulti.MongoDBMultiCluster, healthy map[string]bool, now time.Time, centralClient kubernetesClient.Client, log *zap.SugaredLogger) []mdbmulti.MongoDBMultiCluster {
+	var updated []mdbmulti.MongoDBMultiCluster
+	for _, listed := range resources {
+		mrs := mdbmulti.MongoDBMultiCluster{}
+		if err := centralClient.Get(ctx, listed.ObjectKey(), &mrs); err != nil {
+			log.Errorf("Failed to read resource %s before its failback: %s", listed.Name, err)
+			continue
+		}
+		if m.failback(ctx, mrs, healthy, now, centralClient, log) {
+			updated = append(updated, mrs)
+		}
+	}
+	return updated
+}
@@ -0,0 +1,194 @@
+package memberwatch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newFailbackTestResource(t *testing.T) mdbmulti.MongoDBMultiCluster {
+	mrs := newFailoverTestResource(&mdbmulti.FailoverPolicy{Failback: &mdbmulti.FailbackPolicy{Enabled: true}})
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "cluster-a", Members: 2},
+		{ClusterName: "cluster-b", Members: 1},
+		{ClusterName: "cluster-c", Members: 1},
+	}
+
+	// cluster-a failed and its members were moved to the other clusters
+	plan := planFailover(mrs, "cluster-a", nil)
+	override, err := json.Marshal(plan.clusterSpecList)
+	require.NoError(t, err)
+	mrs.Annotations = map[string]string{
+		failedcluster.FailedClusterAnnotation:       `[{"ClusterName":"cluster-a","Members":2}]`,
+		failedcluster.ClusterSpecOverrideAnnotation: string(override),
+	}
+	reachGoalState(t, &mrs)
+	return mrs
+}
+
+// reachGoalState simulates the reconciliation of the resource reaching the members of the cluster spec override.
+func reachGoalState(t *testing.T, mrs *mdbmulti.MongoDBMultiCluster) {
+	lastAchievedSpec := mrs.Spec
+	lastAchievedSpec.ClusterSpecList = mrs.GetDesiredSpecList()
+	lastAchievedSpecBytes, err := json.Marshal(lastAchievedSpec)
+	require.NoError(t, err)
+	mrs.Annotations[util.LastAchievedSpec] = string(lastAchievedSpecBytes)
+	mrs.Status.Phase = status.PhaseRunning
+}
+
+// applyStepInMemory applies the failback step to the annotations of the resource.
+func applyStepInMemory(t *testing.T, mrs *mdbmulti.MongoDBMultiCluster, step failbackStep) {
+	delete(mrs.Annotations, failedcluster.FailedClusterAnnotation)
+	delete(mrs.Annotations, failedcluster.ClusterSpecOverrideAnnotation)
+	if len(step.failedClusters) > 0 {
+		failedClustersBytes, err := json.Marshal(step.failedClusters)
+		require.NoError(t, err)
+		mrs.Annotations[failedcluster.FailedClusterAnnotation] = string(failedClustersBytes)
+	}
+	if step.clusterSpecList != nil {
+		overrideBytes, err := json.Marshal(step.clusterSpecList)
+		require.NoError(t, err)
+		mrs.Annotations[failedcluster.ClusterSpecOverrideAnnotation] = string(overrideBytes)
+	}
+}
+
+func allHealthy(string) bool { return true }
+
+func TestPlanFailback_Disabled(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+	mrs.Spec.Failover.Failback.Enabled = false
+
+	_, ok := planFailback(mrs, allHealthy, allHealthy)
+	assert.False(t, ok)
+}
+
+func TestPlanFailback_WaitsForSustainedRecovery(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+	checker := MemberClusterHealthChecker{}
+	now := time.Now()
+
+	checker.markHealth("cluster-a", true, now)
+	_, ok := planFailback(mrs, allHealthy, checker.recoveredFor(mrs.GetFailbackStableFor(), now.Add(time.Minute)))
+	assert.False(t, ok)
+
+	// a failed health check resets the recovery
+	checker.markHealth("cluster-a", false, now.Add(time.Minute))
+	checker.markHealth("cluster-a", true, now.Add(2*time.Minute))
+	_, ok = planFailback(mrs, allHealthy, checker.recoveredFor(mrs.GetFailbackStableFor(), now.Add(6*time.Minute)))
+	assert.False(t, ok)
+
+	step, ok := planFailback(mrs, allHealthy, checker.recoveredFor(mrs.GetFailbackStableFor(), now.Add(7*time.Minute)))
+	assert.True(t, ok)
+	assert.Empty(t, step.failedClusters)
+	assert.Equal(t, map[string]int{"cluster-a": 0, "cluster-b": 2, "cluster-c": 2}, plannedMembers(failoverPlan{clusterSpecList: step.clusterSpecList}))
+}
+
+func TestPlanFailback_MovesOneMemberAtATime(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+
+	step, ok := planFailback(mrs, allHealthy, allHealthy)
+	require.True(t, ok)
+	applyStepInMemory(t, &mrs, step)
+
+	var moves []map[string]int
+	for i := 0; i < 10; i++ {
+		// the next member is only moved once the resource reached the goal state
+		_, ok := planFailback(mrs, allHealthy, allHealthy)
+		require.False(t, ok)
+		reachGoalState(t, &mrs)
+
+		step, ok := planFailback(mrs, allHealthy, allHealthy)
+		require.True(t, ok)
+		applyStepInMemory(t, &mrs, step)
+		mrs.Status.Phase = status.PhasePending
+		if step.clusterSpecList == nil {
+			break
+		}
+		moves = append(moves, plannedMembers(failoverPlan{clusterSpecList: step.clusterSpecList}))
+	}
+
+	assert.Equal(t, []map[string]int{
+		{"cluster-a": 1, "cluster-b": 1, "cluster-c": 2},
+		{"cluster-a": 2, "cluster-b": 1, "cluster-c": 1},
+	}, moves)
+	_, hasOverride := mdbmulti.HasClustersToFailOver(mrs.Annotations)
+	assert.False(t, hasOverride)
+	assert.NotContains(t, mrs.Annotations, failedcluster.FailedClusterAnnotation)
+}
+
+func TestPlanFailback_DoesNotMoveToUnhealthyClusters(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+	step, ok := planFailback(mrs, allHealthy, allHealthy)
+	require.True(t, ok)
+	applyStepInMemory(t, &mrs, step)
+	reachGoalState(t, &mrs)
+
+	_, ok = planFailback(mrs, func(clusterName string) bool { return clusterName != "cluster-a" }, allHealthy)
+	assert.False(t, ok)
+}
+
+func TestPlanFailback_Freeze(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+	mrs.Spec.Failover.Strategy = mdbmulti.FailoverStrategyFreeze
+	delete(mrs.Annotations, failedcluster.ClusterSpecOverrideAnnotation)
+	// the resource is failed while a cluster is frozen
+	mrs.Status.Phase = status.PhaseFailed
+
+	step, ok := planFailback(mrs, allHealthy, allHealthy)
+
+	assert.True(t, ok)
+	assert.Empty(t, step.failedClusters)
+	assert.Nil(t, step.clusterSpecList)
+}
+
+func TestApplyFailbackStep(t *testing.T) {
+	ctx := context.Background()
+	mrs := newFailbackTestResource(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&mrs).Build()
+
+	require.NoError(t, applyFailbackStep(ctx, mrs, failbackStep{}, kubernetesClient.NewClient(fakeClient)))
+
+	updated := mdbmulti.MongoDBMultiCluster{}
+	require.NoError(t, fakeClient.Get(ctx, mrs.ObjectKey(), &updated))
+	assert.NotContains(t, updated.Annotations, failedcluster.FailedClusterAnnotation)
+	assert.NotContains(t, updated.Annotations, failedcluster.ClusterSpecOverrideAnnotation)
+	assert.Contains(t, updated.Annotations, util.LastAchievedSpec)
+}
+
+func TestFailbackResources_FailoverInTheSameHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	listed := newFailbackTestResource(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&listed).Build()
+	client := kubernetesClient.NewClient(fakeClient)
+
+	// cluster-a recovered a while ago, and cluster-b fails in the same health check
+	now := time.Now()
+	m := &MemberClusterHealthChecker{}
+	m.markHealth("cluster-a", true, now.Add(-time.Hour))
+	m.markHealth("cluster-b", false, now)
+	m.markHealth("cluster-c", true, now.Add(-time.Hour))
+	healthy := map[string]bool{"cluster-a": true, "cluster-b": false, "cluster-c": true}
+	require.NoError(t, applyFailoverPlan(ctx, listed, planFailover(listed, "cluster-b", nil), client))
+
+	updated := m.failbackResources(ctx, []mdbmulti.MongoDBMultiCluster{listed}, healthy, now, client, zap.S())
+
+	// cluster-a is unmarked as failed without undoing the failover of cluster-b
+	require.Len(t, updated, 1)
+	current := mdbmulti.MongoDBMultiCluster{}
+	require.NoError(t, fakeClient.Get(ctx, listed.ObjectKey(), &current))
+	assert.Equal(t, []failedcluster.FailedCluster{{ClusterName: "cluster-b", Members: 1}}, readFailedClusterAnnotation(current.Annotations))
+	assert.Equal(t, map[string]int{"cluster-a": 0, "cluster-c": 4}, plannedMembers(failoverPlan{clusterSpecList: current.GetDesiredSpecList()}))
+}
@@ -0,0 +1,330 @@
+package memberwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	memberclusterv1 "github.com/mongodb/mongodb-kubernetes/api/v1/membercluster"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/timeutil"
+)
+
+const (
+	// FailoverPlannedReason is the reason of the event recorded when the failover plan of a resource is chosen
+	FailoverPlannedReason = "FailoverPlanned"
+
+	// maxVotingMembers is the maximum number of voting members of a replica set
+	maxVotingMembers = 7
+)
+
+// clusterLocation is the region and zone of a member cluster.
+type clusterLocation struct {
+	region string
+	zone   string
+}
+
+// failoverPlan is the redistribution of the members of a failed cluster chosen with the failover policy of the
+// resource.
+type failoverPlan struct {
+	clusterName string
+	strategy    mdbmulti.FailoverStrategy
+	// clusterSpecList is the clusterSpecList after the failover, it's nil if the members are not moved
+	clusterSpecList mdb.ClusterSpecList
+	message         string
+}
+
+// status returns the plan as the failover status of the resource.
+func (p failoverPlan) status(mrs mdbmulti.MongoDBMultiCluster) mdbmulti.FailoverStatus {
+	failoverStatus := mdbmulti.FailoverStatus{
+		Strategy:  p.strategy,
+		Message:   p.message,
+		PlannedAt: timeutil.Now(),
+	}
+	for _, failedCluster := range readFailedClusterAnnotation(mrs.Annotations) {
+		failoverStatus.FailedClusters = append(failoverStatus.FailedClusters, failedCluster.ClusterName)
+	}
+	failoverStatus.FailedClusters = append(failoverStatus.FailedClusters, p.clusterName)
+	for _, item := range p.clusterSpecList {
+		failoverStatus.Members = append(failoverStatus.Members, mdbmulti.FailoverClusterMembers{ClusterName: item.ClusterName, Members: item.Members})
+	}
+	return failoverStatus
+}
+
+// planFailover chooses the clusters the members of the failed cluster are moved to. The members are moved to the
+// healthy preferred targets of the failed cluster if there are any, otherwise the failover strategy is applied.
+// The clusters already marked as failed are never chosen.
+func planFailover(mrs mdbmulti.MongoDBMultiCluster, clusterName string, locations map[string]clusterLocation) failoverPlan {
+	plan := failoverPlan{clusterName: clusterName, strategy: mrs.GetFailoverStrategy()}
+	if plan.strategy == mdbmulti.FailoverStrategyFreeze {
+		plan.message = fmt.Sprintf("Cluster %s is marked as failed, its members are not moved because the failover strategy is %s", clusterName, plan.strategy)
+		return plan
+	}
+
+	clusters := currentClusterSpecList(mrs)
+	failed := mdb.ClusterSpecItem{ClusterName: clusterName}
+	if item := getClusterSpecItem(clusters, clusterName); item != nil {
+		failed = *item.DeepCopy()
+	}
+	clusters = removeCluster(clusters, clusterName)
+
+	failedClusters := map[string]struct{}{}
+	for _, failedCluster := range readFailedClusterAnnotation(mrs.Annotations) {
+		failedClusters[failedCluster.ClusterName] = struct{}{}
+	}
+	var healthy []int
+	for n, c := range clusters {
+		if _, failed := failedClusters[c.ClusterName]; !failed {
+			healthy = append(healthy, n)
+		}
+	}
+	if len(healthy) == 0 {
+		plan.message = fmt.Sprintf("Cluster %s is marked as failed, its members are not moved because there is no healthy cluster to move them to", clusterName)
+		return plan
+	}
+
+	targets, reason := failoverTargets(mrs, clusters, healthy, clusterName, plan.strategy, locations)
+	before := clusters.DeepCopy()
+	nonVoting := moveMembers(clusters, failed, targets, locations)
+
+	plan.clusterSpecList = clusters
+	plan.message = fmt.Sprintf("Moving %d members of failed cluster %s %s: %s", failed.Members, clusterName, reason, describeMovedMembers(before, clusters))
+	if nonVoting > 0 {
+		plan.message += fmt.Sprintf(". %d of them are made non-voting to keep the replica set within %d voting members and the majority of the votes in its region", nonVoting, maxVotingMembers)
+	}
+	return plan
+}
+
+// moveMembers adds the members of the failed cluster one by one to the target cluster with the fewest members. Each
+// member carries its memberConfig entry along, so its votes, priority and tags are kept. A moved member is made
+// non-voting if its votes would take the replica set over maxVotingMembers voting members, or would take the majority
+// of the votes away from the region which had it before the failover. It returns the number of members made non-voting.
+func moveMembers(clusters mdb.ClusterSpecList, failed mdb.ClusterSpecItem, targets []int, locations map[string]clusterLocation) int {
+	majorityRegion := regionWithMajorityOfVotes(append(clusters.DeepCopy(), failed), locations)
+	votes := votesByRegion(clusters, locations)
+	total := 0
+	for _, v := range votes {
+		total += v
+	}
+
+	nonVoting := 0
+	for i := 0; i < failed.Members; i++ {
+		n := clusterWithMinimumMembersOf(clusters, targets)
+		option := memberOption(failed, i)
+		region := locations[clusters[n].ClusterName].region
+		if v := option.GetVotes(); v > 0 {
+			losesMajority := majorityRegion != "" && region != majorityRegion && votes[majorityRegion]*2 <= total+v
+			if total+v > maxVotingMembers || losesMajority {
+				option.Votes = ptr.To(0)
+				option.Priority = ptr.To("0")
+				nonVoting++
+			} else {
+				votes[region] += v
+				total += v
+			}
+		}
+		if !isDefaultMemberOption(option) || len(clusters[n].MemberConfig) > 0 {
+			clusters[n].MemberConfig = append(padMemberConfig(clusters[n].MemberConfig, clusters[n].Members), option)
+		}
+		clusters[n].Members++
+	}
+
+	alignMemberConfig(clusters)
+	return nonVoting
+}
+
+// memberOption returns the memberConfig entry of the i-th member of the cluster, the members without an entry are
+// voting members with the default priority.
+func memberOption(item mdb.ClusterSpecItem, i int) automationconfig.MemberOptions {
+	if i < len(item.MemberConfig) {
+		return *item.MemberConfig[i].DeepCopy()
+	}
+	return automationconfig.MemberOptions{}
+}
+
+func isDefaultMemberOption(option automationconfig.MemberOptions) bool {
+	return option.Votes == nil && option.Priority == nil && len(option.Tags) == 0
+}
+
+// votesByRegion returns the votes of the members of each region, the clusters without a location are counted in the
+// "" region.
+func votesByRegion(clusters mdb.ClusterSpecList, locations map[string]clusterLocation) map[string]int {
+	votes := map[string]int{}
+	for _, c := range clusters {
+		for i := 0; i < c.Members; i++ {
+			option := memberOption(c, i)
+			votes[locations[c.ClusterName].region] += option.GetVotes()
+		}
+	}
+	return votes
+}
+
+// regionWithMajorityOfVotes returns the region which has more than half of the votes of the replica set, or "" if no
+// known region has it.
+func regionWithMajorityOfVotes(clusters mdb.ClusterSpecList, locations map[string]clusterLocation) string {
+	votes := votesByRegion(clusters, locations)
+	total := 0
+	for _, v := range votes {
+		total += v
+	}
+	for region, v := range votes {
+		if region != "" && v*2 > total {
+			return region
+		}
+	}
+	return ""
+}
+
+// padMemberConfig adds default entries to the memberConfig until it has an entry for each of the members.
+func padMemberConfig(memberConfig []automationconfig.MemberOptions, members int) []automationconfig.MemberOptions {
+	for len(memberConfig) < members {
+		memberConfig = append(memberConfig, automationconfig.MemberOptions{})
+	}
+	return memberConfig
+}
+
+// alignMemberConfig pads the memberConfig of all the clusters if any of them has one: the memberConfig entries of the
+// clusters are concatenated into the member options of the replica set, so each of them must match its members.
+func alignMemberConfig(clusters mdb.ClusterSpecList) {
+	for _, c := range clusters {
+		if len(c.MemberConfig) == 0 {
+			continue
+		}
+		for n := range clusters {
+			clusters[n].MemberConfig = padMemberConfig(clusters[n].MemberConfig, clusters[n].Members)
+		}
+		return
+	}
+}
+
+// failoverTargets returns the indexes of the clusters the members are distributed to and the reason they were chosen.
+func failoverTargets(mrs mdbmulti.MongoDBMultiCluster, clusters mdb.ClusterSpecList, healthy []int, clusterName string, strategy mdbmulti.FailoverStrategy, locations map[string]clusterLocation) ([]int, string) {
+	preferred := filterClusters(clusters, healthy, func(c mdb.ClusterSpecItem) bool {
+		for _, target := range mrs.GetPreferredFailoverTargets(clusterName) {
+			if target == c.ClusterName {
+				return true
+			}
+		}
+		return false
+	})
+	if len(preferred) > 0 {
+		return preferred, "to its preferred targets"
+	}
+
+	if strategy == mdbmulti.FailoverStrategyRegionAware {
+		failed, ok := locations[clusterName]
+		if !ok || failed.region == "" {
+			return healthy, "evenly, the region of the cluster is unknown"
+		}
+		sameRegion := filterClusters(clusters, healthy, func(c mdb.ClusterSpecItem) bool {
+			return locations[c.ClusterName].region == failed.region
+		})
+		otherZone := filterClusters(clusters, sameRegion, func(c mdb.ClusterSpecItem) bool {
+			return locations[c.ClusterName].zone != failed.zone
+		})
+		if len(otherZone) > 0 {
+			return otherZone, fmt.Sprintf("to the other zones of region %s", failed.region)
+		}
+		if len(sameRegion) > 0 {
+			return sameRegion, fmt.Sprintf("within region %s", failed.region)
+		}
+		return healthy, fmt.Sprintf("evenly, there is no healthy cluster left in region %s so its votes are not preserved", failed.region)
+	}
+
+	return healthy, "evenly"
+}
+
+func filterClusters(clusters mdb.ClusterSpecList, indexes []int, keep func(c mdb.ClusterSpecItem) bool) []int {
+	var filtered []int
+	for _, n := range indexes {
+		if keep(clusters[n]) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// currentClusterSpecList returns a copy of the clusterSpecList currently deployed, which is the override of the
+// previous failover if there was one.
+func currentClusterSpecList(mrs mdbmulti.MongoDBMultiCluster) mdb.ClusterSpecList {
+	if override, ok := mdbmulti.HasClustersToFailOver(mrs.Annotations); ok {
+		var clusters mdb.ClusterSpecList
+		if err := json.Unmarshal([]byte(override), &clusters); err == nil {
+			return clusters
+		}
+	}
+	return mrs.Spec.ClusterSpecList.DeepCopy()
+}
+
+func removeCluster(clusters mdb.ClusterSpecList, clusterName string) mdb.ClusterSpecList {
+	for n, c := range clusters {
+		if c.ClusterName == clusterName {
+			return append(clusters[:n], clusters[n+1:]...)
+		}
+	}
+	return clusters
+}
+
+// describeMovedMembers describes the members added to each cluster, e.g. "cluster-2 +1, cluster-3 +2".
+func describeMovedMembers(before, after mdb.ClusterSpecList) string {
+	var moved []string
+	for n := range after {
+		if added := after[n].Members - before[n].Members; added > 0 {
+			moved = append(moved, fmt.Sprintf("%s +%d", after[n].ClusterName, added))
+		}
+	}
+	return strings.Join(moved, ", ")
+}
+
+// readClusterLocations reads the region and zone of the member clusters from the labels of the MongoDBMemberCluster
+// resources. The member clusters configured in the KubeConfig file have no location.
+func readClusterLocations(ctx context.Context, centralClient kubernetesClient.Client, log *zap.SugaredLogger) map[string]clusterLocation {
+	locations := map[string]clusterLocation{}
+	memberClusters := &memberclusterv1.MongoDBMemberClusterList{}
+	if err := centralClient.List(ctx, memberClusters); err != nil {
+		log.Debugf("Failed to list the MongoDBMemberCluster resources, the locations of the member clusters are unknown: %s", err)
+		return locations
+	}
+	for _, memberCluster := range memberClusters.Items {
+		locations[memberCluster.GetClusterName()] = clusterLocation{region: memberCluster.Region(), zone: memberCluster.Zone()}
+	}
+	return locations
+}
+
+// publishFailoverPlan writes the plan to the status of the resource and records it as an event, before it is
+// applied.
+func (m *MemberClusterHealthChecker) publishFailoverPlan(ctx context.Context, mrs *mdbmulti.MongoDBMultiCluster, plan failoverPlan, centralClient kubernetesClient.Client, log *zap.SugaredLogger) {
+	log.Infof("Failover plan of resource %s: %s", mrs.Name, plan.message)
+	if m.Recorder != nil {
+		m.Recorder.Event(mrs, corev1.EventTypeWarning, FailoverPlannedReason, plan.message)
+	}
+
+	patch := client.MergeFrom(mrs.DeepCopy())
+	failoverStatus := plan.status(*mrs)
+	mrs.Status.Failover = &failoverStatus
+	if err := centralClient.Status().Patch(ctx, mrs, patch); err != nil {
+		log.Errorf("Failed to write the failover plan to the status of resource %s: %s", mrs.Name, err)
+	}
+}
+
+// applyFailoverPlan marks the cluster as failed and, if the members are moved, overrides the clusterSpecList of the
+// resource.
+func applyFailoverPlan(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, plan failoverPlan, client kubernetesClient.Client) error {
+	if err := addFailedClustersAnnotation(ctx, mrs, plan.clusterName, client); err != nil {
+		return err
+	}
+	if plan.clusterSpecList == nil {
+		return nil
+	}
+	return setClusterSpecOverride(ctx, mrs, plan.clusterSpecList, client)
+}
@@ -0,0 +1,217 @@
+package memberwatch
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/membercluster"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+func newFailoverTestResource(policy *mdbmulti.FailoverPolicy) mdbmulti.MongoDBMultiCluster {
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "us-east-a", Members: 2},
+		{ClusterName: "us-east-b", Members: 1},
+		{ClusterName: "us-east-c", Members: 1},
+		{ClusterName: "eu-west-a", Members: 1},
+	}
+	mrs.Spec.Failover = policy
+	return *mrs
+}
+
+var testClusterLocations = map[string]clusterLocation{
+	"us-east-a": {region: "us-east-1", zone: "us-east-1a"},
+	"us-east-b": {region: "us-east-1", zone: "us-east-1a"},
+	"us-east-c": {region: "us-east-1", zone: "us-east-1b"},
+	"eu-west-a": {region: "eu-west-1", zone: "eu-west-1a"},
+}
+
+func plannedMembers(plan failoverPlan) map[string]int {
+	members := map[string]int{}
+	for _, item := range plan.clusterSpecList {
+		members[item.ClusterName] = item.Members
+	}
+	return members
+}
+
+func TestPlanFailover_Even(t *testing.T) {
+	plan := planFailover(newFailoverTestResource(nil), "us-east-a", nil)
+
+	assert.Equal(t, mdbmulti.FailoverStrategyEven, plan.strategy)
+	assert.Equal(t, map[string]int{"us-east-b": 2, "us-east-c": 2, "eu-west-a": 1}, plannedMembers(plan))
+	assert.Equal(t, "Moving 2 members of failed cluster us-east-a evenly: us-east-b +1, us-east-c +1", plan.message)
+}
+
+func TestPlanFailover_PreferredTargets(t *testing.T) {
+	mrs := newFailoverTestResource(&mdbmulti.FailoverPolicy{
+		PreferredTargets: []mdbmulti.PreferredFailoverTargets{{ClusterName: "us-east-a", Targets: []string{"eu-west-a"}}},
+	})
+
+	plan := planFailover(mrs, "us-east-a", nil)
+	assert.Equal(t, map[string]int{"us-east-b": 1, "us-east-c": 1, "eu-west-a": 3}, plannedMembers(plan))
+
+	// the strategy is used if the preferred targets already failed
+	mrs.Annotations = map[string]string{failedcluster.FailedClusterAnnotation: getFailedClusterList([]string{"eu-west-a"})}
+	plan = planFailover(mrs, "us-east-a", nil)
+	assert.Equal(t, map[string]int{"us-east-b": 2, "us-east-c": 2, "eu-west-a": 1}, plannedMembers(plan))
+}
+
+func TestPlanFailover_RegionAware(t *testing.T) {
+	mrs := newFailoverTestResource(&mdbmulti.FailoverPolicy{Strategy: mdbmulti.FailoverStrategyRegionAware})
+
+	// the clusters of the same region in another zone are preferred
+	plan := planFailover(mrs, "us-east-a", testClusterLocations)
+	assert.Equal(t, map[string]int{"us-east-b": 1, "us-east-c": 3, "eu-west-a": 1}, plannedMembers(plan))
+	assert.Contains(t, plan.message, "to the other zones of region us-east-1")
+
+	// the clusters of the same zone are used if the other zones failed
+	frozen := mrs.DeepCopy()
+	frozen.Annotations = map[string]string{failedcluster.FailedClusterAnnotation: getFailedClusterList([]string{"us-east-c"})}
+	plan = planFailover(*frozen, "us-east-a", testClusterLocations)
+	assert.Equal(t, map[string]int{"us-east-b": 3, "us-east-c": 1, "eu-west-a": 1}, plannedMembers(plan))
+	assert.Contains(t, plan.message, "within region us-east-1")
+
+	// the votes of the region can't be preserved if there is no other cluster in the region
+	plan = planFailover(mrs, "eu-west-a", testClusterLocations)
+	assert.Equal(t, map[string]int{"us-east-a": 2, "us-east-b": 2, "us-east-c": 1}, plannedMembers(plan))
+	assert.Contains(t, plan.message, "its votes are not preserved")
+
+	// the members are distributed evenly if the locations are unknown
+	plan = planFailover(mrs, "us-east-a", nil)
+	assert.Equal(t, map[string]int{"us-east-b": 2, "us-east-c": 2, "eu-west-a": 1}, plannedMembers(plan))
+}
+
+func TestPlanFailover_MovesMemberConfig(t *testing.T) {
+	mrs := newFailoverTestResource(nil)
+	mrs.Spec.ClusterSpecList[0].MemberConfig = []automationconfig.MemberOptions{
+		{Votes: ptr.To(1), Priority: ptr.To("2")},
+		{Votes: ptr.To(0), Priority: ptr.To("0")},
+	}
+
+	plan := planFailover(mrs, "us-east-a", nil)
+
+	assert.Equal(t, map[string]int{"us-east-b": 2, "us-east-c": 2, "eu-west-a": 1}, plannedMembers(plan))
+	// each moved member keeps its memberConfig, the memberConfig of the other members is padded so it still matches
+	assert.Equal(t, []automationconfig.MemberOptions{{}, {Votes: ptr.To(1), Priority: ptr.To("2")}}, plan.clusterSpecList[0].MemberConfig)
+	assert.Equal(t, []automationconfig.MemberOptions{{}, {Votes: ptr.To(0), Priority: ptr.To("0")}}, plan.clusterSpecList[1].MemberConfig)
+	assert.Equal(t, []automationconfig.MemberOptions{{}}, plan.clusterSpecList[2].MemberConfig)
+	assert.NotContains(t, plan.message, "non-voting")
+
+	// no memberConfig is added if the resource has none
+	plan = planFailover(newFailoverTestResource(nil), "us-east-a", nil)
+	for _, item := range plan.clusterSpecList {
+		assert.Empty(t, item.MemberConfig)
+	}
+}
+
+func TestPlanFailover_KeepsVotingLimits(t *testing.T) {
+	// the region which had the majority of the votes keeps it, the members moved out of it are made non-voting
+	mrs := newFailoverTestResource(&mdbmulti.FailoverPolicy{
+		Strategy:         mdbmulti.FailoverStrategyRegionAware,
+		PreferredTargets: []mdbmulti.PreferredFailoverTargets{{ClusterName: "us-east-a", Targets: []string{"eu-west-a"}}},
+	})
+	plan := planFailover(mrs, "us-east-a", testClusterLocations)
+	assert.Equal(t, map[string]int{"us-east-b": 1, "us-east-c": 1, "eu-west-a": 3}, plannedMembers(plan))
+	nonVoting := automationconfig.MemberOptions{Votes: ptr.To(0), Priority: ptr.To("0")}
+	assert.Equal(t, []automationconfig.MemberOptions{{}, nonVoting, nonVoting}, plan.clusterSpecList[2].MemberConfig)
+	assert.Equal(t, []automationconfig.MemberOptions{{}}, plan.clusterSpecList[0].MemberConfig)
+	assert.Contains(t, plan.message, "2 of them are made non-voting")
+
+	// the moved members don't take the replica set over 7 voting members
+	mrs = newFailoverTestResource(nil)
+	mrs.Spec.ClusterSpecList = mdb.ClusterSpecList{
+		{ClusterName: "us-east-a", Members: 4},
+		{ClusterName: "us-east-b", Members: 3},
+		{ClusterName: "us-east-c", Members: 1, MemberConfig: []automationconfig.MemberOptions{{Votes: ptr.To(1), Tags: map[string]string{"dc": "c"}}}},
+	}
+	plan = planFailover(mrs, "us-east-c", nil)
+	assert.Equal(t, map[string]int{"us-east-a": 4, "us-east-b": 4}, plannedMembers(plan))
+	assert.Equal(t, automationconfig.MemberOptions{Votes: ptr.To(0), Priority: ptr.To("0"), Tags: map[string]string{"dc": "c"}}, plan.clusterSpecList[1].MemberConfig[3])
+	assert.Len(t, plan.clusterSpecList[0].MemberConfig, 4)
+}
+
+func TestPlanFailover_Freeze(t *testing.T) {
+	plan := planFailover(newFailoverTestResource(&mdbmulti.FailoverPolicy{Strategy: mdbmulti.FailoverStrategyFreeze}), "us-east-a", nil)
+
+	assert.Nil(t, plan.clusterSpecList)
+	assert.Contains(t, plan.message, "its members are not moved")
+}
+
+func TestPlanFailover_StartsFromPreviousFailover(t *testing.T) {
+	mrs := newFailoverTestResource(nil)
+	previous := planFailover(mrs, "us-east-a", nil)
+	override, err := json.Marshal(previous.clusterSpecList)
+	require.NoError(t, err)
+	mrs.Annotations = map[string]string{
+		failedcluster.FailedClusterAnnotation:       getFailedClusterList([]string{"us-east-a"}),
+		failedcluster.ClusterSpecOverrideAnnotation: string(override),
+	}
+
+	plan := planFailover(mrs, "us-east-b", nil)
+
+	assert.Equal(t, map[string]int{"us-east-c": 3, "eu-west-a": 2}, plannedMembers(plan))
+	assert.Equal(t, []string{"us-east-a", "us-east-b"}, plan.status(mrs).FailedClusters)
+}
+
+func TestPublishAndApplyFailoverPlan(t *testing.T) {
+	ctx := context.Background()
+	mrs := newFailoverTestResource(nil)
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&mrs).WithStatusSubresource(&mrs).Build()
+	centralClient := kubernetesClient.NewClient(fakeClient)
+	recorder := record.NewFakeRecorder(1)
+	checker := MemberClusterHealthChecker{Recorder: recorder}
+
+	plan := planFailover(mrs, "us-east-a", nil)
+	checker.publishFailoverPlan(ctx, &mrs, plan, centralClient, zap.S())
+	require.NoError(t, applyFailoverPlan(ctx, mrs, plan, centralClient))
+
+	assert.Equal(t, "Warning FailoverPlanned "+plan.message, <-recorder.Events)
+
+	updated := mdbmulti.MongoDBMultiCluster{}
+	require.NoError(t, fakeClient.Get(ctx, mrs.ObjectKey(), &updated))
+	require.NotNil(t, updated.Status.Failover)
+	assert.Equal(t, []string{"us-east-a"}, updated.Status.Failover.FailedClusters)
+	assert.Len(t, updated.Status.Failover.Members, 3)
+
+	failedClusters, err := updated.GetFailedClusterNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-east-a"}, failedClusters)
+	override, ok := mdbmulti.HasClustersToFailOver(updated.Annotations)
+	assert.True(t, ok)
+	assert.Contains(t, override, "us-east-b")
+	assert.NotContains(t, override, "us-east-a")
+}
+
+func TestReadClusterLocations(t *testing.T) {
+	ctx := context.Background()
+	memberCluster := &membercluster.MongoDBMemberCluster{}
+	memberCluster.Name = "us-east"
+	memberCluster.Spec.ClusterName = "us-east-a"
+	memberCluster.Labels = map[string]string{"topology.kubernetes.io/region": "us-east-1", "topology.kubernetes.io/zone": "us-east-1a"}
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(memberCluster).Build()
+
+	locations := readClusterLocations(ctx, kubernetesClient.NewClient(fakeClient), zap.S())
+
+	assert.Equal(t, map[string]clusterLocation{"us-east-a": {region: "us-east-1", zone: "us-east-1a"}}, locations)
+}
+
+func failoverTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	return scheme
+}
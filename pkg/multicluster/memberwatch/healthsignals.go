@@ -0,0 +1,373 @@
+package memberwatch
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/env"
+)
+
+const (
+	// CanaryPodName is the name of the pod scheduled in the member clusters to check that pods can be scheduled
+	CanaryPodName = "mongodb-kubernetes-health-canary"
+	// DefaultCanaryTimeout is the time the canary pod has to be scheduled in
+	DefaultCanaryTimeout = 60 * time.Second
+	// DefaultDatabaseSignalWeight is the weight of each of the database signals in the health score of a member
+	// cluster, relative to the signals of the cluster itself which weigh 1
+	DefaultDatabaseSignalWeight = 0.5
+	// DefaultAgentHeartbeatsMaxAge is how long the heartbeats of the agents read from Ops Manager are reused
+	DefaultAgentHeartbeatsMaxAge = 60 * time.Second
+)
+
+// errSignalPending is returned by the signals which don't have a result yet.
+var errSignalPending = xerrors.New("the first result of the signal is pending")
+
+// HealthCheckTarget is the member cluster the health signals are checked for.
+type HealthCheckTarget struct {
+	ClusterName string
+	Client      kubernetesClient.Client
+	// Resources are the MongoDBMultiCluster resources with members in the member cluster
+	Resources []mdbmulti.MongoDBMultiCluster
+}
+
+// HealthSignal is a health check of a member cluster going beyond the readiness of its API server. The signals are
+// combined into the health score of the member cluster, which decides whether the cluster is failed over.
+type HealthSignal interface {
+	Name() string
+	// Check returns the health of the member cluster, from 0 for unhealthy to 1 for healthy. An error means that the
+	// signal is not available, e.g. if the operator is not allowed to read the resources it's based on, and the
+	// signal is then ignored in the health score.
+	Check(ctx context.Context, target HealthCheckTarget) (float64, error)
+}
+
+// weightedSignal is a health signal with its weight in the health score of the member cluster.
+type weightedSignal struct {
+	signal HealthSignal
+	weight float64
+}
+
+// withWeight returns the signals with the given weight in the health score.
+func withWeight(signals []HealthSignal, weight float64) []weightedSignal {
+	weighted := make([]weightedSignal, 0, len(signals))
+	for _, signal := range signals {
+		weighted = append(weighted, weightedSignal{signal: signal, weight: weight})
+	}
+	return weighted
+}
+
+// checkHealthSignals combines the health signals of the member cluster into its health score, which is the weighted
+// average of the available signals from 0 to 100. The score is 100 if none of the signals is available.
+func checkHealthSignals(ctx context.Context, signals []weightedSignal, target HealthCheckTarget, log *zap.SugaredLogger) (int, []multicluster.SignalScore) {
+	var scores []multicluster.SignalScore
+	sum, weights := 0.0, 0.0
+	for _, s := range signals {
+		score, signalScore := checkHealthSignal(ctx, s.signal, target, log)
+		signalScore.Weight = s.weight
+		scores = append(scores, signalScore)
+		if signalScore.Error != "" {
+			continue
+		}
+		sum += score * s.weight
+		weights += s.weight
+	}
+	if weights == 0 {
+		return 100, scores
+	}
+	return toPercent(sum / weights), scores
+}
+
+func checkHealthSignal(ctx context.Context, signal HealthSignal, target HealthCheckTarget, log *zap.SugaredLogger) (float64, multicluster.SignalScore) {
+	score, err := signal.Check(ctx, target)
+	if err != nil {
+		log.Debugf("Health signal %s of cluster %s is not available: %s", signal.Name(), target.ClusterName, err)
+		return 0, multicluster.SignalScore{Name: signal.Name(), Error: err.Error()}
+	}
+	score = math.Min(math.Max(score, 0), 1)
+	return score, multicluster.SignalScore{Name: signal.Name(), Score: toPercent(score)}
+}
+
+func toPercent(ratio float64) int {
+	return int(math.Round(ratio * 100))
+}
+
+// NodeReadinessSignal is the ratio of the schedulable nodes of the member cluster which are Ready.
+type NodeReadinessSignal struct{}
+
+var _ HealthSignal = NodeReadinessSignal{}
+
+func (NodeReadinessSignal) Name() string {
+	return "nodeReadiness"
+}
+
+func (NodeReadinessSignal) Check(ctx context.Context, target HealthCheckTarget) (float64, error) {
+	nodes := &corev1.NodeList{}
+	if err := target.Client.List(ctx, nodes); err != nil {
+		return 0, xerrors.Errorf("failed to list the nodes: %w", err)
+	}
+
+	schedulable, ready := 0, 0
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		schedulable++
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+	if schedulable == 0 {
+		return 0, nil
+	}
+	return float64(ready) / float64(schedulable), nil
+}
+
+// CanaryPodSignal checks that pods can be scheduled in the member cluster. A canary pod is created in the Namespace,
+// which is watched by the operator in the member clusters too, and deleted once it's scheduled. The signal is 0 if
+// the pod isn't scheduled within the Timeout. As the pod is scheduled asynchronously, the result of the previous
+// canary pod is returned until the current one is scheduled or times out.
+type CanaryPodSignal struct {
+	Namespace string
+	Image     string
+	Timeout   time.Duration
+	// results holds the result of the last canary pod of each member cluster
+	results map[string]float64
+}
+
+var _ HealthSignal = &CanaryPodSignal{}
+
+// NewCanaryPodSignal returns the canary pod signal creating the pods in the namespace, with the image configured in
+// the MEMBER_CLUSTER_CANARY_IMAGE environment variable.
+func NewCanaryPodSignal(namespace string) *CanaryPodSignal {
+	return &CanaryPodSignal{
+		Namespace: namespace,
+		Image:     env.ReadOrDefault(multicluster.CanaryImageEnv, multicluster.DefaultCanaryImage), // nolint:forbidigo
+		Timeout:   DefaultCanaryTimeout,
+		results:   map[string]float64{},
+	}
+}
+
+func (s *CanaryPodSignal) Name() string {
+	return "canaryPod"
+}
+
+func (s *CanaryPodSignal) Check(ctx context.Context, target HealthCheckTarget) (float64, error) {
+	if s.results == nil {
+		s.results = map[string]float64{}
+	}
+
+	pod := corev1.Pod{}
+	err := target.Client.Get(ctx, kube.ObjectKey(s.Namespace, CanaryPodName), &pod)
+	switch {
+	case apiErrors.IsNotFound(err):
+		if err := target.Client.Create(ctx, s.canaryPod()); err != nil && !apiErrors.IsAlreadyExists(err) {
+			return 0, xerrors.Errorf("failed to create the canary pod: %w", err)
+		}
+	case err != nil:
+		return 0, xerrors.Errorf("failed to read the canary pod: %w", err)
+	case isPodScheduled(pod):
+		s.results[target.ClusterName] = 1
+		if err := deleteCanaryPod(ctx, target.Client, pod); err != nil {
+			return 0, err
+		}
+	case time.Since(pod.CreationTimestamp.Time) > s.Timeout:
+		s.results[target.ClusterName] = 0
+		if err := deleteCanaryPod(ctx, target.Client, pod); err != nil {
+			return 0, err
+		}
+	}
+
+	result, ok := s.results[target.ClusterName]
+	if !ok {
+		return 0, errSignalPending
+	}
+	return result, nil
+}
+
+func (s *CanaryPodSignal) canaryPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CanaryPodName,
+			Namespace: s.Namespace,
+			Labels:    map[string]string{"app": CanaryPodName},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  ptr.To(false),
+			TerminationGracePeriodSeconds: ptr.To(int64(0)),
+			Containers: []corev1.Container{
+				{
+					Name:  "canary",
+					Image: s.Image,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1m"),
+							corev1.ResourceMemory: resource.MustParse("8Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func isPodScheduled(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteCanaryPod(ctx context.Context, memberClient kubernetesClient.Client, pod corev1.Pod) error {
+	if err := memberClient.Delete(ctx, &pod); err != nil && !apiErrors.IsNotFound(err) {
+		return xerrors.Errorf("failed to delete the canary pod: %w", err)
+	}
+	return nil
+}
+
+// StatefulSetReadinessSignal is the ratio of the pods of the StatefulSets of the MongoDBMultiCluster resources in the
+// member cluster which are Ready. It measures the databases, so it's meant to be one of the DatabaseSignals.
+type StatefulSetReadinessSignal struct{}
+
+var _ HealthSignal = StatefulSetReadinessSignal{}
+
+func (StatefulSetReadinessSignal) Name() string {
+	return "statefulSetReadiness"
+}
+
+func (StatefulSetReadinessSignal) Check(ctx context.Context, target HealthCheckTarget) (float64, error) {
+	replicas, ready := 0, 0
+	for _, mrs := range target.Resources {
+		sts := appsv1.StatefulSet{}
+		err := target.Client.Get(ctx, kube.ObjectKey(mrs.Namespace, mrs.MultiStatefulsetName(mrs.ClusterNum(target.ClusterName))), &sts)
+		if apiErrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, xerrors.Errorf("failed to read the StatefulSet of resource %s: %w", mrs.Name, err)
+		}
+		replicas += int(ptr.Deref(sts.Spec.Replicas, 1))
+		ready += int(sts.Status.ReadyReplicas)
+	}
+	if replicas == 0 {
+		return 0, xerrors.New("there are no database pods in the member cluster")
+	}
+	return math.Min(float64(ready)/float64(replicas), 1), nil
+}
+
+// AgentHeartbeatCount is the number of automation agents of a resource in a member cluster, and how many of them
+// recently sent a heartbeat to Ops Manager.
+type AgentHeartbeatCount struct {
+	Alive    int
+	Expected int
+}
+
+// AgentHeartbeats returns the heartbeats of the automation agents of the MongoDBMultiCluster resource in each of its
+// member clusters.
+type AgentHeartbeats func(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster) (map[string]AgentHeartbeatCount, error)
+
+// AgentHeartbeatSignal is the ratio of the automation agents in the member cluster which recently sent a heartbeat to
+// Ops Manager. The resources whose Ops Manager can't be reached are ignored. It measures the databases, so it's meant
+// to be one of the DatabaseSignals. The heartbeats of each resource are read from Ops Manager once for all member
+// clusters, and reused for MaxAge.
+type AgentHeartbeatSignal struct {
+	Heartbeats AgentHeartbeats
+	MaxAge     time.Duration
+	// heartbeats holds the last heartbeats read for each resource
+	heartbeats map[types.NamespacedName]agentHeartbeatsResult
+}
+
+type agentHeartbeatsResult struct {
+	readAt time.Time
+	counts map[string]AgentHeartbeatCount
+	err    error
+}
+
+var _ HealthSignal = &AgentHeartbeatSignal{}
+
+// NewAgentHeartbeatSignal returns the agent heartbeat signal reusing the heartbeats for DefaultAgentHeartbeatsMaxAge.
+func NewAgentHeartbeatSignal(heartbeats AgentHeartbeats) *AgentHeartbeatSignal {
+	return &AgentHeartbeatSignal{
+		Heartbeats: heartbeats,
+		MaxAge:     DefaultAgentHeartbeatsMaxAge,
+		heartbeats: map[types.NamespacedName]agentHeartbeatsResult{},
+	}
+}
+
+func (s *AgentHeartbeatSignal) Name() string {
+	return "agentHeartbeats"
+}
+
+func (s *AgentHeartbeatSignal) Check(ctx context.Context, target HealthCheckTarget) (float64, error) {
+	var lastErr error
+	alive, expected := 0, 0
+	for _, mrs := range target.Resources {
+		counts, err := s.readHeartbeats(ctx, mrs)
+		if err != nil {
+			lastErr = xerrors.Errorf("failed to read the agents of resource %s: %w", mrs.Name, err)
+			continue
+		}
+		alive += counts[target.ClusterName].Alive
+		expected += counts[target.ClusterName].Expected
+	}
+	if expected == 0 {
+		if lastErr != nil {
+			return 0, lastErr
+		}
+		return 0, xerrors.New("there are no agents in the member cluster")
+	}
+	return float64(alive) / float64(expected), nil
+}
+
+// readHeartbeats returns the heartbeats of the resource, which are read from Ops Manager if the last ones are older
+// than MaxAge.
+func (s *AgentHeartbeatSignal) readHeartbeats(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster) (map[string]AgentHeartbeatCount, error) {
+	if s.heartbeats == nil {
+		s.heartbeats = map[types.NamespacedName]agentHeartbeatsResult{}
+	}
+	now := time.Now()
+	// the heartbeats of the deleted resources are dropped once they expire
+	for key, result := range s.heartbeats {
+		if now.Sub(result.readAt) >= s.MaxAge {
+			delete(s.heartbeats, key)
+		}
+	}
+
+	key := mrs.ObjectKey()
+	if result, ok := s.heartbeats[key]; ok {
+		return result.counts, result.err
+	}
+	counts, err := s.Heartbeats(ctx, mrs)
+	s.heartbeats[key] = agentHeartbeatsResult{readAt: now, counts: counts, err: err}
+	return counts, err
+}
+
+// resourcesInCluster returns the resources with members in the member cluster.
+func resourcesInCluster(resources []mdbmulti.MongoDBMultiCluster, clusterName string) []mdbmulti.MongoDBMultiCluster {
+	var inCluster []mdbmulti.MongoDBMultiCluster
+	for _, mrs := range resources {
+		if item := getClusterSpecItem(mrs.GetDesiredSpecList(), clusterName); item != nil && item.Members > 0 {
+			inCluster = append(inCluster, mrs)
+		}
+	}
+	return inCluster
+}
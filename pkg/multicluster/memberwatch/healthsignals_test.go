@@ -0,0 +1,278 @@
+package memberwatch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+)
+
+type staticSignal struct {
+	name  string
+	score float64
+	err   error
+}
+
+func (s staticSignal) Name() string {
+	return s.name
+}
+
+func (s staticSignal) Check(context.Context, HealthCheckTarget) (float64, error) {
+	return s.score, s.err
+}
+
+func healthCheckTarget(t *testing.T, objects ...client.Object) HealthCheckTarget {
+	scheme := failoverTestScheme(t)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return HealthCheckTarget{ClusterName: "cluster-a", Client: kubernetesClient.NewClient(fakeClient)}
+}
+
+func TestCheckHealthSignals(t *testing.T) {
+	ctx := context.Background()
+	signals := withWeight([]HealthSignal{
+		staticSignal{name: "nodeReadiness", score: 0.5},
+		staticSignal{name: "canaryPod", score: 1},
+		staticSignal{name: "agentHeartbeats", err: xerrors.New("forbidden")},
+	}, 1)
+
+	score, scores := checkHealthSignals(ctx, signals, HealthCheckTarget{ClusterName: "cluster-a"}, zap.S())
+
+	// the unavailable signals are ignored
+	assert.Equal(t, 75, score)
+	assert.Equal(t, []multicluster.SignalScore{
+		{Name: "nodeReadiness", Score: 50, Weight: 1},
+		{Name: "canaryPod", Score: 100, Weight: 1},
+		{Name: "agentHeartbeats", Error: "forbidden", Weight: 1},
+	}, scores)
+
+	score, _ = checkHealthSignals(ctx, signals[2:], HealthCheckTarget{ClusterName: "cluster-a"}, zap.S())
+	assert.Equal(t, 100, score)
+}
+
+func TestClusterHealth_DatabaseSignalsAreWeighted(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+	}))
+	defer server.Close()
+	registry := multicluster.NewClientRegistry(map[string]client.Client{"cluster-a": fake.NewFakeClient()})
+	checker := MemberClusterHealthChecker{
+		Signals: []HealthSignal{staticSignal{name: "nodeReadiness", score: 1}, staticSignal{name: "canaryPod", score: 1}},
+		DatabaseSignals: []HealthSignal{
+			staticSignal{name: "statefulSetReadiness", score: 0},
+			staticSignal{name: "agentHeartbeats", score: 0},
+		},
+		DatabaseSignalWeight: DefaultDatabaseSignalWeight,
+		HealthScoreThreshold: 50,
+	}
+	healthCheck := NewMemberHealthCheck(server.URL, nil, "token", zap.S())
+
+	// the databases failing in the member cluster lower its score, but don't make it unhealthy on their own
+	health := checker.clusterHealth(ctx, "cluster-a", healthCheck, registry, nil, zap.S())
+	assert.True(t, health.Healthy)
+	assert.Equal(t, 67, health.Score)
+	assert.Equal(t, []multicluster.SignalScore{
+		{Name: "nodeReadiness", Score: 100, Weight: 1},
+		{Name: "canaryPod", Score: 100, Weight: 1},
+		{Name: "statefulSetReadiness", Score: 0, Weight: DefaultDatabaseSignalWeight},
+		{Name: "agentHeartbeats", Score: 0, Weight: DefaultDatabaseSignalWeight},
+	}, health.Signals)
+
+	// combined with the failures of the cluster itself they do
+	checker.Signals[0] = staticSignal{name: "nodeReadiness", score: 0.25}
+	health = checker.clusterHealth(ctx, "cluster-a", healthCheck, registry, nil, zap.S())
+	assert.False(t, health.Healthy)
+	assert.Equal(t, 42, health.Score)
+}
+
+func TestClusterHealth_Threshold(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+	}))
+	defer server.Close()
+	registry := multicluster.NewClientRegistry(map[string]client.Client{"cluster-a": fake.NewFakeClient()})
+	checker := MemberClusterHealthChecker{
+		Signals:              []HealthSignal{staticSignal{name: "nodeReadiness", score: 0.4}},
+		HealthScoreThreshold: 50,
+	}
+
+	health := checker.clusterHealth(ctx, "cluster-a", NewMemberHealthCheck(server.URL, nil, "token", zap.S()), registry, nil, zap.S())
+	assert.False(t, health.Healthy)
+	assert.Equal(t, 40, health.Score)
+
+	checker.HealthScoreThreshold = 40
+	health = checker.clusterHealth(ctx, "cluster-a", NewMemberHealthCheck(server.URL, nil, "token", zap.S()), registry, nil, zap.S())
+	assert.True(t, health.Healthy)
+	assert.Len(t, health.Signals, 1)
+}
+
+func testNode(name string, ready bool, unschedulable bool) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+	}
+}
+
+func TestNodeReadinessSignal(t *testing.T) {
+	ctx := context.Background()
+
+	// the cordoned nodes are ignored
+	target := healthCheckTarget(t, testNode("node-1", true, false), testNode("node-2", false, false), testNode("node-3", false, true))
+	score, err := NodeReadinessSignal{}.Check(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, score)
+
+	score, err = NodeReadinessSignal{}.Check(ctx, healthCheckTarget(t))
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+}
+
+func TestCanaryPodSignal(t *testing.T) {
+	ctx := context.Background()
+	target := healthCheckTarget(t)
+	signal := NewCanaryPodSignal("watched-ns")
+	canaryKey := kube.ObjectKey("watched-ns", CanaryPodName)
+
+	// the canary pod is created and the result is pending until it's scheduled
+	_, err := signal.Check(ctx, target)
+	assert.ErrorIs(t, err, errSignalPending)
+	pod := corev1.Pod{}
+	require.NoError(t, target.Client.Get(ctx, canaryKey, &pod))
+	assert.Equal(t, multicluster.DefaultCanaryImage, pod.Spec.Containers[0].Image)
+
+	// the scheduled canary pod is deleted
+	pod.CreationTimestamp = metav1.Now()
+	require.NoError(t, target.Client.Update(ctx, &pod))
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}}
+	require.NoError(t, target.Client.Status().Update(ctx, &pod))
+	score, err := signal.Check(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, score)
+	assert.True(t, apiErrors.IsNotFound(target.Client.Get(ctx, canaryKey, &corev1.Pod{})))
+
+	// the result of the previous canary pod is returned while the next one is pending
+	score, err = signal.Check(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, score)
+	// the fake client doesn't set the creation timestamp
+	pod = corev1.Pod{}
+	require.NoError(t, target.Client.Get(ctx, canaryKey, &pod))
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Second))
+	require.NoError(t, target.Client.Update(ctx, &pod))
+	score, err = signal.Check(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, score)
+
+	// the canary pod not scheduled in time fails the signal
+	signal.Timeout = 5 * time.Second
+	score, err = signal.Check(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+	assert.True(t, apiErrors.IsNotFound(target.Client.Get(ctx, canaryKey, &corev1.Pod{})))
+}
+
+func TestStatefulSetReadinessSignal(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().Build()
+	mrs.Spec.Mapping = map[string]int{"cluster-a": 0}
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: mrs.MultiStatefulsetName(0), Namespace: mrs.Namespace},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))},
+		Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+	}
+
+	target := healthCheckTarget(t, sts)
+	target.Resources = []mdbmulti.MongoDBMultiCluster{*mrs}
+	score, err := StatefulSetReadinessSignal{}.Check(ctx, target)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0/3.0, score, 0.001)
+
+	// the signal is not available without database pods
+	target = healthCheckTarget(t)
+	target.Resources = []mdbmulti.MongoDBMultiCluster{*mrs}
+	_, err = StatefulSetReadinessSignal{}.Check(ctx, target)
+	assert.Error(t, err)
+}
+
+func TestAgentHeartbeatSignal(t *testing.T) {
+	ctx := context.Background()
+	first := mdbmulti.DefaultMultiReplicaSetBuilder().SetName("first").Build()
+	second := mdbmulti.DefaultMultiReplicaSetBuilder().SetName("second").Build()
+	signal := NewAgentHeartbeatSignal(func(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster) (map[string]AgentHeartbeatCount, error) {
+		if mrs.Name == "second" {
+			return nil, xerrors.New("Ops Manager is not reachable")
+		}
+		return map[string]AgentHeartbeatCount{"cluster-a": {Alive: 1, Expected: 2}}, nil
+	})
+
+	// the resources whose Ops Manager is not reachable are ignored
+	score, err := signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-a", Resources: []mdbmulti.MongoDBMultiCluster{*first, *second}})
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, score)
+
+	_, err = signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-a", Resources: []mdbmulti.MongoDBMultiCluster{*second}})
+	assert.ErrorContains(t, err, "Ops Manager is not reachable")
+
+	_, err = signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-b", Resources: []mdbmulti.MongoDBMultiCluster{*first}})
+	assert.ErrorContains(t, err, "there are no agents in the member cluster")
+}
+
+func TestAgentHeartbeatSignal_ReusesHeartbeats(t *testing.T) {
+	ctx := context.Background()
+	mrs := mdbmulti.DefaultMultiReplicaSetBuilder().Build()
+	reads := 0
+	signal := NewAgentHeartbeatSignal(func(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster) (map[string]AgentHeartbeatCount, error) {
+		reads++
+		return map[string]AgentHeartbeatCount{"cluster-a": {Alive: 1, Expected: 1}, "cluster-b": {Alive: 0, Expected: 1}}, nil
+	})
+
+	// the heartbeats are read once for all member clusters
+	score, err := signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-a", Resources: []mdbmulti.MongoDBMultiCluster{*mrs}})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, score)
+	score, err = signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-b", Resources: []mdbmulti.MongoDBMultiCluster{*mrs}})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, score)
+	assert.Equal(t, 1, reads)
+
+	// and read again once they expire
+	signal.MaxAge = 0
+	_, err = signal.Check(ctx, HealthCheckTarget{ClusterName: "cluster-a", Resources: []mdbmulti.MongoDBMultiCluster{*mrs}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, reads)
+}
+
+func TestResourcesInCluster(t *testing.T) {
+	mrs := newFailbackTestResource(t)
+
+	// cluster-a failed and has no members in the cluster spec override
+	assert.Empty(t, resourcesInCluster([]mdbmulti.MongoDBMultiCluster{mrs}, "cluster-a"))
+	assert.Len(t, resourcesInCluster([]mdbmulti.MongoDBMultiCluster{mrs}, "cluster-b"), 1)
+}
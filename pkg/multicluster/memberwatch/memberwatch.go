@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
 	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
 	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
 	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
@@ -23,6 +26,27 @@ import (
 
 type MemberClusterHealthChecker struct {
 	Cache map[string]*MemberHeathCheck
+	// configs holds the configurations of the Registry the health checks of the Cache were created from
+	configs map[string]*rest.Config
+	// Recorder records the failover plans and the failback steps as events of the MongoDBMultiCluster resources, if set
+	Recorder record.EventRecorder
+	// healthySince holds since when each member cluster passes the health checks
+	healthySince map[string]time.Time
+	// Signals are checked in addition to the readiness of the API server of the member clusters, and combined into
+	// their health score
+	Signals []HealthSignal
+	// DatabaseSignals measure the databases in the member clusters rather than the clusters themselves. They are
+	// combined into the health score with the DatabaseSignalWeight, so the failures of the databases lower the score
+	// of their member cluster without failing it over on their own.
+	DatabaseSignals []HealthSignal
+	// DatabaseSignalWeight is the weight of each of the DatabaseSignals in the health score, relative to the Signals
+	// which weigh 1
+	DatabaseSignalWeight float64
+	// HealthScoreThreshold is the minimum health score, from 0 to 100, of a healthy member cluster
+	HealthScoreThreshold int
+	// OpsManagerEvents enqueues the multi-cluster MongoDBOpsManager resources the failed clusters are failed over in,
+	// the MongoDBOpsManager resources are not failed over if it's nil
+	OpsManagerEvents chan event.GenericEvent
 }
 
 type ClusterCredentials struct {
@@ -88,15 +112,46 @@ func (m *MemberClusterHealthChecker) populateCache(clustersMap map[string]cluste
 	}
 }
 
+// updateCache adds the health checks of the member clusters registered while the operator is running, and removes
+// the health checks of the clusters which are not registered anymore. The health check of a cluster is recreated if
+// the configuration of its client changed, e.g. if the token was rotated.
+func (m *MemberClusterHealthChecker) updateCache(registry *multicluster.Registry, log *zap.SugaredLogger) {
+	if m.configs == nil {
+		m.configs = map[string]*rest.Config{}
+	}
+
+	for clusterName := range m.Cache {
+		if !registry.Has(clusterName) {
+			delete(m.Cache, clusterName)
+			delete(m.configs, clusterName)
+		}
+	}
+
+	for _, clusterName := range registry.Names() {
+		config := registry.Config(clusterName)
+		if config == nil || config.BearerToken == "" {
+			continue
+		}
+		if _, ok := m.Cache[clusterName]; ok && m.configs[clusterName] == config {
+			continue
+		}
+		m.Cache[clusterName] = NewMemberHealthCheck(config.Host, config.CAData, config.BearerToken, log)
+		m.configs[clusterName] = config
+	}
+}
+
 // WatchMemberClusterHealth watches member clusters healthcheck. If a cluster fails healthcheck it re-enqueues the
-// MongoDBMultiCluster resources. It is spun up in the mongodb multi reconciler as a go-routine, and is executed every 10 seconds.
-func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Context, log *zap.SugaredLogger, watchChannel chan event.GenericEvent, centralClient kubernetesClient.Client, clustersMap map[string]cluster.Cluster) {
+// MongoDBMultiCluster resources, and the MongoDBOpsManager resources if OpsManagerEvents is set. It is spun up in the mongodb multi reconciler as a go-routine, and is executed every 10 seconds.
+// The result of each health check is recorded in the Registry.
+func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Context, log *zap.SugaredLogger, watchChannel chan event.GenericEvent, centralClient kubernetesClient.Client, registry *multicluster.Registry) {
 	// check if the local cache is populated if not let's do that
 	if len(m.Cache) == 0 {
-		m.populateCache(clustersMap, log)
+		m.populateCache(registry.Clusters(), log)
 	}
 
 	for {
+		m.updateCache(registry, log)
+
 		log.Info("Running member cluster healthcheck")
 		mdbmList := &mdbmulti.MongoDBMultiClusterList{}
 
@@ -104,20 +159,41 @@ func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Contex
 		if err != nil {
 			log.Errorf("Failed to fetch MongoDBMultiClusterList from Kubernetes: %s", err)
 		}
+		omList := &omv1.MongoDBOpsManagerList{}
+		if m.OpsManagerEvents != nil {
+			if err := centralClient.List(ctx, omList, &client.ListOptions{Namespace: ""}); err != nil {
+				log.Errorf("Failed to fetch MongoDBOpsManagerList from Kubernetes: %s", err)
+			}
+		}
 
 		// check the cluster health status corresponding to each member cluster
+		now := time.Now()
+		healthyClusters := map[string]bool{}
 		for k, v := range m.Cache {
-			if v.IsClusterHealthy(log) {
-				log.Infof("Cluster %s reported healthy", k)
+			health := m.clusterHealth(ctx, k, v, registry, mdbmList.Items, log)
+			healthy := health.Healthy
+			registry.SetHealth(k, health)
+			m.markHealth(k, healthy, now)
+			healthyClusters[k] = healthy
+			if healthy {
+				log.Infof("Cluster %s reported healthy with health score %d", k, health.Score)
 				continue
 			}
 
-			log.Warnf("Cluster %s reported unhealthy", k)
+			log.Warnf("Cluster %s reported unhealthy with health score %d", k, health.Score)
+			m.failOverOpsManagers(ctx, k, omList.Items, centralClient, log)
 			// re-enqueue all the MDBMultis the operator is watching into the reconcile loop
+			var locations map[string]clusterLocation
 			for _, mdbm := range mdbmList.Items {
 				if shouldAddFailedClusterAnnotation(mdbm.Annotations, k) && multicluster.ShouldPerformFailover() {
+					if locations == nil && mdbm.GetFailoverStrategy() == mdbmulti.FailoverStrategyRegionAware {
+						locations = readClusterLocations(ctx, centralClient, log)
+					}
+					plan := planFailover(mdbm, k, locations)
+					m.publishFailoverPlan(ctx, &mdbm, plan, centralClient, log)
+
 					log.Infof("Enqueuing resource: %s, because cluster %s has failed healthcheck", mdbm.Name, k)
-					err := AddFailoverAnnotation(ctx, mdbm, k, centralClient)
+					err := applyFailoverPlan(ctx, mdbm, plan, centralClient)
 					if err != nil {
 						log.Errorf("Failed to add failover annotation to the mdbmc resource: %s, error: %s", mdbm.Name, err)
 					}
@@ -131,10 +207,37 @@ func (m *MemberClusterHealthChecker) WatchMemberClusterHealth(ctx context.Contex
 				}
 			}
 		}
+		// move the members back to the clusters which recovered
+		if multicluster.ShouldPerformFailover() {
+			for _, mdbm := range m.failbackResources(ctx, mdbmList.Items, healthyClusters, now, centralClient, log) {
+				watchChannel <- event.GenericEvent{Object: &mdbm}
+			}
+		}
+		if m.OpsManagerEvents != nil {
+			m.failBackOpsManagers(ctx, omList.Items, now, centralClient, log)
+		}
 		time.Sleep(10 * time.Second)
 	}
 }
 
+// clusterHealth checks the API server of the member cluster and, if it's ready, the health signals. The member
+// cluster is healthy if its health score reaches the HealthScoreThreshold, the score is 0 if the API server is not
+// ready.
+func (m *MemberClusterHealthChecker) clusterHealth(ctx context.Context, clusterName string, healthCheck *MemberHeathCheck, registry *multicluster.Registry, resources []mdbmulti.MongoDBMultiCluster, log *zap.SugaredLogger) multicluster.ClusterHealth {
+	if !healthCheck.IsClusterHealthy(log) {
+		return multicluster.ClusterHealth{}
+	}
+	memberClient, ok := registry.KubeClient(clusterName)
+	if (len(m.Signals) == 0 && len(m.DatabaseSignals) == 0) || !ok {
+		return multicluster.ClusterHealth{Healthy: true, Score: 100}
+	}
+
+	target := HealthCheckTarget{ClusterName: clusterName, Client: memberClient, Resources: resourcesInCluster(resources, clusterName)}
+	signals := append(withWeight(m.Signals, 1), withWeight(m.DatabaseSignals, m.DatabaseSignalWeight)...)
+	score, scores := checkHealthSignals(ctx, signals, target, log)
+	return multicluster.ClusterHealth{Healthy: score >= m.HealthScoreThreshold, Score: score, Signals: scores}
+}
+
 // shouldAddFailedClusterAnnotation checks if we should add this cluster in the failedCluster annotation,
 // if it's already not present.
 func shouldAddFailedClusterAnnotation(annotations map[string]string, clusterName string) bool {
@@ -168,11 +271,21 @@ func readFailedClusterAnnotation(annotations map[string]string) []failedcluster.
 
 // clusterWithMinimumMembers returns the index of the cluster with the minimum number of nodes.
 func clusterWithMinimumMembers(clusters mdb.ClusterSpecList) int {
+	indexes := make([]int, len(clusters))
+	for n := range clusters {
+		indexes[n] = n
+	}
+	return clusterWithMinimumMembersOf(clusters, indexes)
+}
+
+// clusterWithMinimumMembersOf returns the index of the cluster with the minimum number of nodes amongst the given
+// cluster indexes.
+func clusterWithMinimumMembersOf(clusters mdb.ClusterSpecList, indexes []int) int {
 	mini, index := math.MaxInt64, -1
 
-	for nn, c := range clusters {
-		if c.Members < mini {
-			mini = c.Members
+	for _, nn := range indexes {
+		if clusters[nn].Members < mini {
+			mini = clusters[nn].Members
 			index = nn
 		}
 	}
@@ -183,43 +296,41 @@ func clusterWithMinimumMembers(clusters mdb.ClusterSpecList) int {
 func distributeFailedMembers(clusters mdb.ClusterSpecList, clustername string) mdb.ClusterSpecList {
 	// add the cluster override annotations. Get the current clusterspec list from the CR and
 	// increase the members of the first cluster by the number of failed nodes
-	membersToFailOver := 0
+	membersToFailOver := getClusterMembers(clusters, clustername)
+	clusters = removeCluster(clusters, clustername)
 
-	for n, c := range clusters {
-		if c.ClusterName == clustername {
-			membersToFailOver = c.Members
-			clusters = append(clusters[:n], clusters[n+1:]...)
-			break
-		}
+	indexes := make([]int, len(clusters))
+	for n := range clusters {
+		indexes[n] = n
 	}
+	distributeMembers(clusters, membersToFailOver, indexes)
+	return clusters
+}
 
-	for membersToFailOver > 0 {
+// distributeMembers adds the members one by one to the cluster with the fewest members amongst the target clusters.
+func distributeMembers(clusters mdb.ClusterSpecList, members int, targets []int) {
+	for members > 0 {
 		// pick the cluster with the minumum number of nodes currently and increament
 		// its count by 1.
-		nn := clusterWithMinimumMembers(clusters)
+		nn := clusterWithMinimumMembersOf(clusters, targets)
 		clusters[nn].Members += 1
-		membersToFailOver -= 1
+		members -= 1
 	}
-
-	return clusters
 }
 
 // AddFailoverAnnotation adds the failed cluster spec to the annotation of the MongoDBMultiCluster CR for it to be used
-// while performing the reconcilliation
+// while performing the reconcilliation. The members of the failed cluster are moved according to the failover
+// policy of the resource.
 func AddFailoverAnnotation(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, clustername string, client kubernetesClient.Client) error {
 	if mrs.Annotations == nil {
 		mrs.Annotations = map[string]string{}
 	}
 
-	err := addFailedClustersAnnotation(ctx, mrs, clustername, client)
-	if err != nil {
-		return err
-	}
-
-	currentClusterSpecs := mrs.Spec.ClusterSpecList
-	currentClusterSpecs = distributeFailedMembers(currentClusterSpecs, clustername)
+	return applyFailoverPlan(ctx, mrs, planFailover(mrs, clustername, nil), client)
+}
 
-	updatedClusterSpec, err := json.Marshal(currentClusterSpecs)
+func setClusterSpecOverride(ctx context.Context, mrs mdbmulti.MongoDBMultiCluster, clusterSpecList mdb.ClusterSpecList, client kubernetesClient.Client) error {
+	updatedClusterSpec, err := json.Marshal(clusterSpecList)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,341 @@
+package memberwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/annotations"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+// opsManagerFailoverPlan is the redistribution of the AppDB members and of the Ops Manager and Backup Daemon replicas
+// of a failed cluster to the healthy clusters of a multi-cluster MongoDBOpsManager resource.
+type opsManagerFailoverPlan struct {
+	clusterName string
+	// appDBClusterSpecList is the AppDB clusterSpecList after the failover, it's nil if the AppDB members are not moved
+	appDBClusterSpecList mdb.ClusterSpecList
+	// opsManagerClusterSpecList is the Ops Manager clusterSpecList after the failover, it's nil if the Ops Manager
+	// replicas are not moved
+	opsManagerClusterSpecList []omv1.ClusterSpecOMItem
+	message                   string
+}
+
+// hasOpsManagerMembers returns true if the multi-cluster AppDB or Ops Manager of the resource currently has members
+// in the cluster.
+func hasOpsManagerMembers(om omv1.MongoDBOpsManager, clusterName string) bool {
+	if item := getClusterSpecItem(currentAppDBClusterSpecList(om), clusterName); item != nil && item.Members > 0 {
+		return true
+	}
+	for _, item := range currentOpsManagerClusterSpecList(om) {
+		if item.ClusterName == clusterName && (item.Members > 0 || (item.Backup != nil && item.Backup.Members > 0)) {
+			return true
+		}
+	}
+	return false
+}
+
+// currentAppDBClusterSpecList returns a copy of the AppDB clusterSpecList currently deployed, which is the override of
+// the previous failover if there was one. It's empty if the AppDB is not multi-cluster.
+func currentAppDBClusterSpecList(om omv1.MongoDBOpsManager) mdb.ClusterSpecList {
+	if !om.Spec.AppDB.IsMultiCluster() {
+		return nil
+	}
+	if override, ok := om.Annotations[omv1.AppDBClusterSpecOverrideAnnotation]; ok {
+		var clusters mdb.ClusterSpecList
+		if err := json.Unmarshal([]byte(override), &clusters); err == nil {
+			return clusters
+		}
+	}
+	return om.Spec.AppDB.ClusterSpecList.DeepCopy()
+}
+
+// currentOpsManagerClusterSpecList returns a copy of the Ops Manager clusterSpecList currently deployed, which is the
+// override of the previous failover if there was one. It's empty if Ops Manager is not multi-cluster.
+func currentOpsManagerClusterSpecList(om omv1.MongoDBOpsManager) []omv1.ClusterSpecOMItem {
+	if !om.Spec.IsMultiCluster() {
+		return nil
+	}
+	if override, ok := om.Annotations[omv1.OpsManagerClusterSpecOverrideAnnotation]; ok {
+		var clusters []omv1.ClusterSpecOMItem
+		if err := json.Unmarshal([]byte(override), &clusters); err == nil {
+			return clusters
+		}
+	}
+	clusters := make([]omv1.ClusterSpecOMItem, len(om.Spec.ClusterSpecList))
+	for n := range om.Spec.ClusterSpecList {
+		clusters[n] = *om.Spec.ClusterSpecList[n].DeepCopy()
+	}
+	return clusters
+}
+
+// planOpsManagerFailover evenly distributes the AppDB members and the Ops Manager and Backup Daemon replicas of the
+// failed cluster to the clusters which are not marked as failed.
+func planOpsManagerFailover(om omv1.MongoDBOpsManager, clusterName string) opsManagerFailoverPlan {
+	plan := opsManagerFailoverPlan{clusterName: clusterName}
+	failedClusters := map[string]struct{}{clusterName: {}}
+	for _, failedCluster := range readFailedClusterAnnotation(om.Annotations) {
+		failedClusters[failedCluster.ClusterName] = struct{}{}
+	}
+	var moved []string
+
+	if appDBClusters := currentAppDBClusterSpecList(om); getClusterSpecItem(appDBClusters, clusterName) != nil {
+		members := getClusterMembers(appDBClusters, clusterName)
+		appDBClusters = removeCluster(appDBClusters, clusterName)
+		healthy := filterClusters(appDBClusters, allIndexes(len(appDBClusters)), func(c mdb.ClusterSpecItem) bool {
+			_, failed := failedClusters[c.ClusterName]
+			return !failed
+		})
+		if len(healthy) > 0 {
+			before := appDBClusters.DeepCopy()
+			distributeMembers(appDBClusters, members, healthy)
+			plan.appDBClusterSpecList = appDBClusters
+			moved = append(moved, fmt.Sprintf("%d AppDB members (%s)", members, describeMovedMembers(before, appDBClusters)))
+		}
+	}
+
+	opsManagerClusters := currentOpsManagerClusterSpecList(om)
+	for n, item := range opsManagerClusters {
+		if item.ClusterName != clusterName {
+			continue
+		}
+		opsManagerClusters = append(opsManagerClusters[:n], opsManagerClusters[n+1:]...)
+		var healthy []int
+		for nn, c := range opsManagerClusters {
+			if _, failed := failedClusters[c.ClusterName]; !failed {
+				healthy = append(healthy, nn)
+			}
+		}
+		if len(healthy) == 0 {
+			break
+		}
+		backupMembers := 0
+		if item.Backup != nil {
+			backupMembers = item.Backup.Members
+		}
+		distributeOpsManagerReplicas(opsManagerClusters, item.Members, backupMembers, healthy)
+		plan.opsManagerClusterSpecList = opsManagerClusters
+		moved = append(moved, fmt.Sprintf("%d Ops Manager and %d Backup Daemon replicas", item.Members, backupMembers))
+		break
+	}
+
+	if len(moved) == 0 {
+		plan.message = fmt.Sprintf("Cluster %s is marked as failed, its members are not moved because there is no healthy cluster to move them to", clusterName)
+		return plan
+	}
+	plan.message = fmt.Sprintf("Moving %s of failed cluster %s evenly", strings.Join(moved, " and "), clusterName)
+	return plan
+}
+
+func allIndexes(length int) []int {
+	indexes := make([]int, length)
+	for n := range indexes {
+		indexes[n] = n
+	}
+	return indexes
+}
+
+// distributeOpsManagerReplicas adds the Ops Manager and the Backup Daemon replicas one by one to the target cluster
+// with the fewest replicas.
+func distributeOpsManagerReplicas(clusters []omv1.ClusterSpecOMItem, members int, backupMembers int, targets []int) {
+	for ; members > 0; members-- {
+		mini, index := math.MaxInt64, -1
+		for _, n := range targets {
+			if clusters[n].Members < mini {
+				mini, index = clusters[n].Members, n
+			}
+		}
+		clusters[index].Members += 1
+	}
+	for ; backupMembers > 0; backupMembers-- {
+		mini, index := math.MaxInt64, -1
+		for _, n := range targets {
+			current := 0
+			if clusters[n].Backup != nil {
+				current = clusters[n].Backup.Members
+			}
+			if current < mini {
+				mini, index = current, n
+			}
+		}
+		if clusters[index].Backup == nil {
+			clusters[index].Backup = &omv1.MongoDBOpsManagerBackupClusterSpecItem{}
+		}
+		clusters[index].Backup.Members += 1
+	}
+}
+
+// opsManagerFailoverAnnotations are the annotations the failover of the MongoDBOpsManager resources is recorded in.
+var opsManagerFailoverAnnotations = []string{failedcluster.FailedClusterAnnotation, omv1.AppDBClusterSpecOverrideAnnotation, omv1.OpsManagerClusterSpecOverrideAnnotation}
+
+// applyOpsManagerFailoverPlan marks the cluster as failed and overrides the clusterSpecLists of the AppDB and Ops
+// Manager the members were moved in.
+func applyOpsManagerFailoverPlan(ctx context.Context, om *omv1.MongoDBOpsManager, plan opsManagerFailoverPlan, client kubernetesClient.Client) error {
+	annotationsToSet, err := failoverPlanAnnotations(*om, plan)
+	if err != nil {
+		return err
+	}
+	return annotations.SetAnnotations(ctx, om, annotationsToSet, client)
+}
+
+// failoverPlanAnnotations returns the annotations marking the cluster as failed and overriding the clusterSpecLists
+// the members were moved in.
+func failoverPlanAnnotations(om omv1.MongoDBOpsManager, plan opsManagerFailoverPlan) (map[string]string, error) {
+	failedClusters := append(readFailedClusterAnnotation(om.Annotations), failedcluster.FailedCluster{
+		ClusterName: plan.clusterName,
+		Members:     getClusterMembers(currentAppDBClusterSpecList(om), plan.clusterName),
+	})
+	failedClustersBytes, err := json.Marshal(failedClusters)
+	if err != nil {
+		return nil, err
+	}
+	annotationsToSet := map[string]string{failedcluster.FailedClusterAnnotation: string(failedClustersBytes)}
+
+	if plan.appDBClusterSpecList != nil {
+		appDBClustersBytes, err := json.Marshal(plan.appDBClusterSpecList)
+		if err != nil {
+			return nil, err
+		}
+		annotationsToSet[omv1.AppDBClusterSpecOverrideAnnotation] = string(appDBClustersBytes)
+	}
+	if plan.opsManagerClusterSpecList != nil {
+		opsManagerClustersBytes, err := json.Marshal(plan.opsManagerClusterSpecList)
+		if err != nil {
+			return nil, err
+		}
+		annotationsToSet[omv1.OpsManagerClusterSpecOverrideAnnotation] = string(opsManagerClustersBytes)
+	}
+	return annotationsToSet, nil
+}
+
+// failOverOpsManagers marks the unhealthy cluster as failed in the multi-cluster MongoDBOpsManager resources with
+// members in it. If the automated failover is enabled, the AppDB members and the Ops Manager and Backup Daemon
+// replicas of the cluster are moved to the healthy clusters and the resource is enqueued. The annotations of the
+// resources are updated in place, so the next failed cluster is added to them.
+func (m *MemberClusterHealthChecker) failOverOpsManagers(ctx context.Context, clusterName string, opsManagers []omv1.MongoDBOpsManager, centralClient kubernetesClient.Client, log *zap.SugaredLogger) {
+	for n := range opsManagers {
+		om := &opsManagers[n]
+		if !shouldAddFailedClusterAnnotation(om.Annotations, clusterName) || !hasOpsManagerMembers(*om, clusterName) {
+			continue
+		}
+
+		plan := opsManagerFailoverPlan{clusterName: clusterName, message: fmt.Sprintf("Cluster %s is marked as failed", clusterName)}
+		if multicluster.ShouldPerformFailover() {
+			plan = planOpsManagerFailover(*om, clusterName)
+		}
+		log.Infof("Failover of Ops Manager resource %s: %s", om.Name, plan.message)
+		if m.Recorder != nil {
+			m.Recorder.Event(om, corev1.EventTypeWarning, FailoverPlannedReason, plan.message)
+		}
+		if err := applyOpsManagerFailoverPlan(ctx, om, plan, centralClient); err != nil {
+			log.Errorf("Failed to add failover annotations to the Ops Manager resource %s: %s", om.Name, err)
+			continue
+		}
+		m.OpsManagerEvents <- event.GenericEvent{Object: om.DeepCopy()}
+	}
+}
+
+// planOpsManagerFailback returns the failover annotations of the resource once the recovered clusters are not marked
+// as failed anymore, and the recovered clusters. The failover of the clusters which are still failed is planned again
+// from the clusterSpecLists of the spec, so the members of the recovered clusters are moved back to them. The AppDB
+// is scaled one member at a time by its reconciler.
+func planOpsManagerFailback(om omv1.MongoDBOpsManager, recovered func(clusterName string) bool, moveMembers bool) (map[string]string, []string, error) {
+	failedClusters := readFailedClusterAnnotation(om.Annotations)
+	replayed := *om.DeepCopy()
+	for _, annotation := range opsManagerFailoverAnnotations {
+		delete(replayed.Annotations, annotation)
+	}
+
+	var recoveredClusters []string
+	for _, failed := range failedClusters {
+		if recovered(failed.ClusterName) {
+			recoveredClusters = append(recoveredClusters, failed.ClusterName)
+			continue
+		}
+		plan := opsManagerFailoverPlan{clusterName: failed.ClusterName}
+		if moveMembers {
+			plan = planOpsManagerFailover(replayed, failed.ClusterName)
+		}
+		failoverAnnotations, err := failoverPlanAnnotations(replayed, plan)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range failoverAnnotations {
+			replayed.Annotations[key] = value
+		}
+	}
+
+	failbackAnnotations := map[string]string{}
+	for _, annotation := range opsManagerFailoverAnnotations {
+		if value, ok := replayed.Annotations[annotation]; ok {
+			failbackAnnotations[annotation] = value
+		}
+	}
+	return failbackAnnotations, recoveredClusters, nil
+}
+
+// failBackOpsManagers moves the members of the multi-cluster MongoDBOpsManager resources back to the failed clusters
+// which have been healthy for mdbmulti.DefaultFailbackStableFor, and enqueues the resources. Each resource is read
+// again first, as it may have been failed over in the same health check.
+func (m *MemberClusterHealthChecker) failBackOpsManagers(ctx context.Context, opsManagers []omv1.MongoDBOpsManager, now time.Time, centralClient kubernetesClient.Client, log *zap.SugaredLogger) {
+	recovered := m.recoveredFor(mdbmulti.DefaultFailbackStableFor, now)
+	for _, listed := range opsManagers {
+		if _, ok := listed.Annotations[failedcluster.FailedClusterAnnotation]; !ok {
+			continue
+		}
+		om := &omv1.MongoDBOpsManager{}
+		if err := centralClient.Get(ctx, listed.ObjectKey(), om); err != nil {
+			log.Errorf("Failed to read the Ops Manager resource %s before its failback: %s", listed.Name, err)
+			continue
+		}
+
+		failbackAnnotations, recoveredClusters, err := planOpsManagerFailback(*om, recovered, multicluster.ShouldPerformFailover())
+		if err != nil {
+			log.Errorf("Failed to plan the failback of the Ops Manager resource %s: %s", om.Name, err)
+			continue
+		}
+		if len(recoveredClusters) == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("Clusters %s recovered, they are not marked as failed anymore and their members are moved back", strings.Join(recoveredClusters, ", "))
+		log.Infof("Failback of Ops Manager resource %s: %s", om.Name, message)
+		if m.Recorder != nil {
+			m.Recorder.Event(om, corev1.EventTypeNormal, FailbackReason, message)
+		}
+		if err := applyOpsManagerFailback(ctx, om, failbackAnnotations, centralClient); err != nil {
+			log.Errorf("Failed to update the failover annotations of the Ops Manager resource %s: %s", om.Name, err)
+			continue
+		}
+		m.OpsManagerEvents <- event.GenericEvent{Object: om.DeepCopy()}
+	}
+}
+
+// applyOpsManagerFailback replaces the failover annotations of the resource with the ones of the failback.
+func applyOpsManagerFailback(ctx context.Context, om *omv1.MongoDBOpsManager, failbackAnnotations map[string]string, client kubernetesClient.Client) error {
+	var annotationsToRemove []string
+	for _, annotation := range opsManagerFailoverAnnotations {
+		if _, ok := failbackAnnotations[annotation]; !ok {
+			annotationsToRemove = append(annotationsToRemove, annotation)
+		}
+	}
+	if len(failbackAnnotations) > 0 {
+		if err := annotations.SetAnnotations(ctx, om, failbackAnnotations, client); err != nil {
+			return err
+		}
+	}
+	return annotations.RemoveAnnotations(ctx, om, annotationsToRemove, client)
+}
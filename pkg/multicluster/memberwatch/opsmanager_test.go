@@ -0,0 +1,168 @@
+package memberwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster/failedcluster"
+)
+
+func newOpsManagerFailoverTestResource() omv1.MongoDBOpsManager {
+	return *omv1.NewOpsManagerBuilderDefault().
+		SetAppDBTopology(omv1.ClusterTopologyMultiCluster).
+		SetAppDBClusterSpecList(mdb.ClusterSpecList{
+			{ClusterName: "cluster-a", Members: 2},
+			{ClusterName: "cluster-b", Members: 1},
+			{ClusterName: "cluster-c", Members: 1},
+		}).
+		SetOpsManagerTopology(omv1.ClusterTopologyMultiCluster).
+		SetOpsManagerClusterSpecList([]omv1.ClusterSpecOMItem{
+			{ClusterName: "cluster-a", Members: 2, Backup: &omv1.MongoDBOpsManagerBackupClusterSpecItem{Members: 1}},
+			{ClusterName: "cluster-b", Members: 1},
+		}).
+		Build()
+}
+
+func TestPlanOpsManagerFailover(t *testing.T) {
+	opsManager := newOpsManagerFailoverTestResource()
+
+	plan := planOpsManagerFailover(opsManager, "cluster-a")
+
+	assert.Equal(t, mdb.ClusterSpecList{{ClusterName: "cluster-b", Members: 2}, {ClusterName: "cluster-c", Members: 2}}, plan.appDBClusterSpecList)
+	assert.Equal(t, []omv1.ClusterSpecOMItem{
+		{ClusterName: "cluster-b", Members: 3, Backup: &omv1.MongoDBOpsManagerBackupClusterSpecItem{Members: 1}},
+	}, plan.opsManagerClusterSpecList)
+	assert.Equal(t, "Moving 2 AppDB members (cluster-b +1, cluster-c +1) and 2 Ops Manager and 1 Backup Daemon replicas of failed cluster cluster-a evenly", plan.message)
+	// the spec is not changed
+	assert.Equal(t, 2, opsManager.Spec.ClusterSpecList[0].Members)
+}
+
+func TestPlanOpsManagerFailover_SkipsFailedClusters(t *testing.T) {
+	opsManager := newOpsManagerFailoverTestResource()
+	opsManager.Annotations = map[string]string{failedcluster.FailedClusterAnnotation: `[{"ClusterName":"cluster-b","Members":1}]`}
+
+	plan := planOpsManagerFailover(opsManager, "cluster-a")
+
+	assert.Equal(t, mdb.ClusterSpecList{{ClusterName: "cluster-b", Members: 1}, {ClusterName: "cluster-c", Members: 3}}, plan.appDBClusterSpecList)
+	// there is no healthy cluster left for Ops Manager
+	assert.Nil(t, plan.opsManagerClusterSpecList)
+}
+
+func TestHasOpsManagerMembers(t *testing.T) {
+	opsManager := newOpsManagerFailoverTestResource()
+	assert.True(t, hasOpsManagerMembers(opsManager, "cluster-c"))
+	assert.False(t, hasOpsManagerMembers(opsManager, "cluster-d"))
+
+	singleCluster := *omv1.NewOpsManagerBuilderDefault().Build()
+	assert.False(t, hasOpsManagerMembers(singleCluster, "cluster-a"))
+}
+
+func TestFailOverOpsManagers(t *testing.T) {
+	t.Setenv("PERFORM_FAILOVER", "true")
+	ctx := context.Background()
+	opsManager := newOpsManagerFailoverTestResource()
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&opsManager).Build()
+	events := make(chan event.GenericEvent, 2)
+	checker := MemberClusterHealthChecker{Recorder: record.NewFakeRecorder(2), OpsManagerEvents: events}
+	opsManagers := []omv1.MongoDBOpsManager{opsManager}
+
+	checker.failOverOpsManagers(ctx, "cluster-a", opsManagers, kubernetesClient.NewClient(fakeClient), zap.S())
+	// the resource isn't failed over twice
+	checker.failOverOpsManagers(ctx, "cluster-a", opsManagers, kubernetesClient.NewClient(fakeClient), zap.S())
+
+	require.Len(t, events, 1)
+	updated := omv1.MongoDBOpsManager{}
+	require.NoError(t, fakeClient.Get(ctx, opsManager.ObjectKey(), &updated))
+	failedClusters, err := updated.GetFailedClusterNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster-a"}, failedClusters)
+
+	require.NoError(t, updated.ApplyClusterSpecOverrides())
+	assert.Equal(t, mdb.ClusterSpecList{{ClusterName: "cluster-b", Members: 2}, {ClusterName: "cluster-c", Members: 2}}, updated.Spec.AppDB.ClusterSpecList)
+	assert.Len(t, updated.Spec.ClusterSpecList, 1)
+}
+
+func TestFailOverOpsManagers_FailoverDisabled(t *testing.T) {
+	ctx := context.Background()
+	opsManager := newOpsManagerFailoverTestResource()
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&opsManager).Build()
+	events := make(chan event.GenericEvent, 1)
+	checker := MemberClusterHealthChecker{OpsManagerEvents: events}
+
+	checker.failOverOpsManagers(ctx, "cluster-a", []omv1.MongoDBOpsManager{opsManager}, kubernetesClient.NewClient(fakeClient), zap.S())
+
+	// the cluster is only marked as failed
+	require.Len(t, events, 1)
+	updated := omv1.MongoDBOpsManager{}
+	require.NoError(t, fakeClient.Get(ctx, opsManager.ObjectKey(), &updated))
+	assert.Contains(t, updated.Annotations, failedcluster.FailedClusterAnnotation)
+	assert.NotContains(t, updated.Annotations, omv1.AppDBClusterSpecOverrideAnnotation)
+	assert.NotContains(t, updated.Annotations, omv1.OpsManagerClusterSpecOverrideAnnotation)
+}
+
+// failOverOpsManagerInMemory applies the failover of the cluster to the annotations of the resource.
+func failOverOpsManagerInMemory(t *testing.T, opsManager *omv1.MongoDBOpsManager, clusterName string) {
+	failoverAnnotations, err := failoverPlanAnnotations(*opsManager, planOpsManagerFailover(*opsManager, clusterName))
+	require.NoError(t, err)
+	if opsManager.Annotations == nil {
+		opsManager.Annotations = map[string]string{}
+	}
+	for key, value := range failoverAnnotations {
+		opsManager.Annotations[key] = value
+	}
+}
+
+func TestPlanOpsManagerFailback(t *testing.T) {
+	opsManager := newOpsManagerFailoverTestResource()
+	failOverOpsManagerInMemory(t, &opsManager, "cluster-a")
+	failOverOpsManagerInMemory(t, &opsManager, "cluster-c")
+
+	failbackAnnotations, recoveredClusters, err := planOpsManagerFailback(opsManager, func(clusterName string) bool { return clusterName == "cluster-a" }, true)
+	require.NoError(t, err)
+
+	// the failover of cluster-c is planned again as if cluster-a never failed
+	assert.Equal(t, []string{"cluster-a"}, recoveredClusters)
+	assert.Equal(t, `[{"ClusterName":"cluster-c","Members":1}]`, failbackAnnotations[failedcluster.FailedClusterAnnotation])
+	opsManager.Annotations = failbackAnnotations
+	require.NoError(t, opsManager.ApplyClusterSpecOverrides())
+	assert.Equal(t, mdb.ClusterSpecList{{ClusterName: "cluster-a", Members: 2}, {ClusterName: "cluster-b", Members: 2}}, opsManager.Spec.AppDB.ClusterSpecList)
+	assert.Equal(t, 2, opsManager.Spec.ClusterSpecList[0].Members)
+	assert.NotContains(t, failbackAnnotations, omv1.OpsManagerClusterSpecOverrideAnnotation)
+}
+
+func TestFailBackOpsManagers(t *testing.T) {
+	ctx := context.Background()
+	opsManager := newOpsManagerFailoverTestResource()
+	failOverOpsManagerInMemory(t, &opsManager, "cluster-a")
+	fakeClient := fake.NewClientBuilder().WithScheme(failoverTestScheme(t)).WithObjects(&opsManager).Build()
+	events := make(chan event.GenericEvent, 1)
+	checker := MemberClusterHealthChecker{Recorder: record.NewFakeRecorder(1), OpsManagerEvents: events}
+	opsManagers := []omv1.MongoDBOpsManager{opsManager}
+
+	// the cluster has to be healthy for long enough
+	now := time.Now()
+	checker.markHealth("cluster-a", true, now.Add(-time.Minute))
+	checker.failBackOpsManagers(ctx, opsManagers, now, kubernetesClient.NewClient(fakeClient), zap.S())
+	require.Len(t, events, 0)
+
+	checker.failBackOpsManagers(ctx, opsManagers, now.Add(mdbmulti.DefaultFailbackStableFor), kubernetesClient.NewClient(fakeClient), zap.S())
+
+	require.Len(t, events, 1)
+	updated := omv1.MongoDBOpsManager{}
+	require.NoError(t, fakeClient.Get(ctx, opsManager.ObjectKey(), &updated))
+	for _, annotation := range opsManagerFailoverAnnotations {
+		assert.NotContains(t, updated.Annotations, annotation)
+	}
+}
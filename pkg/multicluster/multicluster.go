@@ -12,6 +12,7 @@ import (
 	"github.com/ghodss/yaml"
 	"golang.org/x/xerrors"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 
@@ -28,6 +29,13 @@ const (
 	DefaultKubeConfigPath   = "/etc/config/kubeconfig/kubeconfig"
 	KubeConfigPathEnv       = "KUBE_CONFIG_PATH"
 	ClusterClientTimeoutEnv = "CLUSTER_CLIENT_TIMEOUT"
+
+	// HealthScoreThresholdEnv is the minimum health score, from 0 to 100, of a healthy member cluster
+	HealthScoreThresholdEnv     = "MEMBER_CLUSTER_HEALTH_SCORE_THRESHOLD"
+	DefaultHealthScoreThreshold = 50
+	// CanaryImageEnv is the image of the canary pods scheduled to check the health of the member clusters
+	CanaryImageEnv     = "MEMBER_CLUSTER_CANARY_IMAGE"
+	DefaultCanaryImage = "registry.k8s.io/pause:3.10"
 )
 
 type KubeConfig struct {
@@ -174,6 +182,21 @@ func GetRsNamefromMultiStsName(name string) string {
 	return strings.Join(ss[:len(ss)-1], "-")
 }
 
+// NewCluster creates the cluster object of a member cluster, caching the resources of the watched namespaces only.
+func NewCluster(config *restclient.Config, namespacesToWatch []string) (cluster.Cluster, error) {
+	return cluster.New(config, func(options *cluster.Options) {
+		if len(namespacesToWatch) > 1 || namespacesToWatch[0] != "" {
+			defaultNamespaces := make(map[string]cache.Config)
+			for _, namespace := range namespacesToWatch {
+				defaultNamespaces[namespace] = cache.Config{}
+			}
+			options.Cache = cache.Options{
+				DefaultNamespaces: defaultNamespaces,
+			}
+		}
+	})
+}
+
 func ClustersMapToClientMap(clusterMap map[string]cluster.Cluster) map[string]client.Client {
 	clientMap := map[string]client.Client{}
 	for memberClusterName, memberCluster := range clusterMap {
@@ -0,0 +1,286 @@
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/secrets"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+)
+
+// ClusterListener is notified about the member clusters added to the Registry, e.g. to watch the resources in the
+// member cluster.
+type ClusterListener func(clusterName string, memberCluster cluster.Cluster) error
+
+// Registry holds the member clusters the operator manages resources in. The member clusters are either read from
+// the KubeConfig file on startup, or registered with MongoDBMemberCluster resources while the operator is running,
+// so the controllers must read the member clusters from the Registry on each reconciliation instead of keeping them.
+type Registry struct {
+	mu        sync.RWMutex
+	clusters  map[string]registeredCluster
+	listeners []ClusterListener
+}
+
+type registeredCluster struct {
+	client  client.Client
+	cluster cluster.Cluster
+	config  *rest.Config
+	// stop stops the cache of the cluster, it's nil if the cluster is run by the manager
+	stop context.CancelFunc
+	// health is nil until the cluster is health checked
+	health *ClusterHealth
+}
+
+// ClusterHealth is the result of the health check of a member cluster.
+type ClusterHealth struct {
+	Healthy bool
+	// Score is the health score of the member cluster, from 0 to 100
+	Score int
+	// Signals are the scores of the health signals the Score is combined from, they're empty if only the API server
+	// was checked
+	Signals []SignalScore
+}
+
+// SignalScore is the score of a single health signal of a member cluster.
+type SignalScore struct {
+	Name string
+	// Score of the signal, from 0 to 100
+	Score int
+	// Error is set if the signal is not available, the signal is then ignored in the health score
+	Error string
+	// Weight of the signal in the health score, the signals measuring the databases in the member cluster weigh less
+	// than the signals of the cluster itself
+	Weight float64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{clusters: map[string]registeredCluster{}}
+}
+
+// NewClientRegistry returns a Registry with the given clients and no cluster objects, which is enough for the
+// controllers which don't watch the resources of the member clusters.
+func NewClientRegistry(clients map[string]client.Client) *Registry {
+	registry := NewRegistry()
+	for clusterName, memberClient := range clients {
+		registry.clusters[clusterName] = registeredCluster{client: memberClient}
+	}
+	return registry
+}
+
+// Add registers the member cluster under the name, replacing and stopping the cluster previously registered with
+// the same name. The listeners are notified about the new cluster. The stop function, if set, is called once the
+// cluster is removed from the Registry.
+func (r *Registry) Add(clusterName string, memberCluster cluster.Cluster, config *rest.Config, stop context.CancelFunc) error {
+	r.mu.Lock()
+	if previous, ok := r.clusters[clusterName]; ok && previous.stop != nil {
+		previous.stop()
+	}
+	r.clusters[clusterName] = registeredCluster{client: memberCluster.GetClient(), cluster: memberCluster, config: config, stop: stop}
+	listeners := append([]ClusterListener{}, r.listeners...)
+	r.mu.Unlock()
+
+	var errs []error
+	for _, listener := range listeners {
+		if err := listener(clusterName, memberCluster); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return xerrors.Errorf("failed to notify the listeners about member cluster %s: %w", clusterName, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Remove unregisters and stops the member cluster. It returns false if the cluster isn't registered.
+func (r *Registry) Remove(clusterName string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered, ok := r.clusters[clusterName]
+	if !ok {
+		return false
+	}
+	if registered.stop != nil {
+		registered.stop()
+	}
+	delete(r.clusters, clusterName)
+	return true
+}
+
+// OnAdd registers the listener and notifies it about the clusters already registered.
+func (r *Registry) OnAdd(listener ClusterListener) error {
+	r.mu.Lock()
+	r.listeners = append(r.listeners, listener)
+	clusters := r.clusterObjects()
+	r.mu.Unlock()
+
+	for clusterName, memberCluster := range clusters {
+		if err := listener(clusterName, memberCluster); err != nil {
+			return xerrors.Errorf("failed to notify the listener about member cluster %s: %w", clusterName, err)
+		}
+	}
+	return nil
+}
+
+// Has returns true if the member cluster is registered.
+func (r *Registry) Has(clusterName string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.clusters[clusterName]
+	return ok
+}
+
+// Names returns the sorted names of the registered member clusters.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.clusters))
+	for clusterName := range r.clusters {
+		names = append(names, clusterName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClientMap returns the clients of the registered member clusters by cluster name. The map is a snapshot owned by
+// the caller.
+func (r *Registry) ClientMap() map[string]client.Client {
+	clients := map[string]client.Client{}
+	if r == nil {
+		return clients
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for clusterName, registered := range r.clusters {
+		clients[clusterName] = registered.client
+	}
+	return clients
+}
+
+// KubeClients returns the clients of the registered member clusters by cluster name, wrapped into
+// kubernetesClient.Client.
+func (r *Registry) KubeClients() map[string]kubernetesClient.Client {
+	clients := map[string]kubernetesClient.Client{}
+	for clusterName, memberClient := range r.ClientMap() {
+		clients[clusterName] = kubernetesClient.NewClient(memberClient)
+	}
+	return clients
+}
+
+// KubeClient returns the client of the member cluster, ok is false if the cluster isn't registered.
+func (r *Registry) KubeClient(clusterName string) (memberClient kubernetesClient.Client, ok bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registered, ok := r.clusters[clusterName]
+	if !ok {
+		return nil, false
+	}
+	return kubernetesClient.NewClient(registered.client), true
+}
+
+// SecretClients returns the secret clients of the registered member clusters by cluster name.
+func (r *Registry) SecretClients() map[string]secrets.SecretClient {
+	secretClients := map[string]secrets.SecretClient{}
+	for clusterName, memberClient := range r.KubeClients() {
+		secretClients[clusterName] = secrets.SecretClient{
+			VaultClient: nil, // Vault is not supported yet on multicluster
+			KubeClient:  memberClient,
+		}
+	}
+	return secretClients
+}
+
+// Clusters returns the cluster objects of the registered member clusters by cluster name.
+func (r *Registry) Clusters() map[string]cluster.Cluster {
+	if r == nil {
+		return map[string]cluster.Cluster{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.clusterObjects()
+}
+
+func (r *Registry) clusterObjects() map[string]cluster.Cluster {
+	clusters := map[string]cluster.Cluster{}
+	for clusterName, registered := range r.clusters {
+		if registered.cluster != nil {
+			clusters[clusterName] = registered.cluster
+		}
+	}
+	return clusters
+}
+
+// Config returns the configuration the client of the member cluster was created with, or nil if it is unknown.
+func (r *Registry) Config(clusterName string) *rest.Config {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.clusters[clusterName].config
+}
+
+// SetHealthy records the result of the last health check of the API server of the member cluster.
+func (r *Registry) SetHealthy(clusterName string, healthy bool) {
+	health := ClusterHealth{Healthy: healthy}
+	if healthy {
+		health.Score = 100
+	}
+	r.SetHealth(clusterName, health)
+}
+
+// SetHealth records the result of the last health check of the member cluster.
+func (r *Registry) SetHealth(clusterName string, health ClusterHealth) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if registered, ok := r.clusters[clusterName]; ok {
+		registered.health = &health
+		r.clusters[clusterName] = registered
+	}
+}
+
+// Healthy returns the result of the last health check of the member cluster, checked is false if the cluster
+// hasn't been health checked yet.
+func (r *Registry) Healthy(clusterName string) (healthy bool, checked bool) {
+	health, checked := r.Health(clusterName)
+	return health.Healthy, checked
+}
+
+// Health returns the result of the last health check of the member cluster, including its health score. checked is
+// false if the cluster hasn't been health checked yet.
+func (r *Registry) Health(clusterName string) (health ClusterHealth, checked bool) {
+	if r == nil {
+		return ClusterHealth{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registered, ok := r.clusters[clusterName]
+	if !ok || registered.health == nil {
+		return ClusterHealth{}, false
+	}
+	return *registered.health, true
+}
@@ -0,0 +1,101 @@
+package multicluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+func TestRegistry_AddAndRemove(t *testing.T) {
+	registry := NewRegistry()
+	stopped := map[string]int{}
+	stopFunc := func(name string) func() {
+		return func() { stopped[name]++ }
+	}
+
+	require.NoError(t, registry.Add("cluster-2", New(fake.NewFakeClient()), &rest.Config{Host: "https://cluster-2"}, stopFunc("cluster-2")))
+	require.NoError(t, registry.Add("cluster-1", New(fake.NewFakeClient()), nil, nil))
+
+	assert.Equal(t, []string{"cluster-1", "cluster-2"}, registry.Names())
+	assert.Len(t, registry.ClientMap(), 2)
+	assert.Len(t, registry.Clusters(), 2)
+	assert.Equal(t, "https://cluster-2", registry.Config("cluster-2").Host)
+	assert.Nil(t, registry.Config("cluster-1"))
+
+	// replacing a cluster stops the previous one
+	require.NoError(t, registry.Add("cluster-2", New(fake.NewFakeClient()), &rest.Config{Host: "https://new-cluster-2"}, stopFunc("cluster-2")))
+	assert.Equal(t, 1, stopped["cluster-2"])
+	assert.Equal(t, "https://new-cluster-2", registry.Config("cluster-2").Host)
+
+	assert.True(t, registry.Remove("cluster-2"))
+	assert.Equal(t, 2, stopped["cluster-2"])
+	assert.False(t, registry.Has("cluster-2"))
+	assert.False(t, registry.Remove("cluster-2"))
+	assert.Equal(t, []string{"cluster-1"}, registry.Names())
+}
+
+func TestRegistry_OnAdd(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Add("cluster-1", New(fake.NewFakeClient()), nil, nil))
+
+	var notified []string
+	require.NoError(t, registry.OnAdd(func(clusterName string, _ cluster.Cluster) error {
+		notified = append(notified, clusterName)
+		return nil
+	}))
+	// the listener is notified about the clusters registered before and after it
+	assert.Equal(t, []string{"cluster-1"}, notified)
+
+	require.NoError(t, registry.Add("cluster-2", New(fake.NewFakeClient()), nil, nil))
+	assert.Equal(t, []string{"cluster-1", "cluster-2"}, notified)
+
+	assert.Error(t, registry.OnAdd(func(clusterName string, _ cluster.Cluster) error {
+		return xerrors.New("watch failed")
+	}))
+	err := registry.Add("cluster-3", New(fake.NewFakeClient()), nil, nil)
+	assert.ErrorContains(t, err, "watch failed")
+	// the cluster is registered even if a listener fails
+	assert.True(t, registry.Has("cluster-3"))
+}
+
+func TestRegistry_Health(t *testing.T) {
+	registry := NewClientRegistry(map[string]client.Client{"cluster-1": fake.NewFakeClient()})
+
+	_, checked := registry.Healthy("cluster-1")
+	assert.False(t, checked)
+
+	registry.SetHealthy("cluster-1", false)
+	healthy, checked := registry.Healthy("cluster-1")
+	assert.True(t, checked)
+	assert.False(t, healthy)
+
+	registry.SetHealth("cluster-1", ClusterHealth{Healthy: true, Score: 75, Signals: []SignalScore{{Name: "nodes", Score: 50}}})
+	health, checked := registry.Health("cluster-1")
+	assert.True(t, checked)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, 75, health.Score)
+
+	// the health of unknown clusters is not recorded
+	registry.SetHealthy("cluster-2", true)
+	_, checked = registry.Healthy("cluster-2")
+	assert.False(t, checked)
+
+	// the clusters without a cluster object are not returned by Clusters
+	assert.Empty(t, registry.Clusters())
+	assert.Len(t, registry.ClientMap(), 1)
+}
+
+func TestRegistry_Nil(t *testing.T) {
+	var registry *Registry
+
+	assert.Empty(t, registry.ClientMap())
+	assert.NotNil(t, registry.ClientMap())
+	assert.Empty(t, registry.Names())
+	assert.False(t, registry.Has("cluster-1"))
+}
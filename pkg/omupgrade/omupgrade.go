@@ -0,0 +1,187 @@
+package omupgrade
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/blang/semver"
+	"golang.org/x/xerrors"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
+)
+
+// UpgradePath describes the prerequisites of an upgrade to an Ops Manager major version, as documented in the
+// Ops Manager upgrade guide.
+type UpgradePath struct {
+	// MinimumFromVersion is the oldest Ops Manager version which can be upgraded to this major version directly.
+	MinimumFromVersion string `json:"minimumFromVersion"`
+	// MinimumAppDBVersion is the oldest MongoDB version of the Application Database supported by this major version.
+	MinimumAppDBVersion string `json:"minimumAppDBVersion,omitempty"`
+	// MinimumAppDBFeatureCompatibilityVersion is the lowest FCV of the Application Database supported by this major version.
+	MinimumAppDBFeatureCompatibilityVersion string `json:"minimumAppDBFeatureCompatibilityVersion,omitempty"`
+}
+
+// Manifest is the part of the version manifest (release.json) describing Ops Manager upgrades.
+type Manifest struct {
+	// UpgradePaths maps the Ops Manager major version to the prerequisites of the upgrade to it.
+	UpgradePaths map[string]UpgradePath
+	// AgentVersions maps the Ops Manager version to the agent version shipped with it.
+	AgentVersions map[omv1.OpsManagerVersion]omv1.AgentVersion
+}
+
+type manifestFile struct {
+	SupportedImages struct {
+		OpsManager struct {
+			UpgradePaths map[string]UpgradePath `json:"upgradePaths"`
+		} `json:"ops-manager"`
+		MongoDBAgent struct {
+			OpsManagerMapping omv1.OpsManagerVersionMapping `json:"opsManagerMapping"`
+		} `json:"mongodb-agent"`
+	} `json:"supportedImages"`
+}
+
+// ReadManifest reads the Ops Manager upgrade paths and agent versions from the version manifest file.
+func ReadManifest(filePath string) (Manifest, error) {
+	fileBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return Manifest{}, xerrors.Errorf("failed reading file %s: %w", filePath, err)
+	}
+
+	var content manifestFile
+	if err := json.Unmarshal(fileBytes, &content); err != nil {
+		return Manifest{}, xerrors.Errorf("failed unmarshalling bytes from file %s: %w", filePath, err)
+	}
+
+	return Manifest{
+		UpgradePaths:  content.SupportedImages.OpsManager.UpgradePaths,
+		AgentVersions: content.SupportedImages.MongoDBAgent.OpsManagerMapping.OpsManager,
+	}, nil
+}
+
+// HasUpgradePaths returns false if the version manifest doesn't describe any upgrade path, which is the case for
+// manifests shipped before the upgrade paths were added to it.
+func (m Manifest) HasUpgradePaths() bool {
+	return len(m.UpgradePaths) > 0
+}
+
+// Upgrade describes the Ops Manager upgrade to validate.
+type Upgrade struct {
+	FromVersion  string
+	ToVersion    string
+	AppDBVersion string
+	AppDBFCV     string
+}
+
+// IsMajorUpgrade returns true if the Ops Manager major version changes between the two versions.
+func IsMajorUpgrade(fromVersion, toVersion string) bool {
+	from, err := versionutil.StringToSemverVersion(fromVersion)
+	if err != nil {
+		return false
+	}
+	to, err := versionutil.StringToSemverVersion(toVersion)
+	if err != nil {
+		return false
+	}
+	return from.Major != to.Major
+}
+
+// Validate checks that the upgrade follows a supported upgrade path and that the Application Database and the agents
+// satisfy the requirements of the target Ops Manager major version. Upgrades within the same major version are not
+// restricted. If the manifest has no upgrade paths (see HasUpgradePaths), only the agent version is validated.
+func (m Manifest) Validate(upgrade Upgrade) error {
+	from, err := parseVersion(upgrade.FromVersion)
+	if err != nil {
+		return err
+	}
+	to, err := parseVersion(upgrade.ToVersion)
+	if err != nil {
+		return err
+	}
+	if from.Major == to.Major {
+		return nil
+	}
+	if to.Major < from.Major {
+		return xerrors.Errorf("downgrading Ops Manager from %s to %s is not supported", upgrade.FromVersion, upgrade.ToVersion)
+	}
+
+	major := strconv.FormatUint(to.Major, 10)
+	if m.HasUpgradePaths() {
+		if err := m.validateUpgradePath(upgrade, from, major); err != nil {
+			return err
+		}
+	}
+
+	if !m.hasAgentVersionForMajor(to.Major) {
+		return xerrors.Errorf("the version manifest doesn't contain an agent version for Ops Manager %s", major)
+	}
+
+	return nil
+}
+
+// validateUpgradePath checks the upgrade against the prerequisites of the upgrade path to the target major version.
+func (m Manifest) validateUpgradePath(upgrade Upgrade, from semver.Version, major string) error {
+	path, ok := m.UpgradePaths[major]
+	if !ok {
+		return xerrors.Errorf("the version manifest doesn't describe the upgrade path to Ops Manager %s", major)
+	}
+
+	minimumFrom, err := parseVersion(path.MinimumFromVersion)
+	if err != nil {
+		return err
+	}
+	if from.LT(minimumFrom) {
+		return xerrors.Errorf("Ops Manager %s can't be upgraded to %s directly, upgrade to Ops Manager %s or later first", upgrade.FromVersion, upgrade.ToVersion, path.MinimumFromVersion)
+	}
+
+	if path.MinimumAppDBVersion != "" {
+		appDBVersion, err := parseVersion(upgrade.AppDBVersion)
+		if err != nil {
+			return err
+		}
+		minimumAppDB, err := parseVersion(path.MinimumAppDBVersion)
+		if err != nil {
+			return err
+		}
+		if appDBVersion.LT(minimumAppDB) {
+			return xerrors.Errorf("Ops Manager %s requires the Application Database version %s or later, the current version is %s", upgrade.ToVersion, path.MinimumAppDBVersion, upgrade.AppDBVersion)
+		}
+	}
+
+	if path.MinimumAppDBFeatureCompatibilityVersion != "" {
+		appDBFCV, err := semver.ParseTolerant(upgrade.AppDBFCV)
+		if err != nil {
+			return xerrors.Errorf("failed to parse the Application Database featureCompatibilityVersion %s: %w", upgrade.AppDBFCV, err)
+		}
+		minimumFCV, err := semver.ParseTolerant(path.MinimumAppDBFeatureCompatibilityVersion)
+		if err != nil {
+			return xerrors.Errorf("failed to parse the featureCompatibilityVersion %s: %w", path.MinimumAppDBFeatureCompatibilityVersion, err)
+		}
+		if appDBFCV.LT(minimumFCV) {
+			return xerrors.Errorf("Ops Manager %s requires the Application Database featureCompatibilityVersion %s or later, the current one is %s", upgrade.ToVersion, path.MinimumAppDBFeatureCompatibilityVersion, upgrade.AppDBFCV)
+		}
+	}
+
+	return nil
+}
+
+func (m Manifest) hasAgentVersionForMajor(major uint64) bool {
+	for omVersion := range m.AgentVersions {
+		if v, err := versionutil.StringToSemverVersion(string(omVersion)); err == nil && v.Major == major {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVersion parses the version ignoring any suffix after the patch, so "6.0.5-ent" is the same as "6.0.5".
+func parseVersion(version string) (semver.Version, error) {
+	v, err := versionutil.StringToSemverVersion(version)
+	if err != nil {
+		return semver.Version{}, xerrors.Errorf("failed to parse version %s: %w", version, err)
+	}
+	v.Pre = nil
+	v.Build = nil
+	return v, nil
+}
@@ -0,0 +1,144 @@
+package omupgrade
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	omv1 "github.com/mongodb/mongodb-kubernetes/api/v1/om"
+)
+
+var manifestContents = `
+{
+  "supportedImages": {
+    "ops-manager": {
+      "versions": ["6.0.26", "7.0.12", "8.0.0"],
+      "upgradePaths": {
+        "7": {
+          "minimumFromVersion": "6.0.0",
+          "minimumAppDBVersion": "4.4.0",
+          "minimumAppDBFeatureCompatibilityVersion": "4.4"
+        },
+        "8": {
+          "minimumFromVersion": "7.0.0",
+          "minimumAppDBVersion": "6.0.0",
+          "minimumAppDBFeatureCompatibilityVersion": "6.0"
+        }
+      }
+    },
+    "mongodb-agent": {
+      "opsManagerMapping": {
+        "ops_manager": {
+          "6.0.26": {"agent_version": "12.0.33.7866-1"},
+          "7.0.12": {"agent_version": "107.0.12.8669-1"},
+          "8.0.0": {"agent_version": "108.0.0.8694-1"}
+        }
+      }
+    }
+  }
+}
+`
+
+func TestReadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.json")
+	require.NoError(t, os.WriteFile(path, []byte(manifestContents), 0o600))
+
+	manifest, err := ReadManifest(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, UpgradePath{MinimumFromVersion: "7.0.0", MinimumAppDBVersion: "6.0.0", MinimumAppDBFeatureCompatibilityVersion: "6.0"}, manifest.UpgradePaths["8"])
+	assert.Equal(t, omv1.AgentVersion{AgentVersion: "108.0.0.8694-1"}, manifest.AgentVersions["8.0.0"])
+
+	_, err = ReadManifest(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.json")
+	require.NoError(t, os.WriteFile(path, []byte(manifestContents), 0o600))
+	manifest, err := ReadManifest(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		upgrade       Upgrade
+		manifest      Manifest
+		expectedError string
+	}{
+		{
+			name:    "patch upgrade is not restricted",
+			upgrade: Upgrade{FromVersion: "7.0.12", ToVersion: "7.0.13", AppDBVersion: "4.2.0", AppDBFCV: "4.2"},
+		},
+		{
+			name:    "supported major upgrade",
+			upgrade: Upgrade{FromVersion: "7.0.12", ToVersion: "8.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+		},
+		{
+			name:          "major downgrade",
+			upgrade:       Upgrade{FromVersion: "8.0.0", ToVersion: "7.0.12", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+			expectedError: "downgrading Ops Manager from 8.0.0 to 7.0.12 is not supported",
+		},
+		{
+			name:          "skipping a major version",
+			upgrade:       Upgrade{FromVersion: "6.0.26", ToVersion: "8.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+			expectedError: "upgrade to Ops Manager 7.0.0 or later first",
+		},
+		{
+			name:          "application database is too old",
+			upgrade:       Upgrade{FromVersion: "7.0.12", ToVersion: "8.0.0", AppDBVersion: "5.0.15-ent", AppDBFCV: "5.0"},
+			expectedError: "requires the Application Database version 6.0.0 or later, the current version is 5.0.15-ent",
+		},
+		{
+			name:          "application database FCV is too old",
+			upgrade:       Upgrade{FromVersion: "7.0.12", ToVersion: "8.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "5.0"},
+			expectedError: "requires the Application Database featureCompatibilityVersion 6.0 or later",
+		},
+		{
+			name:          "unknown upgrade path",
+			upgrade:       Upgrade{FromVersion: "8.0.0", ToVersion: "9.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+			expectedError: "doesn't describe the upgrade path to Ops Manager 9",
+		},
+		{
+			name:     "manifest without upgrade paths",
+			upgrade:  Upgrade{FromVersion: "6.0.26", ToVersion: "8.0.0", AppDBVersion: "4.2.24-ent", AppDBFCV: "4.2"},
+			manifest: Manifest{UpgradePaths: map[string]UpgradePath{}, AgentVersions: manifest.AgentVersions},
+		},
+		{
+			name:          "manifest without upgrade paths and no agent for the target version",
+			upgrade:       Upgrade{FromVersion: "7.0.12", ToVersion: "9.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+			manifest:      Manifest{UpgradePaths: map[string]UpgradePath{}, AgentVersions: manifest.AgentVersions},
+			expectedError: "doesn't contain an agent version for Ops Manager 9",
+		},
+		{
+			name:          "no agent for the target version",
+			upgrade:       Upgrade{FromVersion: "7.0.12", ToVersion: "8.0.0", AppDBVersion: "6.0.5-ent", AppDBFCV: "6.0"},
+			manifest:      Manifest{UpgradePaths: manifest.UpgradePaths},
+			expectedError: "doesn't contain an agent version for Ops Manager 8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := manifest
+			if tt.manifest.UpgradePaths != nil {
+				m = tt.manifest
+			}
+			err := m.Validate(tt.upgrade)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestIsMajorUpgrade(t *testing.T) {
+	assert.True(t, IsMajorUpgrade("7.0.12", "8.0.0"))
+	assert.True(t, IsMajorUpgrade("8.0.0", "7.0.12"))
+	assert.False(t, IsMajorUpgrade("7.0.12", "7.0.13"))
+	assert.False(t, IsMajorUpgrade("7.0.12", "invalid"))
+}
@@ -0,0 +1,213 @@
+// Package pvcresize implements the resize of the persistent volumes of a StatefulSet, which can't be changed in
+// place: the PVCs are expanded first and then the StatefulSet is recreated, orphaning its pods, with the new claim
+// templates. The progress is reported in the status of the resource using the api/v1/status/pvc phases.
+package pvcresize
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status/pvc"
+	"github.com/mongodb/mongodb-kubernetes/controllers/operator/workflow"
+	kubernetesClient "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/kube/client"
+	"github.com/mongodb/mongodb-kubernetes/pkg/kube"
+	"github.com/mongodb/mongodb-kubernetes/pkg/statefulset"
+)
+
+// HandlePVCResize handles the state machine of a PVC resize.
+// Note: it modifies the desiredSTS.annotation to trigger a rolling restart later
+// We leverage workflowStatus.WithAdditionalOptions(...) to merge/update/add to existing mdb.status.pvc
+// The resize is resumable: every step is idempotent and derived from the existing StatefulSet and PVCs, so an interrupted
+// resize is continued on the next reconciliation.
+func HandlePVCResize(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet, log *zap.SugaredLogger) workflow.Status {
+	existingStatefulSet, stsErr := memberClient.GetStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
+	if stsErr != nil {
+		// if we are here it means its first reconciling, we can skip the whole pvc state machine
+		if apiErrors.IsNotFound(stsErr) {
+			return workflow.OK()
+		} else {
+			return workflow.Failed(stsErr)
+		}
+	}
+
+	pvcResizes := resourceStorageHasChanged(existingStatefulSet.Spec.VolumeClaimTemplates, desiredSts.Spec.VolumeClaimTemplates)
+
+	increaseStorageOfAtLeastOnePVC := false
+	// we have decreased the storage for at least one pvc, we do not support that
+	for _, pvcResize := range pvcResizes {
+		if pvcResize.resizeIndicator == 1 {
+			log.Debug("Can't update the stateful set, as we cannot decrease the pvc size")
+			return workflow.Failed(xerrors.Errorf("can't update pvc and statefulset to a smaller storage, from: %s - to:%s", pvcResize.from, pvcResize.to))
+		}
+		if pvcResize.resizeIndicator == -1 {
+			log.Infof("Detected PVC size expansion; for pvc %s, from: %s to: %s", pvcResize.pvcName, pvcResize.from, pvcResize.to)
+			increaseStorageOfAtLeastOnePVC = true
+		}
+	}
+
+	// The sts claim has been increased (based on resourceChangeIndicator) for at least one PVC,
+	// and we are not in the middle of a resize (that means pvcPhase is pvc.PhaseNoAction) for this statefulset.
+	// This means we want to start one
+	if increaseStorageOfAtLeastOnePVC {
+		err := statefulset.AddPVCAnnotation(desiredSts)
+		if err != nil {
+			return workflow.Failed(xerrors.Errorf("can't add pvc annotation, err: %s", err))
+		}
+		log.Infof("Detected PVC size expansion; patching all pvcs and increasing the size for sts: %s", desiredSts.Name)
+		if err := resizePVCsStorage(memberClient, desiredSts); err != nil {
+			return workflow.Failed(xerrors.Errorf("can't resize pvc, err: %s", err))
+		}
+
+		finishedResizing, err := hasFinishedResizing(ctx, memberClient, desiredSts)
+		if err != nil {
+			return workflow.Failed(err)
+		}
+		if finishedResizing {
+			log.Info("PVCs finished resizing")
+			log.Info("Deleting StatefulSet and orphan pods")
+			// Cascade delete the StatefulSet
+			deletePolicy := metav1.DeletePropagationOrphan
+			if err := memberClient.Delete(context.TODO(), desiredSts, client.PropagationPolicy(deletePolicy)); err != nil && !apiErrors.IsNotFound(err) {
+				return workflow.Failed(xerrors.Errorf("error deleting sts, err: %s", err))
+			}
+
+			deletedIsStatefulset := checkStatefulsetIsDeleted(ctx, memberClient, desiredSts, 1*time.Second, log)
+
+			if !deletedIsStatefulset {
+				log.Info("deletion has not been reflected in kube yet, restarting the reconcile")
+				return workflow.Pending("STS has been orphaned but not yet reflected in kubernetes. " +
+					"Restarting the reconcile").WithAdditionalOptions(status.NewPVCsStatusOption(&status.PVC{Phase: pvc.PhasePVCResize, StatefulsetName: desiredSts.Name}))
+			}
+			log.Info("Statefulset have been orphaned")
+			// the StatefulSet is created again by the caller, which can't be done with the resource version of the deleted one
+			desiredSts.ResourceVersion = ""
+			return workflow.OK().WithAdditionalOptions(status.NewPVCsStatusOption(&status.PVC{Phase: pvc.PhaseSTSOrphaned, StatefulsetName: desiredSts.Name}))
+		} else {
+			log.Info("PVCs are still resizing, waiting until it has finished")
+			return workflow.Pending("PVC resizes has not finished; current state of sts: %s: %s", desiredSts.Name, pvc.PhasePVCResize).WithAdditionalOptions(status.NewPVCsStatusOption(&status.PVC{Phase: pvc.PhasePVCResize, StatefulsetName: desiredSts.Name}))
+		}
+	}
+
+	return workflow.OK()
+}
+
+func checkStatefulsetIsDeleted(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet, sleepDuration time.Duration, log *zap.SugaredLogger) bool {
+	// After deleting the statefulset it can take seconds to be reflected in kubernetes.
+	// In case it is still not reflected
+	deletedIsStatefulset := false
+	for i := 0; i < 3; i++ {
+		time.Sleep(sleepDuration)
+		_, stsErr := memberClient.GetStatefulSet(ctx, kube.ObjectKey(desiredSts.Namespace, desiredSts.Name))
+		if apiErrors.IsNotFound(stsErr) {
+			deletedIsStatefulset = true
+			break
+		} else {
+			log.Info("Statefulset still exists, attempting again")
+		}
+	}
+	return deletedIsStatefulset
+}
+
+func hasFinishedResizing(ctx context.Context, memberClient kubernetesClient.Client, desiredSts *appsv1.StatefulSet) (bool, error) {
+	pvcList := corev1.PersistentVolumeClaimList{}
+	if err := memberClient.List(ctx, &pvcList, client.InNamespace(desiredSts.Namespace)); err != nil {
+		return false, err
+	}
+
+	finishedResizing := true
+	for _, currentPVC := range pvcList.Items {
+		if template, index := getMatchingPVCTemplateFromSTS(desiredSts, &currentPVC); template != nil {
+			if currentPVC.Status.Capacity.Storage().Cmp(*desiredSts.Spec.VolumeClaimTemplates[index].Spec.Resources.Requests.Storage()) != 0 {
+				finishedResizing = false
+			}
+		}
+	}
+	return finishedResizing, nil
+}
+
+// resizePVCsStorage takes the sts we want to create and update all matching pvc with the new storage
+func resizePVCsStorage(memberClient kubernetesClient.Client, statefulSetToCreate *appsv1.StatefulSet) error {
+	pvcList := corev1.PersistentVolumeClaimList{}
+
+	// this is to ensure that requests to a potentially not allowed resource is not blocking the operator until the end
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := memberClient.List(ctx, &pvcList, client.InNamespace(statefulSetToCreate.Namespace)); err != nil {
+		return err
+	}
+	for _, existingPVC := range pvcList.Items {
+		if template, _ := getMatchingPVCTemplateFromSTS(statefulSetToCreate, &existingPVC); template != nil {
+			existingPVC.Spec.Resources.Requests[corev1.ResourceStorage] = *template.Spec.Resources.Requests.Storage()
+			if err := memberClient.Update(ctx, &existingPVC); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func getMatchingPVCTemplateFromSTS(statefulSet *appsv1.StatefulSet, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, int) {
+	for i, claimTemplate := range statefulSet.Spec.VolumeClaimTemplates {
+		expectedPrefix := fmt.Sprintf("%s-%s", claimTemplate.Name, statefulSet.Name)
+
+		// Regex to match expectedPrefix followed by a dash and a number (ordinal)
+		regexPattern := fmt.Sprintf("^%s-[0-9]+$", regexp.QuoteMeta(expectedPrefix))
+		if matched, _ := regexp.MatchString(regexPattern, pvc.Name); matched {
+			return &claimTemplate, i
+		}
+	}
+	return nil, -1
+}
+
+type pvcResize struct {
+	pvcName         string
+	resizeIndicator int
+	from            string
+	to              string
+}
+
+// resourceStorageHasChanged returns 0 if both storage sizes are equal or not exist at all,
+//
+//	 1: toCreateVolumeClaims < desiredVolumeClaims → decrease storage
+//	-1: toCreateVolumeClaims > desiredVolumeClaims → increase storage
+//	 0: toCreateVolumeClaims = desiredVolumeClaims → storage stays same
+func resourceStorageHasChanged(existingVolumeClaims []corev1.PersistentVolumeClaim, desiredVolumeClaims []corev1.PersistentVolumeClaim) []pvcResize {
+	existingClaimByName := map[string]*corev1.PersistentVolumeClaim{}
+	var pvcResizes []pvcResize
+
+	for _, existingClaim := range existingVolumeClaims {
+		existingClaimByName[existingClaim.Name] = &existingClaim
+	}
+
+	for _, desiredClaim := range desiredVolumeClaims {
+		// if the desiredClaim does not exist in the list of claims, then we don't need to consider resizing, since
+		// its most likely a new one
+		if existingPVCClaim, ok := existingClaimByName[desiredClaim.Name]; ok {
+			desiredPVCClaimStorage := desiredClaim.Spec.Resources.Requests.Storage()
+			existingPVCClaimStorage := existingPVCClaim.Spec.Resources.Requests.Storage()
+			if desiredPVCClaimStorage != nil && existingPVCClaimStorage != nil {
+				pvcResizes = append(pvcResizes, pvcResize{
+					pvcName:         desiredClaim.Name,
+					resizeIndicator: existingPVCClaimStorage.Cmp(*desiredPVCClaimStorage),
+					from:            existingPVCClaimStorage.String(),
+					to:              desiredPVCClaimStorage.String(),
+				})
+			}
+		}
+	}
+
+	return pvcResizes
+}
@@ -0,0 +1,141 @@
+// Package references validates the references of the resources to other Kubernetes resources. The validations are
+// run by the admission webhook and during the reconciliation, so the dangling references are reported as soon as the
+// resources are applied.
+package references
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	rolev1 "github.com/mongodb/mongodb-kubernetes/api/v1/role"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+// Validator resolves the references of the resources with a client, which is expected to be the cached client of
+// the manager. The references to resources which don't exist are reported as warnings as they can be created after
+// the referencing resource. The references which can't be resolved because of other errors are not reported, they
+// are reported by the reconciliation.
+type Validator struct {
+	client client.Reader
+	// clusterMongoDBRolesEnabled is false if the operator doesn't watch the ClusterMongoDBRoles, the roleRefs are
+	// rejected by the reconciliation in that case.
+	clusterMongoDBRolesEnabled bool
+}
+
+func NewValidator(client client.Reader, clusterMongoDBRolesEnabled bool) *Validator {
+	return &Validator{client: client, clusterMongoDBRolesEnabled: clusterMongoDBRolesEnabled}
+}
+
+// Validate returns the results of the validations of the references of the resource. Only the failed validations
+// are returned.
+func (v *Validator) Validate(ctx context.Context, obj runtime.Object) []v1.ValidationResult {
+	var results []v1.ValidationResult
+	switch resource := obj.(type) {
+	case *mdbv1.MongoDB:
+		results = v.validateRoleRefs(ctx, resource.Spec.GetSecurity().RoleRefs)
+	case *mdbmulti.MongoDBMultiCluster:
+		results = v.validateRoleRefs(ctx, resource.Spec.GetSecurity().RoleRefs)
+	case *userv1.MongoDBUser:
+		results = v.validateMongoDBUser(ctx, resource)
+	case *searchv1.MongoDBSearch:
+		results = v.validateMongoDBSearch(ctx, resource)
+	}
+	return results
+}
+
+// Process returns the error of the first validation which failed with an error, and the warnings of the others.
+func Process(results []v1.ValidationResult) ([]status.Warning, error) {
+	var warnings []status.Warning
+	for _, res := range results {
+		if res.Level == v1.ErrorLevel {
+			return warnings, xerrors.New(res.Msg)
+		}
+		if res.Level == v1.WarningLevel {
+			warnings = append(warnings, status.Warning(res.Msg))
+		}
+	}
+	return warnings, nil
+}
+
+func (v *Validator) validateRoleRefs(ctx context.Context, roleRefs []mdbv1.MongoDBRoleRef) []v1.ValidationResult {
+	if !v.clusterMongoDBRolesEnabled {
+		return nil
+	}
+
+	var results []v1.ValidationResult
+	for _, ref := range roleRefs {
+		if ref.Kind != util.ClusterMongoDBRoleKind {
+			continue
+		}
+		if v.notFound(ctx, types.NamespacedName{Name: ref.Name}, &rolev1.ClusterMongoDBRole{}) {
+			results = append(results, v1.ValidationWarning("ClusterMongoDBRole %s referenced in spec.security.roleRefs doesn't exist", ref.Name))
+		}
+	}
+	return results
+}
+
+func (v *Validator) validateMongoDBUser(ctx context.Context, user *userv1.MongoDBUser) []v1.ValidationResult {
+	var results []v1.ValidationResult
+
+	if ref := user.Spec.MongoDBResourceRef; ref.Name != "" {
+		namespace := user.Namespace
+		if ref.Namespace != "" {
+			namespace = ref.Namespace
+		}
+		name := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		if v.notFound(ctx, name, &mdbv1.MongoDB{}) && v.notFound(ctx, name, &mdbmulti.MongoDBMultiCluster{}) {
+			results = append(results, v1.ValidationWarning("MongoDB or MongoDBMultiCluster %s referenced in spec.mongodbResourceRef doesn't exist", name))
+		}
+	}
+
+	if ref := user.Spec.PasswordSecretKeyRef; ref.Name != "" {
+		if v.notFound(ctx, types.NamespacedName{Namespace: user.Namespace, Name: ref.Name}, &corev1.Secret{}) {
+			results = append(results, v1.ValidationWarning("Secret %s referenced in spec.passwordSecretKeyRef doesn't exist", ref.Name))
+		}
+	}
+
+	return results
+}
+
+func (v *Validator) validateMongoDBSearch(ctx context.Context, search *searchv1.MongoDBSearch) []v1.ValidationResult {
+	if search.IsExternalMongoDBSource() {
+		external := search.Spec.Source.ExternalMongoDBSource
+		// the keyfile is only used to authenticate the wireproto server to the source database
+		if !search.IsWireprotoEnabled() {
+			return nil
+		}
+		if external.KeyFileSecretKeyRef == nil || external.KeyFileSecretKeyRef.Name == "" {
+			return []v1.ValidationResult{v1.ValidationError("The keyfile of the external source must be set in spec.source.external.keyfileSecretRef when the wireproto server is enabled")}
+		}
+		if v.notFound(ctx, types.NamespacedName{Namespace: search.Namespace, Name: external.KeyFileSecretKeyRef.Name}, &corev1.Secret{}) {
+			return []v1.ValidationResult{v1.ValidationWarning("Secret %s referenced in spec.source.external.keyfileSecretRef doesn't exist", external.KeyFileSecretKeyRef.Name)}
+		}
+		return nil
+	}
+
+	ref := search.GetMongoDBResourceRef()
+	name := types.NamespacedName{Namespace: search.Namespace, Name: ref.Name}
+	if v.notFound(ctx, name, &mdbv1.MongoDB{}) && v.notFound(ctx, name, &mdbcv1.MongoDBCommunity{}) && v.notFound(ctx, name, &mdbmulti.MongoDBMultiCluster{}) {
+		return []v1.ValidationResult{v1.ValidationWarning("MongoDB, MongoDBCommunity or MongoDBMultiCluster %s used as the source of the search doesn't exist", name)}
+	}
+	return nil
+}
+
+// notFound returns true only if the resource is known not to exist.
+func (v *Validator) notFound(ctx context.Context, name types.NamespacedName, obj client.Object) bool {
+	return apiErrors.IsNotFound(v.client.Get(ctx, name, obj))
+}
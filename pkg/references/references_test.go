@@ -0,0 +1,137 @@
+package references
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/mdbmulti"
+	rolev1 "github.com/mongodb/mongodb-kubernetes/api/v1/role"
+	searchv1 "github.com/mongodb/mongodb-kubernetes/api/v1/search"
+	"github.com/mongodb/mongodb-kubernetes/api/v1/status"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	mdbcv1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme, err := v1.SchemeBuilder.Build()
+	require.NoError(t, err)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, mdbcv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestValidate_RoleRefs(t *testing.T) {
+	ctx := context.Background()
+	role := &rolev1.ClusterMongoDBRole{ObjectMeta: metav1.ObjectMeta{Name: "existing-role"}}
+	mdb := mdbv1.NewReplicaSetBuilder().SetRoleRefs([]mdbv1.MongoDBRoleRef{
+		{Name: "existing-role", Kind: util.ClusterMongoDBRoleKind},
+		{Name: "missing-role", Kind: util.ClusterMongoDBRoleKind},
+	}).Build()
+	c := newFakeClient(t, role)
+
+	results := NewValidator(c, true).Validate(ctx, mdb)
+	assert.Equal(t, []v1.ValidationResult{v1.ValidationWarning("ClusterMongoDBRole missing-role referenced in spec.security.roleRefs doesn't exist")}, results)
+
+	// the roleRefs are rejected by the reconciliation if the ClusterMongoDBRoles are disabled
+	assert.Empty(t, NewValidator(c, false).Validate(ctx, mdb))
+}
+
+func TestValidate_MongoDBUser(t *testing.T) {
+	ctx := context.Background()
+	user := &userv1.MongoDBUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "ns"},
+		Spec: userv1.MongoDBUserSpec{
+			Username:             "user",
+			Database:             "admin",
+			MongoDBResourceRef:   userv1.MongoDBResourceRef{Name: "mdb"},
+			PasswordSecretKeyRef: userv1.SecretKeyRef{Name: "user-password", Key: "password"},
+		},
+	}
+
+	results := NewValidator(newFakeClient(t), false).Validate(ctx, user)
+	assert.Equal(t, []v1.ValidationResult{
+		v1.ValidationWarning("MongoDB or MongoDBMultiCluster ns/mdb referenced in spec.mongodbResourceRef doesn't exist"),
+		v1.ValidationWarning("Secret user-password referenced in spec.passwordSecretKeyRef doesn't exist"),
+	}, results)
+
+	multi := &mdbmulti.MongoDBMultiCluster{ObjectMeta: metav1.ObjectMeta{Name: "mdb", Namespace: "ns"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-password", Namespace: "ns"}}
+	assert.Empty(t, NewValidator(newFakeClient(t, multi, secret), false).Validate(ctx, user))
+}
+
+func TestValidate_MongoDBSearch(t *testing.T) {
+	externalSearch := func(keyfileSecretRef *userv1.SecretKeyRef) *searchv1.MongoDBSearch {
+		return &searchv1.MongoDBSearch{
+			ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns", Annotations: map[string]string{searchv1.ForceWireprotoAnnotation: "true"}},
+			Spec: searchv1.MongoDBSearchSpec{Source: &searchv1.MongoDBSource{ExternalMongoDBSource: &searchv1.ExternalMongoDBSource{
+				HostAndPorts:        []string{"mdb-0.example.com:27017"},
+				KeyFileSecretKeyRef: keyfileSecretRef,
+			}}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		search          *searchv1.MongoDBSearch
+		objects         []client.Object
+		expectedResults []v1.ValidationResult
+	}{
+		{
+			name:            "source database doesn't exist",
+			search:          &searchv1.MongoDBSearch{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns"}},
+			expectedResults: []v1.ValidationResult{v1.ValidationWarning("MongoDB, MongoDBCommunity or MongoDBMultiCluster ns/search used as the source of the search doesn't exist")},
+		},
+		{
+			name:    "source database exists",
+			search:  &searchv1.MongoDBSearch{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns"}},
+			objects: []client.Object{&mdbcv1.MongoDBCommunity{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns"}}},
+		},
+		{
+			name:    "multi-cluster source database exists",
+			search:  &searchv1.MongoDBSearch{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns"}},
+			objects: []client.Object{&mdbmulti.MongoDBMultiCluster{ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: "ns"}}},
+		},
+		{
+			name:            "external source without keyfile",
+			search:          externalSearch(nil),
+			expectedResults: []v1.ValidationResult{v1.ValidationError("The keyfile of the external source must be set in spec.source.external.keyfileSecretRef when the wireproto server is enabled")},
+		},
+		{
+			name:            "external source keyfile secret doesn't exist",
+			search:          externalSearch(&userv1.SecretKeyRef{Name: "keyfile"}),
+			expectedResults: []v1.ValidationResult{v1.ValidationWarning("Secret keyfile referenced in spec.source.external.keyfileSecretRef doesn't exist")},
+		},
+		{
+			name:    "external source keyfile secret exists",
+			search:  externalSearch(&userv1.SecretKeyRef{Name: "keyfile"}),
+			objects: []client.Object{&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keyfile", Namespace: "ns"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := NewValidator(newFakeClient(t, tt.objects...), false).Validate(context.Background(), tt.search)
+			assert.Equal(t, tt.expectedResults, results)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	warnings, err := Process([]v1.ValidationResult{v1.ValidationWarning("first warning"), v1.ValidationWarning("second warning")})
+	assert.NoError(t, err)
+	assert.Equal(t, []status.Warning{"first warning", "second warning"}, warnings)
+
+	_, err = Process([]v1.ValidationResult{v1.ValidationWarning("warning"), v1.ValidationError("error")})
+	assert.EqualError(t, err, "error")
+}
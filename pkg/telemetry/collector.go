@@ -12,7 +12,6 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
-	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	kubeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +24,7 @@ import (
 	mcov1 "github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/api/v1"
 	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/util/envvar"
 	"github.com/mongodb/mongodb-kubernetes/pkg/images"
+	"github.com/mongodb/mongodb-kubernetes/pkg/multicluster"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/architectures"
 	"github.com/mongodb/mongodb-kubernetes/pkg/util/versionutil"
@@ -44,38 +44,38 @@ type ConfigClient interface {
 }
 
 type LeaderRunnable struct {
-	memberClusterObjectsMap map[string]cluster.Cluster
-	operatorMgr             manager.Manager
-	atlasClient             *Client
-	currentNamespace        string
-	mongodbImage            string
-	databaseNonStaticImage  string
-	configuredOperatorEnv   util.OperatorEnvironment
+	memberClusters         *multicluster.Registry
+	operatorMgr            manager.Manager
+	atlasClient            *Client
+	currentNamespace       string
+	mongodbImage           string
+	databaseNonStaticImage string
+	configuredOperatorEnv  util.OperatorEnvironment
 }
 
 func (l *LeaderRunnable) NeedLeaderElection() bool {
 	return true
 }
 
-func NewLeaderRunnable(operatorMgr manager.Manager, memberClusterObjectsMap map[string]cluster.Cluster, currentNamespace, mongodbImage, databaseNonStaticImage string, operatorEnv util.OperatorEnvironment) (*LeaderRunnable, error) {
+func NewLeaderRunnable(operatorMgr manager.Manager, memberClusters *multicluster.Registry, currentNamespace, mongodbImage, databaseNonStaticImage string, operatorEnv util.OperatorEnvironment) (*LeaderRunnable, error) {
 	atlasClient, err := NewClient(nil)
 	if err != nil {
 		return nil, xerrors.Errorf("Failed creating atlas telemetry client: %w", err)
 	}
 	return &LeaderRunnable{
-		atlasClient:             atlasClient,
-		operatorMgr:             operatorMgr,
-		memberClusterObjectsMap: memberClusterObjectsMap,
-		currentNamespace:        currentNamespace,
-		configuredOperatorEnv:   operatorEnv,
-		mongodbImage:            mongodbImage,
-		databaseNonStaticImage:  databaseNonStaticImage,
+		atlasClient:            atlasClient,
+		operatorMgr:            operatorMgr,
+		memberClusters:         memberClusters,
+		currentNamespace:       currentNamespace,
+		configuredOperatorEnv:  operatorEnv,
+		mongodbImage:           mongodbImage,
+		databaseNonStaticImage: databaseNonStaticImage,
 	}, nil
 }
 
 func (l *LeaderRunnable) Start(ctx context.Context) error {
 	Logger.Debug("Starting leader-only telemetry goroutine")
-	RunTelemetry(ctx, l.mongodbImage, l.databaseNonStaticImage, l.currentNamespace, l.operatorMgr, l.memberClusterObjectsMap, l.atlasClient, l.configuredOperatorEnv)
+	RunTelemetry(ctx, l.mongodbImage, l.databaseNonStaticImage, l.currentNamespace, l.operatorMgr, l.memberClusters, l.atlasClient, l.configuredOperatorEnv)
 
 	return nil
 }
@@ -83,7 +83,7 @@ func (l *LeaderRunnable) Start(ctx context.Context) error {
 type snapshotCollector func(ctx context.Context, memberClusterMap map[string]ConfigClient, operatorClusterMgr manager.Manager, operatorUUID, mongodbImage, databaseNonStaticImage string) []Event
 
 // RunTelemetry lists the specified CRDs and sends them as events to Segment
-func RunTelemetry(leaderTrace context.Context, mongodbImage, databaseNonStaticImage, namespace string, operatorClusterMgr manager.Manager, clusterMap map[string]cluster.Cluster, atlasClient *Client, configuredOperatorEnv util.OperatorEnvironment) {
+func RunTelemetry(leaderTrace context.Context, mongodbImage, databaseNonStaticImage, namespace string, operatorClusterMgr manager.Manager, memberClusters *multicluster.Registry, atlasClient *Client, configuredOperatorEnv util.OperatorEnvironment) {
 	Logger.Debug("Collecting telemetry!")
 	ctx, span := TRACER.Start(leaderTrace, "RunTelemetry")
 	span.SetAttributes(
@@ -99,12 +99,6 @@ func RunTelemetry(leaderTrace context.Context, mongodbImage, databaseNonStaticIm
 	}
 	Logger.Debugf("%s is set to: %s", CollectionFrequency, duration)
 
-	// converting to a smaller interface for better testing and clearer responsibilities
-	cc := map[string]ConfigClient{}
-	for s, c := range clusterMap {
-		cc[s] = c
-	}
-
 	// Mapping of snapshot types to their respective collector functions
 	// The functions are not 100% identical, this map takes care of that
 	snapshotCollectors := map[EventType]func(ctx context.Context, memberClusterMap map[string]ConfigClient, operatorClusterMgr manager.Manager, operatorUUID, mongodbImage, databaseNonStaticImage string) []Event{
@@ -123,6 +117,13 @@ func RunTelemetry(leaderTrace context.Context, mongodbImage, databaseNonStaticIm
 		// we are calling this per "ticker" as customers might enable RBACs after the operator has been deployed
 		operatorUUID := getOrGenerateOperatorUUID(ctx, operatorClusterMgr.GetClient(), namespace)
 
+		// converting to a smaller interface for better testing and clearer responsibilities. The member clusters are
+		// read on each collection as they can be registered and removed while the operator is running.
+		cc := map[string]ConfigClient{}
+		for s, c := range memberClusters.Clusters() {
+			cc[s] = c
+		}
+
 		for eventType, f := range snapshotCollectors {
 			collectAndSendSnapshot(ctx, eventType, f, cc, operatorClusterMgr, operatorUUID, mongodbImage, databaseNonStaticImage, namespace, atlasClient, configuredOperatorEnv)
 		}
@@ -30,6 +30,21 @@ const (
 	// MongoDbSearchController name of the MongoDBSearch controller
 	MongoDbSearchController = "mongodbsearch-controller"
 
+	// MongoDbSearchIndexController name of the MongoDBSearchIndex controller
+	MongoDbSearchIndexController = "mongodbsearchindex-controller"
+
+	// MongoDbAlertConfigController name of the MongoDBAlertConfig controller
+	MongoDbAlertConfigController = "mongodbalertconfig-controller"
+
+	// MongoDbOrganizationController name of the MongoDBOrganization controller
+	MongoDbOrganizationController = "mongodborganization-controller"
+
+	// MongoDbProjectController name of the MongoDBProject controller
+	MongoDbProjectController = "mongodbproject-controller"
+
+	// MongoDbMemberClusterController name of the MongoDBMemberCluster controller
+	MongoDbMemberClusterController = "mongodbmembercluster-controller"
+
 	// Kinds
 	ClusterMongoDBRoleKind = "ClusterMongoDBRole"
 
@@ -284,6 +299,9 @@ const (
 	// Annotation keys used by the operator
 	LastAchievedSpec        = "mongodb.com/v1.lastSuccessfulConfiguration"
 	LastAchievedRsMemberIds = "mongodb.com/v1.lastAchievedRsMemberIds"
+	// PlanAnnotation enables the plan mode of MongoDB and MongoDBMultiCluster resources: the changes to the Ops Manager deployment are
+	// published in a ConfigMap instead of being applied.
+	PlanAnnotation = "mongodb.com/v1.plan"
 
 	// SecretVolumeName is the name of the volume resource.
 	SecretVolumeName = "secret-certs"
@@ -316,7 +334,12 @@ const (
 
 	MdbAppdbAssumeOldFormat = "MDB_APPDB_ASSUME_OLD_FORMAT"
 
-	UserFinalizer = "mongodb.com/v1.userRemovalFinalizer"
+	UserFinalizer          = "mongodb.com/v1.userRemovalFinalizer"
+	SearchIndexFinalizer   = "mongodb.com/v1.searchIndexRemovalFinalizer"
+	AlertConfigFinalizer   = "mongodb.com/v1.alertConfigRemovalFinalizer"
+	OrganizationFinalizer  = "mongodb.com/v1.organizationRemovalFinalizer"
+	ProjectFinalizer       = "mongodb.com/v1.projectRemovalFinalizer"
+	MemberClusterFinalizer = "mongodb.com/v1.memberClusterRemovalFinalizer"
 )
 
 type OperatorEnvironment string
@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const (
+	mongoDBCRDName = "mongodb.mongodb.com"
+	// conversionPath is the path the conversion webhook is registered at by controller-runtime
+	conversionPath = "/convert"
+)
+
+// configureConversionWebhook points the conversion of the MongoDB CRD to the conversion webhook of the operator and
+// serves the versions other than the storage one. The CRD is shipped without the conversion webhook and with these
+// versions not served, as the API server can't convert them until the webhook and its CA bundle are configured.
+func configureConversionWebhook(ctx context.Context, c client.Client, serviceLocation types.NamespacedName, caBundle []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: mongoDBCRDName}, crd); err != nil {
+		return err
+	}
+
+	original := crd.DeepCopy()
+	path := conversionPath
+	var port int32 = 443
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Name:      serviceLocation.Name,
+					Namespace: serviceLocation.Namespace,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	serveConvertedVersions(crd)
+	return c.Patch(ctx, crd, client.MergeFrom(original))
+}
+
+func serveConvertedVersions(crd *apiextensionsv1.CustomResourceDefinition) {
+	for i := range crd.Spec.Versions {
+		if !crd.Spec.Versions[i].Storage {
+			crd.Spec.Versions[i].Served = true
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newMongoDBCRD(conversion *apiextensionsv1.CustomResourceConversion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: mongoDBCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:      "mongodb.com",
+			Conversion: conversion,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v2", Served: false, Storage: false},
+			},
+		},
+	}
+}
+
+func newCRDClient(t *testing.T, crd *apiextensionsv1.CustomResourceDefinition) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+}
+
+func TestConfigureConversionWebhook(t *testing.T) {
+	ctx := context.Background()
+	c := newCRDClient(t, newMongoDBCRD(&apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}))
+
+	serviceLocation := types.NamespacedName{Name: "operator-webhook", Namespace: "operator-ns"}
+	require.NoError(t, configureConversionWebhook(ctx, c, serviceLocation, []byte("ca")))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: mongoDBCRDName}, crd))
+	assert.Equal(t, apiextensionsv1.WebhookConverter, crd.Spec.Conversion.Strategy)
+	clientConfig := crd.Spec.Conversion.Webhook.ClientConfig
+	assert.Equal(t, "operator-webhook", clientConfig.Service.Name)
+	assert.Equal(t, "operator-ns", clientConfig.Service.Namespace)
+	assert.Equal(t, conversionPath, *clientConfig.Service.Path)
+	assert.Equal(t, []byte("ca"), clientConfig.CABundle)
+	assert.Equal(t, []string{"v1"}, crd.Spec.Conversion.Webhook.ConversionReviewVersions)
+
+	// v2 is only served once the API server can convert it
+	assert.True(t, crd.Spec.Versions[0].Served)
+	assert.True(t, crd.Spec.Versions[0].Storage)
+	assert.True(t, crd.Spec.Versions[1].Served)
+	assert.False(t, crd.Spec.Versions[1].Storage)
+}
+
+func TestConfigureConversionWebhook_CRDNotInstalled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	err := configureConversionWebhook(context.Background(), c, types.NamespacedName{Name: "operator-webhook", Namespace: "mongodb"}, []byte("ca"))
+	assert.True(t, apiErrors.IsNotFound(err))
+}
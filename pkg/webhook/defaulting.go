@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	crWebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/controllers/construct"
+	"github.com/mongodb/mongodb-kubernetes/mongodb-community-operator/pkg/automationconfig"
+	"github.com/mongodb/mongodb-kubernetes/pkg/util"
+)
+
+const (
+	mongoDBDefaultingPath             = "/mutate-mongodb-com-v1-mongodb"
+	mongoDBMultiClusterDefaultingPath = "/mutate-mongodb-com-v1-mongodbmulticluster"
+	opsManagerDefaultingPath          = "/mutate-mongodb-com-v1-mongodbopsmanager"
+	mongoDBUserDefaultingPath         = "/mutate-mongodb-com-v1-mongodbuser"
+
+	defaultClusterDomain = "cluster.local"
+)
+
+// defaultsFunc sets the defaults of a resource which aren't set yet. The namespace is the namespace of the
+// admission request as the resource doesn't contain it on creation.
+type defaultsFunc func(obj *unstructured.Unstructured, namespace string) error
+
+// defaultingPaths are the webhook paths and the defaults of the kinds the mutating webhook is registered for.
+var defaultingPaths = map[string]struct {
+	path        string
+	setDefaults defaultsFunc
+}{
+	"MongoDB":             {path: mongoDBDefaultingPath, setDefaults: setMongoDBDefaults},
+	"MongoDBMultiCluster": {path: mongoDBMultiClusterDefaultingPath, setDefaults: setMongoDBMultiClusterDefaults},
+	"MongoDBOpsManager":   {path: opsManagerDefaultingPath, setDefaults: setOpsManagerDefaults},
+	"MongoDBUser":         {path: mongoDBUserDefaultingPath, setDefaults: setMongoDBUserDefaults},
+}
+
+// RegisterDefaultingWebhook registers the mutating webhook writing the defaults of the resources of the kind into
+// their spec, so the effective configuration is visible in the resource.
+func RegisterDefaultingWebhook(server crWebhook.Server, kind string) error {
+	defaulting, ok := defaultingPaths[kind]
+	if !ok {
+		return xerrors.Errorf("no defaulting webhook exists for the kind %s", kind)
+	}
+	server.Register(defaulting.path, &crWebhook.Admission{Handler: &defaultingHandler{setDefaults: defaulting.setDefaults}})
+	return nil
+}
+
+// defaultingHandler patches the resource with the defaults applied by the operator. The defaults are set on the
+// unstructured resource, the typed resources can't be used as their decoding initializes the fields used internally
+// by the operator.
+type defaultingHandler struct {
+	setDefaults defaultsFunc
+}
+
+func (h *defaultingHandler) Handle(_ context.Context, req admission.Request) admission.Response {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := h.setDefaults(obj, req.Namespace); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	defaulted, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+func setMongoDBDefaults(obj *unstructured.Unstructured, _ string) error {
+	if err := setDefault(obj, defaultClusterDomain, "spec", "clusterDomain"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, true, "spec", "persistent"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, string(mdbv1.Info), "spec", "logLevel"); err != nil {
+		return err
+	}
+
+	persistent, _, err := unstructured.NestedBool(obj.Object, "spec", "persistent")
+	if err != nil || !persistent {
+		return err
+	}
+	resourceType, _, err := unstructured.NestedString(obj.Object, "spec", "type")
+	if err != nil {
+		return err
+	}
+	if resourceType == string(mdbv1.ShardedCluster) {
+		if err := setStorageDefaults(obj, util.DefaultMongodStorageSize, "spec", "shardPodSpec"); err != nil {
+			return err
+		}
+		return setStorageDefaults(obj, util.DefaultConfigSrvStorageSize, "spec", "configSrvPodSpec")
+	}
+	return setStorageDefaults(obj, util.DefaultMongodStorageSize, "spec", "podSpec")
+}
+
+func setMongoDBMultiClusterDefaults(obj *unstructured.Unstructured, _ string) error {
+	if err := setDefault(obj, defaultClusterDomain, "spec", "clusterDomain"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, true, "spec", "persistent"); err != nil {
+		return err
+	}
+	return setDefault(obj, string(mdbv1.Info), "spec", "logLevel")
+}
+
+func setOpsManagerDefaults(obj *unstructured.Unstructured, _ string) error {
+	// the deprecated clusterName is used as the cluster domain if it's set
+	clusterDomain, _, err := unstructured.NestedString(obj.Object, "spec", "clusterName")
+	if err != nil {
+		return err
+	}
+	if clusterDomain == "" {
+		clusterDomain = defaultClusterDomain
+	}
+
+	if err := setDefault(obj, clusterDomain, "spec", "clusterDomain"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, int64(1), "spec", "replicas"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, true, "spec", "backup", "enabled"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, int64(1), "spec", "backup", "members"); err != nil {
+		return err
+	}
+
+	// the memory limit of the Ops Manager and Backup Daemon containers
+	if err := setContainerDefault(obj, util.OpsManagerContainerName, util.DefaultMemoryOpsManager, statefulSetContainersPath("spec"), "resources", "limits", "memory"); err != nil {
+		return err
+	}
+	backupEnabled, _, err := unstructured.NestedBool(obj.Object, "spec", "backup", "enabled")
+	if err != nil {
+		return err
+	}
+	if backupEnabled {
+		if err := setContainerDefault(obj, util.BackupDaemonContainerName, util.DefaultMemoryOpsManager, statefulSetContainersPath("spec", "backup"), "resources", "limits", "memory"); err != nil {
+			return err
+		}
+	}
+
+	// the log rotation of the agents and the memory request of the mongod container of the Application Database
+	if err := setDefault(obj, string(mdbv1.Info), "spec", "applicationDatabase", "agent", "logLevel"); err != nil {
+		return err
+	}
+	if err := setDefault(obj, int64(automationconfig.DefaultAgentMaxLogFileDurationHours), "spec", "applicationDatabase", "agent", "maxLogFileDurationHours"); err != nil {
+		return err
+	}
+	appDBContainersPath := []string{"spec", "applicationDatabase", "podSpec", "podTemplate", "spec", "containers"}
+	return setContainerDefault(obj, construct.MongodbName, util.DefaultMemoryAppDB, appDBContainersPath, "resources", "requests", "memory")
+}
+
+func setMongoDBUserDefaults(obj *unstructured.Unstructured, namespace string) error {
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+
+	if err := setDefault(obj, "admin", "spec", "db"); err != nil {
+		return err
+	}
+	return setDefault(obj, namespace, "spec", "mongodbResourceRef", "namespace")
+}
+
+// setStorageDefaults sets the storage of the volumes of the persistence of the pod spec at the path. The storage of
+// the data, journal and logs volumes is set if the multiple volumes are configured, of the single volume otherwise.
+func setStorageDefaults(obj *unstructured.Unstructured, dataStorage string, podSpecPath ...string) error {
+	persistencePath := append(podSpecPath, "persistence")
+	multiple, found, err := unstructured.NestedMap(obj.Object, append(persistencePath, "multiple")...)
+	if err != nil {
+		return err
+	}
+	if !found || multiple == nil {
+		return setDefault(obj, dataStorage, append(persistencePath, "single", "storage")...)
+	}
+
+	volumeStorage := []struct {
+		volume  string
+		storage string
+	}{
+		{volume: "data", storage: dataStorage},
+		{volume: "journal", storage: util.DefaultJournalStorageSize},
+		{volume: "logs", storage: util.DefaultLogsStorageSize},
+	}
+	for _, v := range volumeStorage {
+		if err := setDefault(obj, v.storage, append(persistencePath, "multiple", v.volume, "storage")...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statefulSetContainersPath returns the path of the containers of the StatefulSet override under the path.
+func statefulSetContainersPath(path ...string) []string {
+	return append(path, "statefulSet", "spec", "template", "spec", "containers")
+}
+
+// setContainerDefault sets the field of the container with the name in the list of containers at the path. The
+// container is added to the list if it's not there, the containers are merged by name into the containers built by
+// the operator.
+func setContainerDefault(obj *unstructured.Unstructured, name string, value interface{}, containersPath []string, fields ...string) error {
+	containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+	if err != nil {
+		return err
+	}
+
+	var container map[string]interface{}
+	index := -1
+	for n, c := range containers {
+		if c, ok := c.(map[string]interface{}); ok && c["name"] == name {
+			container, index = c, n
+			break
+		}
+	}
+	if index == -1 {
+		container = map[string]interface{}{"name": name}
+		containers = append(containers, container)
+		index = len(containers) - 1
+	}
+
+	if err := setDefault(&unstructured.Unstructured{Object: container}, value, fields...); err != nil {
+		return err
+	}
+	containers[index] = container
+	return unstructured.SetNestedSlice(obj.Object, containers, containersPath...)
+}
+
+// setDefault sets the field to the value if the field isn't set or contains the zero value of its type.
+func setDefault(obj *unstructured.Unstructured, value interface{}, fields ...string) error {
+	current, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if err != nil {
+		return err
+	}
+	if found && current != nil && current != "" && current != int64(0) {
+		return nil
+	}
+	return unstructured.SetNestedField(obj.Object, value, fields...)
+}
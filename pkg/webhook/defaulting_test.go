@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func defaultingRequest(namespace, object string) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Namespace: namespace,
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}}
+}
+
+func TestDefaultingHandler_MongoDB(t *testing.T) {
+	handler := &defaultingHandler{setDefaults: setMongoDBDefaults}
+
+	response := handler.Handle(context.Background(), defaultingRequest("ns", `{"apiVersion":"mongodb.com/v1","kind":"MongoDB","metadata":{"name":"rs"},"spec":{"type":"ReplicaSet","members":3}}`))
+
+	require.True(t, response.Allowed)
+	assert.ElementsMatch(t, []jsonpatch.Operation{
+		{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+		{Operation: "add", Path: "/spec/persistent", Value: true},
+		{Operation: "add", Path: "/spec/logLevel", Value: "INFO"},
+		{Operation: "add", Path: "/spec/podSpec", Value: map[string]interface{}{"persistence": map[string]interface{}{"single": map[string]interface{}{"storage": "16G"}}}},
+	}, response.Patches)
+}
+
+func TestDefaultingHandler_MongoDBStorage(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            string
+		expectedPatches []jsonpatch.Operation
+	}{
+		{
+			name: "multiple volumes",
+			spec: `{"type":"ReplicaSet","logLevel":"DEBUG","persistent":true,"podSpec":{"persistence":{"multiple":{"data":{"storage":"20G"}}}}}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+				{Operation: "add", Path: "/spec/podSpec/persistence/multiple/journal", Value: map[string]interface{}{"storage": "1G"}},
+				{Operation: "add", Path: "/spec/podSpec/persistence/multiple/logs", Value: map[string]interface{}{"storage": "3G"}},
+			},
+		},
+		{
+			name: "sharded cluster",
+			spec: `{"type":"ShardedCluster","logLevel":"DEBUG","persistent":true,"shardPodSpec":{"persistence":{"single":{"storage":"20G"}}}}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+				{Operation: "add", Path: "/spec/configSrvPodSpec", Value: map[string]interface{}{"persistence": map[string]interface{}{"single": map[string]interface{}{"storage": "5G"}}}},
+			},
+		},
+		{
+			name: "not persistent",
+			spec: `{"type":"ReplicaSet","logLevel":"DEBUG","persistent":false}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &defaultingHandler{setDefaults: setMongoDBDefaults}
+
+			response := handler.Handle(context.Background(), defaultingRequest("ns", `{"apiVersion":"mongodb.com/v1","kind":"MongoDB","metadata":{"name":"rs"},"spec":`+tt.spec+`}`))
+
+			require.True(t, response.Allowed)
+			assert.ElementsMatch(t, tt.expectedPatches, response.Patches)
+		})
+	}
+}
+
+func TestDefaultingHandler_KeepsValuesSet(t *testing.T) {
+	handler := &defaultingHandler{setDefaults: setMongoDBMultiClusterDefaults}
+
+	response := handler.Handle(context.Background(), defaultingRequest("ns", `{"apiVersion":"mongodb.com/v1","kind":"MongoDBMultiCluster","metadata":{"name":"mdbm"},"spec":{"clusterDomain":"example.com","persistent":false,"logLevel":"DEBUG"}}`))
+
+	require.True(t, response.Allowed)
+	assert.Empty(t, response.Patches)
+}
+
+func TestDefaultingHandler_OpsManager(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            string
+		expectedPatches []jsonpatch.Operation
+	}{
+		{
+			name: "backup is not set",
+			spec: `{"version":"8.0.0"}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+				{Operation: "add", Path: "/spec/replicas", Value: float64(1)},
+				{Operation: "add", Path: "/spec/backup", Value: map[string]interface{}{"enabled": true, "members": float64(1), "statefulSet": containersOverride("mongodb-backup-daemon", "limits", "5G")}},
+				{Operation: "add", Path: "/spec/statefulSet", Value: containersOverride("mongodb-ops-manager", "limits", "5G")},
+				{Operation: "add", Path: "/spec/applicationDatabase", Value: map[string]interface{}{
+					"agent":   map[string]interface{}{"logLevel": "INFO", "maxLogFileDurationHours": float64(24)},
+					"podSpec": map[string]interface{}{"podTemplate": containersOverride("mongod", "requests", "500M")["spec"].(map[string]interface{})["template"]},
+				}},
+			},
+		},
+		{
+			name: "deprecated cluster name is used as the cluster domain",
+			spec: `{"version":"8.0.0","clusterName":"example.com","replicas":2,"backup":{"enabled":false},"applicationDatabase":{"agent":{"logLevel":"DEBUG","maxLogFileDurationHours":12}}}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "example.com"},
+				{Operation: "add", Path: "/spec/backup/members", Value: float64(1)},
+				{Operation: "add", Path: "/spec/statefulSet", Value: containersOverride("mongodb-ops-manager", "limits", "5G")},
+				{Operation: "add", Path: "/spec/applicationDatabase/podSpec", Value: map[string]interface{}{"podTemplate": containersOverride("mongod", "requests", "500M")["spec"].(map[string]interface{})["template"]}},
+			},
+		},
+		{
+			name: "the resources of the containers are kept",
+			spec: `{"version":"8.0.0","replicas":1,"backup":{"enabled":false,"members":1},"statefulSet":{"spec":{"template":{"spec":{"containers":[{"name":"mongodb-ops-manager","resources":{"limits":{"memory":"8G"}}}]}}}},"applicationDatabase":{"agent":{"logLevel":"INFO","maxLogFileDurationHours":24},"podSpec":{"podTemplate":{"spec":{"containers":[{"name":"mongodb-agent"}]}}}}}`,
+			expectedPatches: []jsonpatch.Operation{
+				{Operation: "add", Path: "/spec/clusterDomain", Value: "cluster.local"},
+				{Operation: "add", Path: "/spec/applicationDatabase/podSpec/podTemplate/spec/containers/1", Value: map[string]interface{}{"name": "mongod", "resources": map[string]interface{}{"requests": map[string]interface{}{"memory": "500M"}}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &defaultingHandler{setDefaults: setOpsManagerDefaults}
+
+			response := handler.Handle(context.Background(), defaultingRequest("ns", `{"apiVersion":"mongodb.com/v1","kind":"MongoDBOpsManager","metadata":{"name":"om"},"spec":`+tt.spec+`}`))
+
+			require.True(t, response.Allowed)
+			assert.ElementsMatch(t, tt.expectedPatches, response.Patches)
+		})
+	}
+}
+
+// containersOverride returns a StatefulSet override setting the memory limits or requests of the container.
+func containersOverride(name, resources, memory string) map[string]interface{} {
+	return map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": name, "resources": map[string]interface{}{resources: map[string]interface{}{"memory": memory}}}},
+	}}}}
+}
+
+func TestDefaultingHandler_MongoDBUser(t *testing.T) {
+	handler := &defaultingHandler{setDefaults: setMongoDBUserDefaults}
+
+	response := handler.Handle(context.Background(), defaultingRequest("ns", `{"apiVersion":"mongodb.com/v1","kind":"MongoDBUser","metadata":{"name":"user"},"spec":{"username":"user","db":"","mongodbResourceRef":{"name":"rs"}}}`))
+
+	require.True(t, response.Allowed)
+	assert.ElementsMatch(t, []jsonpatch.Operation{
+		{Operation: "replace", Path: "/spec/db", Value: "admin"},
+		{Operation: "add", Path: "/spec/mongodbResourceRef/namespace", Value: "ns"},
+	}, response.Patches)
+}
+
+func TestDefaultingHandler_InvalidObject(t *testing.T) {
+	handler := &defaultingHandler{setDefaults: setMongoDBDefaults}
+
+	response := handler.Handle(context.Background(), defaultingRequest("ns", `{"spec":`))
+
+	assert.False(t, response.Allowed)
+}
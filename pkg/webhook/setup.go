@@ -23,6 +23,11 @@ import (
 // This label must match the label used for Operator deployment
 const controllerLabelName = "app.kubernetes.io/name"
 
+const (
+	validatingWebhookConfigurationName = "mdbpolicy.mongodb.com"
+	mutatingWebhookConfigurationName   = "mdbdefaults.mongodb.com"
+)
+
 // createWebhookService creates a Kubernetes service for the webhook.
 func createWebhookService(ctx context.Context, client client.Client, location types.NamespacedName, webhookPort int, svcSelector string) error {
 	svc := corev1.Service{
@@ -64,10 +69,7 @@ func createWebhookService(ctx context.Context, client client.Client, location ty
 // validating admission webhook based on the name and namespace of the webhook
 // service.
 func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.ValidatingWebhookConfiguration {
-	caBytes, err := os.ReadFile("/tmp/k8s-webhook-server/serving-certs/tls.crt")
-	if err != nil {
-		panic("could not read CA")
-	}
+	caBytes := readCABundle()
 
 	// need to make variables as one can't take the address of a constant
 	scope := admissionv1.NamespacedScope
@@ -77,9 +79,11 @@ func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.Validati
 	dbPath := "/validate-mongodb-com-v1-mongodb"
 	dbmultiPath := "/validate-mongodb-com-v1-mongodbmulticluster"
 	omPath := "/validate-mongodb-com-v1-mongodbopsmanager"
+	userPath := "/validate-mongodb-com-v1-mongodbuser"
+	searchPath := "/validate-mongodb-com-v1-mongodbsearch"
 	return admissionv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "mdbpolicy.mongodb.com",
+			Name: validatingWebhookConfigurationName,
 		},
 		Webhooks: []admissionv1.ValidatingWebhook{
 			{
@@ -101,9 +105,11 @@ func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.Validati
 							admissionv1.Create,
 							admissionv1.Update,
 						},
+						// the webhooks decode the resources as v1, the API server converts the
+						// resources of the other versions to v1 before calling them
 						Rule: admissionv1.Rule{
 							APIGroups:   []string{"mongodb.com"},
-							APIVersions: []string{"*"},
+							APIVersions: []string{"v1"},
 							Resources:   []string{"mongodb"},
 							Scope:       &scope,
 						},
@@ -132,7 +138,7 @@ func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.Validati
 						},
 						Rule: admissionv1.Rule{
 							APIGroups:   []string{"mongodb.com"},
-							APIVersions: []string{"*"},
+							APIVersions: []string{"v1"},
 							Resources:   []string{"mongodbmulticluster"},
 							Scope:       &scope,
 						},
@@ -163,7 +169,7 @@ func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.Validati
 						},
 						Rule: admissionv1.Rule{
 							APIGroups:   []string{"mongodb.com"},
-							APIVersions: []string{"*"},
+							APIVersions: []string{"v1"},
 							Resources:   []string{"opsmanagers"},
 							Scope:       &scope,
 						},
@@ -173,8 +179,138 @@ func GetWebhookConfig(serviceLocation types.NamespacedName) admissionv1.Validati
 				SideEffects:             &sideEffects,
 				FailurePolicy:           &failurePolicy,
 			},
+			{
+				Name: "mdbuserpolicy.mongodb.com",
+				ClientConfig: admissionv1.WebhookClientConfig{
+					Service: &admissionv1.ServiceReference{
+						Name:      serviceLocation.Name,
+						Namespace: serviceLocation.Namespace,
+						Path:      &userPath,
+						Port:      &port,
+					},
+					CABundle: caBytes,
+				},
+				Rules: []admissionv1.RuleWithOperations{
+					{
+						Operations: []admissionv1.OperationType{
+							admissionv1.Create,
+							admissionv1.Update,
+						},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"mongodb.com"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"mongodbusers"},
+							Scope:       &scope,
+						},
+					},
+				},
+				AdmissionReviewVersions: []string{"v1"},
+				SideEffects:             &sideEffects,
+				FailurePolicy:           &failurePolicy,
+			},
+			{
+				Name: "mdbsearchpolicy.mongodb.com",
+				ClientConfig: admissionv1.WebhookClientConfig{
+					Service: &admissionv1.ServiceReference{
+						Name:      serviceLocation.Name,
+						Namespace: serviceLocation.Namespace,
+						Path:      &searchPath,
+						Port:      &port,
+					},
+					CABundle: caBytes,
+				},
+				Rules: []admissionv1.RuleWithOperations{
+					{
+						Operations: []admissionv1.OperationType{
+							admissionv1.Create,
+							admissionv1.Update,
+						},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"mongodb.com"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"mongodbsearch"},
+							Scope:       &scope,
+						},
+					},
+				},
+				AdmissionReviewVersions: []string{"v1"},
+				SideEffects:             &sideEffects,
+				FailurePolicy:           &failurePolicy,
+			},
+		},
+	}
+}
+
+// GetMutatingWebhookConfig constructs a Kubernetes configuration resource for the
+// mutating admission webhook writing the defaults into the resources.
+func GetMutatingWebhookConfig(serviceLocation types.NamespacedName) admissionv1.MutatingWebhookConfiguration {
+	caBytes := readCABundle()
+
+	// need to make variables as one can't take the address of a constant
+	scope := admissionv1.NamespacedScope
+	sideEffects := admissionv1.SideEffectClassNone
+	failurePolicy := admissionv1.Ignore
+	reinvocationPolicy := admissionv1.NeverReinvocationPolicy
+	var port int32 = 443
+
+	webhooks := []struct {
+		name     string
+		path     string
+		resource string
+	}{
+		{name: "mdbdefaults.mongodb.com", path: mongoDBDefaultingPath, resource: "mongodb"},
+		{name: "mdbmultidefaults.mongodb.com", path: mongoDBMultiClusterDefaultingPath, resource: "mongodbmulticluster"},
+		{name: "omdefaults.mongodb.com", path: opsManagerDefaultingPath, resource: "opsmanagers"},
+		{name: "mdbuserdefaults.mongodb.com", path: mongoDBUserDefaultingPath, resource: "mongodbusers"},
+	}
+
+	webhookConfig := admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: mutatingWebhookConfigurationName,
 		},
 	}
+	for _, w := range webhooks {
+		path := w.path
+		webhookConfig.Webhooks = append(webhookConfig.Webhooks, admissionv1.MutatingWebhook{
+			Name: w.name,
+			ClientConfig: admissionv1.WebhookClientConfig{
+				Service: &admissionv1.ServiceReference{
+					Name:      serviceLocation.Name,
+					Namespace: serviceLocation.Namespace,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: caBytes,
+			},
+			Rules: []admissionv1.RuleWithOperations{
+				{
+					Operations: []admissionv1.OperationType{
+						admissionv1.Create,
+						admissionv1.Update,
+					},
+					Rule: admissionv1.Rule{
+						APIGroups:   []string{"mongodb.com"},
+						APIVersions: []string{"v1"},
+						Resources:   []string{w.resource},
+						Scope:       &scope,
+					},
+				},
+			},
+			AdmissionReviewVersions: []string{"v1"},
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failurePolicy,
+			ReinvocationPolicy:      &reinvocationPolicy,
+		})
+	}
+	return webhookConfig
+}
+
+func readCABundle() []byte {
+	caBytes, err := os.ReadFile("/tmp/k8s-webhook-server/serving-certs/tls.crt")
+	if err != nil {
+		panic("could not read CA")
+	}
+	return caBytes
 }
 
 func shouldRegisterWebhookConfiguration() bool {
@@ -183,7 +319,7 @@ func shouldRegisterWebhookConfiguration() bool {
 
 func Setup(ctx context.Context, client client.Client, serviceLocation types.NamespacedName, certDirectory string, webhookPort int, svcSelector string, log *zap.SugaredLogger) error {
 	if !shouldRegisterWebhookConfiguration() {
-		log.Debugf("Skipping configuration of ValidatingWebhookConfiguration")
+		log.Debugf("Skipping configuration of ValidatingWebhookConfiguration and MutatingWebhookConfiguration")
 		// After upgrading OLM version after migrating to proper OLM webhooks we don't need that `operator-service` anymore.
 		// By default, the service is created by the operator in createWebhookService below
 		// It will also be useful here if someone decides to disable automatic webhook configuration by the operator.
@@ -192,17 +328,7 @@ func Setup(ctx context.Context, client client.Client, serviceLocation types.Name
 			// we don't want to fail the operator startup if we cannot do the cleanup
 		}
 
-		webhookConfig := admissionv1.ValidatingWebhookConfiguration{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "mdbpolicy.mongodb.com",
-			},
-		}
-		if err := client.Delete(ctx, &webhookConfig); err != nil {
-			if !apiErrors.IsNotFound(err) {
-				log.Warnf("Failed to perform cleanup of ValidatingWebhookConfiguration %s. The operator might not have necessary permissions. Remove the configuration manually. Error: %s", webhookConfig.Name, err)
-				// we don't want to fail the operator startup if we cannot do the cleanup
-			}
-		}
+		deleteWebhookConfigurations(ctx, client, log)
 
 		return nil
 	}
@@ -216,22 +342,60 @@ func Setup(ctx context.Context, client client.Client, serviceLocation types.Name
 		return err
 	}
 
-	webhookConfig := GetWebhookConfig(serviceLocation)
-	err := client.Create(ctx, &webhookConfig)
-	if apiErrors.IsAlreadyExists(err) {
-		// client.Update results in internal K8s error "Invalid value: 0x0: must be specified for an update"
-		// (see https://github.com/kubernetes/kubernetes/issues/80515)
-		// this fixed in K8s 1.16.0+
-		if err = client.Delete(context.Background(), &webhookConfig); err == nil {
-			err = client.Create(ctx, &webhookConfig)
+	for _, webhookConfig := range getWebhookConfigurations(serviceLocation) {
+		if err := createWebhookConfiguration(ctx, client, webhookConfig); err != nil {
+			log.Warnf("Failed to configure admission webhooks. The operator might not have necessary permissions anymore. " +
+				"Admission webhooks might not work correctly. Ignore this error if the cluster role for the operator was removed deliberately.")
+			return nil
 		}
+		log.Debugf("Configured %T %s", webhookConfig, webhookConfig.GetName())
 	}
-	if err != nil {
-		log.Warnf("Failed to configure admission webhooks. The operator might not have necessary permissions anymore. " +
-			"Admission webhooks might not work correctly. Ignore this error if the cluster role for the operator was removed deliberately.")
+
+	if err := configureConversionWebhook(ctx, client, serviceLocation, readCABundle()); err != nil {
+		if apiErrors.IsNotFound(err) {
+			log.Debugf("The %s CustomResourceDefinition is not installed, skipping configuration of the conversion webhook", mongoDBCRDName)
+			return nil
+		}
+		log.Warnf("Failed to configure the conversion webhook in the %s CustomResourceDefinition, only the v1 version of MongoDB resources is served. "+
+			"The operator might not have necessary permissions. Error: %s", mongoDBCRDName, err)
 		return nil
 	}
-	log.Debugf("Configured ValidatingWebhookConfiguration %s", webhookConfig.Name)
+	log.Debugf("Configured the conversion webhook in the %s CustomResourceDefinition", mongoDBCRDName)
 
 	return nil
 }
+
+func getWebhookConfigurations(serviceLocation types.NamespacedName) []client.Object {
+	validatingWebhookConfig := GetWebhookConfig(serviceLocation)
+	mutatingWebhookConfig := GetMutatingWebhookConfig(serviceLocation)
+	return []client.Object{&validatingWebhookConfig, &mutatingWebhookConfig}
+}
+
+func deleteWebhookConfigurations(ctx context.Context, c client.Client, log *zap.SugaredLogger) {
+	webhookConfigs := []client.Object{
+		&admissionv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: validatingWebhookConfigurationName}},
+		&admissionv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: mutatingWebhookConfigurationName}},
+	}
+	for _, webhookConfig := range webhookConfigs {
+		if err := c.Delete(ctx, webhookConfig); err != nil {
+			if !apiErrors.IsNotFound(err) {
+				log.Warnf("Failed to perform cleanup of webhook configuration %s. The operator might not have necessary permissions. Remove the configuration manually. Error: %s", webhookConfig.GetName(), err)
+				// we don't want to fail the operator startup if we cannot do the cleanup
+			}
+		}
+	}
+}
+
+// createWebhookConfiguration creates the webhook configuration, replacing the existing one.
+func createWebhookConfiguration(ctx context.Context, c client.Client, webhookConfig client.Object) error {
+	err := c.Create(ctx, webhookConfig)
+	if apiErrors.IsAlreadyExists(err) {
+		// client.Update results in internal K8s error "Invalid value: 0x0: must be specified for an update"
+		// (see https://github.com/kubernetes/kubernetes/issues/80515)
+		// this fixed in K8s 1.16.0+
+		if err = c.Delete(ctx, webhookConfig); err == nil {
+			err = c.Create(ctx, webhookConfig)
+		}
+	}
+	return err
+}
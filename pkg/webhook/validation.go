@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	crWebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/mongodb/mongodb-kubernetes/pkg/references"
+)
+
+// referenceValidator runs the validations of the resource itself, if it has any, and the validations of its
+// references to the other resources.
+type referenceValidator struct {
+	references *references.Validator
+}
+
+var _ admission.CustomValidator = &referenceValidator{}
+
+// NewReferenceValidator returns the validator of the validating webhook resolving the references of the resources
+// with the cached client of the manager.
+func NewReferenceValidator(validator *references.Validator) admission.CustomValidator {
+	return &referenceValidator{references: validator}
+}
+
+func (v *referenceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	if validator, ok := obj.(crWebhook.Validator); ok {
+		var err error
+		if warnings, err = validator.ValidateCreate(); err != nil {
+			return warnings, err
+		}
+	}
+	return v.validateReferences(ctx, obj, warnings)
+}
+
+func (v *referenceValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	if validator, ok := newObj.(crWebhook.Validator); ok {
+		var err error
+		if warnings, err = validator.ValidateUpdate(oldObj); err != nil {
+			return warnings, err
+		}
+	}
+	return v.validateReferences(ctx, newObj, warnings)
+}
+
+// ValidateDelete does nothing as the references don't need to be valid for the resource to be deleted
+func (v *referenceValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *referenceValidator) validateReferences(ctx context.Context, obj runtime.Object, warnings admission.Warnings) (admission.Warnings, error) {
+	referenceWarnings, err := references.Process(v.references.Validate(ctx, obj))
+	for _, warning := range referenceWarnings {
+		warnings = append(warnings, string(warning))
+	}
+	return warnings, err
+}
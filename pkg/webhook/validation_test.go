@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/mongodb/mongodb-kubernetes/api/v1"
+	mdbv1 "github.com/mongodb/mongodb-kubernetes/api/v1/mdb"
+	userv1 "github.com/mongodb/mongodb-kubernetes/api/v1/user"
+	"github.com/mongodb/mongodb-kubernetes/pkg/references"
+)
+
+func newReferenceValidator(t *testing.T) admission.CustomValidator {
+	scheme, err := v1.SchemeBuilder.Build()
+	require.NoError(t, err)
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return NewReferenceValidator(references.NewValidator(fake.NewClientBuilder().WithScheme(scheme).Build(), false))
+}
+
+func TestReferenceValidator_RunsValidationsOfResource(t *testing.T) {
+	validator := newReferenceValidator(t)
+	rs := mdbv1.NewReplicaSetBuilder().Build()
+	rs.Spec.Members = 0
+
+	_, err := validator.ValidateCreate(context.Background(), rs)
+	assert.Error(t, err)
+}
+
+func TestReferenceValidator_ReturnsWarningsOfReferences(t *testing.T) {
+	validator := newReferenceValidator(t)
+	user := &userv1.MongoDBUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "user", Namespace: "ns"},
+		Spec:       userv1.MongoDBUserSpec{Username: "user", Database: "admin", MongoDBResourceRef: userv1.MongoDBResourceRef{Name: "mdb"}},
+	}
+
+	warnings, err := validator.ValidateCreate(context.Background(), user)
+	require.NoError(t, err)
+	assert.Equal(t, admission.Warnings{"MongoDB or MongoDBMultiCluster ns/mdb referenced in spec.mongodbResourceRef doesn't exist"}, warnings)
+
+	warnings, err = validator.ValidateUpdate(context.Background(), user.DeepCopy(), user)
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1)
+}
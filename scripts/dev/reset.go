@@ -314,7 +314,12 @@ func resetNamespace(ctx context.Context, contextName string, namespace string, d
 			"mongodbusers.mongodb.com",
 			"opsmanagers.mongodb.com",
 			"mongodbsearch.mongodb.com",
+			"mongodbsearchindexes.mongodb.com",
+			"mongodbalertconfigs.mongodb.com",
+			"mongodborganizations.mongodb.com",
+			"mongodbprojects.mongodb.com",
 			"clustermongodbroles.mongodb.com",
+			"mongodbmemberclusters.mongodb.com",
 		}
 		deleteCRDs(ctx, dynamicClient, crdNames, collectError)
 	}